@@ -3,9 +3,11 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"github.com/yuyacode/AppLiftMessageApi/batch"
@@ -21,8 +23,11 @@ func init() {
 
 // example: go run -tags=batch batch.go --mode=generate_api_key --target=company
 func main() {
-	mode := flag.String("mode", "", "mode: 'generate_api_key' or 'generate_access_token_secret_key' or 'generate_refresh_token_secret_key'")
-	target := flag.String("target", "", "target: 'company' or 'student'")
+	mode := flag.String("mode", "", "mode: 'generate_api_key' or 'generate_access_token_secret_key' or 'generate_refresh_token_secret_key' or 'generate_attachment_url_secret_key' or 'export_user_data' or 'erase_user_data' or 'purge' or 'migrate'")
+	target := flag.String("target", "", "target: 'company' or 'student' ('common' is also allowed for migrate)")
+	userID := flag.Int64("user_id", 0, "user ID (export_user_data, erase_user_data)")
+	out := flag.String("out", "", "output zip file path (export_user_data)")
+	dryRun := flag.Bool("dry-run", false, "only report counts without deleting (erase_user_data, purge)")
 	flag.Parse()
 	switch *mode {
 	case "generate_api_key":
@@ -37,7 +42,7 @@ func main() {
 			log.Fatalf("failed to generate API key: %v", err)
 		}
 		fmt.Printf("API Key successfully generated: %s\n", apiKey)
-	case "generate_access_token_secret_key", "generate_refresh_token_secret_key":
+	case "generate_access_token_secret_key", "generate_refresh_token_secret_key", "generate_attachment_url_secret_key":
 		if *target != "" {
 			log.Fatalf("unnecessary option '--target'")
 		}
@@ -46,6 +51,60 @@ func main() {
 			log.Fatalf("failed to %s: %v", *mode, err)
 		}
 		fmt.Printf("succeeded to %s: %s\n", *mode, secretKey)
+	case "export_user_data", "erase_user_data":
+		if *target != "company" && *target != "student" {
+			log.Fatalf("invalid target")
+		}
+		if *userID <= 0 {
+			log.Fatalf("missing required option '--user_id'")
+		}
+		if *mode == "export_user_data" {
+			if *out == "" {
+				log.Fatalf("missing required option '--out'")
+			}
+			if err := batch.ExportUserData(*target, *userID, *out); err != nil {
+				log.Fatalf("failed to export user data: %v", err)
+			}
+			fmt.Printf("succeeded to export user data: %s\n", *out)
+			return
+		}
+		report, err := batch.EraseUserData(*target, *userID, *dryRun)
+		if err != nil {
+			log.Fatalf("failed to erase user data: %v", err)
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Fatalf("failed to print report: %v", err)
+		}
+	case "purge":
+		if *target != "" {
+			log.Fatalf("unnecessary option '--target'")
+		}
+		report, err := batch.Purge(*dryRun)
+		if report != nil {
+			fmt.Printf("dry run: %t\n", report.DryRun)
+			fmt.Printf("common.messages (deleted): %d\n", report.DeletedMessages)
+			fmt.Printf("common.messages (abandoned drafts): %d\n", report.AbandonedDrafts)
+			fmt.Printf("common.message_attachments: %d\n", report.MessageAttachments)
+			fmt.Printf("common.message_reactions: %d\n", report.MessageReactions)
+			fmt.Printf("company.message_api_credentials: %d\n", report.CompanyAPICredentials)
+			fmt.Printf("student.message_api_credentials: %d\n", report.StudentAPICredentials)
+			for _, key := range report.BlobDeleteFailures {
+				fmt.Printf("failed to delete blob: %s\n", key)
+			}
+		}
+		if err != nil {
+			log.Fatalf("failed to purge: %v", err)
+		}
+	case "migrate":
+		// example: go run -tags=batch batch.go --mode=migrate --target=common to 2
+		if *target != "" && *target != "company" && *target != "student" && *target != "common" {
+			log.Fatalf("invalid target")
+		}
+		if err := batch.Migrate(*target, flag.Args(), os.Stdout); err != nil {
+			log.Fatalf("failed to migrate: %v", err)
+		}
 	default:
 		log.Fatalf("invalid mode")
 	}
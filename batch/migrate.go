@@ -0,0 +1,120 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/config"
+	"github.com/yuyacode/AppLiftMessageApi/migration"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+// Migrate は対象DB（空の場合は company, student, common の全て）に up, down, status, to <version> を実行し、結果を w に書き出す
+func Migrate(target string, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand: 'up', 'down', 'status' or 'to <version>'")
+	}
+	command := args[0]
+	var version int64
+	switch command {
+	case "up", "down", "status":
+		if len(args) != 1 {
+			return fmt.Errorf("unnecessary arguments for '%s'", command)
+		}
+	case "to":
+		if len(args) != 2 {
+			return fmt.Errorf("'to' requires a version")
+		}
+		var err error
+		version, err = strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid version: %s", args[1])
+		}
+	default:
+		return fmt.Errorf("invalid subcommand: %s", command)
+	}
+	targets := []string{"company", "student", "common"}
+	if target != "" {
+		targets = []string{target}
+	}
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	for _, targetDB := range targets {
+		if err := migrate(ctx, cfg, targetDB, command, version, w); err != nil {
+			return fmt.Errorf("%s: %w", targetDB, err)
+		}
+	}
+	return nil
+}
+
+func migrate(ctx context.Context, cfg *config.Config, targetDB, command string, version int64, w io.Writer) error {
+	migrations, err := migration.Load(cfg.DBDriver, targetDB)
+	if err != nil {
+		return err
+	}
+	dbHandler, dbCloseFunc, err := store.New(ctx, cfg, targetDB)
+	defer dbCloseFunc()
+	if err != nil {
+		return err
+	}
+	m := migration.NewMigrator(dbHandler, migrations, clock.RealClocker{})
+	switch command {
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.Applied {
+				appliedAt = "applied at " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%s: %06d_%s %s\n", targetDB, s.Version, s.Name, appliedAt)
+		}
+		return nil
+	case "down":
+		done, err := m.Down(ctx)
+		if done != nil && err == nil {
+			fmt.Fprintf(w, "%s: reverted %06d_%s\n", targetDB, done.Version, done.Name)
+		} else if err == nil {
+			fmt.Fprintf(w, "%s: no migration to revert\n", targetDB)
+		}
+		return err
+	case "up":
+		done, err := m.Up(ctx)
+		for _, mg := range done {
+			fmt.Fprintf(w, "%s: applied %06d_%s\n", targetDB, mg.Version, mg.Name)
+		}
+		if err == nil && len(done) == 0 {
+			fmt.Fprintf(w, "%s: already up to date\n", targetDB)
+		}
+		return err
+	}
+	// to は適用と巻き戻しのどちらも行いうるため、実行前の状態から向きを判断して表示する
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	appliedBefore := make(map[int64]bool, len(statuses))
+	for _, s := range statuses {
+		appliedBefore[s.Version] = s.Applied
+	}
+	done, err := m.To(ctx, version)
+	for _, mg := range done {
+		action := "applied"
+		if appliedBefore[mg.Version] {
+			action = "reverted"
+		}
+		fmt.Fprintf(w, "%s: %s %06d_%s\n", targetDB, action, mg.Version, mg.Name)
+	}
+	if err == nil && len(done) == 0 {
+		fmt.Fprintf(w, "%s: already at version %d\n", targetDB, version)
+	}
+	return err
+}
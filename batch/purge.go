@@ -0,0 +1,33 @@
+package batch
+
+import (
+	"context"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/config"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/service"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+// Purge は設定された保持期間を過ぎたデータを削除（dryRun の場合は件数の確認のみ）する
+func Purge(dryRun bool) (*entity.PurgeReport, error) {
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	dbHandlers, closeDB, blobStorage, err := openResources(ctx, cfg)
+	defer closeDB()
+	if err != nil {
+		return nil, err
+	}
+	now := clock.RealClocker{}.Now().Time
+	policy := entity.RetentionPolicy{
+		DeletedMessagesBefore:    now.AddDate(0, 0, -cfg.RetentionDeletedMessageDays),
+		AbandonedDraftsBefore:    now.AddDate(0, 0, -cfg.RetentionAbandonedDraftDays),
+		DeletedCredentialsBefore: now.AddDate(0, 0, -cfg.RetentionDeletedCredentialDays),
+	}
+	s := service.NewPurge(dbHandlers, store.NewPurgeRepository(clock.RealClocker{}), blobStorage)
+	return s.Purge(ctx, policy, cfg.PurgeChunkSize, dryRun)
+}
@@ -0,0 +1,38 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/blob"
+	"github.com/yuyacode/AppLiftMessageApi/config"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+// openResources は company・student・common の各DBとファイルストレージを開く。
+// 返す関数は、エラー時も含めて開いたDBを全て閉じる
+func openResources(ctx context.Context, cfg *config.Config) (map[string]*sqlx.DB, func(), blob.Storage, error) {
+	dbList := [3]string{"company", "student", "common"}
+	dbHandlers := make(map[string]*sqlx.DB, len(dbList))
+	closeFuncs := make([]func(), 0, len(dbList))
+	closeDB := func() {
+		for _, f := range closeFuncs {
+			f()
+		}
+	}
+	for _, v := range dbList {
+		dbHandler, dbCloseFunc, err := store.New(ctx, cfg, v)
+		closeFuncs = append(closeFuncs, dbCloseFunc)
+		if err != nil {
+			return nil, closeDB, nil, fmt.Errorf("failed to connect %s db: %w", v, err)
+		}
+		dbHandlers[v] = dbHandler
+	}
+	blobStorage, err := blob.New(cfg)
+	if err != nil {
+		return nil, closeDB, nil, err
+	}
+	return dbHandlers, closeDB, blobStorage, nil
+}
@@ -0,0 +1,57 @@
+package batch
+
+import (
+	"context"
+	"os"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/config"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/service"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+// ExportUserData は利用者の個人データを zip にまとめて out に書き出す
+func ExportUserData(target string, userID int64, out string) error {
+	ctx := context.Background()
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return err
+	}
+	dbHandlers, closeDB, blobStorage, err := openResources(ctx, cfg)
+	defer closeDB()
+	if err != nil {
+		return err
+	}
+	s := service.NewExportUserData(dbHandlers, store.NewUserDataRepository(clock.RealClocker{}), blobStorage)
+	data, err := s.ExportUserData(ctx, target, userID)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := s.WriteUserDataArchive(ctx, f, data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// EraseUserData は利用者の個人データを消去（dryRun の場合は件数の確認のみ）する
+func EraseUserData(target string, userID int64, dryRun bool) (*entity.UserDataErasureReport, error) {
+	ctx := context.Background()
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return nil, err
+	}
+	dbHandlers, closeDB, blobStorage, err := openResources(ctx, cfg)
+	defer closeDB()
+	if err != nil {
+		return nil, err
+	}
+	userDataRepo := store.NewUserDataRepository(clock.RealClocker{})
+	s := service.NewEraseUserData(dbHandlers, userDataRepo, userDataRepo, blobStorage)
+	return s.EraseUserData(ctx, target, userID, dryRun)
+}
@@ -0,0 +1,46 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	ls, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	t.Run("put, get and delete", func(t *testing.T) {
+		err := ls.Put(ctx, "attachments/1/abc", []byte("%PDF-1.4"), "application/pdf")
+		require.NoError(t, err)
+		rc, err := ls.Get(ctx, "attachments/1/abc")
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		assert.Equal(t, "%PDF-1.4", string(content))
+		require.NoError(t, ls.Delete(ctx, "attachments/1/abc"))
+		_, err = ls.Get(ctx, "attachments/1/abc")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("get missing key", func(t *testing.T) {
+		_, err := ls.Get(ctx, "attachments/1/missing")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("delete missing key", func(t *testing.T) {
+		assert.NoError(t, ls.Delete(ctx, "attachments/1/missing"))
+	})
+
+	t.Run("reject path traversal", func(t *testing.T) {
+		for _, key := range []string{"", "../secret", "attachments/../../secret", "/etc/passwd"} {
+			err := ls.Put(ctx, key, []byte("x"), "text/plain")
+			assert.Error(t, err, key)
+		}
+	})
+}
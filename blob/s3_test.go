@@ -0,0 +1,65 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3Storage(t *testing.T) {
+	ctx := context.Background()
+	objects := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=access-key/") ||
+			!strings.Contains(auth, "SignedHeaders=host;x-amz-content-sha256;x-amz-date") ||
+			r.Header.Get("X-Amz-Date") == "" || r.Header.Get("X-Amz-Content-Sha256") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			objects[r.URL.EscapedPath()] = string(body)
+		case http.MethodGet:
+			body, ok := objects[r.URL.EscapedPath()]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			io.WriteString(w, body)
+		case http.MethodDelete:
+			delete(objects, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	s, err := NewS3Storage(srv.URL, "ap-northeast-1", "bucket", "access-key", "secret-key", true)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Put(ctx, "attachments/1/履歴書", []byte("%PDF-1.4"), "application/pdf"))
+	assert.Contains(t, objects, "/bucket/attachments/1/%E5%B1%A5%E6%AD%B4%E6%9B%B8")
+
+	rc, err := s.Get(ctx, "attachments/1/履歴書")
+	require.NoError(t, err)
+	content, err := io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.4", string(content))
+
+	require.NoError(t, s.Delete(ctx, "attachments/1/履歴書"))
+	_, err = s.Get(ctx, "attachments/1/履歴書")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	bad, err := NewS3Storage(srv.URL, "ap-northeast-1", "bucket", "other-key", "secret-key", true)
+	require.NoError(t, err)
+	err = bad.Put(ctx, "attachments/1/abc", []byte("x"), "text/plain")
+	assert.ErrorContains(t, err, "status 403")
+}
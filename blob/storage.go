@@ -0,0 +1,34 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/yuyacode/AppLiftMessageApi/config"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+type Storage interface {
+	Put(ctx context.Context, key string, content []byte, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+var (
+	_ Storage = (*LocalStorage)(nil)
+	_ Storage = (*S3Storage)(nil)
+)
+
+func New(cfg *config.Config) (Storage, error) {
+	switch cfg.BlobDriver {
+	case "local":
+		return NewLocalStorage(cfg.BlobLocalDir)
+	case "s3":
+		return NewS3Storage(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKeyID, cfg.S3SecretAccessKey, cfg.S3ForcePathStyle)
+	default:
+		return nil, fmt.Errorf("invalid blob driver: %s", cfg.BlobDriver)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// certReloader は証明書と秘密鍵のファイルの更新日時を TLS ハンドシェイクのたびに確認し、変わっていれば読み込み直す。
+// 証明書の更新（cert-manager などによる差し替え）を、サーバーを再起動せずに反映するため
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu          sync.Mutex
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	cr := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := cr.reload(); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+func (cr *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	certInfo, certErr := os.Stat(cr.certFile)
+	keyInfo, keyErr := os.Stat(cr.keyFile)
+	if certErr == nil && keyErr == nil && (!certInfo.ModTime().Equal(cr.certModTime) || !keyInfo.ModTime().Equal(cr.keyModTime)) {
+		// 差し替えの途中などで読み込めない場合は、読み込み済みの証明書を使い続ける
+		if err := cr.reload(); err != nil {
+			slog.Warn("failed to reload TLS certificate", slog.String("error", err.Error()))
+		}
+	}
+	return cr.cert, nil
+}
+
+// reload は呼び出し元で mu をロックしてから呼ぶ（初回の読み込みを除く）
+func (cr *certReloader) reload() error {
+	certInfo, err := os.Stat(cr.certFile)
+	if err != nil {
+		return err
+	}
+	keyInfo, err := os.Stat(cr.keyFile)
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(cr.certFile, cr.keyFile)
+	if err != nil {
+		return err
+	}
+	cr.cert = &cert
+	cr.certModTime = certInfo.ModTime()
+	cr.keyModTime = keyInfo.ModTime()
+	return nil
+}
@@ -0,0 +1,327 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+// Client はメッセージAPIを呼び出す他の Go サービス向けのクライアント。
+// アクセストークンの期限切れ（token_expired）を受け取ると、リフレッシュトークンで再発行してから1度だけリクエストをやり直す
+type Client struct {
+	BaseURL *url.URL
+	Client  *http.Client
+	// MaxRetries は冪等なリクエストを、通信エラーや 502, 503, 504 で再試行する回数
+	MaxRetries   int
+	RetryBackoff time.Duration
+	// OnTokenRefresh はトークンを再発行した後に呼ばれる。リフレッシュトークンも入れ替わるため、呼び出し側で保存し直すこと。
+	// 認証情報のロック中に呼ぶため、Client のメソッドは呼ばないこと
+	OnTokenRefresh func(ctx context.Context, credentials Credentials)
+
+	mu          sync.Mutex
+	credentials Credentials
+}
+
+// Credentials は /messages/register で発行された認証情報。アプリ側の DB から読み込んで渡す
+type Credentials struct {
+	ClientID     string
+	ClientSecret string
+	AccessToken  string
+	RefreshToken string
+}
+
+func New(baseURL string, credentials Credentials) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base url: %s", baseURL)
+	}
+	return &Client{
+		BaseURL:      u,
+		Client:       &http.Client{Timeout: 30 * time.Second},
+		MaxRetries:   2,
+		RetryBackoff: 200 * time.Millisecond,
+		credentials:  credentials,
+	}, nil
+}
+
+// Credentials は現在の認証情報を返す。自動で再発行された場合は新しいトークンを返す
+func (c *Client) Credentials() Credentials {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.credentials
+}
+
+func (c *Client) SetCredentials(credentials Credentials) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.credentials = credentials
+}
+
+// Register は API キーで userID の認証情報を発行する。発行された認証情報はレスポンスには含まれず、アプリ側の DB に保存される
+func (c *Client) Register(ctx context.Context, apiKey, appKind string, userID int64) error {
+	body := struct {
+		UserID  int64  `json:"user_id"`
+		AppKind string `json:"app_kind"`
+	}{UserID: userID, AppKind: appKind}
+	return c.do(ctx, &request{method: http.MethodPost, path: "/messages/register", token: apiKey, body: body}, nil)
+}
+
+// RefreshToken はリフレッシュトークンでアクセストークンとリフレッシュトークンを再発行し、保持している認証情報を更新する
+func (c *Client) RefreshToken(ctx context.Context) (Credentials, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refreshTokenLocked(ctx)
+}
+
+func (c *Client) refreshTokenLocked(ctx context.Context) (Credentials, error) {
+	body := struct {
+		RefreshToken string `json:"refresh_token"`
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+	}{
+		RefreshToken: c.credentials.RefreshToken,
+		ClientID:     c.credentials.ClientID,
+		ClientSecret: c.credentials.ClientSecret,
+	}
+	var rsp struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := c.do(ctx, &request{method: http.MethodPost, path: "/messages/token", body: body}, &rsp); err != nil {
+		return Credentials{}, err
+	}
+	c.credentials.AccessToken = rsp.AccessToken
+	c.credentials.RefreshToken = rsp.RefreshToken
+	if c.OnTokenRefresh != nil {
+		c.OnTokenRefresh(ctx, c.credentials)
+	}
+	return c.credentials, nil
+}
+
+type Message struct {
+	ID               entity.MessageID  `json:"id"`
+	IsFromCompany    int8              `json:"is_from_company"`
+	IsFromStudent    int8              `json:"is_from_student"`
+	Content          string            `json:"content"`
+	IsSent           int8              `json:"is_sent"`
+	SentAt           time.Time         `json:"sent_at"`
+	ReplyToMessageID *entity.MessageID `json:"reply_to_message_id"`
+	ReplyTo          *ReplyTo          `json:"reply_to"`
+	Attachments      []Attachment      `json:"attachments"`
+	Reactions        []Reaction        `json:"reactions"`
+}
+
+type ReplyTo struct {
+	MessageID     entity.MessageID `json:"message_id"`
+	IsFromCompany int8             `json:"is_from_company"`
+	IsFromStudent int8             `json:"is_from_student"`
+	Excerpt       string           `json:"excerpt"`
+	IsDeleted     bool             `json:"is_deleted"`
+}
+
+type Attachment struct {
+	ID          entity.AttachmentID `json:"id"`
+	FileName    string              `json:"file_name"`
+	ContentType string              `json:"content_type"`
+	Size        int64               `json:"size"`
+}
+
+type Reaction struct {
+	Reaction    string `json:"reaction"`
+	Count       int    `json:"count"`
+	ReactedByMe bool   `json:"reacted_by_me"`
+}
+
+func (c *Client) ListMessages(ctx context.Context, threadID entity.MessageThreadID) ([]Message, error) {
+	query := url.Values{"thread_id": {strconv.FormatInt(int64(threadID), 10)}}
+	var messages []Message
+	if err := c.do(ctx, &request{method: http.MethodGet, path: "/messages", query: query, authorized: true, idempotent: true}, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// AddMessageRequest は Content か TemplateID のどちらかを指定する
+type AddMessageRequest struct {
+	MessageThreadID  entity.MessageThreadID    `json:"message_thread_id"`
+	IsFromCompany    int8                      `json:"is_from_company"`
+	IsFromStudent    int8                      `json:"is_from_student"`
+	Content          string                    `json:"content,omitempty"`
+	TemplateID       *entity.MessageTemplateID `json:"template_id,omitempty"`
+	Variables        map[string]string         `json:"variables,omitempty"`
+	IsSent           int8                      `json:"is_sent"`
+	SentAt           time.Time                 `json:"sent_at"`
+	ReplyToMessageID *entity.MessageID         `json:"reply_to_message_id,omitempty"`
+}
+
+// AddMessage は二重送信を避けるため、再試行しない
+func (c *Client) AddMessage(ctx context.Context, req *AddMessageRequest) (entity.MessageID, error) {
+	var rsp struct {
+		ID entity.MessageID `json:"id"`
+	}
+	if err := c.do(ctx, &request{method: http.MethodPost, path: "/messages", body: req, authorized: true}, &rsp); err != nil {
+		return 0, err
+	}
+	return rsp.ID, nil
+}
+
+func (c *Client) EditMessage(ctx context.Context, id entity.MessageID, content string) error {
+	body := struct {
+		Content string `json:"content"`
+	}{Content: content}
+	return c.do(ctx, &request{method: http.MethodPatch, path: fmt.Sprintf("/messages/%d", id), body: body, authorized: true, idempotent: true}, nil)
+}
+
+func (c *Client) DeleteMessage(ctx context.Context, id entity.MessageID) error {
+	return c.do(ctx, &request{method: http.MethodDelete, path: fmt.Sprintf("/messages/%d", id), authorized: true, idempotent: true}, nil)
+}
+
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   any
+	// token は authorized でないリクエストの Authorization ヘッダー（API キー）
+	token      string
+	authorized bool
+	idempotent bool
+}
+
+func (c *Client) do(ctx context.Context, req *request, out any) error {
+	var body []byte
+	if req.body != nil {
+		var err error
+		body, err = json.Marshal(req.body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+	}
+	token := req.token
+	if req.authorized {
+		token = c.Credentials().AccessToken
+	}
+	err := c.doWithRetry(ctx, req, body, token, out)
+	if !req.authorized || !IsTokenExpired(err) {
+		return err
+	}
+	token, err = c.refreshExpiredToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	return c.doWithRetry(ctx, req, body, token, out)
+}
+
+// refreshExpiredToken は expiredToken を再発行する。並行するリクエストが既に再発行していれば、そのトークンを返す
+func (c *Client) refreshExpiredToken(ctx context.Context, expiredToken string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.credentials.AccessToken != expiredToken {
+		return c.credentials.AccessToken, nil
+	}
+	credentials, err := c.refreshTokenLocked(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh access token: %w", err)
+	}
+	return credentials.AccessToken, nil
+}
+
+func (c *Client) doWithRetry(ctx context.Context, req *request, body []byte, token string, out any) error {
+	backoff := c.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		wait, err := c.send(ctx, req, body, token, out)
+		if err == nil || !req.idempotent || attempt >= c.MaxRetries || !isRetryable(ctx, err) {
+			return err
+		}
+		if wait == 0 {
+			wait = backoff
+			backoff *= 2
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// send はリクエストを1度送る。503 の Retry-After で待つ時間を指定された場合は、それを返す
+func (c *Client) send(ctx context.Context, req *request, body []byte, token string, out any) (time.Duration, error) {
+	u := c.BaseURL.JoinPath(req.path)
+	u.RawQuery = req.query.Encode()
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u.String(), r)
+	if err != nil {
+		return 0, err
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+	rsp, err := c.Client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		var wait time.Duration
+		if seconds, err := strconv.Atoi(rsp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			wait = time.Duration(seconds) * time.Second
+		}
+		return wait, responseError(rsp)
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, rsp.Body)
+		return 0, nil
+	}
+	if err := json.NewDecoder(rsp.Body).Decode(out); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return 0, nil
+}
+
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	// http.Client.Do は通信エラーを *url.Error で返す
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+func responseError(rsp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(rsp.Body, 64<<10))
+	apiErr := &Error{StatusCode: rsp.StatusCode}
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Message == "" {
+		// ロードバランサーなど API 以外が返したエラー
+		apiErr.Message = http.StatusText(rsp.StatusCode)
+		apiErr.Detail = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	c, err := New(srv.URL, Credentials{ClientID: "id", ClientSecret: "secret", AccessToken: "old-access", RefreshToken: "old-refresh"})
+	require.NoError(t, err)
+	c.RetryBackoff = time.Millisecond
+	return c
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("localhost:8080", Credentials{})
+	assert.Error(t, err)
+	c, err := New("https://message.example.com/api", Credentials{AccessToken: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, "a", c.Credentials().AccessToken)
+}
+
+func TestClient_ListMessages(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/messages", r.URL.Path)
+		assert.Equal(t, "3", r.URL.Query().Get("thread_id"))
+		assert.Equal(t, "Bearer old-access", r.Header.Get("Authorization"))
+		writeJSON(w, http.StatusOK, []map[string]any{{
+			"id": 1, "is_from_company": 1, "is_from_student": 0, "content": "hello", "is_sent": 1,
+			"sent_at": "2025-01-01T09:00:00+09:00", "reply_to_message_id": nil, "reply_to": nil,
+			"attachments": []any{}, "reactions": []map[string]any{{"reaction": "👍", "count": 1, "reacted_by_me": true}},
+		}})
+	})
+	messages, err := c.ListMessages(context.Background(), 3)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, entity.MessageID(1), messages[0].ID)
+	assert.Equal(t, "hello", messages[0].Content)
+	assert.Equal(t, []Reaction{{Reaction: "👍", Count: 1, ReactedByMe: true}}, messages[0].Reactions)
+}
+
+func TestClient_Error(t *testing.T) {
+	t.Run("error response", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusForbidden, map[string]any{
+				"type": "urn:applift-message-api:error:forbidden", "title": "Permission denied", "status": 403,
+				"code": "forbidden", "message": "unauthorized: lack the necessary permissions to edit message",
+				"instance": "/messages/1", "request_id": "req-1",
+			})
+		})
+		err := c.EditMessage(context.Background(), 1, "edited")
+		var apiErr *Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, &Error{
+			StatusCode: http.StatusForbidden,
+			Type:       "urn:applift-message-api:error:forbidden",
+			Title:      "Permission denied",
+			Code:       "forbidden",
+			Message:    "unauthorized: lack the necessary permissions to edit message",
+			Instance:   "/messages/1",
+			RequestID:  "req-1",
+		}, apiErr)
+		assert.Equal(t, "message api returned 403: forbidden: unauthorized: lack the necessary permissions to edit message", err.Error())
+		assert.False(t, IsTokenExpired(err))
+	})
+
+	t.Run("non-JSON response", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "upstream connect error", http.StatusBadGateway)
+		})
+		c.MaxRetries = 0
+		err := c.DeleteMessage(context.Background(), 1)
+		var apiErr *Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, &Error{StatusCode: http.StatusBadGateway, Message: "Bad Gateway", Detail: "upstream connect error"}, apiErr)
+	})
+}
+
+func TestClient_RefreshOnTokenExpired(t *testing.T) {
+	var refreshes, listCalls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/messages/token":
+			refreshes.Add(1)
+			var body map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]string{"refresh_token": "old-refresh", "client_id": "id", "client_secret": "secret"}, body)
+			writeJSON(w, http.StatusOK, map[string]string{"access_token": "new-access", "refresh_token": "new-refresh"})
+		case "/messages":
+			listCalls.Add(1)
+			if r.Header.Get("Authorization") != "Bearer new-access" {
+				writeJSON(w, http.StatusUnauthorized, map[string]string{"code": "token_expired", "message": "token_expired", "detail": "The access token has expired"})
+				return
+			}
+			writeJSON(w, http.StatusOK, []any{})
+		}
+	})
+	var saved Credentials
+	c.OnTokenRefresh = func(ctx context.Context, credentials Credentials) {
+		saved = credentials
+	}
+	messages, err := c.ListMessages(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+	assert.Equal(t, int32(1), refreshes.Load())
+	assert.Equal(t, int32(2), listCalls.Load())
+	want := Credentials{ClientID: "id", ClientSecret: "secret", AccessToken: "new-access", RefreshToken: "new-refresh"}
+	assert.Equal(t, want, c.Credentials())
+	assert.Equal(t, want, saved)
+}
+
+func TestClient_RefreshFails(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/messages/token" {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"code": "invalid_token", "message": "invalid_token", "detail": "invalid refresh token"})
+			return
+		}
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"code": "token_expired", "message": "token_expired"})
+	})
+	_, err := c.ListMessages(context.Background(), 1)
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "invalid_token", apiErr.Code)
+	assert.Equal(t, "old-access", c.Credentials().AccessToken)
+}
+
+func TestClient_Retry(t *testing.T) {
+	t.Run("idempotent request is retried", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				writeJSON(w, http.StatusBadGateway, map[string]string{"message": "bad gateway"})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]string{"message": "delete message was successful"})
+		})
+		require.NoError(t, c.DeleteMessage(context.Background(), 1))
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("gives up after MaxRetries", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			writeJSON(w, http.StatusGatewayTimeout, map[string]string{"message": "timeout"})
+		})
+		c.MaxRetries = 1
+		_, err := c.ListMessages(context.Background(), 1)
+		assert.Error(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("AddMessage is not retried", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			writeJSON(w, http.StatusBadGateway, map[string]string{"message": "bad gateway"})
+		})
+		_, err := c.AddMessage(context.Background(), &AddMessageRequest{MessageThreadID: 1, IsFromCompany: 1, Content: "hello", IsSent: 1, SentAt: time.Now()})
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("client error is not retried", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": "message not found"})
+		})
+		assert.Error(t, c.EditMessage(context.Background(), 1, "edited"))
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
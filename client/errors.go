@@ -0,0 +1,36 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error は API のエラーレスポンス（RFC 9457 の Problem Details、handler.ErrResponse）に、ステータスコードを加えたもの。
+// エラーの種類は Code で判定する
+type Error struct {
+	StatusCode int    `json:"-"`
+	Type       string `json:"type,omitempty"`
+	Title      string `json:"title,omitempty"`
+	Code       string `json:"code,omitempty"`
+	Message    string `json:"message"`
+	Detail     string `json:"detail,omitempty"`
+	Instance   string `json:"instance,omitempty"`
+	RequestID  string `json:"request_id,omitempty"`
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if e.Code != "" {
+		msg = e.Code + ": " + msg
+	}
+	if e.Detail == "" {
+		return fmt.Sprintf("message api returned %d: %s", e.StatusCode, msg)
+	}
+	return fmt.Sprintf("message api returned %d: %s: %s", e.StatusCode, msg, e.Detail)
+}
+
+// IsTokenExpired はアクセストークンの期限切れによるエラーかどうかを返す
+func IsTokenExpired(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Code == "token_expired"
+}
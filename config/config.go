@@ -1,25 +1,141 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
 	"github.com/caarlos0/env"
+	"github.com/go-playground/validator/v10"
+	"github.com/joho/godotenv"
 )
 
+const dotenvFile = "/app/.env"
+
 type Config struct {
-	Env        string `env:"ENV"         envDefault:"dev"`
-	Port       int    `env:"PORT"        envDefault:"8080"`
-	DBHost     string `env:"DB_HOST"     envDefault:"127.0.0.1"`
-	DBPort     int    `env:"DB_PORT"     envDefault:"3306"`
-	DBCompany  string `env:"DB_COMPANY"  envDefault:"company"`
-	DBStudent  string `env:"DB_STUDENT"  envDefault:"student"`
-	DBCommon   string `env:"DB_COMMON"   envDefault:"common"`
-	DBUserName string `env:"DB_USERNAME" envDefault:"user3"`
-	DBPassword string `env:"DB_PASSWORD" envDefault:"password3"`
+	Env                            string        `env:"ENV"                               envDefault:"dev"            validate:"required"`
+	Port                           int           `env:"PORT"                              envDefault:"8080"           validate:"min=1,max=65535"`
+	AllowedOrigins                 []string      `env:"ALLOWED_ORIGINS"                                               validate:"required_unless=Env dev"`
+	CORSAllowCredentials           bool          `env:"CORS_ALLOW_CREDENTIALS"            envDefault:"false"`
+	HTTPReadHeaderTimeout          time.Duration `env:"HTTP_READ_HEADER_TIMEOUT"          envDefault:"5s"             validate:"gte=0"`
+	HTTPReadTimeout                time.Duration `env:"HTTP_READ_TIMEOUT"                 envDefault:"60s"            validate:"gte=0"`
+	HTTPWriteTimeout               time.Duration `env:"HTTP_WRITE_TIMEOUT"                envDefault:"60s"            validate:"gte=0"`
+	HTTPIdleTimeout                time.Duration `env:"HTTP_IDLE_TIMEOUT"                 envDefault:"120s"           validate:"gte=0"`
+	HTTPMaxHeaderBytes             int           `env:"HTTP_MAX_HEADER_BYTES"             envDefault:"1048576"        validate:"gt=0"`
+	HTTP2Enabled                   bool          `env:"HTTP2_ENABLED"                     envDefault:"true"`
+	TLSCertFile                    string        `env:"TLS_CERT_FILE"                                                 validate:"required_with=TLSKeyFile,omitempty,file"`
+	TLSKeyFile                     string        `env:"TLS_KEY_FILE"                                                  validate:"required_with=TLSCertFile,omitempty,file"`
+	RequestBodyMaxSize             int64         `env:"REQUEST_BODY_MAX_SIZE"             envDefault:"1048576"        validate:"gt=0"`
+	ShutdownTimeout                time.Duration `env:"SHUTDOWN_TIMEOUT"                  envDefault:"30s"            validate:"gt=0"`
+	ShutdownDrainDelay             time.Duration `env:"SHUTDOWN_DRAIN_DELAY"              envDefault:"5s"             validate:"gte=0"`
+	LogLevel                       string        `env:"LOG_LEVEL"                         envDefault:"info"           validate:"oneof=debug info warn error"`
+	TraceExporter                  string        `env:"TRACE_EXPORTER"                    envDefault:"none"           validate:"oneof=none stdout otlp"`
+	TraceSampleRatio               float64       `env:"TRACE_SAMPLE_RATIO"                envDefault:"1"              validate:"gte=0,lte=1"`
+	DBDriver                       string        `env:"DB_DRIVER"                         envDefault:"mysql"          validate:"oneof=mysql sqlite memory"`
+	DBMemorySeedFile               string        `env:"DB_MEMORY_SEED_FILE"                                           validate:"omitempty,file"`
+	SQLiteDir                      string        `env:"SQLITE_DIR"                        envDefault:"/app/data"      validate:"required_if=DBDriver sqlite"`
+	DBHost                         string        `env:"DB_HOST"                           envDefault:"127.0.0.1"`
+	DBPort                         int           `env:"DB_PORT"                           envDefault:"3306"           validate:"min=1,max=65535"`
+	DBCompany                      string        `env:"DB_COMPANY"                        envDefault:"company"        validate:"required"`
+	DBStudent                      string        `env:"DB_STUDENT"                        envDefault:"student"        validate:"required"`
+	DBCommon                       string        `env:"DB_COMMON"                         envDefault:"common"         validate:"required"`
+	DBCompanyMaxOpenConns          int           `env:"DB_COMPANY_MAX_OPEN_CONNS"         envDefault:"10"             validate:"gte=0"`
+	DBCompanyMaxIdleConns          int           `env:"DB_COMPANY_MAX_IDLE_CONNS"         envDefault:"5"              validate:"gte=0"`
+	DBCompanyConnMaxLifetime       time.Duration `env:"DB_COMPANY_CONN_MAX_LIFETIME"      envDefault:"5m"             validate:"gte=0"`
+	DBStudentMaxOpenConns          int           `env:"DB_STUDENT_MAX_OPEN_CONNS"         envDefault:"10"             validate:"gte=0"`
+	DBStudentMaxIdleConns          int           `env:"DB_STUDENT_MAX_IDLE_CONNS"         envDefault:"5"              validate:"gte=0"`
+	DBStudentConnMaxLifetime       time.Duration `env:"DB_STUDENT_CONN_MAX_LIFETIME"      envDefault:"5m"             validate:"gte=0"`
+	DBCommonMaxOpenConns           int           `env:"DB_COMMON_MAX_OPEN_CONNS"          envDefault:"20"             validate:"gte=0"`
+	DBCommonMaxIdleConns           int           `env:"DB_COMMON_MAX_IDLE_CONNS"          envDefault:"10"             validate:"gte=0"`
+	DBCommonConnMaxLifetime        time.Duration `env:"DB_COMMON_CONN_MAX_LIFETIME"       envDefault:"5m"             validate:"gte=0"`
+	DBCompanyReplicaDSN            string        `env:"DB_COMPANY_REPLICA_DSN"                                        validate:"excluded_unless=DBDriver mysql"           secret:"true"`
+	DBStudentReplicaDSN            string        `env:"DB_STUDENT_REPLICA_DSN"                                        validate:"excluded_unless=DBDriver mysql"           secret:"true"`
+	DBCommonReplicaDSN             string        `env:"DB_COMMON_REPLICA_DSN"                                         validate:"excluded_unless=DBDriver mysql"           secret:"true"`
+	DBReplicaStickyWindow          time.Duration `env:"DB_REPLICA_STICKY_WINDOW"          envDefault:"5s"             validate:"gte=0"`
+	DBConnectRetries               int           `env:"DB_CONNECT_RETRIES"                envDefault:"10"             validate:"gte=0"`
+	DBConnectBackoff               time.Duration `env:"DB_CONNECT_BACKOFF"                envDefault:"500ms"          validate:"gt=0"`
+	DBConnectMaxBackoff            time.Duration `env:"DB_CONNECT_MAX_BACKOFF"            envDefault:"10s"            validate:"gtefield=DBConnectBackoff"`
+	DBUserName                     string        `env:"DB_USERNAME"                       envDefault:"user3"`
+	DBPassword                     string        `env:"DB_PASSWORD"                       envDefault:"password3"                                                          secret:"true"`
+	BlobDriver                     string        `env:"BLOB_DRIVER"                       envDefault:"local"          validate:"oneof=local s3"`
+	BlobLocalDir                   string        `env:"BLOB_LOCAL_DIR"                    envDefault:"/app/storage"   validate:"required_if=BlobDriver local"`
+	S3Endpoint                     string        `env:"S3_ENDPOINT"`
+	S3Region                       string        `env:"S3_REGION"                         envDefault:"ap-northeast-1"`
+	S3Bucket                       string        `env:"S3_BUCKET"                                                     validate:"required_if=BlobDriver s3"`
+	S3AccessKeyID                  string        `env:"S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey              string        `env:"S3_SECRET_ACCESS_KEY"                                                                                              secret:"true"`
+	S3ForcePathStyle               bool          `env:"S3_FORCE_PATH_STYLE"               envDefault:"false"`
+	AccessTokenSecretKey           string        `env:"ACCESS_TOKEN_SECRET_KEY"                                       validate:"omitempty,base64"                         secret:"true"`
+	RefreshTokenSecretKey          string        `env:"REFRESH_TOKEN_SECRET_KEY"                                      validate:"omitempty,base64"                         secret:"true"`
+	AttachmentMaxSize              int64         `env:"ATTACHMENT_MAX_SIZE"               envDefault:"10485760"       validate:"gt=0"`
+	AttachmentURLTTL               time.Duration `env:"ATTACHMENT_URL_TTL"                envDefault:"5m"             validate:"gt=0"`
+	AttachmentURLSecretKey         string        `env:"ATTACHMENT_URL_SECRET_KEY"                                     validate:"omitempty,base64"                         secret:"true"`
+	AdminAPIKey                    string        `env:"ADMIN_API_KEY"                                                                                                     secret:"true"`
+	RetentionDeletedMessageDays    int           `env:"RETENTION_DELETED_MESSAGE_DAYS"    envDefault:"90"             validate:"gt=0"`
+	RetentionAbandonedDraftDays    int           `env:"RETENTION_ABANDONED_DRAFT_DAYS"    envDefault:"180"            validate:"gt=0"`
+	RetentionDeletedCredentialDays int           `env:"RETENTION_DELETED_CREDENTIAL_DAYS" envDefault:"90"             validate:"gt=0"`
+	PurgeChunkSize                 int           `env:"PURGE_CHUNK_SIZE"                  envDefault:"500"            validate:"gt=0"`
 }
 
+// NewConfig は CONFIG_FILE で指定された設定ファイル（省略可）を読み込んで Load する
 func NewConfig() (*Config, error) {
+	return Load(os.Getenv("CONFIG_FILE"))
+}
+
+// Load は次の順に値を重ねて設定を作り、検証する（後のものが優先）。
+//  1. envDefault のデフォルト値
+//  2. path の設定ファイル（YAML か TOML。空の場合は読み込まない）
+//  3. 環境変数（ローカル環境（ENV=dev）では /app/.env も読み込む）
+//  4. <環境変数名>_FILE で指定されたファイルの内容（Docker や Kubernetes の secret 向け）
+func Load(path string) (*Config, error) {
+	// ローカル環境（ENV=dev）でのみ環境変数を.envで管理している
+	if err := godotenv.Load(dotenvFile); err != nil && os.Getenv("ENV") == "dev" {
+		return nil, fmt.Errorf("failed to load %s: %w", dotenvFile, err)
+	}
 	cfg := &Config{}
 	if err := env.Parse(cfg); err != nil {
 		return nil, err
 	}
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := applyFileValues(cfg, values); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	}
+	if err := applySecretFiles(cfg); err != nil {
+		return nil, err
+	}
+	trimLists(cfg)
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
+
+// Validate は validate タグに従って設定値を検証する。エラーには環境変数名を含める
+func (cfg *Config) Validate() error {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return f.Tag.Get("env")
+	})
+	err := v.Struct(cfg)
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+	msgs := make([]string, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		if fe.Param() != "" {
+			msgs = append(msgs, fmt.Sprintf("%s: failed on %s=%s", fe.Field(), fe.Tag(), fe.Param()))
+		} else {
+			msgs = append(msgs, fmt.Sprintf("%s: failed on %s", fe.Field(), fe.Tag()))
+		}
+	}
+	return fmt.Errorf("invalid config: %s", strings.Join(msgs, "; "))
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecretKey = "a2tra2tra2tra2tra2tra2tra2tra2tra2tra2tra2s="
+
+func setRequiredEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("ENV", "test")
+	t.Setenv("ACCESS_TOKEN_SECRET_KEY", testSecretKey)
+	t.Setenv("REFRESH_TOKEN_SECRET_KEY", testSecretKey)
+	t.Setenv("ALLOWED_ORIGINS", "https://app.example.com")
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Layers(t *testing.T) {
+	setRequiredEnv(t)
+	path := writeFile(t, "config.yaml", `
+port: 9090
+log_level: debug
+db:
+  host: db.internal
+  password: from-file
+shutdown_timeout: 10s
+`)
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("DB_PASSWORD_FILE", writeFile(t, "db_password", "from-secret-file\n"))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 9090, cfg.Port, "file overrides default")
+	assert.Equal(t, "db.internal", cfg.DBHost, "nested keys are joined with _")
+	assert.Equal(t, 10*time.Second, cfg.ShutdownTimeout)
+	assert.Equal(t, "warn", cfg.LogLevel, "env overrides file")
+	assert.Equal(t, "from-secret-file", cfg.DBPassword, "_FILE overrides file")
+	assert.Equal(t, "company", cfg.DBCompany, "default is kept")
+}
+
+func TestLoad_TOML(t *testing.T) {
+	setRequiredEnv(t)
+	// 環境変数が優先されるため、ファイルの値を確認する項目は未設定にする（t.Setenv の後始末で元に戻る）
+	require.NoError(t, os.Unsetenv("ALLOWED_ORIGINS"))
+	path := writeFile(t, "config.toml", `
+port = 9091
+allowed_origins = ["https://app.example.com", "https://*.preview.example.com"]
+trace_sample_ratio = 0.5
+http2_enabled = false
+`)
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 9091, cfg.Port)
+	assert.Equal(t, []string{"https://app.example.com", "https://*.preview.example.com"}, cfg.AllowedOrigins)
+	assert.Equal(t, 0.5, cfg.TraceSampleRatio)
+	assert.False(t, cfg.HTTP2Enabled)
+}
+
+// TestLoad_WithoutTokenSecretKeys はトークンを扱わないバッチ（migrate, purge など）が、トークンの秘密鍵なしで設定を読み込めることを確認する
+func TestLoad_WithoutTokenSecretKeys(t *testing.T) {
+	setRequiredEnv(t)
+	require.NoError(t, os.Unsetenv("ACCESS_TOKEN_SECRET_KEY"))
+	require.NoError(t, os.Unsetenv("REFRESH_TOKEN_SECRET_KEY"))
+	cfg, err := Load("")
+	require.NoError(t, err)
+	assert.Empty(t, cfg.AccessTokenSecretKey)
+	assert.Empty(t, cfg.RefreshTokenSecretKey)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:    "unknown key",
+			file:    "db_hots: db.internal\n",
+			wantErr: "unknown key: db_hots",
+		},
+		{
+			name:    "invalid value type",
+			file:    "port: eighty\n",
+			wantErr: "port",
+		},
+		{
+			name:    "validation",
+			env:     map[string]string{"LOG_LEVEL": "verbose", "PORT": "0"},
+			wantErr: "PORT: failed on min=1; LOG_LEVEL: failed on oneof=debug info warn error",
+		},
+		{
+			name:    "invalid secret key",
+			env:     map[string]string{"ACCESS_TOKEN_SECRET_KEY": "not base64!"},
+			wantErr: "ACCESS_TOKEN_SECRET_KEY: failed on base64",
+		},
+		{
+			name:    "tls cert without key",
+			env:     map[string]string{"TLS_CERT_FILE": "/dev/null"},
+			wantErr: "TLS_KEY_FILE: failed on required_with=TLSCertFile",
+		},
+		{
+			name:    "allowed origins required outside dev",
+			env:     map[string]string{"ENV": "prd", "ALLOWED_ORIGINS": " , "},
+			wantErr: "ALLOWED_ORIGINS: failed on required_unless=Env dev",
+		},
+		{
+			name:    "replica requires mysql",
+			env:     map[string]string{"DB_DRIVER": "memory", "DB_COMMON_REPLICA_DSN": "user:pass@tcp(replica:3306)/common"},
+			wantErr: "DB_COMMON_REPLICA_DSN: failed on excluded_unless=DBDriver mysql",
+		},
+		{
+			name:    "both env and _FILE",
+			env:     map[string]string{"ADMIN_API_KEY": "a", "ADMIN_API_KEY_FILE": "/dev/null"},
+			wantErr: "both ADMIN_API_KEY and ADMIN_API_KEY_FILE are set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRequiredEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			var path string
+			if tt.file != "" {
+				path = writeFile(t, "config.yaml", tt.file)
+			}
+			_, err := Load(path)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPrint(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("ADMIN_API_KEY", "")
+	t.Setenv("ALLOWED_ORIGINS", "https://app.example.com, https://*.preview.example.com")
+	cfg, err := Load("")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, Print(&buf, cfg))
+	out := buf.String()
+	assert.Contains(t, out, "PORT=8080\n")
+	assert.Contains(t, out, "SHUTDOWN_TIMEOUT=30s\n")
+	assert.Contains(t, out, "ALLOWED_ORIGINS=https://app.example.com,https://*.preview.example.com\n")
+	assert.Contains(t, out, "ACCESS_TOKEN_SECRET_KEY=[REDACTED]\n")
+	assert.Contains(t, out, "DB_PASSWORD=[REDACTED]\n")
+	assert.Contains(t, out, "ADMIN_API_KEY=\n")
+	assert.NotContains(t, out, testSecretKey)
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// readFile は YAML か TOML の設定ファイルを読み込み、キーを小文字の環境変数名（db_host など）にそろえて返す。
+// ネストしたキーは _ でつなぐため、db: {host: x} と db_host: x は同じ意味になる
+func readFile(path string) (map[string]any, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	raw := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, &raw)
+	case ".toml":
+		err = toml.Unmarshal(b, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported config file format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	values := map[string]any{}
+	flatten(values, "", raw)
+	return values, nil
+}
+
+func flatten(dst map[string]any, prefix string, src map[string]any) {
+	for k, v := range src {
+		key := strings.ToLower(k)
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+		if nested, ok := v.(map[string]any); ok {
+			flatten(dst, key, nested)
+			continue
+		}
+		dst[key] = v
+	}
+}
+
+// applyFileValues は設定ファイルの値を反映する。環境変数が設定されている項目は環境変数を優先するため上書きしない
+func applyFileValues(cfg *Config, values map[string]any) error {
+	rv := reflect.ValueOf(cfg).Elem()
+	rt := rv.Type()
+	known := make(map[string]bool, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		name := rt.Field(i).Tag.Get("env")
+		key := strings.ToLower(name)
+		known[key] = true
+		v, ok := values[key]
+		if !ok {
+			continue
+		}
+		if _, ok := os.LookupEnv(name); ok {
+			continue
+		}
+		if err := setField(rv.Field(i), fileValueString(v)); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	// 綴りの誤りに気付けるよう、知らないキーはエラーにする
+	for key := range values {
+		if !known[key] {
+			return fmt.Errorf("unknown key: %s", key)
+		}
+	}
+	return nil
+}
+
+// applySecretFiles は <環境変数名>_FILE が設定されている項目に、そのファイルの内容（末尾の改行を除く）を設定する
+func applySecretFiles(cfg *Config) error {
+	rv := reflect.ValueOf(cfg).Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		name := rt.Field(i).Tag.Get("env")
+		path, ok := os.LookupEnv(name + "_FILE")
+		if !ok {
+			continue
+		}
+		if _, ok := os.LookupEnv(name); ok {
+			return fmt.Errorf("both %s and %s_FILE are set", name, name)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s_FILE: %w", name, err)
+		}
+		if err := setField(rv.Field(i), strings.TrimRight(string(b), "\r\n")); err != nil {
+			return fmt.Errorf("%s_FILE: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// fileValueString は設定ファイルの値を環境変数と同じ形式の文字列にする。配列はカンマ区切りにする
+func fileValueString(v any) string {
+	list, ok := v.([]any)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	items := make([]string, 0, len(list))
+	for _, item := range list {
+		items = append(items, fmt.Sprint(item))
+	}
+	return strings.Join(items, ",")
+}
+
+// trimLists はカンマ区切りのリストの各要素の前後の空白を除き、空の要素を取り除く。
+// 環境変数が空文字列の場合に、空文字列1つからなるリストにならないようにする
+func trimLists(cfg *Config) {
+	rv := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		list, ok := rv.Field(i).Interface().([]string)
+		if !ok {
+			continue
+		}
+		var trimmed []string
+		for _, item := range list {
+			if item = strings.TrimSpace(item); item != "" {
+				trimmed = append(trimmed, item)
+			}
+		}
+		rv.Field(i).Set(reflect.ValueOf(trimmed))
+	}
+}
+
+func setField(field reflect.Value, value string) error {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type: %s", field.Type())
+		}
+		field.Set(reflect.ValueOf(strings.Split(value, ",")))
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type: %s", field.Type())
+	}
+	return nil
+}
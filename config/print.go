@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// Print は有効な設定値を環境変数の形式（KEY=value）で書き出す。secret タグの付いた項目は値を伏せる
+func Print(w io.Writer, cfg *Config) error {
+	rv := reflect.ValueOf(cfg).Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		var value string
+		if list, ok := rv.Field(i).Interface().([]string); ok {
+			value = strings.Join(list, ",")
+		} else {
+			value = fmt.Sprint(rv.Field(i).Interface())
+		}
+		if f.Tag.Get("secret") == "true" && value != "" {
+			value = redacted
+		}
+		if _, err := fmt.Fprintf(w, "%s=%s\n", f.Tag.Get("env"), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
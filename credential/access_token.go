@@ -6,13 +6,9 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
-	"net/http"
-	"os"
 	"strconv"
 	"strings"
 
-	"github.com/joho/godotenv"
-
 	"github.com/yuyacode/AppLiftMessageApi/handler"
 )
 
@@ -44,15 +40,15 @@ func DecryptAccessToken(accessToken string) (string, int64, error) {
 	decoded, err := base64.StdEncoding.DecodeString(accessToken)
 	if err != nil {
 		return "", 0, handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to decode access token",
-			err.Error(),
+			handler.ErrCodeInvalidToken,
+			"invalid_token",
+			fmt.Sprintf("failed to decode access token: %v", err),
 		)
 	}
 	secretKey, err := getAccessTokenSecretKey()
 	if err != nil {
 		return "", 0, handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to get access token secret key",
 			err.Error(),
 		)
@@ -60,7 +56,7 @@ func DecryptAccessToken(accessToken string) (string, int64, error) {
 	block, err := aes.NewCipher(secretKey)
 	if err != nil {
 		return "", 0, handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to create cipher block",
 			err.Error(),
 		)
@@ -68,14 +64,14 @@ func DecryptAccessToken(accessToken string) (string, int64, error) {
 	aesGCM, err := cipher.NewGCM(block)
 	if err != nil {
 		return "", 0, handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to create GCM",
 			err.Error(),
 		)
 	}
 	if len(decoded) < aesGCM.NonceSize() {
 		return "", 0, handler.NewServiceError(
-			http.StatusUnauthorized,
+			handler.ErrCodeInvalidToken,
 			"invalid_token",
 			"access token too short",
 		)
@@ -84,16 +80,16 @@ func DecryptAccessToken(accessToken string) (string, int64, error) {
 	plainText, err := aesGCM.Open(nil, nonce, cipherText, nil)
 	if err != nil {
 		return "", 0, handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to decrypt access token",
-			err.Error(),
+			handler.ErrCodeInvalidToken,
+			"invalid_token",
+			fmt.Sprintf("failed to decrypt access token: %v", err),
 		)
 	}
 	parsedData := string(plainText)
 	parts := strings.Split(parsedData, "|")
 	if len(parts) != 3 {
 		return "", 0, handler.NewServiceError(
-			http.StatusUnauthorized,
+			handler.ErrCodeInvalidToken,
 			"invalid_token",
 			"invalid access token format",
 		)
@@ -103,14 +99,14 @@ func DecryptAccessToken(accessToken string) (string, int64, error) {
 		appKind = strings.TrimPrefix(parts[0], "appkind:")
 		if appKind != "company" && appKind != "student" {
 			return "", 0, handler.NewServiceError(
-				http.StatusUnauthorized,
+				handler.ErrCodeInvalidToken,
 				"invalid_token",
 				fmt.Sprintf("invalid appkind in access token: %v", err),
 			)
 		}
 	} else {
 		return "", 0, handler.NewServiceError(
-			http.StatusUnauthorized,
+			handler.ErrCodeInvalidToken,
 			"invalid_token",
 			"appkind not found in access token",
 		)
@@ -121,14 +117,14 @@ func DecryptAccessToken(accessToken string) (string, int64, error) {
 		userID, err = strconv.ParseInt(userIDStr, 10, 64)
 		if err != nil {
 			return "", 0, handler.NewServiceError(
-				http.StatusUnauthorized,
+				handler.ErrCodeInvalidToken,
 				"invalid_token",
 				fmt.Sprintf("invalid user_id in access token: %v", err),
 			)
 		}
 	} else {
 		return "", 0, handler.NewServiceError(
-			http.StatusUnauthorized,
+			handler.ErrCodeInvalidToken,
 			"invalid_token",
 			"user_id not found in access token",
 		)
@@ -137,19 +133,10 @@ func DecryptAccessToken(accessToken string) (string, int64, error) {
 }
 
 func getAccessTokenSecretKey() ([]byte, error) {
-	// ローカル環境（ENV=dev）でのみ環境変数を.envで管理している
-	if err := godotenv.Load("/app/.env"); err != nil {
-		if os.Getenv("ENV") == "dev" {
-			return nil, err
-		}
-	}
-	secretKeyBase64 := os.Getenv("ACCESS_TOKEN_SECRET_KEY")
-	if secretKeyBase64 == "" {
-		return nil, fmt.Errorf("ACCESS_TOKEN_SECRET_KEY environment variable not set")
-	}
-	secretKey, err := base64.StdEncoding.DecodeString(secretKeyBase64)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode access token secret key: %w", err)
+	secretKeys.RLock()
+	defer secretKeys.RUnlock()
+	if len(secretKeys.accessToken) == 0 {
+		return nil, fmt.Errorf("access token secret key is not set")
 	}
-	return secretKey, nil
+	return secretKeys.accessToken, nil
 }
@@ -0,0 +1,42 @@
+package credential
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func SignAttachmentURL(secretKey []byte, id entity.AttachmentID, expiresAt time.Time) string {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", attachmentSignature(secretKey, id, expires))
+	return fmt.Sprintf("/messages/attachments/%d?%s", id, query.Encode())
+}
+
+func VerifyAttachmentSignature(secretKey []byte, id entity.AttachmentID, expires, signature string, now time.Time) error {
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid expires: %w", err)
+	}
+	expected := attachmentSignature(secretKey, id, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	if now.Unix() > expiresUnix {
+		return fmt.Errorf("download link has expired")
+	}
+	return nil
+}
+
+func attachmentSignature(secretKey []byte, id entity.AttachmentID, expires string) string {
+	mac := hmac.New(sha256.New, secretKey)
+	mac.Write([]byte(fmt.Sprintf("attachment:%d|expires:%s", id, expires)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -6,13 +6,9 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
-	"net/http"
-	"os"
 	"strconv"
 	"strings"
 
-	"github.com/joho/godotenv"
-
 	"github.com/yuyacode/AppLiftMessageApi/handler"
 )
 
@@ -44,15 +40,15 @@ func DecryptRefreshToken(refreshToken string) (string, int64, error) {
 	decoded, err := base64.StdEncoding.DecodeString(refreshToken)
 	if err != nil {
 		return "", 0, handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to decode refresh token",
-			err.Error(),
+			handler.ErrCodeInvalidToken,
+			"invalid_token",
+			fmt.Sprintf("failed to decode refresh token: %v", err),
 		)
 	}
 	secretKey, err := getRefreshTokenSecretKey()
 	if err != nil {
 		return "", 0, handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to get refresh token secret key",
 			err.Error(),
 		)
@@ -60,7 +56,7 @@ func DecryptRefreshToken(refreshToken string) (string, int64, error) {
 	block, err := aes.NewCipher(secretKey)
 	if err != nil {
 		return "", 0, handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to create cipher block",
 			err.Error(),
 		)
@@ -68,14 +64,14 @@ func DecryptRefreshToken(refreshToken string) (string, int64, error) {
 	aesGCM, err := cipher.NewGCM(block)
 	if err != nil {
 		return "", 0, handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to create GCM",
 			err.Error(),
 		)
 	}
 	if len(decoded) < aesGCM.NonceSize() {
 		return "", 0, handler.NewServiceError(
-			http.StatusUnauthorized,
+			handler.ErrCodeInvalidToken,
 			"invalid_token",
 			"refresh token too short",
 		)
@@ -84,16 +80,16 @@ func DecryptRefreshToken(refreshToken string) (string, int64, error) {
 	plainText, err := aesGCM.Open(nil, nonce, cipherText, nil)
 	if err != nil {
 		return "", 0, handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to decrypt refresh token",
-			err.Error(),
+			handler.ErrCodeInvalidToken,
+			"invalid_token",
+			fmt.Sprintf("failed to decrypt refresh token: %v", err),
 		)
 	}
 	parsedData := string(plainText)
 	parts := strings.Split(parsedData, "|")
 	if len(parts) != 3 {
 		return "", 0, handler.NewServiceError(
-			http.StatusUnauthorized,
+			handler.ErrCodeInvalidToken,
 			"invalid_token",
 			"invalid refresh token format",
 		)
@@ -103,14 +99,14 @@ func DecryptRefreshToken(refreshToken string) (string, int64, error) {
 		appKind = strings.TrimPrefix(parts[0], "appkind:")
 		if appKind != "company" && appKind != "student" {
 			return "", 0, handler.NewServiceError(
-				http.StatusUnauthorized,
+				handler.ErrCodeInvalidToken,
 				"invalid_token",
 				fmt.Sprintf("invalid appkind in refresh token: %v", err),
 			)
 		}
 	} else {
 		return "", 0, handler.NewServiceError(
-			http.StatusUnauthorized,
+			handler.ErrCodeInvalidToken,
 			"invalid_token",
 			"appkind not found in refresh token",
 		)
@@ -121,14 +117,14 @@ func DecryptRefreshToken(refreshToken string) (string, int64, error) {
 		userID, err = strconv.ParseInt(userIDStr, 10, 64)
 		if err != nil {
 			return "", 0, handler.NewServiceError(
-				http.StatusUnauthorized,
+				handler.ErrCodeInvalidToken,
 				"invalid_token",
 				fmt.Sprintf("invalid user_id in refresh token: %v", err),
 			)
 		}
 	} else {
 		return "", 0, handler.NewServiceError(
-			http.StatusUnauthorized,
+			handler.ErrCodeInvalidToken,
 			"invalid_token",
 			"user_id not found in refresh token",
 		)
@@ -137,19 +133,10 @@ func DecryptRefreshToken(refreshToken string) (string, int64, error) {
 }
 
 func getRefreshTokenSecretKey() ([]byte, error) {
-	// ローカル環境（ENV=dev）でのみ環境変数を.envで管理している
-	if err := godotenv.Load("/app/.env"); err != nil {
-		if os.Getenv("ENV") == "dev" {
-			return nil, err
-		}
-	}
-	secretKeyBase64 := os.Getenv("REFRESH_TOKEN_SECRET_KEY")
-	if secretKeyBase64 == "" {
-		return nil, fmt.Errorf("REFRESH_TOKEN_SECRET_KEY environment variable not set")
-	}
-	secretKey, err := base64.StdEncoding.DecodeString(secretKeyBase64)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode refresh token secret key: %w", err)
+	secretKeys.RLock()
+	defer secretKeys.RUnlock()
+	if len(secretKeys.refreshToken) == 0 {
+		return nil, fmt.Errorf("refresh token secret key is not set")
 	}
-	return secretKey, nil
+	return secretKeys.refreshToken, nil
 }
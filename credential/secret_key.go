@@ -0,0 +1,60 @@
+package credential
+
+import (
+	"context"
+	"crypto/aes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// secretKeys はトークンの暗号化に使う秘密鍵。起動時に SetSecretKeys で設定する
+var secretKeys struct {
+	sync.RWMutex
+	accessToken  []byte
+	refreshToken []byte
+}
+
+// SetSecretKeys は base64 でエンコードされたアクセストークンとリフレッシュトークンの秘密鍵を設定する。
+// バッチは秘密鍵なしで動くため、設定の読み込みでは必須にせず、サーバーの起動時にここで確認する
+func SetSecretKeys(accessTokenSecretKey, refreshTokenSecretKey string) error {
+	if accessTokenSecretKey == "" {
+		return errors.New("ACCESS_TOKEN_SECRET_KEY is not set")
+	}
+	if refreshTokenSecretKey == "" {
+		return errors.New("REFRESH_TOKEN_SECRET_KEY is not set")
+	}
+	accessToken, err := base64.StdEncoding.DecodeString(accessTokenSecretKey)
+	if err != nil {
+		return fmt.Errorf("failed to decode access token secret key: %w", err)
+	}
+	refreshToken, err := base64.StdEncoding.DecodeString(refreshTokenSecretKey)
+	if err != nil {
+		return fmt.Errorf("failed to decode refresh token secret key: %w", err)
+	}
+	// AES の鍵として使えるか（16, 24, 32 バイトか）を起動時に確認する
+	if _, err := aes.NewCipher(accessToken); err != nil {
+		return fmt.Errorf("invalid access token secret key: %w", err)
+	}
+	if _, err := aes.NewCipher(refreshToken); err != nil {
+		return fmt.Errorf("invalid refresh token secret key: %w", err)
+	}
+	secretKeys.Lock()
+	defer secretKeys.Unlock()
+	secretKeys.accessToken = accessToken
+	secretKeys.refreshToken = refreshToken
+	return nil
+}
+
+// CheckAccessTokenSecretKey はアクセストークンの秘密鍵を読み込めるかを確認する。readiness プローブで使う
+func CheckAccessTokenSecretKey(ctx context.Context) error {
+	_, err := getAccessTokenSecretKey()
+	return err
+}
+
+// CheckRefreshTokenSecretKey はリフレッシュトークンの秘密鍵を読み込めるかを確認する。readiness プローブで使う
+func CheckRefreshTokenSecretKey(ctx context.Context) error {
+	_, err := getRefreshTokenSecretKey()
+	return err
+}
@@ -0,0 +1,639 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/yuyacode/AppLiftMessageApi/client"
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/config"
+	"github.com/yuyacode/AppLiftMessageApi/credential"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/migration"
+	"github.com/yuyacode/AppLiftMessageApi/service"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+const (
+	e2eAllowedOrigin = "https://app.example.com"
+	e2eCompanyAPIKey = "company-api-key"
+	e2eStudentAPIKey = "student-api-key"
+	e2eCompanyUserID = 1
+	e2eStudentUserID = 2
+)
+
+// e2eServer は NewMux を SQLite（DB_DRIVER=sqlite）に接続して起動する。
+// DBs はテストからフィクスチャの投入や、発行されたトークンの確認に使う
+type e2eServer struct {
+	URL       string
+	DBs       map[string]*sqlx.DB
+	Readiness *handler.Readiness
+	Mux       http.Handler
+}
+
+func newE2EServer(t *testing.T) *e2eServer {
+	t.Helper()
+	ctx := context.Background()
+	secretKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("k"), 32))
+	t.Setenv("ENV", "test")
+	t.Setenv("ALLOWED_ORIGINS", e2eAllowedOrigin+", https://student.example.jp, https://*.preview.example.com")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+	t.Setenv("ACCESS_TOKEN_SECRET_KEY", secretKey)
+	t.Setenv("REFRESH_TOKEN_SECRET_KEY", secretKey)
+	t.Setenv("ATTACHMENT_URL_SECRET_KEY", secretKey)
+	t.Setenv("DB_DRIVER", "sqlite")
+	t.Setenv("SQLITE_DIR", t.TempDir())
+	t.Setenv("BLOB_DRIVER", "local")
+	t.Setenv("BLOB_LOCAL_DIR", t.TempDir())
+	cfg, err := config.NewConfig()
+	require.NoError(t, err)
+
+	dbs := make(map[string]*sqlx.DB)
+	for _, targetDB := range []string{"company", "student", "common"} {
+		db, closeFunc, err := store.New(ctx, cfg, targetDB)
+		t.Cleanup(closeFunc)
+		require.NoError(t, err)
+		migrations, err := migration.Load(cfg.DBDriver, targetDB)
+		require.NoError(t, err)
+		_, err = migration.NewMigrator(db, migrations, clock.RealClocker{}).Up(ctx)
+		require.NoError(t, err)
+		dbs[targetDB] = db
+	}
+	now := time.Now()
+	for targetDB, apiKey := range map[string]string{"company": e2eCompanyAPIKey, "student": e2eStudentAPIKey} {
+		_, err := dbs[targetDB].ExecContext(ctx, "INSERT INTO message_api_keys (api_key, created_at) VALUES (?, ?);", credential.HashAPIKey(apiKey), now)
+		require.NoError(t, err)
+	}
+	_, err = dbs["common"].ExecContext(ctx, "INSERT INTO message_threads (company_user_id, student_user_id, created_at) VALUES (?, ?, ?);", e2eCompanyUserID, e2eStudentUserID, now)
+	require.NoError(t, err)
+
+	readiness := handler.NewReadiness()
+	mux, dbCloseFuncs, err := NewMux(ctx, cfg, readiness)
+	for _, f := range dbCloseFuncs {
+		t.Cleanup(f)
+	}
+	require.NoError(t, err)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return &e2eServer{URL: srv.URL, DBs: dbs, Readiness: readiness, Mux: mux}
+}
+
+func (s *e2eServer) do(t *testing.T, method, path, token string, body any, header http.Header) (*http.Response, []byte) {
+	t.Helper()
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		require.NoError(t, err)
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, s.URL+path, r)
+	require.NoError(t, err)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, b
+}
+
+type e2eCredential struct {
+	ClientID     string `db:"client_id"`
+	ClientSecret string `db:"client_secret"`
+	AccessToken  string `db:"access_token"`
+	RefreshToken string `db:"refresh_token"`
+}
+
+// register は API キーで認証情報を発行し、DB に保存されたトークンを返す
+func (s *e2eServer) register(t *testing.T, appKind, apiKey string, userID int64) *e2eCredential {
+	t.Helper()
+	resp, body := s.do(t, http.MethodPost, "/messages/register", apiKey, map[string]any{"user_id": userID, "app_kind": appKind}, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	var c e2eCredential
+	err := s.DBs[appKind].GetContext(context.Background(), &c, "SELECT client_id, client_secret, access_token, refresh_token FROM message_api_credentials WHERE user_id = ? AND deleted_at IS NULL;", userID)
+	require.NoError(t, err)
+	return &c
+}
+
+type e2eMessage struct {
+	ID            int64  `json:"id"`
+	IsFromCompany int8   `json:"is_from_company"`
+	Content       string `json:"content"`
+	IsSent        int8   `json:"is_sent"`
+}
+
+func (s *e2eServer) getMessages(t *testing.T, token string) []e2eMessage {
+	t.Helper()
+	resp, body := s.do(t, http.MethodGet, "/messages?thread_id=1", token, nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	var messages []e2eMessage
+	require.NoError(t, json.Unmarshal(body, &messages))
+	return messages
+}
+
+// TestNewMux_RequiresSecretKeys は、設定の読み込みでは任意の秘密鍵が、サーバーの起動には必要なことを確認する
+func TestNewMux_RequiresSecretKeys(t *testing.T) {
+	secretKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("k"), 32))
+	tests := map[string]struct {
+		env     map[string]string
+		wantErr string
+	}{
+		"token secret key": {
+			env:     map[string]string{"ACCESS_TOKEN_SECRET_KEY": "", "REFRESH_TOKEN_SECRET_KEY": ""},
+			wantErr: "ACCESS_TOKEN_SECRET_KEY is not set",
+		},
+		"attachment url secret key": {
+			env:     map[string]string{"ATTACHMENT_URL_SECRET_KEY": ""},
+			wantErr: "ATTACHMENT_URL_SECRET_KEY is not set",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("ENV", "test")
+			t.Setenv("ALLOWED_ORIGINS", e2eAllowedOrigin)
+			t.Setenv("ACCESS_TOKEN_SECRET_KEY", secretKey)
+			t.Setenv("REFRESH_TOKEN_SECRET_KEY", secretKey)
+			t.Setenv("ATTACHMENT_URL_SECRET_KEY", secretKey)
+			t.Setenv("DB_DRIVER", "sqlite")
+			t.Setenv("SQLITE_DIR", t.TempDir())
+			t.Setenv("BLOB_DRIVER", "local")
+			t.Setenv("BLOB_LOCAL_DIR", t.TempDir())
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			cfg, err := config.NewConfig()
+			require.NoError(t, err)
+			_, dbCloseFuncs, err := NewMux(context.Background(), cfg, handler.NewReadiness())
+			for _, f := range dbCloseFuncs {
+				t.Cleanup(f)
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestE2E_MessageFlow(t *testing.T) {
+	s := newE2EServer(t)
+	company := s.register(t, "company", e2eCompanyAPIKey, e2eCompanyUserID)
+	student := s.register(t, "student", e2eStudentAPIKey, e2eStudentUserID)
+
+	var messageID int64
+	t.Run("add", func(t *testing.T) {
+		resp, body := s.do(t, http.MethodPost, "/messages", company.AccessToken, map[string]any{
+			"message_thread_id": 1,
+			"is_from_company":   1,
+			"is_from_student":   0,
+			"content":           "面接日程のご案内です",
+			"is_sent":           1,
+			"sent_at":           time.Now().Format(time.RFC3339),
+		}, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		var rsp struct {
+			ID int64 `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(body, &rsp))
+		assert.NotZero(t, rsp.ID)
+		messageID = rsp.ID
+	})
+
+	t.Run("get from both sides", func(t *testing.T) {
+		for _, token := range []string{company.AccessToken, student.AccessToken} {
+			messages := s.getMessages(t, token)
+			require.Len(t, messages, 1)
+			assert.Equal(t, messageID, messages[0].ID)
+			assert.Equal(t, "面接日程のご案内です", messages[0].Content)
+		}
+	})
+
+	t.Run("student cannot edit company message", func(t *testing.T) {
+		resp, body := s.do(t, http.MethodPatch, fmt.Sprintf("/messages/%d", messageID), student.AccessToken, map[string]any{"content": "改ざん"}, nil)
+		// 自分が送信したメッセージ以外は、存在しないメッセージと同じく 404 とする
+		require.Equal(t, http.StatusNotFound, resp.StatusCode, string(body))
+		assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+		var errResp handler.ErrResponse
+		require.NoError(t, json.Unmarshal(body, &errResp))
+		assert.Equal(t, handler.ErrCodeMessageNotFound, errResp.Code)
+		messages := s.getMessages(t, company.AccessToken)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "面接日程のご案内です", messages[0].Content)
+	})
+
+	t.Run("edit", func(t *testing.T) {
+		resp, body := s.do(t, http.MethodPatch, fmt.Sprintf("/messages/%d", messageID), company.AccessToken, map[string]any{"content": "面接日程を変更しました"}, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		messages := s.getMessages(t, student.AccessToken)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "面接日程を変更しました", messages[0].Content)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		resp, body := s.do(t, http.MethodDelete, fmt.Sprintf("/messages/%d", messageID), company.AccessToken, nil, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		assert.Empty(t, s.getMessages(t, company.AccessToken))
+		assert.Empty(t, s.getMessages(t, student.AccessToken))
+	})
+}
+
+func TestE2E_Register(t *testing.T) {
+	s := newE2EServer(t)
+	t.Run("invalid api key", func(t *testing.T) {
+		resp, body := s.do(t, http.MethodPost, "/messages/register", e2eStudentAPIKey, map[string]any{"user_id": e2eCompanyUserID, "app_kind": "company"}, nil)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, string(body))
+	})
+	t.Run("missing api key", func(t *testing.T) {
+		resp, body := s.do(t, http.MethodPost, "/messages/register", "", map[string]any{"user_id": e2eCompanyUserID, "app_kind": "company"}, nil)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, string(body))
+	})
+}
+
+func TestE2E_ExpiredAccessToken(t *testing.T) {
+	s := newE2EServer(t)
+	c := s.register(t, "company", e2eCompanyAPIKey, e2eCompanyUserID)
+	_, err := s.DBs["company"].ExecContext(context.Background(), "UPDATE message_api_credentials SET expires_at = ? WHERE user_id = ?;", time.Now().Add(-time.Minute), e2eCompanyUserID)
+	require.NoError(t, err)
+
+	resp, body := s.do(t, http.MethodGet, "/messages?thread_id=1", c.AccessToken, nil, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Contains(t, string(body), "token_expired")
+
+	t.Run("refresh with wrong client secret", func(t *testing.T) {
+		resp, body := s.do(t, http.MethodPost, "/messages/token", "", map[string]any{"refresh_token": c.RefreshToken, "client_id": c.ClientID, "client_secret": "wrong"}, nil)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Contains(t, string(body), "client_secret is invalid")
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		resp, body := s.do(t, http.MethodPost, "/messages/token", "", map[string]any{"refresh_token": c.RefreshToken, "client_id": c.ClientID, "client_secret": c.ClientSecret}, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		var rsp struct {
+			AccessToken  string `json:"access_token"`
+			RefreshToken string `json:"refresh_token"`
+		}
+		require.NoError(t, json.Unmarshal(body, &rsp))
+		assert.NotEqual(t, c.AccessToken, rsp.AccessToken)
+		assert.Empty(t, s.getMessages(t, rsp.AccessToken))
+
+		resp, _ = s.do(t, http.MethodGet, "/messages?thread_id=1", c.AccessToken, nil, nil)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+// TestE2E_PurgeKeepsRefreshableCredential は、アクセストークンの期限が切れていてもリフレッシュできる認証情報は削除せず、
+// 論理削除された認証情報のみを削除することを確認する
+func TestE2E_PurgeKeepsRefreshableCredential(t *testing.T) {
+	s := newE2EServer(t)
+	ctx := context.Background()
+	c := s.register(t, "company", e2eCompanyAPIKey, e2eCompanyUserID)
+	s.register(t, "student", e2eStudentAPIKey, e2eStudentUserID)
+	longAgo := time.Now().AddDate(-1, 0, 0)
+	_, err := s.DBs["company"].ExecContext(ctx, "UPDATE message_api_credentials SET expires_at = ? WHERE user_id = ?;", longAgo, e2eCompanyUserID)
+	require.NoError(t, err)
+	_, err = s.DBs["student"].ExecContext(ctx, "UPDATE message_api_credentials SET deleted_at = ? WHERE user_id = ?;", longAgo, e2eStudentUserID)
+	require.NoError(t, err)
+
+	now := time.Now()
+	policy := entity.RetentionPolicy{DeletedMessagesBefore: now, AbandonedDraftsBefore: now, DeletedCredentialsBefore: now}
+	report, err := service.NewPurge(s.DBs, store.NewPurgeRepository(clock.RealClocker{}), nil).Purge(ctx, policy, 100, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), report.CompanyAPICredentials)
+	assert.Equal(t, int64(1), report.StudentAPICredentials)
+
+	resp, body := s.do(t, http.MethodPost, "/messages/token", "", map[string]any{"refresh_token": c.RefreshToken, "client_id": c.ClientID, "client_secret": c.ClientSecret}, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+}
+
+// TestE2E_InvalidToken はクライアントが送った不正なトークンが 500 ではなく 401 になることを確認する
+func TestE2E_InvalidToken(t *testing.T) {
+	t.Setenv("ADMIN_API_KEY", "admin-api-key")
+	s := newE2EServer(t)
+	c := s.register(t, "company", e2eCompanyAPIKey, e2eCompanyUserID)
+	// 形式は正しいが、サーバーの鍵で暗号化されていないトークン
+	forged := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("x"), 64))
+	tests := map[string]struct {
+		method string
+		path   string
+		token  string
+		body   any
+	}{
+		"malformed access token": {method: http.MethodGet, path: "/messages?thread_id=1", token: "garbage!!"},
+		"forged access token":    {method: http.MethodGet, path: "/messages?thread_id=1", token: forged},
+		"forged refresh token": {
+			method: http.MethodPost,
+			path:   "/messages/token",
+			body:   map[string]any{"refresh_token": forged, "client_id": c.ClientID, "client_secret": c.ClientSecret},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, body := s.do(t, tt.method, tt.path, tt.token, tt.body, nil)
+			require.Equal(t, http.StatusUnauthorized, resp.StatusCode, string(body))
+			assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+			var errResp handler.ErrResponse
+			require.NoError(t, json.Unmarshal(body, &errResp))
+			assert.Equal(t, handler.ErrCodeInvalidToken, errResp.Code)
+		})
+	}
+
+	resp, body := s.do(t, http.MethodGet, "/metrics", "", nil, http.Header{"X-Admin-Api-Key": {"admin-api-key"}})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), `applift_message_api_token_verification_failures_total{reason="invalid_token",token="refresh"}`)
+}
+
+func TestE2E_CORSPreflight(t *testing.T) {
+	s := newE2EServer(t)
+	tests := map[string]struct {
+		origin          string
+		wantAllowOrigin string
+	}{
+		"allowed origin": {
+			origin:          e2eAllowedOrigin,
+			wantAllowOrigin: e2eAllowedOrigin,
+		},
+		"second origin": {
+			origin:          "https://student.example.jp",
+			wantAllowOrigin: "https://student.example.jp",
+		},
+		"preview deployment": {
+			origin:          "https://pr-42.preview.example.com",
+			wantAllowOrigin: "https://pr-42.preview.example.com",
+		},
+		"other origin": {
+			origin:          "https://evil.example.com",
+			wantAllowOrigin: "",
+		},
+		"suffix without subdomain dot": {
+			origin:          "https://evilpreview.example.com",
+			wantAllowOrigin: "",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, _ := s.do(t, http.MethodOptions, "/messages", "", nil, http.Header{
+				"Origin":                         {tt.origin},
+				"Access-Control-Request-Method":  {http.MethodPost},
+				"Access-Control-Request-Headers": {"Authorization, Content-Type"},
+			})
+			assert.Equal(t, tt.wantAllowOrigin, resp.Header.Get("Access-Control-Allow-Origin"))
+			if tt.wantAllowOrigin != "" {
+				assert.Contains(t, resp.Header.Get("Access-Control-Allow-Methods"), http.MethodPost)
+				assert.Equal(t, "true", resp.Header.Get("Access-Control-Allow-Credentials"))
+			}
+		})
+	}
+}
+
+func TestE2E_RequestID(t *testing.T) {
+	s := newE2EServer(t)
+	t.Run("propagates the given id", func(t *testing.T) {
+		resp, _ := s.do(t, http.MethodGet, "/messages?thread_id=1", "", nil, http.Header{"X-Request-Id": {"client-req-1"}})
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, "client-req-1", resp.Header.Get("X-Request-ID"))
+	})
+	t.Run("issues a new id for an invalid one", func(t *testing.T) {
+		resp, _ := s.do(t, http.MethodGet, "/messages?thread_id=1", "", nil, http.Header{"X-Request-Id": {"bad id/<script>"}})
+		got := resp.Header.Get("X-Request-ID")
+		assert.Len(t, got, 32)
+		assert.NotEqual(t, "bad id/<script>", got)
+	})
+}
+
+func TestE2E_Metrics(t *testing.T) {
+	t.Setenv("ADMIN_API_KEY", "admin-api-key")
+	s := newE2EServer(t)
+	c := s.register(t, "company", e2eCompanyAPIKey, e2eCompanyUserID)
+	resp, body := s.do(t, http.MethodPost, "/messages", c.AccessToken, map[string]any{
+		"message_thread_id": 1,
+		"is_from_company":   1,
+		"content":           "hello",
+		"is_sent":           1,
+		"sent_at":           time.Now().Format(time.RFC3339),
+	}, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	resp, _ = s.do(t, http.MethodGet, "/messages?thread_id=1", "", nil, nil)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// メトリクスは管理用APIキーがないと取得できない
+	resp, _ = s.do(t, http.MethodGet, "/metrics", "", nil, nil)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp, body = s.do(t, http.MethodGet, "/metrics", "", nil, http.Header{"X-Admin-Api-Key": {"admin-api-key"}})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	for _, series := range []string{
+		`applift_message_api_http_requests_total{method="POST",route="/messages",status="200"}`,
+		`applift_message_api_http_request_duration_seconds_bucket{method="POST",route="/messages/register",status="200",le="+Inf"}`,
+		`applift_message_api_registrations_total{app_kind="company"}`,
+		`applift_message_api_tokens_issued_total{app_kind="company",grant="register"}`,
+		`applift_message_api_messages_sent_total{app_kind="company"}`,
+		`applift_message_api_token_verification_failures_total{reason="missing_authorization",token="access"}`,
+		`go_sql_max_open_connections{db_name="common"}`,
+	} {
+		assert.Contains(t, string(body), series)
+	}
+}
+
+func TestE2E_Health(t *testing.T) {
+	s := newE2EServer(t)
+	resp, body := s.do(t, http.MethodGet, "/healthz", "", nil, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"status":"ok"}`, string(body))
+
+	resp, body = s.do(t, http.MethodGet, "/readyz", "", nil, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"status":"ready","checks":{"company":"ok","student":"ok","common":"ok","access_token_secret_key":"ok","refresh_token_secret_key":"ok"}}`, string(body))
+
+	s.Readiness.Drain()
+	resp, body = s.do(t, http.MethodGet, "/readyz", "", nil, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.JSONEq(t, `{"status":"draining"}`, string(body))
+	resp, _ = s.do(t, http.MethodGet, "/healthz", "", nil, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestE2E_BodyLimit(t *testing.T) {
+	s := newE2EServer(t)
+	resp, body := s.do(t, http.MethodPost, "/messages/register", e2eCompanyAPIKey, map[string]any{
+		"user_id":  e2eCompanyUserID,
+		"app_kind": "company",
+		"padding":  strings.Repeat("a", 2<<20),
+	}, nil)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode, string(body))
+}
+
+func TestE2E_Tracing(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	s := newE2EServer(t)
+	c := s.register(t, "company", e2eCompanyAPIKey, e2eCompanyUserID)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	resp, body := s.do(t, http.MethodGet, "/messages?thread_id=1", c.AccessToken, nil, http.Header{
+		"Traceparent": {"00-" + traceID + "-00f067aa0ba902b7-01"},
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range sr.Ended() {
+		if span.SpanContext().TraceID().String() == traceID {
+			spans[span.Name()] = span
+		}
+	}
+	server, ok := spans["GET /messages"]
+	require.True(t, ok, "server span not found")
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	svc, ok := spans["GetMessage.GetAllMessages"]
+	require.True(t, ok, "service span not found")
+	assert.Equal(t, server.SpanContext().SpanID(), svc.Parent().SpanID())
+	var repoSpans int
+	for name, span := range spans {
+		if strings.HasPrefix(name, "MessageRepository.") {
+			repoSpans++
+			assert.Equal(t, svc.SpanContext().SpanID(), span.Parent().SpanID(), name)
+		}
+	}
+	assert.NotZero(t, repoSpans)
+}
+
+func TestE2E_Client(t *testing.T) {
+	s := newE2EServer(t)
+	ctx := context.Background()
+	c, err := client.New(s.URL, client.Credentials{})
+	require.NoError(t, err)
+
+	err = c.Register(ctx, "wrong-api-key", "company", e2eCompanyUserID)
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+
+	require.NoError(t, c.Register(ctx, e2eCompanyAPIKey, "company", e2eCompanyUserID))
+	var cred e2eCredential
+	require.NoError(t, s.DBs["company"].GetContext(ctx, &cred, "SELECT client_id, client_secret, access_token, refresh_token FROM message_api_credentials WHERE user_id = ? AND deleted_at IS NULL;", e2eCompanyUserID))
+	c.SetCredentials(client.Credentials{ClientID: cred.ClientID, ClientSecret: cred.ClientSecret, AccessToken: cred.AccessToken, RefreshToken: cred.RefreshToken})
+
+	id, err := c.AddMessage(ctx, &client.AddMessageRequest{MessageThreadID: 1, IsFromCompany: 1, Content: "面接日程のご案内です", IsSent: 1, SentAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, c.EditMessage(ctx, id, "面接日程を変更しました"))
+
+	// アクセストークンの期限が切れても、自動で再発行して取得できる
+	_, err = s.DBs["company"].ExecContext(ctx, "UPDATE message_api_credentials SET expires_at = ? WHERE user_id = ?;", time.Now().Add(-time.Minute), e2eCompanyUserID)
+	require.NoError(t, err)
+	messages, err := c.ListMessages(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "面接日程を変更しました", messages[0].Content)
+	assert.NotEqual(t, cred.AccessToken, c.Credentials().AccessToken)
+
+	require.NoError(t, c.DeleteMessage(ctx, id))
+	messages, err = c.ListMessages(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+// openapiUndocumentedRoutes は openapi.json に載せないルート
+var openapiUndocumentedRoutes = map[string]bool{
+	"/metrics":      true,
+	"/openapi.json": true,
+	"/docs":         true,
+}
+
+func TestE2E_OpenAPIRoutes(t *testing.T) {
+	t.Setenv("ADMIN_API_KEY", "admin-api-key")
+	s := newE2EServer(t)
+	resp, body := s.do(t, http.MethodGet, "/openapi.json", "", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json; charset=utf-8", resp.Header.Get("Content-Type"))
+	var spec struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(body, &spec))
+	assert.Equal(t, "3.1.0", spec.OpenAPI)
+
+	documented := map[string]bool{}
+	for path, item := range spec.Paths {
+		for method := range item {
+			if method != "parameters" {
+				documented[strings.ToUpper(method)+" "+path] = true
+			}
+		}
+	}
+	routed := map[string]bool{}
+	err := chi.Walk(s.Mux.(chi.Routes), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		route = strings.TrimSuffix(route, "/")
+		// /readyz は Handle で登録しているため、全メソッドで応答する
+		if openapiUndocumentedRoutes[route] || (route == "/readyz" && method != http.MethodGet) {
+			return nil
+		}
+		routed[method+" "+route] = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, routed, documented)
+}
+
+func TestE2E_SwaggerUI(t *testing.T) {
+	// Swagger UI は ENV=dev でのみ公開する
+	s := newE2EServer(t)
+	resp, _ := s.do(t, http.MethodGet, "/docs", "", nil, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestE2E_RouterErrors(t *testing.T) {
+	s := newE2EServer(t)
+	tests := map[string]struct {
+		method   string
+		path     string
+		wantCode handler.ErrorCode
+	}{
+		"unknown route":      {method: http.MethodGet, path: "/unknown", wantCode: handler.ErrCodeNotFound},
+		"method not allowed": {method: http.MethodPut, path: "/healthz", wantCode: handler.ErrCodeMethodNotAllowed},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, body := s.do(t, tt.method, tt.path, "", nil, nil)
+			assert.Equal(t, tt.wantCode.Status(), resp.StatusCode, string(body))
+			assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+			var errResp handler.ErrResponse
+			require.NoError(t, json.Unmarshal(body, &errResp))
+			assert.Equal(t, tt.wantCode, errResp.Code)
+		})
+	}
+}
+
+func TestE2E_LocalizedErrors(t *testing.T) {
+	s := newE2EServer(t)
+	resp, body := s.do(t, http.MethodGet, "/unknown", "", nil, http.Header{"Accept-Language": {"ja-JP,ja;q=0.9"}})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "ja", resp.Header.Get("Content-Language"))
+	var errResp handler.ErrResponse
+	require.NoError(t, json.Unmarshal(body, &errResp))
+	assert.Equal(t, handler.ErrCodeNotFound, errResp.Code)
+	assert.Equal(t, "ページが見つかりません", errResp.Title)
+	assert.Equal(t, "not found", errResp.Message)
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"database/sql"
+)
+
+type AttachmentID int64
+
+type Attachment struct {
+	ID          AttachmentID  `json:"id"           db:"id"`
+	MessageID   MessageID     `json:"message_id"   db:"message_id"`
+	FileName    string        `json:"file_name"    db:"file_name"`
+	ContentType string        `json:"content_type" db:"content_type"`
+	Size        int64         `json:"size"         db:"size"`
+	StorageKey  string        `json:"storage_key"  db:"storage_key"`
+	CreatedAt   *sql.NullTime `json:"created_at"   db:"created_at"`
+	DeletedAt   *sql.NullTime `json:"deleted_at"   db:"deleted_at"`
+}
+
+type Attachments []*Attachment
@@ -0,0 +1,25 @@
+package entity
+
+// 一括送信におけるスレッドごとの処理結果
+const (
+	BulkSendStatusSent      = "sent"
+	BulkSendStatusNotFound  = "not_found"
+	BulkSendStatusForbidden = "forbidden"
+	BulkSendStatusFailed    = "failed"
+)
+
+// BulkSendResult の Code は failed の場合のエラーコード。内部のエラーの内容はレスポンスに含めずログにのみ出力する
+type BulkSendResult struct {
+	MessageThreadID MessageThreadID `json:"message_thread_id"`
+	Status          string          `json:"status"`
+	MessageID       *MessageID      `json:"message_id"`
+	Code            string          `json:"code,omitempty"`
+}
+
+type BulkSendResults []*BulkSendResult
+
+// BulkMessage は一括送信でスレッドごとに送る本文。テンプレートはスレッドごとの変数でレンダリングするため、本文はスレッドごとに異なりうる
+type BulkMessage struct {
+	MessageThreadID MessageThreadID
+	Content         string
+}
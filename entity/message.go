@@ -8,16 +8,29 @@ import (
 type MessageID int64
 
 type Message struct {
-	ID              MessageID       `json:"id"                db:"id"`
-	MessageThreadID MessageThreadID `json:"message_thread_id" db:"message_thread_id"`
-	IsFromCompany   int8            `json:"is_from_company"   db:"is_from_company"`
-	IsFromStudent   int8            `json:"is_from_student"   db:"is_from_student"`
-	Content         string          `json:"content"           db:"content"`
-	IsSent          int8            `json:"is_sent"           db:"is_sent"`
-	SentAt          time.Time       `json:"sent_at"           db:"sent_at"`
-	CreatedAt       *sql.NullTime   `json:"created_at"        db:"created_at"`
-	UpdatedAt       *sql.NullTime   `json:"updated_at"        db:"updated_at"`
-	DeletedAt       *sql.NullTime   `json:"deleted_at"        db:"deleted_at"`
+	ID               MessageID         `json:"id"                  db:"id"`
+	MessageThreadID  MessageThreadID   `json:"message_thread_id"   db:"message_thread_id"`
+	IsFromCompany    int8              `json:"is_from_company"     db:"is_from_company"`
+	IsFromStudent    int8              `json:"is_from_student"     db:"is_from_student"`
+	Content          string            `json:"content"             db:"content"`
+	IsSent           int8              `json:"is_sent"             db:"is_sent"`
+	SentAt           time.Time         `json:"sent_at"             db:"sent_at"`
+	CreatedAt        *sql.NullTime     `json:"created_at"          db:"created_at"`
+	UpdatedAt        *sql.NullTime     `json:"updated_at"          db:"updated_at"`
+	DeletedAt        *sql.NullTime     `json:"deleted_at"          db:"deleted_at"`
+	ReplyToMessageID *MessageID        `json:"reply_to_message_id" db:"reply_to_message_id"`
+	ReplyTo          *MessageReply     `json:"reply_to"            db:"-"`
+	Attachments      Attachments       `json:"attachments"         db:"-"`
+	Reactions        ReactionSummaries `json:"reactions"           db:"-"`
 }
 
 type Messages []*Message
+
+// MessageReply は返信先メッセージの参照と、引用表示用の抜粋
+type MessageReply struct {
+	MessageID     MessageID `json:"message_id"`
+	IsFromCompany int8      `json:"is_from_company"`
+	IsFromStudent int8      `json:"is_from_student"`
+	Excerpt       string    `json:"excerpt"`
+	IsDeleted     bool      `json:"is_deleted"`
+}
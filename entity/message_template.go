@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"database/sql"
+)
+
+type MessageTemplateID int64
+
+type MessageTemplate struct {
+	ID            MessageTemplateID `json:"id"              db:"id"`
+	CompanyUserID int64             `json:"company_user_id" db:"company_user_id"`
+	Name          string            `json:"name"            db:"name"`
+	Content       string            `json:"content"         db:"content"`
+	CreatedAt     *sql.NullTime     `json:"created_at"      db:"created_at"`
+	UpdatedAt     *sql.NullTime     `json:"updated_at"      db:"updated_at"`
+	DeletedAt     *sql.NullTime     `json:"deleted_at"      db:"deleted_at"`
+}
+
+type MessageTemplates []*MessageTemplate
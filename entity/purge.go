@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// RetentionPolicy はデータ種別ごとの保持期限（これより前のデータが削除対象）を表す。
+// DeletedCredentialsBefore は論理削除された認証情報が対象で、expires_at（アクセストークンの有効期限）が切れただけの認証情報は削除しない
+type RetentionPolicy struct {
+	DeletedMessagesBefore    time.Time `json:"deleted_messages_before"`
+	AbandonedDraftsBefore    time.Time `json:"abandoned_drafts_before"`
+	DeletedCredentialsBefore time.Time `json:"deleted_credentials_before"`
+}
+
+// PurgeReport はテーブルごとの削除件数（dry run の場合は削除対象の件数）を表す
+type PurgeReport struct {
+	DryRun                bool     `json:"dry_run"`
+	DeletedMessages       int64    `json:"deleted_messages"`
+	AbandonedDrafts       int64    `json:"abandoned_drafts"`
+	MessageAttachments    int64    `json:"message_attachments"`
+	MessageReactions      int64    `json:"message_reactions"`
+	CompanyAPICredentials int64    `json:"company_message_api_credentials"`
+	StudentAPICredentials int64    `json:"student_message_api_credentials"`
+	BlobDeleteFailures    []string `json:"blob_delete_failures,omitempty"`
+}
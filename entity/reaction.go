@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"database/sql"
+)
+
+type ReactionID int64
+
+// 送信できるリアクションは、この一覧にあるものに限る（並び順はレスポンスの並び順にも使う）
+var AllowedReactions = []string{
+	"👍",
+	"🙇",
+	"🎉",
+	"確認しました",
+	"ありがとうございます",
+}
+
+func IsAllowedReaction(reaction string) bool {
+	for _, r := range AllowedReactions {
+		if r == reaction {
+			return true
+		}
+	}
+	return false
+}
+
+type Reaction struct {
+	ID            ReactionID    `json:"id"              db:"id"`
+	MessageID     MessageID     `json:"message_id"      db:"message_id"`
+	IsFromCompany int8          `json:"is_from_company" db:"is_from_company"`
+	IsFromStudent int8          `json:"is_from_student" db:"is_from_student"`
+	Reaction      string        `json:"reaction"        db:"reaction"`
+	CreatedAt     *sql.NullTime `json:"created_at"      db:"created_at"`
+}
+
+type Reactions []*Reaction
+
+// ReactionSummary はメッセージごとにリアクションを集計したもの
+type ReactionSummary struct {
+	Reaction    string `json:"reaction"`
+	Count       int    `json:"count"`
+	ReactedByMe bool   `json:"reacted_by_me"`
+}
+
+type ReactionSummaries []*ReactionSummary
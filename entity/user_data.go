@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// 個人データ消去時に、利用者が送ったメッセージ本文を置き換える文字列
+const ErasedMessageContent = "（このメッセージは削除されました）"
+
+type UserData struct {
+	AppKind     string                  `json:"app_kind"`
+	UserID      int64                   `json:"user_id"`
+	ExportedAt  time.Time               `json:"exported_at"`
+	Threads     []*MessageThread        `json:"threads"`
+	Messages    Messages                `json:"messages"`
+	Attachments Attachments             `json:"attachments"`
+	Credentials []*MessageAPICredential `json:"credentials"`
+}
+
+type UserDataErasureReport struct {
+	AppKind            string   `json:"app_kind"`
+	UserID             int64    `json:"user_id"`
+	DryRun             bool     `json:"dry_run"`
+	Threads            int      `json:"threads"`
+	AnonymizedMessages int      `json:"anonymized_messages"`
+	DeletedAttachments int      `json:"deleted_attachments"`
+	DeletedCredentials int      `json:"deleted_credentials"`
+	BlobDeleteFailures []string `json:"blob_delete_failures,omitempty"`
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+// Excel で開いた際に文字化けしないよう、BOM 付きの UTF-8 で出力する
+const utf8BOM = "\xEF\xBB\xBF"
+
+// Excel がこれらの文字で始まるセルを数式として評価するため、先頭に ' を付けて文字列として扱わせる
+const formulaPrefixes = "=+-@\t\r"
+
+type csvWriter struct {
+	cw       *csv.Writer
+	threadID string
+}
+
+func newCSVWriter(w io.Writer, t *Thread) (*csvWriter, error) {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return nil, err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"id", "message_thread_id", "sender", "content", "is_sent", "sent_at", "updated_at", "reply_to_message_id"}); err != nil {
+		return nil, err
+	}
+	return &csvWriter{cw: cw, threadID: strconv.FormatInt(int64(t.ID), 10)}, nil
+}
+
+func (c *csvWriter) WriteMessage(m *entity.Message) error {
+	var edited, replyTo string
+	if u := updatedAt(m); u != nil {
+		edited = u.Format(time.RFC3339)
+	}
+	if m.ReplyToMessageID != nil {
+		replyTo = strconv.FormatInt(int64(*m.ReplyToMessageID), 10)
+	}
+	return c.cw.Write([]string{
+		strconv.FormatInt(int64(m.ID), 10),
+		c.threadID,
+		sender(m),
+		escapeFormula(m.Content),
+		strconv.Itoa(int(m.IsSent)),
+		m.SentAt.Format(time.RFC3339),
+		edited,
+		replyTo,
+	})
+}
+
+func (c *csvWriter) Close() error {
+	c.cw.Flush()
+	return c.cw.Error()
+}
+
+// escapeFormula はユーザーが入力した値が数式として実行されないようにする（CSV インジェクション対策）
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune(formulaPrefixes, rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
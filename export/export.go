@@ -0,0 +1,79 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+	FormatPDF  = "pdf"
+)
+
+type Thread struct {
+	ID         entity.MessageThreadID
+	ExportedAt time.Time
+	Messages   entity.Messages
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatJSON:
+		return "application/json; charset=utf-8"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Writer はスレッドのメッセージを1件ずつ書き出す。Close で末尾を書き出す
+type Writer interface {
+	WriteMessage(m *entity.Message) error
+	Close() error
+}
+
+// NewWriter は format の Writer を返す。t.Messages は使わず、メッセージは WriteMessage で受け取る
+func NewWriter(w io.Writer, format string, t *Thread) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, t)
+	case FormatJSON:
+		return newJSONWriter(w, t)
+	case FormatPDF:
+		return newPDFThreadWriter(w, t), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// Write は t.Messages をまとめて書き出す
+func Write(w io.Writer, format string, t *Thread) error {
+	ew, err := NewWriter(w, format, t)
+	if err != nil {
+		return err
+	}
+	for _, m := range t.Messages {
+		if err := ew.WriteMessage(m); err != nil {
+			return err
+		}
+	}
+	return ew.Close()
+}
+
+func sender(m *entity.Message) string {
+	if m.IsFromCompany == 1 {
+		return "company"
+	}
+	return "student"
+}
+
+func updatedAt(m *entity.Message) *time.Time {
+	return nullTime(m.UpdatedAt)
+}
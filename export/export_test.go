@@ -0,0 +1,167 @@
+package export
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func newTestThread() *Thread {
+	jst := time.FixedZone("JST", 9*60*60)
+	replyTo := entity.MessageID(1)
+	return &Thread{
+		ID:         10,
+		ExportedAt: time.Date(2025, 4, 2, 9, 0, 0, 0, jst),
+		Messages: entity.Messages{
+			&entity.Message{ID: 1, IsFromCompany: 1, Content: "面接日程のご案内です。\n4月10日はいかがでしょうか", IsSent: 1, SentAt: time.Date(2025, 4, 1, 10, 0, 0, 0, jst)},
+			&entity.Message{
+				ID:               2,
+				IsFromStudent:    1,
+				Content:          "承知しました, \"よろしく\"お願いします",
+				IsSent:           1,
+				SentAt:           time.Date(2025, 4, 1, 11, 0, 0, 0, jst),
+				UpdatedAt:        &sql.NullTime{Time: time.Date(2025, 4, 1, 11, 30, 0, 0, jst), Valid: true},
+				ReplyToMessageID: &replyTo,
+			},
+		},
+	}
+}
+
+func TestWrite_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatCSV, newTestThread()))
+	assert.True(t, strings.HasPrefix(buf.String(), utf8BOM))
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(buf.String(), utf8BOM))).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"id", "message_thread_id", "sender", "content", "is_sent", "sent_at", "updated_at", "reply_to_message_id"},
+		{"1", "10", "company", "面接日程のご案内です。\n4月10日はいかがでしょうか", "1", "2025-04-01T10:00:00+09:00", "", ""},
+		{"2", "10", "student", "承知しました, \"よろしく\"お願いします", "1", "2025-04-01T11:00:00+09:00", "2025-04-01T11:30:00+09:00", "1"},
+	}, records)
+}
+
+func TestWrite_CSVFormulaInjection(t *testing.T) {
+	thread := &Thread{ID: 10}
+	for i, content := range []string{"=HYPERLINK(\"https://example.com\")", "+1", "-1", "@SUM(A1)", "\tx", "\rx", "ok=1"} {
+		thread.Messages = append(thread.Messages, &entity.Message{ID: entity.MessageID(i + 1), IsFromCompany: 1, Content: content, IsSent: 1})
+	}
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatCSV, thread))
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(buf.String(), utf8BOM))).ReadAll()
+	require.NoError(t, err)
+	var contents []string
+	for _, r := range records[1:] {
+		contents = append(contents, r[3])
+	}
+	assert.Equal(t, []string{"'=HYPERLINK(\"https://example.com\")", "'+1", "'-1", "'@SUM(A1)", "'\tx", "'\rx", "ok=1"}, contents)
+}
+
+func TestNewWriter_StreamsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	thread := newTestThread()
+	ew, err := NewWriter(&buf, FormatJSON, &Thread{ID: thread.ID, ExportedAt: thread.ExportedAt})
+	require.NoError(t, err)
+	require.NoError(t, ew.WriteMessage(thread.Messages[0]))
+	// Close の前でも書き込んだメッセージは出力されている
+	assert.Contains(t, buf.String(), `"id":1`)
+	require.NoError(t, ew.Close())
+	assert.JSONEq(t, `{
+		"message_thread_id": 10,
+		"exported_at": "2025-04-02T09:00:00+09:00",
+		"messages": [
+			{"id":1,"sender":"company","content":"面接日程のご案内です。\n4月10日はいかがでしょうか","is_sent":1,"sent_at":"2025-04-01T10:00:00+09:00","updated_at":null,"reply_to_message_id":null}
+		]
+	}`, buf.String())
+
+	buf.Reset()
+	ew, err = NewWriter(&buf, FormatJSON, &Thread{ID: thread.ID, ExportedAt: thread.ExportedAt})
+	require.NoError(t, err)
+	require.NoError(t, ew.Close())
+	assert.JSONEq(t, `{"message_thread_id":10,"exported_at":"2025-04-02T09:00:00+09:00","messages":[]}`, buf.String())
+}
+
+func TestWrite_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatJSON, newTestThread()))
+	assert.JSONEq(t, `{
+		"message_thread_id": 10,
+		"exported_at": "2025-04-02T09:00:00+09:00",
+		"messages": [
+			{"id":1,"sender":"company","content":"面接日程のご案内です。\n4月10日はいかがでしょうか","is_sent":1,"sent_at":"2025-04-01T10:00:00+09:00","updated_at":null,"reply_to_message_id":null},
+			{"id":2,"sender":"student","content":"承知しました, \"よろしく\"お願いします","is_sent":1,"sent_at":"2025-04-01T11:00:00+09:00","updated_at":"2025-04-01T11:30:00+09:00","reply_to_message_id":1}
+		]
+	}`, buf.String())
+}
+
+func TestWrite_PDF(t *testing.T) {
+	t.Run("japanese text is encoded for the CID font", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, FormatPDF, newTestThread()))
+		out := buf.String()
+		assert.True(t, strings.HasPrefix(out, "%PDF-1.4\n"))
+		assert.True(t, strings.HasSuffix(out, "%%EOF\n"))
+		assert.Contains(t, out, "/Encoding /UniJIS-UCS2-HW-H")
+		assert.Contains(t, out, "/BaseFont /HeiseiKakuGo-W5")
+		// 「承知しました」
+		assert.Contains(t, out, "627F77E53057307E3057305F")
+		assertValidXref(t, out)
+	})
+
+	t.Run("long thread is split into pages", func(t *testing.T) {
+		thread := newTestThread()
+		for i := 0; i < 100; i++ {
+			thread.Messages = append(thread.Messages, &entity.Message{ID: entity.MessageID(100 + i), IsFromCompany: 1, Content: strings.Repeat("あ", 120), IsSent: 1})
+		}
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, FormatPDF, thread))
+		out := buf.String()
+		count := regexp.MustCompile(`/Count (\d+)`).FindStringSubmatch(out)
+		require.Len(t, count, 2)
+		pages, _ := strconv.Atoi(count[1])
+		assert.Greater(t, pages, 1)
+		assert.Equal(t, pages, strings.Count(out, "/Type /Page "))
+		assertValidXref(t, out)
+	})
+
+	t.Run("characters outside BMP are replaced", func(t *testing.T) {
+		assert.Equal(t, "<3042003F>", encodeText("あ👍"))
+	})
+
+	t.Run("line is wrapped by glyph width", func(t *testing.T) {
+		lines := wrapLine(strings.Repeat("あ", 60))
+		assert.Equal(t, []string{strings.Repeat("あ", 49), strings.Repeat("あ", 11)}, lines)
+		assert.Equal(t, []string{strings.Repeat("a", 99)}, wrapLine(strings.Repeat("a", 99)))
+	})
+}
+
+func TestWrite_UnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, Write(&buf, "xml", newTestThread()))
+}
+
+// assertValidXref は xref テーブルの各オフセットが対応するオブジェクトの先頭を指していることを確認する
+func assertValidXref(t *testing.T, out string) {
+	t.Helper()
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(out)
+	require.Len(t, m, 2)
+	xref, err := strconv.Atoi(m[1])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out[xref:], "xref\n"))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(out[xref:], -1)
+	require.NotEmpty(t, entries)
+	for i, e := range entries {
+		offset, _ := strconv.Atoi(e[1])
+		assert.True(t, strings.HasPrefix(out[offset:], fmt.Sprintf("%d 0 obj\n", i+1)), "object %d", i+1)
+	}
+}
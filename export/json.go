@@ -0,0 +1,70 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type jsonThread struct {
+	MessageThreadID entity.MessageThreadID `json:"message_thread_id"`
+	ExportedAt      time.Time              `json:"exported_at"`
+}
+
+type jsonMessage struct {
+	ID               entity.MessageID  `json:"id"`
+	Sender           string            `json:"sender"`
+	Content          string            `json:"content"`
+	IsSent           int8              `json:"is_sent"`
+	SentAt           time.Time         `json:"sent_at"`
+	UpdatedAt        *time.Time        `json:"updated_at"`
+	ReplyToMessageID *entity.MessageID `json:"reply_to_message_id"`
+}
+
+// jsonWriter は messages の配列を1件ずつ書き出すため、オブジェクトの括弧と区切りを自前で書く
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+func newJSONWriter(w io.Writer, t *Thread) (*jsonWriter, error) {
+	header, err := json.Marshal(&jsonThread{MessageThreadID: t.ID, ExportedAt: t.ExportedAt})
+	if err != nil {
+		return nil, err
+	}
+	// {"message_thread_id":...,"exported_at":"..."} の閉じ括弧の代わりに messages を続ける
+	if _, err := io.WriteString(w, string(header[:len(header)-1])+`,"messages":[`); err != nil {
+		return nil, err
+	}
+	return &jsonWriter{w: w}, nil
+}
+
+func (j *jsonWriter) WriteMessage(m *entity.Message) error {
+	b, err := json.Marshal(&jsonMessage{
+		ID:               m.ID,
+		Sender:           sender(m),
+		Content:          m.Content,
+		IsSent:           m.IsSent,
+		SentAt:           m.SentAt,
+		UpdatedAt:        updatedAt(m),
+		ReplyToMessageID: m.ReplyToMessageID,
+	})
+	if err != nil {
+		return err
+	}
+	if j.count > 0 {
+		if _, err := io.WriteString(j.w, ","); err != nil {
+			return err
+		}
+	}
+	j.count++
+	_, err = j.w.Write(b)
+	return err
+}
+
+func (j *jsonWriter) Close() error {
+	_, err := io.WriteString(j.w, "]}\n")
+	return err
+}
@@ -0,0 +1,218 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+// A4 縦（単位: pt）
+const (
+	pdfPageWidth  = 595.28
+	pdfPageHeight = 841.89
+	pdfMargin     = 50.0
+	pdfFontSize   = 10.0
+	pdfLineHeight = 15.0
+)
+
+// 日本語を表示するため、PDF ビューア側で用意される CID フォント（Adobe-Japan1）を参照する。
+// UniJIS-UCS2-HW-H は UCS-2 の文字コードをそのまま CID に対応づけ、ASCII を半角グリフで描画する
+const (
+	pdfFontName = "HeiseiKakuGo-W5"
+	pdfEncoding = "UniJIS-UCS2-HW-H"
+)
+
+const (
+	pdfCatalogObj = 1
+	pdfPagesObj   = 2
+	pdfFontObj    = 3
+	pdfCIDFontObj = 4
+	pdfDescObj    = 5
+	pdfFirstPage  = 6
+)
+
+// pdfThreadWriter はページ数とページの参照を先頭に書くため、メッセージをすべて受け取ってから Close で書き出す
+type pdfThreadWriter struct {
+	w      io.Writer
+	thread Thread
+}
+
+func newPDFThreadWriter(w io.Writer, t *Thread) *pdfThreadWriter {
+	return &pdfThreadWriter{w: w, thread: Thread{ID: t.ID, ExportedAt: t.ExportedAt}}
+}
+
+func (p *pdfThreadWriter) WriteMessage(m *entity.Message) error {
+	p.thread.Messages = append(p.thread.Messages, m)
+	return nil
+}
+
+func (p *pdfThreadWriter) Close() error {
+	return writePDF(p.w, &p.thread)
+}
+
+func writePDF(w io.Writer, t *Thread) error {
+	pages := paginate(pdfLines(t))
+	pw := &pdfWriter{w: w}
+	pw.printf("%%PDF-1.4\n%%\xE2\xE3\xCF\xD3\n")
+	pw.object(pdfCatalogObj, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObj))
+	kids := make([]string, 0, len(pages))
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", pdfFirstPage+i*2))
+	}
+	pw.object(pdfPagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	pw.object(pdfFontObj, fmt.Sprintf(
+		"<< /Type /Font /Subtype /Type0 /BaseFont /%s-%s /Encoding /%s /DescendantFonts [%d 0 R] >>",
+		pdfFontName, pdfEncoding, pdfEncoding, pdfCIDFontObj,
+	))
+	// 半角グリフ（CID 1-95, 231-632）の幅のみ指定し、それ以外は全角幅（DW）とする
+	pw.object(pdfCIDFontObj, fmt.Sprintf(
+		"<< /Type /Font /Subtype /CIDFontType0 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> /FontDescriptor %d 0 R /DW 1000 /W [1 95 500 231 632 500] >>",
+		pdfFontName, pdfDescObj,
+	))
+	pw.object(pdfDescObj, fmt.Sprintf(
+		"<< /Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [-92 -250 1010 922] /ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 737 /StemV 114 >>",
+		pdfFontName,
+	))
+	for i, lines := range pages {
+		pageObj := pdfFirstPage + i*2
+		contentObj := pageObj + 1
+		pw.object(pageObj, fmt.Sprintf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>",
+			pdfPagesObj, pdfPageWidth, pdfPageHeight, pdfFontObj, contentObj,
+		))
+		content := pageContent(lines, fmt.Sprintf("%d / %d", i+1, len(pages)))
+		pw.object(contentObj, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+	return pw.finish(pdfFirstPage + len(pages)*2 - 1)
+}
+
+func pdfLines(t *Thread) []string {
+	lines := []string{
+		fmt.Sprintf("メッセージスレッド #%d", t.ID),
+		"出力日時: " + t.ExportedAt.Format("2006-01-02 15:04:05"),
+		"",
+	}
+	for _, m := range t.Messages {
+		side := "学生"
+		if m.IsFromCompany == 1 {
+			side = "企業"
+		}
+		header := fmt.Sprintf("#%d [%s] %s", m.ID, side, m.SentAt.Format("2006-01-02 15:04"))
+		if m.IsSent == 0 {
+			header += " (下書き)"
+		}
+		if u := updatedAt(m); u != nil {
+			header += " 編集: " + u.Format("2006-01-02 15:04")
+		}
+		if m.ReplyToMessageID != nil {
+			header += fmt.Sprintf(" 返信先: #%d", *m.ReplyToMessageID)
+		}
+		lines = append(lines, wrapLine(header)...)
+		content := strings.ReplaceAll(strings.ReplaceAll(m.Content, "\r\n", "\n"), "\t", "    ")
+		for _, paragraph := range strings.Split(content, "\n") {
+			lines = append(lines, wrapLine("  "+paragraph)...)
+		}
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+func paginate(lines []string) [][]string {
+	height := pdfPageHeight - pdfMargin*2
+	perPage := int(height / pdfLineHeight)
+	var pages [][]string
+	for len(lines) > perPage {
+		pages = append(pages, lines[:perPage])
+		lines = lines[perPage:]
+	}
+	return append(pages, lines)
+}
+
+// wrapLine は本文領域の幅に収まるよう、グリフ幅（半角 500 / 全角 1000）で折り返す
+func wrapLine(s string) []string {
+	textWidth := pdfPageWidth - pdfMargin*2
+	maxWidth := int(textWidth / pdfFontSize * 1000)
+	var lines []string
+	var current strings.Builder
+	width := 0
+	for _, r := range s {
+		rw := glyphWidth(r)
+		if width+rw > maxWidth {
+			lines = append(lines, current.String())
+			current.Reset()
+			width = 0
+		}
+		current.WriteRune(r)
+		width += rw
+	}
+	return append(lines, current.String())
+}
+
+func glyphWidth(r rune) int {
+	if r < 0x80 || (r >= 0xFF61 && r <= 0xFF9F) {
+		return 500
+	}
+	return 1000
+}
+
+func pageContent(lines []string, footer string) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "BT\n/F1 %.1f Tf\n%.1f TL\n1 0 0 1 %.2f %.2f Tm\n", pdfFontSize, pdfLineHeight, pdfMargin, pdfPageHeight-pdfMargin-pdfFontSize)
+	for _, line := range lines {
+		fmt.Fprintf(&b, "%s Tj T*\n", encodeText(line))
+	}
+	fmt.Fprintf(&b, "1 0 0 1 %.2f %.2f Tm\n%s Tj\nET", pdfPageWidth/2-pdfMargin/2, pdfMargin/2, encodeText(footer))
+	return b.String()
+}
+
+// encodeText は UCS-2（ビッグエンディアン）の16進文字列に変換する。BMP 外の文字は表現できないため置き換える
+func encodeText(s string) string {
+	var b strings.Builder
+	b.WriteByte('<')
+	for _, r := range s {
+		if r > 0xFFFF || (r >= 0xD800 && r <= 0xDFFF) || r == utf8.RuneError || r < 0x20 {
+			r = '?'
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	b.WriteByte('>')
+	return b.String()
+}
+
+type pdfWriter struct {
+	w       io.Writer
+	offset  int
+	offsets map[int]int
+	err     error
+}
+
+func (pw *pdfWriter) printf(format string, args ...any) {
+	if pw.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(pw.w, format, args...)
+	pw.offset += n
+	pw.err = err
+}
+
+func (pw *pdfWriter) object(num int, body string) {
+	if pw.offsets == nil {
+		pw.offsets = make(map[int]int)
+	}
+	pw.offsets[num] = pw.offset
+	pw.printf("%d 0 obj\n%s\nendobj\n", num, body)
+}
+
+func (pw *pdfWriter) finish(lastObj int) error {
+	xref := pw.offset
+	pw.printf("xref\n0 %d\n0000000000 65535 f \n", lastObj+1)
+	for i := 1; i <= lastObj; i++ {
+		pw.printf("%010d 00000 n \n", pw.offsets[i])
+	}
+	pw.printf("trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", lastObj+1, pdfCatalogObj, xref)
+	return pw.err
+}
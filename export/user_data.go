@@ -0,0 +1,194 @@
+package export
+
+import (
+	"archive/zip"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type userDataManifest struct {
+	AppKind     string    `json:"app_kind"`
+	UserID      int64     `json:"user_id"`
+	ExportedAt  time.Time `json:"exported_at"`
+	Threads     int       `json:"threads"`
+	Messages    int       `json:"messages"`
+	Attachments int       `json:"attachments"`
+	Credentials int       `json:"credentials"`
+}
+
+type userDataThread struct {
+	ID            entity.MessageThreadID `json:"id"`
+	CompanyUserID int64                  `json:"company_user_id"`
+	StudentUserID int64                  `json:"student_user_id"`
+	CreatedAt     *time.Time             `json:"created_at"`
+	UpdatedAt     *time.Time             `json:"updated_at"`
+	DeletedAt     *time.Time             `json:"deleted_at"`
+}
+
+type userDataMessage struct {
+	ID               entity.MessageID       `json:"id"`
+	MessageThreadID  entity.MessageThreadID `json:"message_thread_id"`
+	Sender           string                 `json:"sender"`
+	Content          string                 `json:"content"`
+	IsSent           int8                   `json:"is_sent"`
+	SentAt           time.Time              `json:"sent_at"`
+	ReplyToMessageID *entity.MessageID      `json:"reply_to_message_id"`
+	CreatedAt        *time.Time             `json:"created_at"`
+	UpdatedAt        *time.Time             `json:"updated_at"`
+	DeletedAt        *time.Time             `json:"deleted_at"`
+}
+
+type userDataAttachment struct {
+	ID          entity.AttachmentID `json:"id"`
+	MessageID   entity.MessageID    `json:"message_id"`
+	FileName    string              `json:"file_name"`
+	ContentType string              `json:"content_type"`
+	Size        int64               `json:"size"`
+	Path        string              `json:"path"`
+	CreatedAt   *time.Time          `json:"created_at"`
+}
+
+// シークレットやトークンは含めない
+type userDataCredential struct {
+	ID        entity.MessageAPICredentialID `json:"id"`
+	ClientID  string                        `json:"client_id"`
+	ExpiresAt *time.Time                    `json:"expires_at"`
+	CreatedAt *time.Time                    `json:"created_at"`
+	UpdatedAt *time.Time                    `json:"updated_at"`
+	DeletedAt *time.Time                    `json:"deleted_at"`
+}
+
+// WriteUserDataArchive は利用者1人分の個人データを zip で書き出す。
+// 論理削除済みの添付ファイルは実体を含めない
+func WriteUserDataArchive(w io.Writer, data *entity.UserData, openAttachment func(a *entity.Attachment) (io.ReadCloser, error)) error {
+	zw := zip.NewWriter(w)
+	threads := make([]userDataThread, 0, len(data.Threads))
+	for _, mt := range data.Threads {
+		threads = append(threads, userDataThread{
+			ID:            mt.ID,
+			CompanyUserID: mt.CompanyUserID,
+			StudentUserID: mt.StudentUserID,
+			CreatedAt:     nullTime(mt.CreatedAt),
+			UpdatedAt:     nullTime(mt.UpdatedAt),
+			DeletedAt:     nullTime(mt.DeletedAt),
+		})
+	}
+	messages := make([]userDataMessage, 0, len(data.Messages))
+	for _, m := range data.Messages {
+		messages = append(messages, userDataMessage{
+			ID:               m.ID,
+			MessageThreadID:  m.MessageThreadID,
+			Sender:           sender(m),
+			Content:          m.Content,
+			IsSent:           m.IsSent,
+			SentAt:           m.SentAt,
+			ReplyToMessageID: m.ReplyToMessageID,
+			CreatedAt:        nullTime(m.CreatedAt),
+			UpdatedAt:        nullTime(m.UpdatedAt),
+			DeletedAt:        nullTime(m.DeletedAt),
+		})
+	}
+	var files entity.Attachments
+	attachments := make([]userDataAttachment, 0, len(data.Attachments))
+	for _, a := range data.Attachments {
+		if a.DeletedAt != nil && a.DeletedAt.Valid {
+			continue
+		}
+		files = append(files, a)
+		attachments = append(attachments, userDataAttachment{
+			ID:          a.ID,
+			MessageID:   a.MessageID,
+			FileName:    a.FileName,
+			ContentType: a.ContentType,
+			Size:        a.Size,
+			Path:        attachmentPath(a),
+			CreatedAt:   nullTime(a.CreatedAt),
+		})
+	}
+	credentials := make([]userDataCredential, 0, len(data.Credentials))
+	for _, c := range data.Credentials {
+		credentials = append(credentials, userDataCredential{
+			ID:        c.ID,
+			ClientID:  c.ClientID,
+			ExpiresAt: nullTime(c.ExpiresAt),
+			CreatedAt: nullTime(c.CreatedAt),
+			UpdatedAt: nullTime(c.UpdatedAt),
+			DeletedAt: nullTime(c.DeletedAt),
+		})
+	}
+	manifest := userDataManifest{
+		AppKind:     data.AppKind,
+		UserID:      data.UserID,
+		ExportedAt:  data.ExportedAt,
+		Threads:     len(threads),
+		Messages:    len(messages),
+		Attachments: len(attachments),
+		Credentials: len(credentials),
+	}
+	for _, entry := range []struct {
+		name  string
+		value any
+	}{
+		{"manifest.json", manifest},
+		{"threads.json", threads},
+		{"messages.json", messages},
+		{"attachments.json", attachments},
+		{"credentials.json", credentials},
+	} {
+		if err := writeZipJSON(zw, entry.name, entry.value); err != nil {
+			return err
+		}
+	}
+	for _, a := range files {
+		if err := writeZipAttachment(zw, a, openAttachment); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func writeZipJSON(zw *zip.Writer, name string, v any) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeZipAttachment(zw *zip.Writer, a *entity.Attachment, openAttachment func(a *entity.Attachment) (io.ReadCloser, error)) error {
+	body, err := openAttachment(a)
+	if err != nil {
+		return fmt.Errorf("failed to open attachment %d: %w", a.ID, err)
+	}
+	defer body.Close()
+	f, err := zw.Create(attachmentPath(a))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, body)
+	return err
+}
+
+// attachmentPath は展開時に任意のパスへ書き込まれないよう、ファイル名からディレクトリ要素を取り除く
+func attachmentPath(a *entity.Attachment) string {
+	name := path.Base(a.FileName)
+	if name == "." || name == ".." || name == "/" {
+		name = "file"
+	}
+	return fmt.Sprintf("attachments/%d/%s", a.ID, name)
+}
+
+func nullTime(t *sql.NullTime) *time.Time {
+	if t == nil || !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestWriteUserDataArchive(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	data := &entity.UserData{
+		AppKind:    "student",
+		UserID:     1,
+		ExportedAt: time.Date(2025, 4, 2, 9, 0, 0, 0, jst),
+		Threads: []*entity.MessageThread{
+			{ID: 5, CompanyUserID: 10, StudentUserID: 1},
+		},
+		Messages: entity.Messages{
+			&entity.Message{ID: 3, MessageThreadID: 5, IsFromStudent: 1, Content: "よろしくお願いします", IsSent: 1, SentAt: time.Date(2025, 4, 1, 10, 0, 0, 0, jst)},
+		},
+		Attachments: entity.Attachments{
+			&entity.Attachment{ID: 7, MessageID: 3, FileName: "../resume.pdf", ContentType: "application/pdf", Size: 3, StorageKey: "attachments/3/abc"},
+			&entity.Attachment{ID: 8, MessageID: 3, FileName: "old.pdf", StorageKey: "attachments/3/def", DeletedAt: &sql.NullTime{Time: time.Now(), Valid: true}},
+		},
+		Credentials: []*entity.MessageAPICredential{
+			{ID: 2, UserID: 1, ClientID: "client", ClientSecret: "secret", AccessToken: "token"},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		var buf bytes.Buffer
+		var opened []string
+		err := WriteUserDataArchive(&buf, data, func(a *entity.Attachment) (io.ReadCloser, error) {
+			opened = append(opened, a.StorageKey)
+			return io.NopCloser(strings.NewReader("pdf")), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"attachments/3/abc"}, opened)
+
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		files := map[string]string{}
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			b, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			rc.Close()
+			files[f.Name] = string(b)
+		}
+		assert.ElementsMatch(t, []string{"manifest.json", "threads.json", "messages.json", "attachments.json", "credentials.json", "attachments/7/resume.pdf"}, keys(files))
+		assert.Equal(t, "pdf", files["attachments/7/resume.pdf"])
+		assert.JSONEq(t, `{"app_kind":"student","user_id":1,"exported_at":"2025-04-02T09:00:00+09:00","threads":1,"messages":1,"attachments":1,"credentials":1}`, files["manifest.json"])
+		assert.NotContains(t, files["credentials.json"], "secret")
+		assert.NotContains(t, files["credentials.json"], "token")
+		var messages []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(files["messages.json"]), &messages))
+		if assert.Len(t, messages, 1) {
+			assert.Equal(t, "student", messages[0]["sender"])
+			assert.Equal(t, "よろしくお願いします", messages[0]["content"])
+		}
+	})
+
+	t.Run("fail to open attachment", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := WriteUserDataArchive(&buf, data, func(a *entity.Attachment) (io.ReadCloser, error) {
+			return nil, errors.New("blob error")
+		})
+		assert.ErrorContains(t, err, "failed to open attachment 7")
+	})
+}
+
+func keys(m map[string]string) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	return ks
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/yuyacode/AppLiftMessageApi/request"
+)
+
+// AccessLogMiddleware はリクエストごとにステータス、処理時間、認証した利用者を1行で出力する
+func AccessLogMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			al := &request.AccessLog{}
+			ctx := request.SetAccessLog(r.Context(), al)
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+				slog.String("remote_addr", r.RemoteAddr),
+				slog.String("user_agent", r.UserAgent()),
+			}
+			if al.AppKind != "" {
+				attrs = append(attrs, slog.String("app_kind", al.AppKind))
+			}
+			if al.UserID != 0 {
+				attrs = append(attrs, slog.Int64("user_id", al.UserID))
+			}
+			slog.LogAttrs(ctx, slog.LevelInfo, "access", attrs...)
+		})
+	}
+}
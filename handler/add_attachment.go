@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+// multipart の境界やヘッダー分として、ファイル本体に上乗せして受け付けるバイト数
+const multipartOverhead = 1 << 20
+
+type AddAttachment struct {
+	Service   AddAttachmentService
+	Validator *validator.Validate
+	MaxSize   int64
+}
+
+func NewAddAttachment(service AddAttachmentService, validator *validator.Validate, maxSize int64) *AddAttachment {
+	return &AddAttachment{
+		Service:   service,
+		Validator: validator,
+		MaxSize:   maxSize,
+	}
+}
+
+func (aa *AddAttachment) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "ID must be a number", ""))
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, aa.MaxSize+multipartOverhead)
+	if err := r.ParseMultipartForm(aa.MaxSize); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			RespondError(w, r, NewServiceError(ErrCodeAttachmentTooLarge, "attachment is too large", fmt.Sprintf("attachment must be at most %d bytes", aa.MaxSize)))
+			return
+		}
+		RespondError(w, r, NewServiceError(ErrCodeInvalidMultipart, "invalid multipart form", err.Error()))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		RespondError(w, r, NewServiceError(ErrCodeInvalidMultipart, "missing required form field: file", err.Error()))
+		return
+	}
+	defer file.Close()
+	content, err := io.ReadAll(io.LimitReader(file, aa.MaxSize+1))
+	if err != nil {
+		RespondError(w, r, NewServiceError(ErrCodeInternal, "failed to read attachment", err.Error()))
+		return
+	}
+	a, err := aa.Service.AddAttachment(ctx, entity.MessageID(id), header.Filename, content)
+	if err != nil {
+		RespondError(w, r, err)
+		return
+	}
+	rsp := attachment{
+		ID:          a.ID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+	}
+	RespondJSON(ctx, w, &rsp, http.StatusOK)
+}
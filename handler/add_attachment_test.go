@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func newMultipartRequest(t *testing.T, field, fileName string, content []byte) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	fw, err := mw.CreateFormFile(field, fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodPost, "/messages/1/attachments", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add("id", "1")
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+}
+
+func TestAddAttachment_ServeHTTP(t *testing.T) {
+	v := validator.New()
+
+	t.Run("ID parse error", func(t *testing.T) {
+		t.Parallel()
+		aa := NewAddAttachment(&AddAttachmentServiceMock{}, v, 1024)
+		chiCtx := chi.NewRouteContext()
+		chiCtx.URLParams.Add("id", "abc")
+		r := httptest.NewRequest(http.MethodPost, "/messages/abc/attachments", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+		w := httptest.NewRecorder()
+		aa.ServeHTTP(w, r)
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "ID must be a number", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("not multipart", func(t *testing.T) {
+		t.Parallel()
+		aa := NewAddAttachment(&AddAttachmentServiceMock{}, v, 1024)
+		chiCtx := chi.NewRouteContext()
+		chiCtx.URLParams.Add("id", "1")
+		r := httptest.NewRequest(http.MethodPost, "/messages/1/attachments", bytes.NewBufferString(`{"file":"x"}`))
+		r.Header.Set("Content-Type", "application/json")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+		w := httptest.NewRecorder()
+		aa.ServeHTTP(w, r)
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "invalid multipart form", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("missing file field", func(t *testing.T) {
+		t.Parallel()
+		aa := NewAddAttachment(&AddAttachmentServiceMock{}, v, 1024)
+		w := httptest.NewRecorder()
+		aa.ServeHTTP(w, newMultipartRequest(t, "document", "resume.pdf", []byte("%PDF-1.4")))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "missing required form field: file", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		t.Parallel()
+		aa := NewAddAttachment(&AddAttachmentServiceMock{}, v, 16)
+		w := httptest.NewRecorder()
+		aa.ServeHTTP(w, newMultipartRequest(t, "file", "resume.pdf", bytes.Repeat([]byte("a"), multipartOverhead+32)))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "attachment is too large", errResp.Message)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assertResponseConformsToSpec(t, http.MethodPost, "/messages/{id}/attachments", w)
+	})
+
+	t.Run("service returns ServiceError", func(t *testing.T) {
+		t.Parallel()
+		moq := &AddAttachmentServiceMock{
+			AddAttachmentFunc: func(ctx context.Context, messageID entity.MessageID, fileName string, content []byte) (*entity.Attachment, error) {
+				return nil, NewServiceError(
+					ErrCodeUnsupportedAttachmentType,
+					"attachment type is not allowed",
+					"detected type: application/zip",
+				)
+			},
+		}
+		aa := NewAddAttachment(moq, v, 1024)
+		w := httptest.NewRecorder()
+		aa.ServeHTTP(w, newMultipartRequest(t, "file", "resume.zip", []byte("PK")))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "attachment type is not allowed", errResp.Message)
+		assert.Equal(t, "detected type: application/zip", errResp.Detail)
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("service returns normal error", func(t *testing.T) {
+		t.Parallel()
+		moq := &AddAttachmentServiceMock{
+			AddAttachmentFunc: func(ctx context.Context, messageID entity.MessageID, fileName string, content []byte) (*entity.Attachment, error) {
+				return nil, errors.New("unexpected error")
+			},
+		}
+		aa := NewAddAttachment(moq, v, 1024)
+		w := httptest.NewRecorder()
+		aa.ServeHTTP(w, newMultipartRequest(t, "file", "resume.pdf", []byte("%PDF-1.4")))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, ErrCodeInternal, errResp.Code)
+		assert.Empty(t, errResp.Detail)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		moq := &AddAttachmentServiceMock{
+			AddAttachmentFunc: func(ctx context.Context, messageID entity.MessageID, fileName string, content []byte) (*entity.Attachment, error) {
+				return &entity.Attachment{
+					ID:          entity.AttachmentID(10),
+					MessageID:   messageID,
+					FileName:    fileName,
+					ContentType: "application/pdf",
+					Size:        int64(len(content)),
+				}, nil
+			},
+		}
+		aa := NewAddAttachment(moq, v, 1024)
+		w := httptest.NewRecorder()
+		aa.ServeHTTP(w, newMultipartRequest(t, "file", "resume.pdf", []byte("%PDF-1.4")))
+		var rsp attachment
+		err := json.Unmarshal(w.Body.Bytes(), &rsp)
+		assert.NoError(t, err)
+		assert.Equal(t, attachment{ID: 10, FileName: "resume.pdf", ContentType: "application/pdf", Size: 8}, rsp)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertResponseConformsToSpec(t, http.MethodPost, "/messages/{id}/attachments", w)
+		calls := moq.AddAttachmentCalls()
+		if assert.Len(t, calls, 1) {
+			assert.Equal(t, entity.MessageID(1), calls[0].MessageID)
+			assert.Equal(t, []byte("%PDF-1.4"), calls[0].Content)
+		}
+	})
+}
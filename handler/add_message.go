@@ -11,51 +11,53 @@ import (
 )
 
 type AddMessage struct {
-	Service   AddMessageService
-	Validator *validator.Validate
+	Service         AddMessageService
+	TemplateService RenderMessageTemplateService
+	Validator       *validator.Validate
 }
 
-func NewAddMessage(service AddMessageService, validator *validator.Validate) *AddMessage {
+func NewAddMessage(service AddMessageService, templateService RenderMessageTemplateService, validator *validator.Validate) *AddMessage {
 	return &AddMessage{
-		Service:   service,
-		Validator: validator,
+		Service:         service,
+		TemplateService: templateService,
+		Validator:       validator,
 	}
 }
 
 func (am *AddMessage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	var requestData struct {
-		MessageThreadID entity.MessageThreadID `json:"message_thread_id" validate:"required,numeric"`
-		IsFromCompany   int8                   `json:"is_from_company"   validate:"oneof=0 1"`
-		IsFromStudent   int8                   `json:"is_from_student"   validate:"oneof=0 1"`
-		Content         string                 `json:"content"           validate:"required"`
-		IsSent          int8                   `json:"is_sent"           validate:"oneof=0 1"`
-		SentAt          time.Time              `json:"sent_at"           validate:"required"`
+		MessageThreadID  entity.MessageThreadID    `json:"message_thread_id"   validate:"required,numeric"`
+		IsFromCompany    int8                      `json:"is_from_company"     validate:"oneof=0 1"`
+		IsFromStudent    int8                      `json:"is_from_student"     validate:"oneof=0 1"`
+		Content          string                    `json:"content"             validate:"required_without=TemplateID"`
+		TemplateID       *entity.MessageTemplateID `json:"template_id"         validate:"omitempty,gt=0"`
+		Variables        map[string]string         `json:"variables"`
+		IsSent           int8                      `json:"is_sent"             validate:"oneof=0 1"`
+		SentAt           time.Time                 `json:"sent_at"             validate:"required"`
+		ReplyToMessageID *entity.MessageID         `json:"reply_to_message_id" validate:"omitempty,gt=0"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		RespondError(w, r, newDecodeError(err))
 		return
 	}
 	if err := am.Validator.Struct(requestData); err != nil {
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: err.Error(),
-		}, http.StatusBadRequest)
+		RespondError(w, r, newValidationError(err))
 		return
 	}
-	message, err := am.Service.AddMessage(ctx, requestData.MessageThreadID, requestData.IsFromCompany, requestData.IsFromStudent, requestData.Content, requestData.IsSent, requestData.SentAt)
-	if err != nil {
-		if serviceErr, ok := err.(*ServiceError); ok {
-			RespondJSON(ctx, w, &ErrResponse{
-				Message: serviceErr.Error(),
-				Detail:  serviceErr.DetailError(),
-			}, serviceErr.StatusCode)
+	content := requestData.Content
+	// テンプレート指定時は、サーバー側でレンダリングした内容を本文とする
+	if requestData.TemplateID != nil {
+		rendered, err := am.TemplateService.RenderMessageTemplate(ctx, *requestData.TemplateID, requestData.Variables)
+		if err != nil {
+			RespondError(w, r, err)
 			return
 		}
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		content = rendered
+	}
+	message, err := am.Service.AddMessage(ctx, requestData.MessageThreadID, requestData.IsFromCompany, requestData.IsFromStudent, content, requestData.IsSent, requestData.SentAt, requestData.ReplyToMessageID)
+	if err != nil {
+		RespondError(w, r, err)
 		return
 	}
 	rsp := struct {
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type AddMessageTemplate struct {
+	Service   AddMessageTemplateService
+	Validator *validator.Validate
+}
+
+func NewAddMessageTemplate(service AddMessageTemplateService, validator *validator.Validate) *AddMessageTemplate {
+	return &AddMessageTemplate{
+		Service:   service,
+		Validator: validator,
+	}
+}
+
+func (amt *AddMessageTemplate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var requestData struct {
+		Name    string `json:"name"    validate:"required,max=100"`
+		Content string `json:"content" validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		RespondError(w, r, newDecodeError(err))
+		return
+	}
+	if err := amt.Validator.Struct(requestData); err != nil {
+		RespondError(w, r, newValidationError(err))
+		return
+	}
+	template, err := amt.Service.AddMessageTemplate(ctx, requestData.Name, requestData.Content)
+	if err != nil {
+		RespondError(w, r, err)
+		return
+	}
+	rsp := struct {
+		ID entity.MessageTemplateID `json:"id"`
+	}{ID: template.ID}
+	RespondJSON(ctx, w, &rsp, http.StatusOK)
+}
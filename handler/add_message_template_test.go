@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestAddMessageTemplate_ServeHTTP(t *testing.T) {
+	v := validator.New()
+
+	t.Run("JSON decode error", func(t *testing.T) {
+		t.Parallel()
+		amt := NewAddMessageTemplate(&AddMessageTemplateServiceMock{}, v)
+		r := httptest.NewRequest(http.MethodPost, "/messages/templates", bytes.NewBufferString("{ invalid json }"))
+		w := httptest.NewRecorder()
+		amt.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		t.Parallel()
+		amt := NewAddMessageTemplate(&AddMessageTemplateServiceMock{}, v)
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"name":    strings.Repeat("a", 101),
+			"content": "{{student_name}}様",
+		})
+		r := httptest.NewRequest(http.MethodPost, "/messages/templates", bytes.NewBuffer(requestBody))
+		w := httptest.NewRecorder()
+		amt.ServeHTTP(w, r)
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Contains(t, errResp.Message, "max")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service returns ServiceError", func(t *testing.T) {
+		t.Parallel()
+		moq := &AddMessageTemplateServiceMock{
+			AddMessageTemplateFunc: func(ctx context.Context, name, content string) (*entity.MessageTemplate, error) {
+				return nil, NewServiceError(ErrCodeInternal, "failed to add message template", "insert error")
+			},
+		}
+		amt := NewAddMessageTemplate(moq, v)
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"name":    "面接案内",
+			"content": "{{student_name}}様",
+		})
+		r := httptest.NewRequest(http.MethodPost, "/messages/templates", bytes.NewBuffer(requestBody))
+		w := httptest.NewRecorder()
+		amt.ServeHTTP(w, r)
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "failed to add message template", errResp.Message)
+		assert.Empty(t, errResp.Detail)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assertResponseConformsToSpec(t, http.MethodPost, "/messages/templates", w)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		moq := &AddMessageTemplateServiceMock{
+			AddMessageTemplateFunc: func(ctx context.Context, name, content string) (*entity.MessageTemplate, error) {
+				return &entity.MessageTemplate{ID: 7, Name: name, Content: content}, nil
+			},
+		}
+		amt := NewAddMessageTemplate(moq, v)
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"name":    "面接案内",
+			"content": "{{student_name}}様",
+		})
+		r := httptest.NewRequest(http.MethodPost, "/messages/templates", bytes.NewBuffer(requestBody))
+		w := httptest.NewRecorder()
+		amt.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id":7}`, w.Body.String())
+		assertRequestConformsToSpec(t, http.MethodPost, "/messages/templates", requestBody)
+		assertResponseConformsToSpec(t, http.MethodPost, "/messages/templates", w)
+	})
+}
@@ -21,7 +21,7 @@ func TestAddMessage_ServeHTTP(t *testing.T) {
 
 	t.Run("JSON decode error", func(t *testing.T) {
 		t.Parallel()
-		am := NewAddMessage(&AddMessageServiceMock{}, v)
+		am := NewAddMessage(&AddMessageServiceMock{}, &RenderMessageTemplateServiceMock{}, v)
 		r := httptest.NewRequest(http.MethodPost, "/messages", bytes.NewBufferString("{ invalid json }"))
 		w := httptest.NewRecorder()
 		am.ServeHTTP(w, r)
@@ -29,12 +29,12 @@ func TestAddMessage_ServeHTTP(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Contains(t, errResp.Message, "invalid")
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
 	t.Run("validation error", func(t *testing.T) {
 		t.Parallel()
-		am := NewAddMessage(&AddMessageServiceMock{}, v)
+		am := NewAddMessage(&AddMessageServiceMock{}, &RenderMessageTemplateServiceMock{}, v)
 		requestBody, _ := json.Marshal(map[string]interface{}{
 			// "message_thread_id" を省略
 			"is_from_company": 1,
@@ -56,15 +56,15 @@ func TestAddMessage_ServeHTTP(t *testing.T) {
 	t.Run("service returns ServiceError", func(t *testing.T) {
 		t.Parallel()
 		moq := &AddMessageServiceMock{
-			AddMessageFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time) (*entity.Message, error) {
+			AddMessageFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time, replyToMessageID *entity.MessageID) (*entity.Message, error) {
 				return nil, NewServiceError(
-					http.StatusInternalServerError,
+					ErrCodeInternal,
 					"some service error",
 					"something detail",
 				)
 			},
 		}
-		am := NewAddMessage(moq, v)
+		am := NewAddMessage(moq, &RenderMessageTemplateServiceMock{}, v)
 		requestBody, _ := json.Marshal(map[string]interface{}{
 			"message_thread_id": 1,
 			"is_from_company":   1,
@@ -80,18 +80,18 @@ func TestAddMessage_ServeHTTP(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Equal(t, "some service error", errResp.Message)
-		assert.Equal(t, "something detail", errResp.Detail)
+		assert.Empty(t, errResp.Detail)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
 	t.Run("service returns normal error", func(t *testing.T) {
 		t.Parallel()
 		moq := &AddMessageServiceMock{
-			AddMessageFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time) (*entity.Message, error) {
+			AddMessageFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time, replyToMessageID *entity.MessageID) (*entity.Message, error) {
 				return nil, errors.New("unexpected error")
 			},
 		}
-		am := NewAddMessage(moq, v)
+		am := NewAddMessage(moq, &RenderMessageTemplateServiceMock{}, v)
 		requestBody, _ := json.Marshal(map[string]interface{}{
 			"message_thread_id": 1,
 			"is_from_company":   1,
@@ -106,14 +106,15 @@ func TestAddMessage_ServeHTTP(t *testing.T) {
 		var errResp ErrResponse
 		err := json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
-		assert.Equal(t, "unexpected error", errResp.Message)
+		assert.Equal(t, ErrCodeInternal, errResp.Code)
+		assert.Empty(t, errResp.Detail)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
 	t.Run("success", func(t *testing.T) {
 		t.Parallel()
 		moq := &AddMessageServiceMock{
-			AddMessageFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time) (*entity.Message, error) {
+			AddMessageFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time, replyToMessageID *entity.MessageID) (*entity.Message, error) {
 				return &entity.Message{
 					ID:              entity.MessageID(1),
 					MessageThreadID: messageThreadID,
@@ -125,7 +126,7 @@ func TestAddMessage_ServeHTTP(t *testing.T) {
 				}, nil
 			},
 		}
-		am := NewAddMessage(moq, v)
+		am := NewAddMessage(moq, &RenderMessageTemplateServiceMock{}, v)
 		requestBody, _ := json.Marshal(map[string]interface{}{
 			"message_thread_id": 1,
 			"is_from_company":   1,
@@ -144,5 +145,128 @@ func TestAddMessage_ServeHTTP(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, entity.MessageID(1), rsp.ID)
 		assert.Equal(t, http.StatusOK, w.Code)
+		assertRequestConformsToSpec(t, http.MethodPost, "/messages", requestBody)
+		assertResponseConformsToSpec(t, http.MethodPost, "/messages", w)
+		assert.Nil(t, moq.AddMessageCalls()[0].ReplyToMessageID)
+	})
+
+	t.Run("success with reply", func(t *testing.T) {
+		t.Parallel()
+		moq := &AddMessageServiceMock{
+			AddMessageFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time, replyToMessageID *entity.MessageID) (*entity.Message, error) {
+				return &entity.Message{
+					ID:               entity.MessageID(2),
+					MessageThreadID:  messageThreadID,
+					ReplyToMessageID: replyToMessageID,
+				}, nil
+			},
+		}
+		am := NewAddMessage(moq, &RenderMessageTemplateServiceMock{}, v)
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"message_thread_id":   1,
+			"is_from_company":     0,
+			"is_from_student":     1,
+			"content":             "承知しました",
+			"is_sent":             1,
+			"sent_at":             time.Now().Format(time.RFC3339),
+			"reply_to_message_id": 1,
+		})
+		r := httptest.NewRequest(http.MethodPost, "/messages", bytes.NewBuffer(requestBody))
+		w := httptest.NewRecorder()
+		am.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertRequestConformsToSpec(t, http.MethodPost, "/messages", requestBody)
+		assertResponseConformsToSpec(t, http.MethodPost, "/messages", w)
+		calls := moq.AddMessageCalls()
+		if assert.Len(t, calls, 1) && assert.NotNil(t, calls[0].ReplyToMessageID) {
+			assert.Equal(t, entity.MessageID(1), *calls[0].ReplyToMessageID)
+		}
+	})
+
+	t.Run("invalid reply_to_message_id", func(t *testing.T) {
+		t.Parallel()
+		am := NewAddMessage(&AddMessageServiceMock{}, &RenderMessageTemplateServiceMock{}, v)
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"message_thread_id":   1,
+			"is_from_company":     0,
+			"is_from_student":     1,
+			"content":             "承知しました",
+			"is_sent":             1,
+			"sent_at":             time.Now().Format(time.RFC3339),
+			"reply_to_message_id": 0,
+		})
+		r := httptest.NewRequest(http.MethodPost, "/messages", bytes.NewBuffer(requestBody))
+		w := httptest.NewRecorder()
+		am.ServeHTTP(w, r)
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Contains(t, errResp.Message, "ReplyToMessageID")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("success with template", func(t *testing.T) {
+		t.Parallel()
+		moq := &AddMessageServiceMock{
+			AddMessageFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time, replyToMessageID *entity.MessageID) (*entity.Message, error) {
+				return &entity.Message{ID: entity.MessageID(3), Content: content}, nil
+			},
+		}
+		templateMoq := &RenderMessageTemplateServiceMock{
+			RenderMessageTemplateFunc: func(ctx context.Context, id entity.MessageTemplateID, variables map[string]string) (string, error) {
+				return variables["student_name"] + "様", nil
+			},
+		}
+		am := NewAddMessage(moq, templateMoq, v)
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"message_thread_id": 1,
+			"is_from_company":   1,
+			"is_from_student":   0,
+			"template_id":       2,
+			"variables":         map[string]string{"student_name": "山田太郎"},
+			"is_sent":           1,
+			"sent_at":           time.Now().Format(time.RFC3339),
+		})
+		r := httptest.NewRequest(http.MethodPost, "/messages", bytes.NewBuffer(requestBody))
+		w := httptest.NewRecorder()
+		am.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertRequestConformsToSpec(t, http.MethodPost, "/messages", requestBody)
+		assertResponseConformsToSpec(t, http.MethodPost, "/messages", w)
+		if assert.Len(t, templateMoq.RenderMessageTemplateCalls(), 1) {
+			assert.Equal(t, entity.MessageTemplateID(2), templateMoq.RenderMessageTemplateCalls()[0].ID)
+		}
+		if assert.Len(t, moq.AddMessageCalls(), 1) {
+			assert.Equal(t, "山田太郎様", moq.AddMessageCalls()[0].Content)
+		}
+	})
+
+	t.Run("template render returns ServiceError", func(t *testing.T) {
+		t.Parallel()
+		moq := &AddMessageServiceMock{}
+		templateMoq := &RenderMessageTemplateServiceMock{
+			RenderMessageTemplateFunc: func(ctx context.Context, id entity.MessageTemplateID, variables map[string]string) (string, error) {
+				return "", NewServiceError(ErrCodeTemplateVariablesMissing, "missing template variables", "student_name")
+			},
+		}
+		am := NewAddMessage(moq, templateMoq, v)
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"message_thread_id": 1,
+			"is_from_company":   1,
+			"is_from_student":   0,
+			"template_id":       2,
+			"is_sent":           1,
+			"sent_at":           time.Now().Format(time.RFC3339),
+		})
+		r := httptest.NewRequest(http.MethodPost, "/messages", bytes.NewBuffer(requestBody))
+		w := httptest.NewRecorder()
+		am.ServeHTTP(w, r)
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "missing template variables", errResp.Message)
+		assert.Equal(t, "student_name", errResp.Detail)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Len(t, moq.AddMessageCalls(), 0)
 	})
 }
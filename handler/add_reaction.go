@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type AddReaction struct {
+	Service   AddReactionService
+	Validator *validator.Validate
+}
+
+func NewAddReaction(service AddReactionService, validator *validator.Validate) *AddReaction {
+	return &AddReaction{
+		Service:   service,
+		Validator: validator,
+	}
+}
+
+func (ar *AddReaction) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "ID must be a number", ""))
+		return
+	}
+	var requestData struct {
+		Reaction string `json:"reaction" validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		RespondError(w, r, newDecodeError(err))
+		return
+	}
+	if err := ar.Validator.Struct(requestData); err != nil {
+		RespondError(w, r, newValidationError(err))
+		return
+	}
+	err = ar.Service.AddReaction(ctx, entity.MessageID(id), requestData.Reaction)
+	if err != nil {
+		RespondError(w, r, err)
+		return
+	}
+	RespondJSON(ctx, w, &SuccessResponse{
+		Message: "add reaction was successful",
+	}, http.StatusOK)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestAddReaction_ServeHTTP(t *testing.T) {
+	v := validator.New()
+
+	newRequest := func(id string, body *bytes.Buffer) *http.Request {
+		chiCtx := chi.NewRouteContext()
+		chiCtx.URLParams.Add("id", id)
+		r := httptest.NewRequest(http.MethodPost, "/messages/"+id+"/reactions", body)
+		return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+	}
+
+	t.Run("ID parse error", func(t *testing.T) {
+		t.Parallel()
+		ar := NewAddReaction(&AddReactionServiceMock{}, v)
+		w := httptest.NewRecorder()
+		ar.ServeHTTP(w, newRequest("abc", bytes.NewBufferString(`{"reaction":"👍"}`)))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "ID must be a number", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		t.Parallel()
+		ar := NewAddReaction(&AddReactionServiceMock{}, v)
+		w := httptest.NewRecorder()
+		ar.ServeHTTP(w, newRequest("1", bytes.NewBufferString(`{}`)))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Contains(t, errResp.Message, "required")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service returns ServiceError", func(t *testing.T) {
+		t.Parallel()
+		moq := &AddReactionServiceMock{
+			AddReactionFunc: func(ctx context.Context, messageID entity.MessageID, reaction string) error {
+				return NewServiceError(ErrCodeReactionNotAllowed, "reaction is not allowed", "")
+			},
+		}
+		ar := NewAddReaction(moq, v)
+		w := httptest.NewRecorder()
+		ar.ServeHTTP(w, newRequest("1", bytes.NewBufferString(`{"reaction":"😡"}`)))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "reaction is not allowed", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service returns normal error", func(t *testing.T) {
+		t.Parallel()
+		moq := &AddReactionServiceMock{
+			AddReactionFunc: func(ctx context.Context, messageID entity.MessageID, reaction string) error {
+				return errors.New("unexpected error")
+			},
+		}
+		ar := NewAddReaction(moq, v)
+		w := httptest.NewRecorder()
+		ar.ServeHTTP(w, newRequest("1", bytes.NewBufferString(`{"reaction":"👍"}`)))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, ErrCodeInternal, errResp.Code)
+		assert.Empty(t, errResp.Detail)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		moq := &AddReactionServiceMock{
+			AddReactionFunc: func(ctx context.Context, messageID entity.MessageID, reaction string) error {
+				return nil
+			},
+		}
+		ar := NewAddReaction(moq, v)
+		w := httptest.NewRecorder()
+		ar.ServeHTTP(w, newRequest("1", bytes.NewBufferString(`{"reaction":"確認しました"}`)))
+		var successResp SuccessResponse
+		err := json.Unmarshal(w.Body.Bytes(), &successResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "add reaction was successful", successResp.Message)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertResponseConformsToSpec(t, http.MethodPost, "/messages/{id}/reactions", w)
+		calls := moq.AddReactionCalls()
+		if assert.Len(t, calls, 1) {
+			assert.Equal(t, entity.MessageID(1), calls[0].MessageID)
+			assert.Equal(t, "確認しました", calls[0].Reaction)
+		}
+	})
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// BodyLimitMiddleware はリクエストボディを limit バイトまでに制限する。
+// Content-Length で超過がわかる場合は読み込む前に 413 を返し、chunked の場合は limit を超えた時点で読み込みを打ち切る
+func BodyLimitMiddleware(limit int64) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				RespondError(w, r, NewServiceError(ErrCodeRequestTooLarge, "request body is too large", fmt.Sprintf("request body must be at most %d bytes", limit)))
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type BulkAddMessage struct {
+	Service         BulkAddMessageService
+	TemplateService RenderMessageTemplateService
+	Validator       *validator.Validate
+}
+
+type bulkSendResult struct {
+	MessageThreadID entity.MessageThreadID `json:"message_thread_id"`
+	Status          string                 `json:"status"`
+	MessageID       *entity.MessageID      `json:"message_id"`
+	Code            ErrorCode              `json:"code,omitempty"`
+}
+
+// bulkRecipient は宛先ごとの変数。variables は全宛先共通の variables より優先する
+type bulkRecipient struct {
+	MessageThreadID entity.MessageThreadID `json:"message_thread_id" validate:"gt=0"`
+	Variables       map[string]string      `json:"variables"`
+}
+
+func NewBulkAddMessage(service BulkAddMessageService, templateService RenderMessageTemplateService, validator *validator.Validate) *BulkAddMessage {
+	return &BulkAddMessage{
+		Service:         service,
+		TemplateService: templateService,
+		Validator:       validator,
+	}
+}
+
+func (bam *BulkAddMessage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var requestData struct {
+		MessageThreadIDs []entity.MessageThreadID  `json:"message_thread_ids" validate:"required_without=Recipients,excluded_with=Recipients,omitempty,min=1,max=1000,dive,gt=0"`
+		Recipients       []bulkRecipient           `json:"recipients"         validate:"required_without=MessageThreadIDs,omitempty,min=1,max=1000,dive"`
+		Content          string                    `json:"content"            validate:"required_without=TemplateID"`
+		TemplateID       *entity.MessageTemplateID `json:"template_id"        validate:"omitempty,gt=0"`
+		Variables        map[string]string         `json:"variables"`
+		IsSent           int8                      `json:"is_sent"            validate:"oneof=0 1"`
+		SentAt           time.Time                 `json:"sent_at"            validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		RespondError(w, r, newDecodeError(err))
+		return
+	}
+	if err := bam.Validator.Struct(requestData); err != nil {
+		RespondError(w, r, newValidationError(err))
+		return
+	}
+	recipients := requestData.Recipients
+	for _, id := range requestData.MessageThreadIDs {
+		recipients = append(recipients, bulkRecipient{MessageThreadID: id})
+	}
+	messages := make([]entity.BulkMessage, 0, len(recipients))
+	for _, recipient := range recipients {
+		messages = append(messages, entity.BulkMessage{MessageThreadID: recipient.MessageThreadID, Content: requestData.Content})
+	}
+	if requestData.TemplateID != nil {
+		contents, err := bam.renderTemplate(ctx, *requestData.TemplateID, requestData.Variables, requestData.Recipients, len(messages))
+		if err != nil {
+			RespondError(w, r, err)
+			return
+		}
+		for i := range messages {
+			messages[i].Content = contents[i]
+		}
+	}
+	results, err := bam.Service.BulkAddMessages(ctx, messages, requestData.IsSent, requestData.SentAt)
+	if err != nil {
+		RespondError(w, r, err)
+		return
+	}
+	rsp := struct {
+		Results []bulkSendResult `json:"results"`
+	}{Results: make([]bulkSendResult, 0, len(results))}
+	for _, result := range results {
+		rsp.Results = append(rsp.Results, bulkSendResult{
+			MessageThreadID: result.MessageThreadID,
+			Status:          result.Status,
+			MessageID:       result.MessageID,
+			Code:            ErrorCode(result.Code),
+		})
+	}
+	RespondJSON(ctx, w, &rsp, http.StatusOK)
+}
+
+// renderTemplate はテンプレートを宛先ごとにレンダリングする。
+// message_thread_ids で指定された場合は全宛先で変数が同じため、1度だけレンダリングする
+func (bam *BulkAddMessage) renderTemplate(ctx context.Context, id entity.MessageTemplateID, variables map[string]string, recipients []bulkRecipient, n int) ([]string, error) {
+	if len(recipients) == 0 {
+		rendered, err := bam.TemplateService.RenderMessageTemplate(ctx, id, variables)
+		if err != nil {
+			return nil, err
+		}
+		contents := make([]string, n)
+		for i := range contents {
+			contents[i] = rendered
+		}
+		return contents, nil
+	}
+	recipientVariables := make([]map[string]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		merged := make(map[string]string, len(variables)+len(recipient.Variables))
+		for k, v := range variables {
+			merged[k] = v
+		}
+		for k, v := range recipient.Variables {
+			merged[k] = v
+		}
+		recipientVariables = append(recipientVariables, merged)
+	}
+	return bam.TemplateService.RenderMessageTemplates(ctx, id, recipientVariables)
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestBulkAddMessage_ServeHTTP(t *testing.T) {
+	v := validator.New()
+
+	t.Run("JSON decode error", func(t *testing.T) {
+		t.Parallel()
+		bam := NewBulkAddMessage(&BulkAddMessageServiceMock{}, &RenderMessageTemplateServiceMock{}, v)
+		r := httptest.NewRequest(http.MethodPost, "/messages/bulk", bytes.NewBufferString("{ invalid json }"))
+		w := httptest.NewRecorder()
+		bam.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		t.Parallel()
+		bam := NewBulkAddMessage(&BulkAddMessageServiceMock{}, &RenderMessageTemplateServiceMock{}, v)
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"message_thread_ids": []int64{},
+			"content":            "説明会のご案内",
+			"is_sent":            1,
+			"sent_at":            time.Now().Format(time.RFC3339),
+		})
+		r := httptest.NewRequest(http.MethodPost, "/messages/bulk", bytes.NewBuffer(requestBody))
+		w := httptest.NewRecorder()
+		bam.ServeHTTP(w, r)
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Contains(t, errResp.Message, "MessageThreadIDs")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service returns ServiceError", func(t *testing.T) {
+		t.Parallel()
+		moq := &BulkAddMessageServiceMock{
+			BulkAddMessagesFunc: func(ctx context.Context, messages []entity.BulkMessage, isSent int8, sentAt time.Time) (entity.BulkSendResults, error) {
+				return nil, NewServiceError(ErrCodeForbidden, "unauthorized: bulk send is only available to company users", "")
+			},
+		}
+		bam := NewBulkAddMessage(moq, &RenderMessageTemplateServiceMock{}, v)
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"message_thread_ids": []int64{1},
+			"content":            "説明会のご案内",
+			"is_sent":            1,
+			"sent_at":            time.Now().Format(time.RFC3339),
+		})
+		r := httptest.NewRequest(http.MethodPost, "/messages/bulk", bytes.NewBuffer(requestBody))
+		w := httptest.NewRecorder()
+		bam.ServeHTTP(w, r)
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "unauthorized: bulk send is only available to company users", errResp.Message)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("success with template", func(t *testing.T) {
+		t.Parallel()
+		messageID := entity.MessageID(10)
+		moq := &BulkAddMessageServiceMock{
+			BulkAddMessagesFunc: func(ctx context.Context, messages []entity.BulkMessage, isSent int8, sentAt time.Time) (entity.BulkSendResults, error) {
+				return entity.BulkSendResults{
+					&entity.BulkSendResult{MessageThreadID: 1, Status: entity.BulkSendStatusSent, MessageID: &messageID},
+					&entity.BulkSendResult{MessageThreadID: 2, Status: entity.BulkSendStatusForbidden},
+					&entity.BulkSendResult{MessageThreadID: 3, Status: entity.BulkSendStatusFailed, Code: string(ErrCodeInternal)},
+				}, nil
+			},
+		}
+		templateMoq := &RenderMessageTemplateServiceMock{
+			RenderMessageTemplateFunc: func(ctx context.Context, id entity.MessageTemplateID, variables map[string]string) (string, error) {
+				return "説明会は" + variables["date"] + "です", nil
+			},
+		}
+		bam := NewBulkAddMessage(moq, templateMoq, v)
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"message_thread_ids": []int64{1, 2, 3},
+			"template_id":        3,
+			"variables":          map[string]string{"date": "4月1日"},
+			"is_sent":            1,
+			"sent_at":            time.Now().Format(time.RFC3339),
+		})
+		r := httptest.NewRequest(http.MethodPost, "/messages/bulk", bytes.NewBuffer(requestBody))
+		w := httptest.NewRecorder()
+		bam.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertRequestConformsToSpec(t, http.MethodPost, "/messages/bulk", requestBody)
+		assertResponseConformsToSpec(t, http.MethodPost, "/messages/bulk", w)
+		assert.JSONEq(t, `{"results":[
+			{"message_thread_id":1,"status":"sent","message_id":10},
+			{"message_thread_id":2,"status":"forbidden","message_id":null},
+			{"message_thread_id":3,"status":"failed","message_id":null,"code":"internal_error"}
+		]}`, w.Body.String())
+		if assert.Len(t, moq.BulkAddMessagesCalls(), 1) {
+			call := moq.BulkAddMessagesCalls()[0]
+			assert.Equal(t, []entity.BulkMessage{
+				{MessageThreadID: 1, Content: "説明会は4月1日です"},
+				{MessageThreadID: 2, Content: "説明会は4月1日です"},
+				{MessageThreadID: 3, Content: "説明会は4月1日です"},
+			}, call.Messages)
+		}
+		assert.Len(t, templateMoq.RenderMessageTemplateCalls(), 1)
+	})
+
+	t.Run("success with per-recipient variables", func(t *testing.T) {
+		t.Parallel()
+		moq := &BulkAddMessageServiceMock{
+			BulkAddMessagesFunc: func(ctx context.Context, messages []entity.BulkMessage, isSent int8, sentAt time.Time) (entity.BulkSendResults, error) {
+				results := make(entity.BulkSendResults, 0, len(messages))
+				for i, m := range messages {
+					messageID := entity.MessageID(10 + i)
+					results = append(results, &entity.BulkSendResult{MessageThreadID: m.MessageThreadID, Status: entity.BulkSendStatusSent, MessageID: &messageID})
+				}
+				return results, nil
+			},
+		}
+		templateMoq := &RenderMessageTemplateServiceMock{
+			RenderMessageTemplatesFunc: func(ctx context.Context, id entity.MessageTemplateID, variables []map[string]string) ([]string, error) {
+				contents := make([]string, 0, len(variables))
+				for _, v := range variables {
+					contents = append(contents, v["student_name"]+"さん、説明会は"+v["date"]+"です")
+				}
+				return contents, nil
+			},
+		}
+		bam := NewBulkAddMessage(moq, templateMoq, v)
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"recipients": []map[string]interface{}{
+				{"message_thread_id": 1, "variables": map[string]string{"student_name": "山田"}},
+				{"message_thread_id": 2, "variables": map[string]string{"student_name": "佐藤", "date": "4月2日"}},
+			},
+			"template_id": 3,
+			"variables":   map[string]string{"date": "4月1日"},
+			"is_sent":     1,
+			"sent_at":     time.Now().Format(time.RFC3339),
+		})
+		r := httptest.NewRequest(http.MethodPost, "/messages/bulk", bytes.NewBuffer(requestBody))
+		w := httptest.NewRecorder()
+		bam.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertRequestConformsToSpec(t, http.MethodPost, "/messages/bulk", requestBody)
+		assertResponseConformsToSpec(t, http.MethodPost, "/messages/bulk", w)
+		if assert.Len(t, templateMoq.RenderMessageTemplatesCalls(), 1) {
+			assert.Equal(t, []map[string]string{
+				{"student_name": "山田", "date": "4月1日"},
+				{"student_name": "佐藤", "date": "4月2日"},
+			}, templateMoq.RenderMessageTemplatesCalls()[0].Variables)
+		}
+		if assert.Len(t, moq.BulkAddMessagesCalls(), 1) {
+			assert.Equal(t, []entity.BulkMessage{
+				{MessageThreadID: 1, Content: "山田さん、説明会は4月1日です"},
+				{MessageThreadID: 2, Content: "佐藤さん、説明会は4月2日です"},
+			}, moq.BulkAddMessagesCalls()[0].Messages)
+		}
+	})
+
+	t.Run("message_thread_ids and recipients are exclusive", func(t *testing.T) {
+		t.Parallel()
+		bam := NewBulkAddMessage(&BulkAddMessageServiceMock{}, &RenderMessageTemplateServiceMock{}, v)
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"message_thread_ids": []int64{1},
+			"recipients":         []map[string]interface{}{{"message_thread_id": 2}},
+			"content":            "説明会のご案内",
+			"is_sent":            1,
+			"sent_at":            time.Now().Format(time.RFC3339),
+		})
+		r := httptest.NewRequest(http.MethodPost, "/messages/bulk", bytes.NewBuffer(requestBody))
+		w := httptest.NewRecorder()
+		bam.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/yuyacode/AppLiftMessageApi/request"
+)
+
+const consistencyHeader = "X-Consistency"
+
+// ConsistencyMiddleware は X-Consistency: strong が指定されたリクエストの読み取りを、レプリカではなく primary で行わせる。
+// メッセージを送信した直後の取得など、レプリカの遅延で自分の書き込みが見えないと困る場合に使う
+func ConsistencyMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(consistencyHeader) == "strong" {
+				r = r.WithContext(request.SetReadFromPrimary(r.Context()))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// StickyPrimaryMiddleware は書き込み（GET・HEAD 以外）に成功したユーザーの読み取りを、その後 window の間 primary で行わせる。
+// X-Consistency を指定しないクライアントでも、自分の書き込みが直後の読み取りで見えるようにする（read-your-writes）。
+// 書き込みの記録はプロセスのメモリに持つため、複数台構成で別のインスタンスに振り分けられた場合は X-Consistency: strong が必要になる。
+// ユーザーを特定するため、VerifyAccessTokenMiddleware より後に設定する。window が 0 以下の場合は何もしない
+func StickyPrimaryMiddleware(window time.Duration) func(next http.Handler) http.Handler {
+	var mu sync.Mutex
+	lastWrites := map[string]time.Time{}
+	return func(next http.Handler) http.Handler {
+		if window <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			appKind, ok := request.GetAppKind(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			userID, ok := request.GetUserID(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			key := appKind + ":" + strconv.FormatInt(userID, 10)
+			mu.Lock()
+			lastWrite, ok := lastWrites[key]
+			mu.Unlock()
+			if ok && time.Since(lastWrite) < window {
+				r = r.WithContext(request.SetReadFromPrimary(r.Context()))
+			}
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+			if ww.Status() >= http.StatusBadRequest {
+				return
+			}
+			now := time.Now()
+			mu.Lock()
+			// 期限を過ぎた記録は書き込みのたびに消し、マップが増え続けないようにする
+			for k, t := range lastWrites {
+				if now.Sub(t) >= window {
+					delete(lastWrites, k)
+				}
+			}
+			lastWrites[key] = now
+			mu.Unlock()
+		})
+	}
+}
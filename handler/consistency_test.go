@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/request"
+)
+
+func TestStickyPrimaryMiddleware(t *testing.T) {
+	type step struct {
+		method          string
+		userID          int64
+		status          int
+		wantFromPrimary bool
+	}
+	tests := []struct {
+		name   string
+		window time.Duration
+		steps  []step
+	}{
+		{
+			name:   "reads from primary after own write",
+			window: time.Minute,
+			steps: []step{
+				{method: http.MethodGet, userID: 1, status: http.StatusOK, wantFromPrimary: false},
+				{method: http.MethodPost, userID: 1, status: http.StatusCreated, wantFromPrimary: false},
+				{method: http.MethodGet, userID: 1, status: http.StatusOK, wantFromPrimary: true},
+				{method: http.MethodGet, userID: 2, status: http.StatusOK, wantFromPrimary: false},
+			},
+		},
+		{
+			name:   "failed write is not recorded",
+			window: time.Minute,
+			steps: []step{
+				{method: http.MethodPatch, userID: 1, status: http.StatusForbidden, wantFromPrimary: false},
+				{method: http.MethodGet, userID: 1, status: http.StatusOK, wantFromPrimary: false},
+			},
+		},
+		{
+			name:   "disabled",
+			window: 0,
+			steps: []step{
+				{method: http.MethodDelete, userID: 1, status: http.StatusNoContent, wantFromPrimary: false},
+				{method: http.MethodGet, userID: 1, status: http.StatusOK, wantFromPrimary: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := StickyPrimaryMiddleware(tt.window)
+			for i, s := range tt.steps {
+				var gotFromPrimary bool
+				h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					gotFromPrimary = request.GetReadFromPrimary(r.Context())
+					w.WriteHeader(s.status)
+				}))
+				r := httptest.NewRequest(s.method, "/messages", nil)
+				ctx := request.SetAppKind(r.Context(), "company")
+				ctx = request.SetUserID(ctx, s.userID)
+				h.ServeHTTP(httptest.NewRecorder(), r.WithContext(ctx))
+				assert.Equal(t, s.wantFromPrimary, gotFromPrimary, "step %d", i)
+			}
+		})
+	}
+}
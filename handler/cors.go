@@ -2,38 +2,60 @@ package handler
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
-	"os"
+	"net/url"
+	"strings"
 
 	"github.com/go-chi/cors"
-	"github.com/joho/godotenv"
 )
 
-func CORSMiddleware() func(http.Handler) http.Handler {
-	allowedOrigin, err := getAllowedOrigin()
-	if err != nil {
-		log.Printf("failed to get allowed origin: %v", err)
-		allowedOrigin = "*" // フォールバックとして全オリジンを許可
+// CORSMiddleware は allowedOrigins のオリジンからのリクエストを許可する。
+// オリジンには https://*.example.com のように、ホスト名の先頭のラベルを * にしたパターンも指定できる（プレビュー環境向け）。
+// allowedOrigins が空の場合はローカル環境向けに全オリジンを許可する。ローカル環境（ENV=dev）以外では config の検証で起動時にエラーとなる
+func CORSMiddleware(allowedOrigins []string, allowCredentials bool) (func(http.Handler) http.Handler, error) {
+	if len(allowedOrigins) == 0 {
+		slog.Warn("ALLOWED_ORIGINS is not set, allowing all origins")
+		allowedOrigins = []string{"*"}
+		allowCredentials = false
+	} else {
+		for _, origin := range allowedOrigins {
+			if err := validateOriginPattern(origin, allowCredentials); err != nil {
+				return nil, err
+			}
+		}
 	}
 	return cors.Handler(cors.Options{
-		AllowedOrigins: []string{allowedOrigin},
-		AllowedMethods: []string{"GET", "POST", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type"},
-		MaxAge:         300,
-	})
+		AllowedOrigins:   allowedOrigins,
+		AllowedMethods:   []string{"GET", "POST", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Accept-Language", "Authorization", "Content-Type", requestIDHeader, consistencyHeader, "traceparent", "tracestate"},
+		ExposedHeaders:   []string{requestIDHeader},
+		AllowCredentials: allowCredentials,
+		MaxAge:           300,
+	}), nil
 }
 
-func getAllowedOrigin() (string, error) {
-	// ローカル環境（ENV=dev）でのみ環境変数を.envで管理している
-	if err := godotenv.Load("/app/.env"); err != nil {
-		if os.Getenv("ENV") == "dev" {
-			return "", err
+// validateOriginPattern はオリジンが scheme://host[:port] の形式で、ワイルドカードが先頭のサブドメインのみであることを確認する
+func validateOriginPattern(origin string, allowCredentials bool) error {
+	if origin == "*" {
+		if allowCredentials {
+			return fmt.Errorf("invalid allowed origin %q: wildcard origin cannot be used with credentials", origin)
 		}
+		return nil
+	}
+	scheme, host, ok := strings.Cut(origin, "://")
+	if !ok || (scheme != "http" && scheme != "https") {
+		return fmt.Errorf("invalid allowed origin %q: must start with http:// or https://", origin)
+	}
+	if rest, ok := strings.CutPrefix(host, "*."); ok {
+		host = "wildcard." + rest
+	}
+	if strings.Contains(host, "*") {
+		return fmt.Errorf("invalid allowed origin %q: wildcard is only allowed as the first label of the host", origin)
 	}
-	allowedOrigin := os.Getenv("ALLOWED_ORIGIN")
-	if allowedOrigin == "" {
-		return "", fmt.Errorf("ALLOWED_ORIGIN environment variable not set")
+	u, err := url.Parse(scheme + "://" + host)
+	if err != nil || u.Host != host || u.Hostname() == "" {
+		return fmt.Errorf("invalid allowed origin %q: must be scheme://host[:port] without path", origin)
 	}
-	return allowedOrigin, nil
+	return nil
 }
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	tests := []struct {
+		name             string
+		allowedOrigins   []string
+		allowCredentials bool
+		wantErr          bool
+	}{
+		{name: "empty falls back to all origins", allowedOrigins: nil, allowCredentials: true},
+		{name: "origins and patterns", allowedOrigins: []string{"https://app.example.com", "http://localhost:3000", "https://*.preview.example.com"}, allowCredentials: true},
+		{name: "wildcard without credentials", allowedOrigins: []string{"*"}},
+		{name: "wildcard with credentials", allowedOrigins: []string{"*"}, allowCredentials: true, wantErr: true},
+		{name: "missing scheme", allowedOrigins: []string{"app.example.com"}, wantErr: true},
+		{name: "with path", allowedOrigins: []string{"https://app.example.com/"}, wantErr: true},
+		{name: "wildcard in the middle", allowedOrigins: []string{"https://app.*.example.com"}, wantErr: true},
+		{name: "wildcard scheme", allowedOrigins: []string{"*://app.example.com"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw, err := CORSMiddleware(tt.allowedOrigins, tt.allowCredentials)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, mw)
+		})
+	}
+}
@@ -26,23 +26,12 @@ func (dm *DeleteMessage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: "ID must be a number",
-		}, http.StatusBadRequest)
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "ID must be a number", ""))
 		return
 	}
 	err = dm.Service.DeleteMessage(ctx, entity.MessageID(id))
 	if err != nil {
-		if serviceErr, ok := err.(*ServiceError); ok {
-			RespondJSON(ctx, w, &ErrResponse{
-				Message: serviceErr.Error(),
-				Detail:  serviceErr.DetailError(),
-			}, serviceErr.StatusCode)
-			return
-		}
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		RespondError(w, r, err)
 		return
 	}
 	RespondJSON(ctx, w, &SuccessResponse{
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type DeleteMessageTemplate struct {
+	Service   DeleteMessageTemplateService
+	Validator *validator.Validate
+}
+
+func NewDeleteMessageTemplate(service DeleteMessageTemplateService, validator *validator.Validate) *DeleteMessageTemplate {
+	return &DeleteMessageTemplate{
+		Service:   service,
+		Validator: validator,
+	}
+}
+
+func (dmt *DeleteMessageTemplate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "ID must be a number", ""))
+		return
+	}
+	err = dmt.Service.DeleteMessageTemplate(ctx, entity.MessageTemplateID(id))
+	if err != nil {
+		RespondError(w, r, err)
+		return
+	}
+	RespondJSON(ctx, w, &SuccessResponse{
+		Message: "delete message template was successful",
+	}, http.StatusOK)
+}
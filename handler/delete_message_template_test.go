@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestDeleteMessageTemplate_ServeHTTP(t *testing.T) {
+	v := validator.New()
+
+	newRequest := func(id string) *http.Request {
+		chiCtx := chi.NewRouteContext()
+		chiCtx.URLParams.Add("id", id)
+		r := httptest.NewRequest(http.MethodDelete, "/messages/templates/"+id, nil)
+		return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+	}
+
+	t.Run("ID parse error", func(t *testing.T) {
+		t.Parallel()
+		dmt := NewDeleteMessageTemplate(&DeleteMessageTemplateServiceMock{}, v)
+		w := httptest.NewRecorder()
+		dmt.ServeHTTP(w, newRequest("abc"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "ID must be a number", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service returns non-ServiceError", func(t *testing.T) {
+		t.Parallel()
+		moq := &DeleteMessageTemplateServiceMock{
+			DeleteMessageTemplateFunc: func(ctx context.Context, id entity.MessageTemplateID) error {
+				return errors.New("unexpected error")
+			},
+		}
+		dmt := NewDeleteMessageTemplate(moq, v)
+		w := httptest.NewRecorder()
+		dmt.ServeHTTP(w, newRequest("1"))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		moq := &DeleteMessageTemplateServiceMock{
+			DeleteMessageTemplateFunc: func(ctx context.Context, id entity.MessageTemplateID) error {
+				return nil
+			},
+		}
+		dmt := NewDeleteMessageTemplate(moq, v)
+		w := httptest.NewRecorder()
+		dmt.ServeHTTP(w, newRequest("4"))
+		var resp SuccessResponse
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, "delete message template was successful", resp.Message)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertResponseConformsToSpec(t, http.MethodDelete, "/messages/templates/{id}", w)
+		if assert.Len(t, moq.DeleteMessageTemplateCalls(), 1) {
+			assert.Equal(t, entity.MessageTemplateID(4), moq.DeleteMessageTemplateCalls()[0].ID)
+		}
+	})
+}
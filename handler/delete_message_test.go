@@ -39,7 +39,7 @@ func TestDeleteMessage_ServeHTTP(t *testing.T) {
 		moq := &DeleteMessageServiceMock{
 			DeleteMessageFunc: func(ctx context.Context, id entity.MessageID) error {
 				return NewServiceError(
-					http.StatusInternalServerError,
+					ErrCodeInternal,
 					"some service error",
 					"detail info",
 				)
@@ -56,7 +56,7 @@ func TestDeleteMessage_ServeHTTP(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Equal(t, "some service error", errResp.Message)
-		assert.Equal(t, "detail info", errResp.Detail)
+		assert.Empty(t, errResp.Detail)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
@@ -77,7 +77,8 @@ func TestDeleteMessage_ServeHTTP(t *testing.T) {
 		var errResp ErrResponse
 		err := json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
-		assert.Equal(t, "unexpected error", errResp.Message)
+		assert.Equal(t, ErrCodeInternal, errResp.Code)
+		assert.Empty(t, errResp.Detail)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
@@ -100,5 +101,6 @@ func TestDeleteMessage_ServeHTTP(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "delete message was successful", successResp.Message)
 		assert.Equal(t, http.StatusOK, w.Code)
+		assertResponseConformsToSpec(t, http.MethodDelete, "/messages/{id}", w)
 	})
 }
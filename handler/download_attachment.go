@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type DownloadAttachment struct {
+	Service   DownloadAttachmentService
+	Validator *validator.Validate
+}
+
+func NewDownloadAttachment(service DownloadAttachmentService, validator *validator.Validate) *DownloadAttachment {
+	return &DownloadAttachment{
+		Service:   service,
+		Validator: validator,
+	}
+}
+
+func (da *DownloadAttachment) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "ID must be a number", ""))
+		return
+	}
+	expires := r.URL.Query().Get("expires")
+	signature := r.URL.Query().Get("signature")
+	if expires == "" || signature == "" {
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "missing required query parameter: expires, signature", ""))
+		return
+	}
+	a, body, err := da.Service.DownloadAttachment(ctx, entity.AttachmentID(id), expires, signature)
+	if err != nil {
+		RespondError(w, r, err)
+		return
+	}
+	defer body.Close()
+	w.Header().Set("Content-Type", a.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(a.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, body); err != nil {
+		slog.ErrorContext(ctx, "failed to write attachment", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestDownloadAttachment_ServeHTTP(t *testing.T) {
+	v := validator.New()
+
+	newRequest := func(id, query string) *http.Request {
+		chiCtx := chi.NewRouteContext()
+		chiCtx.URLParams.Add("id", id)
+		r := httptest.NewRequest(http.MethodGet, "/messages/attachments/"+id+query, nil)
+		return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+	}
+
+	t.Run("ID parse error", func(t *testing.T) {
+		t.Parallel()
+		da := NewDownloadAttachment(&DownloadAttachmentServiceMock{}, v)
+		w := httptest.NewRecorder()
+		da.ServeHTTP(w, newRequest("abc", "?expires=1&signature=sig"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "ID must be a number", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/messages/attachments/{id}", w)
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		t.Parallel()
+		da := NewDownloadAttachment(&DownloadAttachmentServiceMock{}, v)
+		w := httptest.NewRecorder()
+		da.ServeHTTP(w, newRequest("1", "?expires=1"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "missing required query parameter: expires, signature", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/messages/attachments/{id}", w)
+	})
+
+	t.Run("service returns ServiceError", func(t *testing.T) {
+		t.Parallel()
+		moq := &DownloadAttachmentServiceMock{
+			DownloadAttachmentFunc: func(ctx context.Context, id entity.AttachmentID, expires, signature string) (*entity.Attachment, io.ReadCloser, error) {
+				return nil, nil, NewServiceError(
+					ErrCodeInvalidSignature,
+					"invalid_signature",
+					"download link has expired",
+				)
+			},
+		}
+		da := NewDownloadAttachment(moq, v)
+		w := httptest.NewRecorder()
+		da.ServeHTTP(w, newRequest("1", "?expires=1&signature=sig"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "invalid_signature", errResp.Message)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/messages/attachments/{id}", w)
+	})
+
+	t.Run("service returns normal error", func(t *testing.T) {
+		t.Parallel()
+		moq := &DownloadAttachmentServiceMock{
+			DownloadAttachmentFunc: func(ctx context.Context, id entity.AttachmentID, expires, signature string) (*entity.Attachment, io.ReadCloser, error) {
+				return nil, nil, errors.New("unexpected error")
+			},
+		}
+		da := NewDownloadAttachment(moq, v)
+		w := httptest.NewRecorder()
+		da.ServeHTTP(w, newRequest("1", "?expires=1&signature=sig"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, ErrCodeInternal, errResp.Code)
+		assert.Empty(t, errResp.Detail)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/messages/attachments/{id}", w)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		moq := &DownloadAttachmentServiceMock{
+			DownloadAttachmentFunc: func(ctx context.Context, id entity.AttachmentID, expires, signature string) (*entity.Attachment, io.ReadCloser, error) {
+				return &entity.Attachment{
+					ID:          id,
+					FileName:    "内定通知書.pdf",
+					ContentType: "application/pdf",
+					Size:        8,
+				}, io.NopCloser(strings.NewReader("%PDF-1.4")), nil
+			},
+		}
+		da := NewDownloadAttachment(moq, v)
+		w := httptest.NewRecorder()
+		da.ServeHTTP(w, newRequest("1", "?expires=1&signature=sig"))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/messages/attachments/{id}", w)
+		assert.Equal(t, "%PDF-1.4", w.Body.String())
+		assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+		assert.Equal(t, "8", w.Header().Get("Content-Length"))
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment;")
+		calls := moq.DownloadAttachmentCalls()
+		if assert.Len(t, calls, 1) {
+			assert.Equal(t, "1", calls[0].Expires)
+			assert.Equal(t, "sig", calls[0].Signature)
+		}
+	})
+}
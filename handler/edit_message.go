@@ -27,38 +27,23 @@ func (em *EditMessage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: "ID must be a number",
-		}, http.StatusBadRequest)
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "ID must be a number", ""))
 		return
 	}
 	var requestData struct {
 		Content string `json:"content" validate:"required"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		RespondError(w, r, newDecodeError(err))
 		return
 	}
 	if err := em.Validator.Struct(requestData); err != nil {
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: err.Error(),
-		}, http.StatusBadRequest)
+		RespondError(w, r, newValidationError(err))
 		return
 	}
 	err = em.Service.EditMessage(ctx, entity.MessageID(id), requestData.Content)
 	if err != nil {
-		if serviceErr, ok := err.(*ServiceError); ok {
-			RespondJSON(ctx, w, &ErrResponse{
-				Message: serviceErr.Error(),
-				Detail:  serviceErr.DetailError(),
-			}, serviceErr.StatusCode)
-			return
-		}
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		RespondError(w, r, err)
 		return
 	}
 	RespondJSON(ctx, w, &SuccessResponse{
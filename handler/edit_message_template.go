@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type EditMessageTemplate struct {
+	Service   EditMessageTemplateService
+	Validator *validator.Validate
+}
+
+func NewEditMessageTemplate(service EditMessageTemplateService, validator *validator.Validate) *EditMessageTemplate {
+	return &EditMessageTemplate{
+		Service:   service,
+		Validator: validator,
+	}
+}
+
+func (emt *EditMessageTemplate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "ID must be a number", ""))
+		return
+	}
+	var requestData struct {
+		Name    string `json:"name"    validate:"required,max=100"`
+		Content string `json:"content" validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		RespondError(w, r, newDecodeError(err))
+		return
+	}
+	if err := emt.Validator.Struct(requestData); err != nil {
+		RespondError(w, r, newValidationError(err))
+		return
+	}
+	err = emt.Service.EditMessageTemplate(ctx, entity.MessageTemplateID(id), requestData.Name, requestData.Content)
+	if err != nil {
+		RespondError(w, r, err)
+		return
+	}
+	RespondJSON(ctx, w, &SuccessResponse{
+		Message: "edit message template was successful",
+	}, http.StatusOK)
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestEditMessageTemplate_ServeHTTP(t *testing.T) {
+	v := validator.New()
+
+	newRequest := func(id string, body *bytes.Buffer) *http.Request {
+		chiCtx := chi.NewRouteContext()
+		chiCtx.URLParams.Add("id", id)
+		r := httptest.NewRequest(http.MethodPatch, "/messages/templates/"+id, body)
+		return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+	}
+
+	t.Run("ID parse error", func(t *testing.T) {
+		t.Parallel()
+		emt := NewEditMessageTemplate(&EditMessageTemplateServiceMock{}, v)
+		w := httptest.NewRecorder()
+		emt.ServeHTTP(w, newRequest("abc", bytes.NewBufferString(`{"name":"a","content":"b"}`)))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "ID must be a number", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		t.Parallel()
+		emt := NewEditMessageTemplate(&EditMessageTemplateServiceMock{}, v)
+		w := httptest.NewRecorder()
+		emt.ServeHTTP(w, newRequest("1", bytes.NewBufferString(`{"name":"a"}`)))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Contains(t, errResp.Message, "required")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service returns ServiceError", func(t *testing.T) {
+		t.Parallel()
+		moq := &EditMessageTemplateServiceMock{
+			EditMessageTemplateFunc: func(ctx context.Context, id entity.MessageTemplateID, name, content string) error {
+				return NewServiceError(ErrCodeTemplateNotFound, "message template not found", "")
+			},
+		}
+		emt := NewEditMessageTemplate(moq, v)
+		w := httptest.NewRecorder()
+		emt.ServeHTTP(w, newRequest("1", bytes.NewBufferString(`{"name":"a","content":"b"}`)))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "message template not found", errResp.Message)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		moq := &EditMessageTemplateServiceMock{
+			EditMessageTemplateFunc: func(ctx context.Context, id entity.MessageTemplateID, name, content string) error {
+				return nil
+			},
+		}
+		emt := NewEditMessageTemplate(moq, v)
+		w := httptest.NewRecorder()
+		emt.ServeHTTP(w, newRequest("1", bytes.NewBufferString(`{"name":"面接案内","content":"{{student_name}}様"}`)))
+		var resp SuccessResponse
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Equal(t, "edit message template was successful", resp.Message)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertResponseConformsToSpec(t, http.MethodPatch, "/messages/templates/{id}", w)
+		if assert.Len(t, moq.EditMessageTemplateCalls(), 1) {
+			call := moq.EditMessageTemplateCalls()[0]
+			assert.Equal(t, entity.MessageTemplateID(1), call.ID)
+			assert.Equal(t, "面接案内", call.Name)
+			assert.Equal(t, "{{student_name}}様", call.Content)
+		}
+	})
+}
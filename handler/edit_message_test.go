@@ -48,7 +48,7 @@ func TestEditMessage_ServeHTTP(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Contains(t, errResp.Message, "invalid")
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
 	t.Run("validation error", func(t *testing.T) {
@@ -73,7 +73,7 @@ func TestEditMessage_ServeHTTP(t *testing.T) {
 		moq := &EditMessageServiceMock{
 			EditMessageFunc: func(ctx context.Context, id entity.MessageID, content string) error {
 				return NewServiceError(
-					http.StatusInternalServerError,
+					ErrCodeInternal,
 					"some service error",
 					"detail info",
 				)
@@ -91,7 +91,7 @@ func TestEditMessage_ServeHTTP(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Equal(t, "some service error", errResp.Message)
-		assert.Equal(t, "detail info", errResp.Detail)
+		assert.Empty(t, errResp.Detail)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
@@ -113,7 +113,8 @@ func TestEditMessage_ServeHTTP(t *testing.T) {
 		var errResp ErrResponse
 		err := json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
-		assert.Equal(t, "unexpected error", errResp.Message)
+		assert.Equal(t, ErrCodeInternal, errResp.Code)
+		assert.Empty(t, errResp.Detail)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
@@ -137,5 +138,7 @@ func TestEditMessage_ServeHTTP(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "edit message was successful", successResp.Message)
 		assert.Equal(t, http.StatusOK, w.Code)
+		assertRequestConformsToSpec(t, http.MethodPatch, "/messages/{id}", body)
+		assertResponseConformsToSpec(t, http.MethodPatch, "/messages/{id}", w)
 	})
 }
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type EraseUserData struct {
+	Service   EraseUserDataService
+	Validator *validator.Validate
+}
+
+func NewEraseUserData(service EraseUserDataService, validator *validator.Validate) *EraseUserData {
+	return &EraseUserData{
+		Service:   service,
+		Validator: validator,
+	}
+}
+
+func (eud *EraseUserData) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	appKind := chi.URLParam(r, "app_kind")
+	if err := eud.Validator.Var(appKind, "oneof=company student"); err != nil {
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "app_kind must be company or student", ""))
+		return
+	}
+	userID, err := strconv.ParseInt(chi.URLParam(r, "user_id"), 10, 64)
+	if err != nil {
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "user_id must be a number", ""))
+		return
+	}
+	dryRun := false
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		dryRun, err = strconv.ParseBool(v)
+		if err != nil {
+			RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "invalid format for query parameter: dry_run. Must be a boolean", ""))
+			return
+		}
+	}
+	report, err := eud.Service.EraseUserData(ctx, appKind, userID, dryRun)
+	if err != nil {
+		RespondError(w, r, err)
+		return
+	}
+	RespondJSON(ctx, w, report, http.StatusOK)
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestEraseUserData_ServeHTTP(t *testing.T) {
+	v := validator.New()
+
+	t.Run("invalid app_kind", func(t *testing.T) {
+		t.Parallel()
+		eud := NewEraseUserData(&EraseUserDataServiceMock{}, v)
+		w := httptest.NewRecorder()
+		eud.ServeHTTP(w, newUserDataRequest(http.MethodPost, "admin", "1", "/erase"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "app_kind must be company or student", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid dry_run", func(t *testing.T) {
+		t.Parallel()
+		eud := NewEraseUserData(&EraseUserDataServiceMock{}, v)
+		w := httptest.NewRecorder()
+		eud.ServeHTTP(w, newUserDataRequest(http.MethodPost, "company", "1", "/erase?dry_run=yes"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "invalid format for query parameter: dry_run. Must be a boolean", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service returns ServiceError", func(t *testing.T) {
+		t.Parallel()
+		moq := &EraseUserDataServiceMock{
+			EraseUserDataFunc: func(ctx context.Context, appKind string, userID int64, dryRun bool) (*entity.UserDataErasureReport, error) {
+				return nil, NewServiceError(ErrCodeInternal, "failed to anonymize messages", "db error")
+			},
+		}
+		eud := NewEraseUserData(moq, v)
+		w := httptest.NewRecorder()
+		eud.ServeHTTP(w, newUserDataRequest(http.MethodPost, "company", "1", "/erase"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "failed to anonymize messages", errResp.Message)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	for name, dryRun := range map[string]bool{
+		"success":         false,
+		"success dry run": true,
+	} {
+		dryRun := dryRun
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			moq := &EraseUserDataServiceMock{
+				EraseUserDataFunc: func(ctx context.Context, appKind string, userID int64, d bool) (*entity.UserDataErasureReport, error) {
+					assert.Equal(t, dryRun, d)
+					return &entity.UserDataErasureReport{AppKind: appKind, UserID: userID, DryRun: d, AnonymizedMessages: 3}, nil
+				},
+			}
+			eud := NewEraseUserData(moq, v)
+			w := httptest.NewRecorder()
+			query := "/erase"
+			if dryRun {
+				query += "?dry_run=true"
+			}
+			eud.ServeHTTP(w, newUserDataRequest(http.MethodPost, "student", "2", query))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assertResponseConformsToSpec(t, http.MethodPost, "/admin/users/{app_kind}/{user_id}/erase", w)
+			var report entity.UserDataErasureReport
+			err := json.Unmarshal(w.Body.Bytes(), &report)
+			assert.NoError(t, err)
+			assert.Equal(t, "student", report.AppKind)
+			assert.Equal(t, int64(2), report.UserID)
+			assert.Equal(t, dryRun, report.DryRun)
+			assert.Equal(t, 3, report.AnonymizedMessages)
+		})
+	}
+}
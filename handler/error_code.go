@@ -0,0 +1,106 @@
+package handler
+
+import "net/http"
+
+// ErrorCode はエラーレスポンスの code。クライアントが処理の分岐に使うため、一度公開した値は変更しない
+type ErrorCode string
+
+const (
+	ErrCodeInvalidJSON              ErrorCode = "invalid_json"
+	ErrCodeValidationFailed         ErrorCode = "validation_failed"
+	ErrCodeInvalidParameter         ErrorCode = "invalid_parameter"
+	ErrCodeInvalidMultipart         ErrorCode = "invalid_multipart"
+	ErrCodeInvalidReplyTo           ErrorCode = "invalid_reply_to"
+	ErrCodeReactionNotAllowed       ErrorCode = "reaction_not_allowed"
+	ErrCodeTemplateVariablesMissing ErrorCode = "template_variables_missing"
+	ErrCodeEmptyContent             ErrorCode = "empty_content"
+	ErrCodeAttachmentEmpty          ErrorCode = "attachment_empty"
+
+	ErrCodeMissingAuthorization ErrorCode = "missing_authorization"
+	ErrCodeInvalidAPIKey        ErrorCode = "invalid_api_key"
+	ErrCodeInvalidClient        ErrorCode = "invalid_client"
+	ErrCodeInvalidToken         ErrorCode = "invalid_token"
+	ErrCodeTokenExpired         ErrorCode = "token_expired"
+
+	ErrCodeForbidden        ErrorCode = "forbidden"
+	ErrCodeInvalidSignature ErrorCode = "invalid_signature"
+
+	ErrCodeNotFound           ErrorCode = "not_found"
+	ErrCodeThreadNotFound     ErrorCode = "thread_not_found"
+	ErrCodeMessageNotFound    ErrorCode = "message_not_found"
+	ErrCodeTemplateNotFound   ErrorCode = "template_not_found"
+	ErrCodeAttachmentNotFound ErrorCode = "attachment_not_found"
+
+	ErrCodeMethodNotAllowed          ErrorCode = "method_not_allowed"
+	ErrCodeRequestTooLarge           ErrorCode = "request_too_large"
+	ErrCodeAttachmentTooLarge        ErrorCode = "attachment_too_large"
+	ErrCodeUnsupportedAttachmentType ErrorCode = "unsupported_attachment_type"
+
+	ErrCodeInternal               ErrorCode = "internal_error"
+	ErrCodeTemporarilyUnavailable ErrorCode = "temporarily_unavailable"
+)
+
+type errorDefinition struct {
+	status int
+	title  string
+}
+
+// errorCatalogue は code ごとのステータスコードと、Problem Details の title
+var errorCatalogue = map[ErrorCode]errorDefinition{
+	ErrCodeInvalidJSON:              {http.StatusBadRequest, "Request body is not valid JSON"},
+	ErrCodeValidationFailed:         {http.StatusBadRequest, "Request body failed validation"},
+	ErrCodeInvalidParameter:         {http.StatusBadRequest, "Invalid path or query parameter"},
+	ErrCodeInvalidMultipart:         {http.StatusBadRequest, "Invalid multipart form"},
+	ErrCodeInvalidReplyTo:           {http.StatusBadRequest, "Invalid reply target"},
+	ErrCodeReactionNotAllowed:       {http.StatusBadRequest, "Reaction is not allowed"},
+	ErrCodeTemplateVariablesMissing: {http.StatusBadRequest, "Template variables are missing"},
+	ErrCodeEmptyContent:             {http.StatusBadRequest, "Message content is empty"},
+	ErrCodeAttachmentEmpty:          {http.StatusBadRequest, "Attachment is empty"},
+
+	ErrCodeMissingAuthorization: {http.StatusUnauthorized, "Authorization is missing"},
+	ErrCodeInvalidAPIKey:        {http.StatusUnauthorized, "Invalid API key"},
+	ErrCodeInvalidClient:        {http.StatusUnauthorized, "Invalid client credentials"},
+	ErrCodeInvalidToken:         {http.StatusUnauthorized, "Invalid token"},
+	ErrCodeTokenExpired:         {http.StatusUnauthorized, "Access token has expired"},
+
+	ErrCodeForbidden:        {http.StatusForbidden, "Permission denied"},
+	ErrCodeInvalidSignature: {http.StatusForbidden, "Invalid or expired download link"},
+
+	ErrCodeNotFound:           {http.StatusNotFound, "Resource not found"},
+	ErrCodeThreadNotFound:     {http.StatusNotFound, "Message thread not found"},
+	ErrCodeMessageNotFound:    {http.StatusNotFound, "Message not found"},
+	ErrCodeTemplateNotFound:   {http.StatusNotFound, "Message template not found"},
+	ErrCodeAttachmentNotFound: {http.StatusNotFound, "Attachment not found"},
+
+	ErrCodeMethodNotAllowed:          {http.StatusMethodNotAllowed, "Method not allowed"},
+	ErrCodeRequestTooLarge:           {http.StatusRequestEntityTooLarge, "Request body is too large"},
+	ErrCodeAttachmentTooLarge:        {http.StatusRequestEntityTooLarge, "Attachment is too large"},
+	ErrCodeUnsupportedAttachmentType: {http.StatusUnsupportedMediaType, "Attachment type is not allowed"},
+
+	ErrCodeInternal:               {http.StatusInternalServerError, "Internal server error"},
+	ErrCodeTemporarilyUnavailable: {http.StatusServiceUnavailable, "Temporarily unavailable, retry later"},
+}
+
+// Status は code に対応するステータスコードを返す。カタログにない code は 500 とする
+func (c ErrorCode) Status() int {
+	if d, ok := errorCatalogue[c]; ok {
+		return d.status
+	}
+	return http.StatusInternalServerError
+}
+
+func (c ErrorCode) Title() string {
+	if d, ok := errorCatalogue[c]; ok {
+		return d.title
+	}
+	return http.StatusText(http.StatusInternalServerError)
+}
+
+// ErrorCodes はカタログの全ての code を返す。OpenAPI のドキュメントとの突き合わせに使う
+func ErrorCodes() []ErrorCode {
+	codes := make([]ErrorCode, 0, len(errorCatalogue))
+	for c := range errorCatalogue {
+		codes = append(codes, c)
+	}
+	return codes
+}
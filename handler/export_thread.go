@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/export"
+)
+
+type ExportThread struct {
+	Service   ExportThreadService
+	Validator *validator.Validate
+}
+
+func NewExportThread(service ExportThreadService, validator *validator.Validate) *ExportThread {
+	return &ExportThread{
+		Service:   service,
+		Validator: validator,
+	}
+}
+
+func (et *ExportThread) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "ID must be a number", ""))
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if err := et.Validator.Var(format, "required,oneof=csv json pdf"); err != nil {
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "invalid query parameter: format. Must be one of csv, json, pdf", ""))
+		return
+	}
+	// ヘッダーは最初のメッセージを書き出す直前に送る。それまでのエラー（権限がないなど）はエラーレスポンスで返せる
+	var ew export.Writer
+	started := false
+	start := func() error {
+		started = true
+		w.Header().Set("Content-Type", export.ContentType(format))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fmt.Sprintf("thread-%d.%s", id, format)}))
+		w.Header().Set("Cache-Control", "private, no-store")
+		w.WriteHeader(http.StatusOK)
+		var err error
+		ew, err = export.NewWriter(w, format, &export.Thread{
+			ID:         entity.MessageThreadID(id),
+			ExportedAt: time.Now(),
+		})
+		return err
+	}
+	err = et.Service.ExportThread(ctx, entity.MessageThreadID(id), func(m *entity.Message) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return ew.WriteMessage(m)
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = ew.Close()
+	}
+	if err == nil {
+		return
+	}
+	if !started {
+		RespondError(w, r, err)
+		return
+	}
+	// 書き出しの途中で失敗した場合はステータスを変えられないため、ログにのみ残す
+	slog.ErrorContext(ctx, "failed to write thread export", slog.String("error", err.Error()))
+}
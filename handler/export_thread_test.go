@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestExportThread_ServeHTTP(t *testing.T) {
+	v := validator.New()
+
+	newRequest := func(id, format string) *http.Request {
+		chiCtx := chi.NewRouteContext()
+		chiCtx.URLParams.Add("id", id)
+		r := httptest.NewRequest(http.MethodGet, "/threads/"+id+"/export?format="+format, nil)
+		return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+	}
+	messages := entity.Messages{
+		&entity.Message{ID: 1, IsFromCompany: 1, Content: "面接日程のご案内", IsSent: 1, SentAt: time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)},
+	}
+
+	t.Run("ID parse error", func(t *testing.T) {
+		t.Parallel()
+		et := NewExportThread(&ExportThreadServiceMock{}, v)
+		w := httptest.NewRecorder()
+		et.ServeHTTP(w, newRequest("abc", "csv"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "ID must be a number", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/threads/{id}/export", w)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		t.Parallel()
+		et := NewExportThread(&ExportThreadServiceMock{}, v)
+		w := httptest.NewRecorder()
+		et.ServeHTTP(w, newRequest("1", "xml"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "invalid query parameter: format. Must be one of csv, json, pdf", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/threads/{id}/export", w)
+	})
+
+	t.Run("service returns ServiceError", func(t *testing.T) {
+		t.Parallel()
+		moq := &ExportThreadServiceMock{
+			ExportThreadFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+				return NewServiceError(ErrCodeForbidden, "unauthorized: lack the necessary permissions to export messages", "")
+			},
+		}
+		et := NewExportThread(moq, v)
+		w := httptest.NewRecorder()
+		et.ServeHTTP(w, newRequest("1", "json"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "unauthorized: lack the necessary permissions to export messages", errResp.Message)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/threads/{id}/export", w)
+	})
+
+	t.Run("success: no messages", func(t *testing.T) {
+		t.Parallel()
+		moq := &ExportThreadServiceMock{
+			ExportThreadFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+				return nil
+			},
+		}
+		et := NewExportThread(moq, v)
+		w := httptest.NewRecorder()
+		et.ServeHTTP(w, newRequest("1", "json"))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/threads/{id}/export", w)
+		var got struct {
+			Messages []json.RawMessage `json:"messages"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.NotNil(t, got.Messages)
+		assert.Empty(t, got.Messages)
+	})
+
+	for _, tc := range []struct {
+		format      string
+		contentType string
+		bodyPrefix  string
+	}{
+		{format: "csv", contentType: "text/csv; charset=utf-8", bodyPrefix: "\xEF\xBB\xBFid,"},
+		{format: "json", contentType: "application/json; charset=utf-8", bodyPrefix: `{"message_thread_id":1,`},
+		{format: "pdf", contentType: "application/pdf", bodyPrefix: "%PDF-"},
+	} {
+		tc := tc
+		t.Run("success: "+tc.format, func(t *testing.T) {
+			t.Parallel()
+			moq := &ExportThreadServiceMock{
+				ExportThreadFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+					for _, m := range messages {
+						if err := fn(m); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			}
+			et := NewExportThread(moq, v)
+			w := httptest.NewRecorder()
+			et.ServeHTTP(w, newRequest("1", tc.format))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assertResponseConformsToSpec(t, http.MethodGet, "/threads/{id}/export", w)
+			assert.Equal(t, tc.contentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, `attachment; filename=thread-1.`+tc.format, w.Header().Get("Content-Disposition"))
+			assert.True(t, strings.HasPrefix(w.Body.String(), tc.bodyPrefix), w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type ExportUserData struct {
+	Service   ExportUserDataService
+	Validator *validator.Validate
+}
+
+func NewExportUserData(service ExportUserDataService, validator *validator.Validate) *ExportUserData {
+	return &ExportUserData{
+		Service:   service,
+		Validator: validator,
+	}
+}
+
+func (eud *ExportUserData) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	appKind := chi.URLParam(r, "app_kind")
+	if err := eud.Validator.Var(appKind, "oneof=company student"); err != nil {
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "app_kind must be company or student", ""))
+		return
+	}
+	userID, err := strconv.ParseInt(chi.URLParam(r, "user_id"), 10, 64)
+	if err != nil {
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "user_id must be a number", ""))
+		return
+	}
+	data, err := eud.Service.ExportUserData(ctx, appKind, userID)
+	if err != nil {
+		RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fmt.Sprintf("%s-user-%d.zip", appKind, userID)}))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	if err := eud.Service.WriteUserDataArchive(ctx, w, data); err != nil {
+		slog.ErrorContext(ctx, "failed to write user data archive", slog.String("error", err.Error()))
+	}
+}
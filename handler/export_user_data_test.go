@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func newUserDataRequest(method, appKind, userID, query string) *http.Request {
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add("app_kind", appKind)
+	chiCtx.URLParams.Add("user_id", userID)
+	r := httptest.NewRequest(method, "/admin/users/"+appKind+"/"+userID+query, nil)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+}
+
+func TestExportUserData_ServeHTTP(t *testing.T) {
+	v := validator.New()
+
+	t.Run("invalid app_kind", func(t *testing.T) {
+		t.Parallel()
+		eud := NewExportUserData(&ExportUserDataServiceMock{}, v)
+		w := httptest.NewRecorder()
+		eud.ServeHTTP(w, newUserDataRequest(http.MethodGet, "admin", "1", "/export"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "app_kind must be company or student", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/admin/users/{app_kind}/{user_id}/export", w)
+	})
+
+	t.Run("user_id parse error", func(t *testing.T) {
+		t.Parallel()
+		eud := NewExportUserData(&ExportUserDataServiceMock{}, v)
+		w := httptest.NewRecorder()
+		eud.ServeHTTP(w, newUserDataRequest(http.MethodGet, "company", "abc", "/export"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "user_id must be a number", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/admin/users/{app_kind}/{user_id}/export", w)
+	})
+
+	t.Run("service returns ServiceError", func(t *testing.T) {
+		t.Parallel()
+		moq := &ExportUserDataServiceMock{
+			ExportUserDataFunc: func(ctx context.Context, appKind string, userID int64) (*entity.UserData, error) {
+				return nil, NewServiceError(ErrCodeInternal, "failed to get message threads", "db error")
+			},
+		}
+		eud := NewExportUserData(moq, v)
+		w := httptest.NewRecorder()
+		eud.ServeHTTP(w, newUserDataRequest(http.MethodGet, "company", "1", "/export"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "failed to get message threads", errResp.Message)
+		assert.Empty(t, errResp.Detail)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/admin/users/{app_kind}/{user_id}/export", w)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		data := &entity.UserData{AppKind: "student", UserID: 2}
+		moq := &ExportUserDataServiceMock{
+			ExportUserDataFunc: func(ctx context.Context, appKind string, userID int64) (*entity.UserData, error) {
+				assert.Equal(t, "student", appKind)
+				assert.Equal(t, int64(2), userID)
+				return data, nil
+			},
+			WriteUserDataArchiveFunc: func(ctx context.Context, w io.Writer, d *entity.UserData) error {
+				assert.Same(t, data, d)
+				_, err := io.WriteString(w, "PK")
+				return err
+			},
+		}
+		eud := NewExportUserData(moq, v)
+		w := httptest.NewRecorder()
+		eud.ServeHTTP(w, newUserDataRequest(http.MethodGet, "student", "2", "/export"))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/admin/users/{app_kind}/{user_id}/export", w)
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=student-user-2.zip", w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "PK", w.Body.String())
+	})
+}
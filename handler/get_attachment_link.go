@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type GetAttachmentLink struct {
+	Service   GetAttachmentLinkService
+	Validator *validator.Validate
+}
+
+func NewGetAttachmentLink(service GetAttachmentLinkService, validator *validator.Validate) *GetAttachmentLink {
+	return &GetAttachmentLink{
+		Service:   service,
+		Validator: validator,
+	}
+}
+
+func (gal *GetAttachmentLink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "ID must be a number", ""))
+		return
+	}
+	url, expiresAt, err := gal.Service.GetAttachmentLink(ctx, entity.AttachmentID(id))
+	if err != nil {
+		RespondError(w, r, err)
+		return
+	}
+	rsp := struct {
+		URL       string    `json:"url"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}{
+		URL:       url,
+		ExpiresAt: expiresAt,
+	}
+	RespondJSON(ctx, w, &rsp, http.StatusOK)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestGetAttachmentLink_ServeHTTP(t *testing.T) {
+	v := validator.New()
+
+	newRequest := func(id string) *http.Request {
+		chiCtx := chi.NewRouteContext()
+		chiCtx.URLParams.Add("id", id)
+		r := httptest.NewRequest(http.MethodGet, "/messages/attachments/"+id+"/link", nil)
+		return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+	}
+
+	t.Run("ID parse error", func(t *testing.T) {
+		t.Parallel()
+		gal := NewGetAttachmentLink(&GetAttachmentLinkServiceMock{}, v)
+		w := httptest.NewRecorder()
+		gal.ServeHTTP(w, newRequest("abc"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "ID must be a number", errResp.Message)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service returns ServiceError", func(t *testing.T) {
+		t.Parallel()
+		moq := &GetAttachmentLinkServiceMock{
+			GetAttachmentLinkFunc: func(ctx context.Context, id entity.AttachmentID) (string, time.Time, error) {
+				return "", time.Time{}, NewServiceError(
+					ErrCodeForbidden,
+					"unauthorized: lack the necessary permissions to download attachment",
+					"",
+				)
+			},
+		}
+		gal := NewGetAttachmentLink(moq, v)
+		w := httptest.NewRecorder()
+		gal.ServeHTTP(w, newRequest("1"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "unauthorized: lack the necessary permissions to download attachment", errResp.Message)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("service returns normal error", func(t *testing.T) {
+		t.Parallel()
+		moq := &GetAttachmentLinkServiceMock{
+			GetAttachmentLinkFunc: func(ctx context.Context, id entity.AttachmentID) (string, time.Time, error) {
+				return "", time.Time{}, errors.New("unexpected error")
+			},
+		}
+		gal := NewGetAttachmentLink(moq, v)
+		w := httptest.NewRecorder()
+		gal.ServeHTTP(w, newRequest("1"))
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, ErrCodeInternal, errResp.Code)
+		assert.Empty(t, errResp.Detail)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		expiresAt := time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC)
+		moq := &GetAttachmentLinkServiceMock{
+			GetAttachmentLinkFunc: func(ctx context.Context, id entity.AttachmentID) (string, time.Time, error) {
+				return "/messages/attachments/1?expires=1735690000&signature=sig", expiresAt, nil
+			},
+		}
+		gal := NewGetAttachmentLink(moq, v)
+		w := httptest.NewRecorder()
+		gal.ServeHTTP(w, newRequest("1"))
+		var rsp struct {
+			URL       string    `json:"url"`
+			ExpiresAt time.Time `json:"expires_at"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &rsp)
+		assert.NoError(t, err)
+		assert.Equal(t, "/messages/attachments/1?expires=1735690000&signature=sig", rsp.URL)
+		assert.Equal(t, expiresAt, rsp.ExpiresAt)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/messages/attachments/{id}/link", w)
+	})
+}
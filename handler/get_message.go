@@ -16,12 +16,37 @@ type GetMessage struct {
 }
 
 type message struct {
-	ID            entity.MessageID `json:"id"              db:"id"`
-	IsFromCompany int8             `json:"is_from_company" db:"is_from_company"`
-	IsFromStudent int8             `json:"is_from_student" db:"is_from_student"`
-	Content       string           `json:"content"         db:"content"`
-	IsSent        int8             `json:"is_sent"         db:"is_sent"`
-	SentAt        time.Time        `json:"sent_at"         db:"sent_at"`
+	ID               entity.MessageID  `json:"id"                  db:"id"`
+	IsFromCompany    int8              `json:"is_from_company"     db:"is_from_company"`
+	IsFromStudent    int8              `json:"is_from_student"     db:"is_from_student"`
+	Content          string            `json:"content"             db:"content"`
+	IsSent           int8              `json:"is_sent"             db:"is_sent"`
+	SentAt           time.Time         `json:"sent_at"             db:"sent_at"`
+	ReplyToMessageID *entity.MessageID `json:"reply_to_message_id" db:"reply_to_message_id"`
+	ReplyTo          *replyTo          `json:"reply_to"`
+	Attachments      []attachment      `json:"attachments"`
+	Reactions        []reaction        `json:"reactions"`
+}
+
+type replyTo struct {
+	MessageID     entity.MessageID `json:"message_id"`
+	IsFromCompany int8             `json:"is_from_company"`
+	IsFromStudent int8             `json:"is_from_student"`
+	Excerpt       string           `json:"excerpt"`
+	IsDeleted     bool             `json:"is_deleted"`
+}
+
+type attachment struct {
+	ID          entity.AttachmentID `json:"id"`
+	FileName    string              `json:"file_name"`
+	ContentType string              `json:"content_type"`
+	Size        int64               `json:"size"`
+}
+
+type reaction struct {
+	Reaction    string `json:"reaction"`
+	Count       int    `json:"count"`
+	ReactedByMe bool   `json:"reacted_by_me"`
 }
 
 func NewGetMessage(service GetMessageService, validator *validator.Validate) *GetMessage {
@@ -35,41 +60,59 @@ func (gm *GetMessage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	threadIDStr := r.URL.Query().Get("thread_id")
 	if threadIDStr == "" {
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: "missing required query parameter: thread_id",
-		}, http.StatusBadRequest)
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "missing required query parameter: thread_id", ""))
 		return
 	}
 	threadIDInt, err := strconv.ParseInt(threadIDStr, 10, 64)
 	if err != nil {
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: "invalid format for query parameter: thread_id. Must be a valid integer",
-		}, http.StatusInternalServerError)
+		RespondError(w, r, NewServiceError(ErrCodeInvalidParameter, "invalid format for query parameter: thread_id. Must be a valid integer", ""))
 		return
 	}
 	messages, err := gm.Service.GetAllMessages(ctx, entity.MessageThreadID(threadIDInt))
 	if err != nil {
-		if serviceErr, ok := err.(*ServiceError); ok {
-			RespondJSON(ctx, w, &ErrResponse{
-				Message: serviceErr.Error(),
-				Detail:  serviceErr.DetailError(),
-			}, serviceErr.StatusCode)
-			return
-		}
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		RespondError(w, r, err)
 		return
 	}
 	rsp := []message{}
 	for _, m := range messages {
+		attachments := []attachment{}
+		for _, a := range m.Attachments {
+			attachments = append(attachments, attachment{
+				ID:          a.ID,
+				FileName:    a.FileName,
+				ContentType: a.ContentType,
+				Size:        a.Size,
+			})
+		}
+		reactions := []reaction{}
+		for _, r := range m.Reactions {
+			reactions = append(reactions, reaction{
+				Reaction:    r.Reaction,
+				Count:       r.Count,
+				ReactedByMe: r.ReactedByMe,
+			})
+		}
+		var reply *replyTo
+		if m.ReplyTo != nil {
+			reply = &replyTo{
+				MessageID:     m.ReplyTo.MessageID,
+				IsFromCompany: m.ReplyTo.IsFromCompany,
+				IsFromStudent: m.ReplyTo.IsFromStudent,
+				Excerpt:       m.ReplyTo.Excerpt,
+				IsDeleted:     m.ReplyTo.IsDeleted,
+			}
+		}
 		rsp = append(rsp, message{
-			ID:            m.ID,
-			IsFromCompany: m.IsFromCompany,
-			IsFromStudent: m.IsFromStudent,
-			Content:       m.Content,
-			IsSent:        m.IsSent,
-			SentAt:        m.SentAt,
+			ID:               m.ID,
+			IsFromCompany:    m.IsFromCompany,
+			IsFromStudent:    m.IsFromStudent,
+			Content:          m.Content,
+			IsSent:           m.IsSent,
+			SentAt:           m.SentAt,
+			ReplyToMessageID: m.ReplyToMessageID,
+			ReplyTo:          reply,
+			Attachments:      attachments,
+			Reactions:        reactions,
 		})
 	}
 	RespondJSON(ctx, w, &rsp, http.StatusOK)
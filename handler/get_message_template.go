@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type GetMessageTemplate struct {
+	Service   GetMessageTemplateService
+	Validator *validator.Validate
+}
+
+type messageTemplate struct {
+	ID      entity.MessageTemplateID `json:"id"`
+	Name    string                   `json:"name"`
+	Content string                   `json:"content"`
+}
+
+func NewGetMessageTemplate(service GetMessageTemplateService, validator *validator.Validate) *GetMessageTemplate {
+	return &GetMessageTemplate{
+		Service:   service,
+		Validator: validator,
+	}
+}
+
+func (gmt *GetMessageTemplate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	templates, err := gmt.Service.GetAllMessageTemplates(ctx)
+	if err != nil {
+		RespondError(w, r, err)
+		return
+	}
+	rsp := make([]messageTemplate, 0, len(templates))
+	for _, t := range templates {
+		rsp = append(rsp, messageTemplate{
+			ID:      t.ID,
+			Name:    t.Name,
+			Content: t.Content,
+		})
+	}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestGetMessageTemplate_ServeHTTP(t *testing.T) {
+	v := validator.New()
+
+	t.Run("service returns ServiceError", func(t *testing.T) {
+		t.Parallel()
+		moq := &GetMessageTemplateServiceMock{
+			GetAllMessageTemplatesFunc: func(ctx context.Context) (entity.MessageTemplates, error) {
+				return nil, NewServiceError(ErrCodeForbidden, "unauthorized: message templates are only available to company users", "")
+			},
+		}
+		gmt := NewGetMessageTemplate(moq, v)
+		r := httptest.NewRequest(http.MethodGet, "/messages/templates", nil)
+		w := httptest.NewRecorder()
+		gmt.ServeHTTP(w, r)
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, "unauthorized: message templates are only available to company users", errResp.Message)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("service returns non-ServiceError", func(t *testing.T) {
+		t.Parallel()
+		moq := &GetMessageTemplateServiceMock{
+			GetAllMessageTemplatesFunc: func(ctx context.Context) (entity.MessageTemplates, error) {
+				return nil, errors.New("unexpected error")
+			},
+		}
+		gmt := NewGetMessageTemplate(moq, v)
+		r := httptest.NewRequest(http.MethodGet, "/messages/templates", nil)
+		w := httptest.NewRecorder()
+		gmt.ServeHTTP(w, r)
+		var errResp ErrResponse
+		err := json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.NoError(t, err)
+		assert.Equal(t, ErrCodeInternal, errResp.Code)
+		assert.Empty(t, errResp.Detail)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		moq := &GetMessageTemplateServiceMock{
+			GetAllMessageTemplatesFunc: func(ctx context.Context) (entity.MessageTemplates, error) {
+				return entity.MessageTemplates{
+					&entity.MessageTemplate{ID: 1, CompanyUserID: 1, Name: "面接案内", Content: "{{student_name}}様"},
+				}, nil
+			},
+		}
+		gmt := NewGetMessageTemplate(moq, v)
+		r := httptest.NewRequest(http.MethodGet, "/messages/templates", nil)
+		w := httptest.NewRecorder()
+		gmt.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/messages/templates", w)
+		assert.JSONEq(t, `[{"id":1,"name":"面接案内","content":"{{student_name}}様"}]`, w.Body.String())
+	})
+
+	t.Run("success with no templates", func(t *testing.T) {
+		t.Parallel()
+		moq := &GetMessageTemplateServiceMock{
+			GetAllMessageTemplatesFunc: func(ctx context.Context) (entity.MessageTemplates, error) {
+				return entity.MessageTemplates{}, nil
+			},
+		}
+		gmt := NewGetMessageTemplate(moq, v)
+		r := httptest.NewRequest(http.MethodGet, "/messages/templates", nil)
+		w := httptest.NewRecorder()
+		gmt.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/messages/templates", w)
+		assert.JSONEq(t, `[]`, w.Body.String())
+	})
+}
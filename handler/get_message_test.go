@@ -25,6 +25,7 @@ func TestGetMessage_ServeHTTP(t *testing.T) {
 		json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.Equal(t, errResp.Message, "missing required query parameter: thread_id")
 		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/messages", w)
 	})
 
 	t.Run("invalid thread_id format", func(t *testing.T) {
@@ -36,7 +37,8 @@ func TestGetMessage_ServeHTTP(t *testing.T) {
 		var errResp ErrResponse
 		json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.Equal(t, errResp.Message, "invalid format for query parameter: thread_id. Must be a valid integer")
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, ErrCodeInvalidParameter, errResp.Code)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
 	t.Run("service returns ServiceError", func(t *testing.T) {
@@ -44,7 +46,7 @@ func TestGetMessage_ServeHTTP(t *testing.T) {
 		moq := &GetMessageServiceMock{
 			GetAllMessagesFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
 				return nil, NewServiceError(
-					http.StatusInternalServerError,
+					ErrCodeInternal,
 					"some service error",
 					"something detail",
 				)
@@ -57,7 +59,7 @@ func TestGetMessage_ServeHTTP(t *testing.T) {
 		var errResp ErrResponse
 		json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.Equal(t, "some service error", errResp.Message)
-		assert.Equal(t, "something detail", errResp.Detail)
+		assert.Empty(t, errResp.Detail)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
@@ -74,7 +76,8 @@ func TestGetMessage_ServeHTTP(t *testing.T) {
 		gm.ServeHTTP(w, r)
 		var errResp ErrResponse
 		json.Unmarshal(w.Body.Bytes(), &errResp)
-		assert.Equal(t, "unexpected error", errResp.Message)
+		assert.Equal(t, ErrCodeInternal, errResp.Code)
+		assert.Empty(t, errResp.Detail)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
@@ -90,6 +93,18 @@ func TestGetMessage_ServeHTTP(t *testing.T) {
 						Content:       "normal message from company user",
 						IsSent:        1,
 						SentAt:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+						Attachments: entity.Attachments{
+							&entity.Attachment{
+								ID:          entity.AttachmentID(10),
+								MessageID:   entity.MessageID(1),
+								FileName:    "offer.pdf",
+								ContentType: "application/pdf",
+								Size:        1024,
+							},
+						},
+						Reactions: entity.ReactionSummaries{
+							&entity.ReactionSummary{Reaction: "👍", Count: 1, ReactedByMe: false},
+						},
 					},
 					&entity.Message{
 						ID:            entity.MessageID(2),
@@ -98,6 +113,15 @@ func TestGetMessage_ServeHTTP(t *testing.T) {
 						Content:       "reservation message from student user",
 						IsSent:        0,
 						SentAt:        time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC),
+						ReplyToMessageID: func() *entity.MessageID {
+							id := entity.MessageID(1)
+							return &id
+						}(),
+						ReplyTo: &entity.MessageReply{
+							MessageID:     entity.MessageID(1),
+							IsFromCompany: 1,
+							Excerpt:       "normal message from company user",
+						},
 					},
 				}, nil
 			},
@@ -115,12 +139,23 @@ func TestGetMessage_ServeHTTP(t *testing.T) {
 		assert.Equal(t, "normal message from company user", messages[0].Content)
 		assert.Equal(t, int8(1), messages[0].IsSent)
 		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), messages[0].SentAt)
+		assert.Equal(t, []attachment{{ID: 10, FileName: "offer.pdf", ContentType: "application/pdf", Size: 1024}}, messages[0].Attachments)
+		assert.Equal(t, []reaction{{Reaction: "👍", Count: 1, ReactedByMe: false}}, messages[0].Reactions)
 		assert.Equal(t, entity.MessageID(2), messages[1].ID)
 		assert.Equal(t, int8(0), messages[1].IsFromCompany)
 		assert.Equal(t, int8(1), messages[1].IsFromStudent)
 		assert.Equal(t, "reservation message from student user", messages[1].Content)
 		assert.Equal(t, int8(0), messages[1].IsSent)
 		assert.Equal(t, time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC), messages[1].SentAt)
+		assert.Empty(t, messages[1].Attachments)
+		assert.Empty(t, messages[1].Reactions)
+		assert.Nil(t, messages[0].ReplyTo)
+		if assert.NotNil(t, messages[1].ReplyTo) {
+			assert.Equal(t, replyTo{MessageID: 1, IsFromCompany: 1, Excerpt: "normal message from company user"}, *messages[1].ReplyTo)
+		}
+		assert.Contains(t, w.Body.String(), `"attachments":[]`)
+		assert.Contains(t, w.Body.String(), `"reactions":[]`)
 		assert.Equal(t, http.StatusOK, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/messages", w)
 	})
 }
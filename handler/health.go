@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const readinessCheckTimeout = 2 * time.Second
+
+// Healthz は liveness プローブ用で、プロセスが応答できれば常に 200 を返す
+func Healthz(w http.ResponseWriter, r *http.Request) {
+	RespondJSON(r.Context(), w, map[string]string{"status": "ok"}, http.StatusOK)
+}
+
+type readinessCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// Readiness は readiness プローブ（/readyz）で、登録した依存先を順に確認して結果を返す。
+// Drain を呼ぶと依存先の状態にかかわらず 503 を返し、ロードバランサーから外れるようにする
+type Readiness struct {
+	checks   []readinessCheck
+	draining atomic.Bool
+}
+
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+func NewReadiness() *Readiness {
+	return &Readiness{}
+}
+
+// AddCheck は依存先の確認を登録する。サーバーがリクエストを受け付ける前に呼ぶこと
+func (rd *Readiness) AddCheck(name string, check func(ctx context.Context) error) {
+	rd.checks = append(rd.checks, readinessCheck{name: name, check: check})
+}
+
+// Drain は以降の /readyz を not ready にする。シャットダウンの開始時に呼ぶ
+func (rd *Readiness) Drain() {
+	rd.draining.Store(true)
+}
+
+func (rd *Readiness) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if rd.draining.Load() {
+		RespondJSON(ctx, w, &readinessResponse{Status: "draining"}, http.StatusServiceUnavailable)
+		return
+	}
+	rsp := &readinessResponse{Status: "ready", Checks: make(map[string]string, len(rd.checks))}
+	status := http.StatusOK
+	for _, c := range rd.checks {
+		checkCtx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+		err := c.check(checkCtx)
+		cancel()
+		if err != nil {
+			// 依存先のエラーには接続先などが含まれうるため、レスポンスには含めずログにのみ出力する
+			slog.WarnContext(ctx, "readiness check failed", slog.String("check", c.name), slog.String("error", err.Error()))
+			rsp.Checks[c.name] = "fail"
+			rsp.Status = "not_ready"
+			status = http.StatusServiceUnavailable
+			continue
+		}
+		rsp.Checks[c.name] = "ok"
+	}
+	RespondJSON(ctx, w, rsp, status)
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadiness_ServeHTTP(t *testing.T) {
+	t.Run("all checks pass", func(t *testing.T) {
+		rd := NewReadiness()
+		rd.AddCheck("db", func(ctx context.Context) error { return nil })
+		w := httptest.NewRecorder()
+		rd.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/readyz", w)
+		assert.JSONEq(t, `{"status":"ready","checks":{"db":"ok"}}`, w.Body.String())
+	})
+
+	t.Run("a check fails", func(t *testing.T) {
+		rd := NewReadiness()
+		rd.AddCheck("db", func(ctx context.Context) error { return nil })
+		rd.AddCheck("secret_key", func(ctx context.Context) error { return errors.New("not set") })
+		w := httptest.NewRecorder()
+		rd.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/readyz", w)
+		assert.JSONEq(t, `{"status":"not_ready","checks":{"db":"ok","secret_key":"fail"}}`, w.Body.String())
+	})
+
+	t.Run("draining", func(t *testing.T) {
+		rd := NewReadiness()
+		called := false
+		rd.AddCheck("db", func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+		rd.Drain()
+		w := httptest.NewRecorder()
+		rd.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assertResponseConformsToSpec(t, http.MethodGet, "/readyz", w)
+		assert.JSONEq(t, `{"status":"draining"}`, w.Body.String())
+		assert.False(t, called)
+	})
+}
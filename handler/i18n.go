@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ja"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	ja_translations "github.com/go-playground/validator/v10/translations/ja"
+)
+
+// エラーメッセージは Accept-Language で ja と en を切り替える。指定がない場合は既存のクライアントに合わせて en とする
+var universalTranslator = newUniversalTranslator()
+
+// translators はロケールごとの翻訳。validator は翻訳を Translator の値で引くため、常にここの値を使う
+var translators = map[string]ut.Translator{}
+
+// validate は翻訳を登録済みの validator。翻訳は同じ Translator に2回登録できないため、init で1度だけ作り NewValidator で共有する
+var validate *validator.Validate
+
+func newUniversalTranslator() *ut.UniversalTranslator {
+	english := en.New()
+	return ut.New(english, english, ja.New())
+}
+
+// 翻訳の文言と validator は init で1度だけ登録し、以降は読み取りのみとする
+func init() {
+	enTrans, _ := universalTranslator.GetTranslator("en")
+	jaTrans, _ := universalTranslator.GetTranslator("ja")
+	for code, definition := range errorCatalogue {
+		mustAddTranslation(enTrans, code, definition.title)
+	}
+	for code, message := range errorMessagesJa {
+		mustAddTranslation(jaTrans, code, message)
+	}
+	translators["en"] = enTrans
+	translators["ja"] = jaTrans
+	validate = validator.New()
+	validate.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return f.Name
+		}
+		return name
+	})
+	if err := en_translations.RegisterDefaultTranslations(validate, enTrans); err != nil {
+		panic(err)
+	}
+	if err := ja_translations.RegisterDefaultTranslations(validate, jaTrans); err != nil {
+		panic(err)
+	}
+}
+
+func mustAddTranslation(trans ut.Translator, code ErrorCode, text string) {
+	if err := trans.Add(code, text, false); err != nil {
+		panic(err)
+	}
+}
+
+// errorMessagesJa は code ごとの日本語のメッセージ。日本語のレスポンスでは title にこれを使う
+var errorMessagesJa = map[ErrorCode]string{
+	ErrCodeInvalidJSON:              "リクエストの JSON の形式が正しくありません",
+	ErrCodeValidationFailed:         "入力内容に誤りがあります",
+	ErrCodeInvalidParameter:         "パラメーターの値が正しくありません",
+	ErrCodeInvalidMultipart:         "ファイルのアップロード形式が正しくありません",
+	ErrCodeInvalidReplyTo:           "返信先のメッセージが正しくありません",
+	ErrCodeReactionNotAllowed:       "このリアクションは使用できません",
+	ErrCodeTemplateVariablesMissing: "テンプレートの差し込み項目が不足しています",
+	ErrCodeEmptyContent:             "メッセージの本文が空です",
+	ErrCodeAttachmentEmpty:          "添付ファイルが空です",
+
+	ErrCodeMissingAuthorization: "認証情報がありません",
+	ErrCodeInvalidAPIKey:        "API キーが正しくありません",
+	ErrCodeInvalidClient:        "クライアントの認証情報が正しくありません",
+	ErrCodeInvalidToken:         "トークンが正しくありません",
+	ErrCodeTokenExpired:         "アクセストークンの有効期限が切れています",
+
+	ErrCodeForbidden:        "この操作を行う権限がありません",
+	ErrCodeInvalidSignature: "ダウンロードリンクが無効か、有効期限が切れています",
+
+	ErrCodeNotFound:           "ページが見つかりません",
+	ErrCodeThreadNotFound:     "メッセージのスレッドが見つかりません",
+	ErrCodeMessageNotFound:    "メッセージが見つかりません",
+	ErrCodeTemplateNotFound:   "メッセージテンプレートが見つかりません",
+	ErrCodeAttachmentNotFound: "添付ファイルが見つかりません",
+
+	ErrCodeMethodNotAllowed:          "許可されていないメソッドです",
+	ErrCodeRequestTooLarge:           "リクエストのサイズが大きすぎます",
+	ErrCodeAttachmentTooLarge:        "添付ファイルのサイズが大きすぎます",
+	ErrCodeUnsupportedAttachmentType: "この形式のファイルは添付できません",
+
+	ErrCodeInternal:               "サーバーでエラーが発生しました",
+	ErrCodeTemporarilyUnavailable: "一時的に利用できません。しばらくしてから再度お試しください",
+}
+
+// NewValidator はエラーメッセージを ja と en に翻訳できる validator を返す。メッセージには Go の構造体のフィールド名ではなく、JSON のキーを使う。
+// 全ての呼び出しで init で作った同じ validator を返す。並行に使えるが、共有しているため RegisterValidation などで変更しないこと
+func NewValidator() (*validator.Validate, error) {
+	return validate, nil
+}
+
+// requestTranslator は Accept-Language で最も優先度の高い、対応しているロケールの翻訳を返す
+func requestTranslator(r *http.Request) ut.Translator {
+	for _, locale := range acceptLanguages(r.Header.Get("Accept-Language")) {
+		if trans, ok := translators[locale]; ok {
+			return trans
+		}
+		if base, _, ok := strings.Cut(locale, "-"); ok {
+			if trans, ok := translators[base]; ok {
+				return trans
+			}
+		}
+	}
+	return translators["en"]
+}
+
+// acceptLanguages は Accept-Language の言語タグを q の降順に、小文字で返す。q=0 のタグは除く
+func acceptLanguages(header string) []string {
+	type tag struct {
+		name string
+		q    float64
+	}
+	var tags []tag
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || name == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, tag{name: name, q: q})
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.name)
+	}
+	return names
+}
+
+// localize は trans の言語でエラーの title と message を返す。
+// message はサービスが返す英語の詳しい内容をどの言語でもそのまま返し、バリデーションのエラーのみ翻訳する
+func localize(trans ut.Translator, se *ServiceError) (title, message string) {
+	title, err := trans.T(se.Code)
+	if err != nil {
+		title = se.Code.Title()
+	}
+	message = se.Message
+	if len(se.validationErrors) > 0 {
+		messages := make([]string, 0, len(se.validationErrors))
+		for _, fe := range se.validationErrors {
+			messages = append(messages, fe.Translate(trans))
+		}
+		message = strings.Join(messages, "; ")
+	}
+	return title, message
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptLanguages(t *testing.T) {
+	tests := map[string]struct {
+		header string
+		want   []string
+	}{
+		"empty":          {header: "", want: []string{}},
+		"single":         {header: "ja", want: []string{"ja"}},
+		"ordered by q":   {header: "en;q=0.5, ja-JP, fr;q=0.8", want: []string{"ja-jp", "fr", "en"}},
+		"q=0 and *":      {header: "ja;q=0, *;q=0.1, EN", want: []string{"en"}},
+		"invalid q":      {header: "ja;q=abc, en", want: []string{"en"}},
+		"stable on tie":  {header: "fr;q=0.9, ja;q=0.9", want: []string{"fr", "ja"}},
+		"extra spacing":  {header: " ja-JP ; q=0.9 ,en;q=0.8", want: []string{"ja-jp", "en"}},
+		"only wildcard":  {header: "*", want: []string{}},
+		"region variant": {header: "en-GB", want: []string{"en-gb"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, acceptLanguages(tt.header))
+		})
+	}
+}
+
+func TestRespondError_Localized(t *testing.T) {
+	tests := map[string]struct {
+		acceptLanguage string
+		wantLanguage   string
+		wantTitle      string
+		wantMessage    string
+	}{
+		"default is en": {
+			wantLanguage: "en",
+			wantTitle:    "Message not found",
+			wantMessage:  "message not found",
+		},
+		"ja": {
+			acceptLanguage: "ja-JP,ja;q=0.9,en;q=0.8",
+			wantLanguage:   "ja",
+			wantTitle:      "メッセージが見つかりません",
+			wantMessage:    "message not found",
+		},
+		"unsupported language falls back to en": {
+			acceptLanguage: "fr",
+			wantLanguage:   "en",
+			wantTitle:      "Message not found",
+			wantMessage:    "message not found",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodDelete, "/messages/1", nil)
+			r.Header.Set("Accept-Language", tt.acceptLanguage)
+			RespondError(w, r, NewServiceError(ErrCodeMessageNotFound, "message not found", ""))
+
+			var errResp ErrResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+			assert.Equal(t, tt.wantLanguage, w.Header().Get("Content-Language"))
+			assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+			assert.Equal(t, ErrCodeMessageNotFound, errResp.Code)
+			assert.Equal(t, tt.wantTitle, errResp.Title)
+			assert.Equal(t, tt.wantMessage, errResp.Message)
+		})
+	}
+}
+
+func TestRespondError_ValidationLocalized(t *testing.T) {
+	v, err := NewValidator()
+	require.NoError(t, err)
+	// 翻訳の登録は1度だけのため、同じ validator を共有する
+	v2, err := NewValidator()
+	require.NoError(t, err)
+	assert.Same(t, v, v2)
+	var requestData struct {
+		MessageThreadID int64  `json:"message_thread_id" validate:"required"`
+		AppKind         string `json:"app_kind" validate:"oneof=company student"`
+	}
+	requestData.AppKind = "school"
+	validationErr := v.Struct(requestData)
+	require.Error(t, validationErr)
+
+	tests := map[string]struct {
+		acceptLanguage string
+		wantMessage    string
+	}{
+		"en": {
+			acceptLanguage: "en",
+			wantMessage:    "message_thread_id is a required field; app_kind must be one of [company student]",
+		},
+		"ja": {
+			acceptLanguage: "ja",
+			wantMessage:    "message_thread_idは必須フィールドです; app_kindは[company student]のうちのいずれかでなければなりません",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/messages", nil)
+			r.Header.Set("Accept-Language", tt.acceptLanguage)
+			RespondError(w, r, newValidationError(validationErr))
+
+			var errResp ErrResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, ErrCodeValidationFailed, errResp.Code)
+			assert.Equal(t, tt.wantMessage, errResp.Message)
+		})
+	}
+}
+
+func TestNewValidator_Concurrent(t *testing.T) {
+	var requestData struct {
+		Reaction string `json:"reaction" validate:"required"`
+	}
+	// t.Parallel は -parallel の数で直列になりうるため、goroutine で同時に呼ぶ
+	const n = 8
+	messages := make([]string, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := NewValidator()
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			var validationErrs validator.ValidationErrors
+			if errors.As(v.Struct(requestData), &validationErrs) {
+				messages[i] = validationErrs[0].Translate(translators["ja"])
+			}
+		}(i)
+	}
+	wg.Wait()
+	for i := 0; i < n; i++ {
+		require.NoError(t, errs[i])
+		assert.Equal(t, "reactionは必須フィールドです", messages[i])
+	}
+}
+
+func TestErrorMessagesJa(t *testing.T) {
+	for _, code := range ErrorCodes() {
+		assert.NotEmpty(t, errorMessagesJa[code], code)
+	}
+	assert.Len(t, errorMessagesJa, len(errorCatalogue))
+}
@@ -2,12 +2,13 @@ package handler
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/yuyacode/AppLiftMessageApi/entity"
 )
 
-//go:generate go run github.com/matryer/moq -out moq_test.go . RegisterOAuthService RefreshAccessTokenService GetMessageService AddMessageService EditMessageService DeleteMessageService
+//go:generate go run github.com/matryer/moq -out moq_test.go . RegisterOAuthService RefreshAccessTokenService GetMessageService AddMessageService EditMessageService DeleteMessageService AddAttachmentService GetAttachmentLinkService DownloadAttachmentService AddReactionService DeleteReactionService GetMessageTemplateService AddMessageTemplateService EditMessageTemplateService DeleteMessageTemplateService RenderMessageTemplateService BulkAddMessageService ExportThreadService ExportUserDataService EraseUserDataService
 
 type VerifyAccessTokenService interface {
 	VerifyAccessToken(ctx context.Context, accessToken string) (string, int64, error)
@@ -29,8 +30,16 @@ type GetMessageService interface {
 	GetAllMessages(ctx context.Context, messageThreadID entity.MessageThreadID) (entity.Messages, error)
 }
 
+type ExportThreadService interface {
+	ExportThread(ctx context.Context, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error
+}
+
 type AddMessageService interface {
-	AddMessage(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time) (*entity.Message, error)
+	AddMessage(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time, replyToMessageID *entity.MessageID) (*entity.Message, error)
+}
+
+type BulkAddMessageService interface {
+	BulkAddMessages(ctx context.Context, messages []entity.BulkMessage, isSent int8, sentAt time.Time) (entity.BulkSendResults, error)
 }
 
 type EditMessageService interface {
@@ -40,3 +49,53 @@ type EditMessageService interface {
 type DeleteMessageService interface {
 	DeleteMessage(ctx context.Context, id entity.MessageID) error
 }
+
+type AddAttachmentService interface {
+	AddAttachment(ctx context.Context, messageID entity.MessageID, fileName string, content []byte) (*entity.Attachment, error)
+}
+
+type GetAttachmentLinkService interface {
+	GetAttachmentLink(ctx context.Context, id entity.AttachmentID) (string, time.Time, error)
+}
+
+type DownloadAttachmentService interface {
+	DownloadAttachment(ctx context.Context, id entity.AttachmentID, expires, signature string) (*entity.Attachment, io.ReadCloser, error)
+}
+
+type AddReactionService interface {
+	AddReaction(ctx context.Context, messageID entity.MessageID, reaction string) error
+}
+
+type DeleteReactionService interface {
+	DeleteReaction(ctx context.Context, messageID entity.MessageID, reaction string) error
+}
+
+type GetMessageTemplateService interface {
+	GetAllMessageTemplates(ctx context.Context) (entity.MessageTemplates, error)
+}
+
+type AddMessageTemplateService interface {
+	AddMessageTemplate(ctx context.Context, name, content string) (*entity.MessageTemplate, error)
+}
+
+type EditMessageTemplateService interface {
+	EditMessageTemplate(ctx context.Context, id entity.MessageTemplateID, name, content string) error
+}
+
+type DeleteMessageTemplateService interface {
+	DeleteMessageTemplate(ctx context.Context, id entity.MessageTemplateID) error
+}
+
+type RenderMessageTemplateService interface {
+	RenderMessageTemplate(ctx context.Context, id entity.MessageTemplateID, variables map[string]string) (string, error)
+	RenderMessageTemplates(ctx context.Context, id entity.MessageTemplateID, variables []map[string]string) ([]string, error)
+}
+
+type ExportUserDataService interface {
+	ExportUserData(ctx context.Context, appKind string, userID int64) (*entity.UserData, error)
+	WriteUserDataArchive(ctx context.Context, w io.Writer, data *entity.UserData) error
+}
+
+type EraseUserDataService interface {
+	EraseUserData(ctx context.Context, appKind string, userID int64, dryRun bool) (*entity.UserDataErasureReport, error)
+}
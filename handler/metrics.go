@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/yuyacode/AppLiftMessageApi/metrics"
+)
+
+// MetricsMiddleware はリクエスト数と処理時間を、ルーティング後に確定する chi のルートパターンごとに記録する。
+// どのルートにも一致しなかったリクエストは unmatched にまとめ、ラベルの種類が増えすぎないようにする
+func MetricsMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+			route := "unmatched"
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			labels := []string{r.Method, route, strconv.Itoa(status)}
+			metrics.HTTPRequests.WithLabelValues(labels...).Inc()
+			metrics.HTTPRequestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// countTokenVerificationFailure は認証エラー（401）となったトークンを理由ごとに数える
+func countTokenVerificationFailure(token string, err error) {
+	var serviceErr *ServiceError
+	if errors.As(err, &serviceErr) && serviceErr.StatusCode == http.StatusUnauthorized {
+		metrics.TokenVerificationFailures.WithLabelValues(token, string(serviceErr.Code)).Inc()
+	}
+}
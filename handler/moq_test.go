@@ -6,6 +6,7 @@ package handler
 import (
 	"context"
 	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"io"
 	"sync"
 	"time"
 )
@@ -242,7 +243,7 @@ var _ AddMessageService = &AddMessageServiceMock{}
 //
 //		// make and configure a mocked AddMessageService
 //		mockedAddMessageService := &AddMessageServiceMock{
-//			AddMessageFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time) (*entity.Message, error) {
+//			AddMessageFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time, replyToMessageID *entity.MessageID) (*entity.Message, error) {
 //				panic("mock out the AddMessage method")
 //			},
 //		}
@@ -253,7 +254,7 @@ var _ AddMessageService = &AddMessageServiceMock{}
 //	}
 type AddMessageServiceMock struct {
 	// AddMessageFunc mocks the AddMessage method.
-	AddMessageFunc func(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time) (*entity.Message, error)
+	AddMessageFunc func(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time, replyToMessageID *entity.MessageID) (*entity.Message, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -273,37 +274,41 @@ type AddMessageServiceMock struct {
 			IsSent int8
 			// SentAt is the sentAt argument value.
 			SentAt time.Time
+			// ReplyToMessageID is the replyToMessageID argument value.
+			ReplyToMessageID *entity.MessageID
 		}
 	}
 	lockAddMessage sync.RWMutex
 }
 
 // AddMessage calls AddMessageFunc.
-func (mock *AddMessageServiceMock) AddMessage(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time) (*entity.Message, error) {
+func (mock *AddMessageServiceMock) AddMessage(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time, replyToMessageID *entity.MessageID) (*entity.Message, error) {
 	if mock.AddMessageFunc == nil {
 		panic("AddMessageServiceMock.AddMessageFunc: method is nil but AddMessageService.AddMessage was just called")
 	}
 	callInfo := struct {
-		Ctx             context.Context
-		MessageThreadID entity.MessageThreadID
-		IsFromCompany   int8
-		IsFromStudent   int8
-		Content         string
-		IsSent          int8
-		SentAt          time.Time
+		Ctx              context.Context
+		MessageThreadID  entity.MessageThreadID
+		IsFromCompany    int8
+		IsFromStudent    int8
+		Content          string
+		IsSent           int8
+		SentAt           time.Time
+		ReplyToMessageID *entity.MessageID
 	}{
-		Ctx:             ctx,
-		MessageThreadID: messageThreadID,
-		IsFromCompany:   isFromCompany,
-		IsFromStudent:   isFromStudent,
-		Content:         content,
-		IsSent:          isSent,
-		SentAt:          sentAt,
+		Ctx:              ctx,
+		MessageThreadID:  messageThreadID,
+		IsFromCompany:    isFromCompany,
+		IsFromStudent:    isFromStudent,
+		Content:          content,
+		IsSent:           isSent,
+		SentAt:           sentAt,
+		ReplyToMessageID: replyToMessageID,
 	}
 	mock.lockAddMessage.Lock()
 	mock.calls.AddMessage = append(mock.calls.AddMessage, callInfo)
 	mock.lockAddMessage.Unlock()
-	return mock.AddMessageFunc(ctx, messageThreadID, isFromCompany, isFromStudent, content, isSent, sentAt)
+	return mock.AddMessageFunc(ctx, messageThreadID, isFromCompany, isFromStudent, content, isSent, sentAt, replyToMessageID)
 }
 
 // AddMessageCalls gets all the calls that were made to AddMessage.
@@ -311,22 +316,24 @@ func (mock *AddMessageServiceMock) AddMessage(ctx context.Context, messageThread
 //
 //	len(mockedAddMessageService.AddMessageCalls())
 func (mock *AddMessageServiceMock) AddMessageCalls() []struct {
-	Ctx             context.Context
-	MessageThreadID entity.MessageThreadID
-	IsFromCompany   int8
-	IsFromStudent   int8
-	Content         string
-	IsSent          int8
-	SentAt          time.Time
+	Ctx              context.Context
+	MessageThreadID  entity.MessageThreadID
+	IsFromCompany    int8
+	IsFromStudent    int8
+	Content          string
+	IsSent           int8
+	SentAt           time.Time
+	ReplyToMessageID *entity.MessageID
 } {
 	var calls []struct {
-		Ctx             context.Context
-		MessageThreadID entity.MessageThreadID
-		IsFromCompany   int8
-		IsFromStudent   int8
-		Content         string
-		IsSent          int8
-		SentAt          time.Time
+		Ctx              context.Context
+		MessageThreadID  entity.MessageThreadID
+		IsFromCompany    int8
+		IsFromStudent    int8
+		Content          string
+		IsSent           int8
+		SentAt           time.Time
+		ReplyToMessageID *entity.MessageID
 	}
 	mock.lockAddMessage.RLock()
 	calls = mock.calls.AddMessage
@@ -483,3 +490,1213 @@ func (mock *DeleteMessageServiceMock) DeleteMessageCalls() []struct {
 	mock.lockDeleteMessage.RUnlock()
 	return calls
 }
+
+// Ensure, that AddAttachmentServiceMock does implement AddAttachmentService.
+// If this is not the case, regenerate this file with moq.
+var _ AddAttachmentService = &AddAttachmentServiceMock{}
+
+// AddAttachmentServiceMock is a mock implementation of AddAttachmentService.
+//
+//	func TestSomethingThatUsesAddAttachmentService(t *testing.T) {
+//
+//		// make and configure a mocked AddAttachmentService
+//		mockedAddAttachmentService := &AddAttachmentServiceMock{
+//			AddAttachmentFunc: func(ctx context.Context, messageID entity.MessageID, fileName string, content []byte) (*entity.Attachment, error) {
+//				panic("mock out the AddAttachment method")
+//			},
+//		}
+//
+//		// use mockedAddAttachmentService in code that requires AddAttachmentService
+//		// and then make assertions.
+//
+//	}
+type AddAttachmentServiceMock struct {
+	// AddAttachmentFunc mocks the AddAttachment method.
+	AddAttachmentFunc func(ctx context.Context, messageID entity.MessageID, fileName string, content []byte) (*entity.Attachment, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddAttachment holds details about calls to the AddAttachment method.
+		AddAttachment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MessageID is the messageID argument value.
+			MessageID entity.MessageID
+			// FileName is the fileName argument value.
+			FileName string
+			// Content is the content argument value.
+			Content []byte
+		}
+	}
+	lockAddAttachment sync.RWMutex
+}
+
+// AddAttachment calls AddAttachmentFunc.
+func (mock *AddAttachmentServiceMock) AddAttachment(ctx context.Context, messageID entity.MessageID, fileName string, content []byte) (*entity.Attachment, error) {
+	if mock.AddAttachmentFunc == nil {
+		panic("AddAttachmentServiceMock.AddAttachmentFunc: method is nil but AddAttachmentService.AddAttachment was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		MessageID entity.MessageID
+		FileName  string
+		Content   []byte
+	}{
+		Ctx:       ctx,
+		MessageID: messageID,
+		FileName:  fileName,
+		Content:   content,
+	}
+	mock.lockAddAttachment.Lock()
+	mock.calls.AddAttachment = append(mock.calls.AddAttachment, callInfo)
+	mock.lockAddAttachment.Unlock()
+	return mock.AddAttachmentFunc(ctx, messageID, fileName, content)
+}
+
+// AddAttachmentCalls gets all the calls that were made to AddAttachment.
+// Check the length with:
+//
+//	len(mockedAddAttachmentService.AddAttachmentCalls())
+func (mock *AddAttachmentServiceMock) AddAttachmentCalls() []struct {
+	Ctx       context.Context
+	MessageID entity.MessageID
+	FileName  string
+	Content   []byte
+} {
+	var calls []struct {
+		Ctx       context.Context
+		MessageID entity.MessageID
+		FileName  string
+		Content   []byte
+	}
+	mock.lockAddAttachment.RLock()
+	calls = mock.calls.AddAttachment
+	mock.lockAddAttachment.RUnlock()
+	return calls
+}
+
+// Ensure, that GetAttachmentLinkServiceMock does implement GetAttachmentLinkService.
+// If this is not the case, regenerate this file with moq.
+var _ GetAttachmentLinkService = &GetAttachmentLinkServiceMock{}
+
+// GetAttachmentLinkServiceMock is a mock implementation of GetAttachmentLinkService.
+//
+//	func TestSomethingThatUsesGetAttachmentLinkService(t *testing.T) {
+//
+//		// make and configure a mocked GetAttachmentLinkService
+//		mockedGetAttachmentLinkService := &GetAttachmentLinkServiceMock{
+//			GetAttachmentLinkFunc: func(ctx context.Context, id entity.AttachmentID) (string, time.Time, error) {
+//				panic("mock out the GetAttachmentLink method")
+//			},
+//		}
+//
+//		// use mockedGetAttachmentLinkService in code that requires GetAttachmentLinkService
+//		// and then make assertions.
+//
+//	}
+type GetAttachmentLinkServiceMock struct {
+	// GetAttachmentLinkFunc mocks the GetAttachmentLink method.
+	GetAttachmentLinkFunc func(ctx context.Context, id entity.AttachmentID) (string, time.Time, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetAttachmentLink holds details about calls to the GetAttachmentLink method.
+		GetAttachmentLink []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.AttachmentID
+		}
+	}
+	lockGetAttachmentLink sync.RWMutex
+}
+
+// GetAttachmentLink calls GetAttachmentLinkFunc.
+func (mock *GetAttachmentLinkServiceMock) GetAttachmentLink(ctx context.Context, id entity.AttachmentID) (string, time.Time, error) {
+	if mock.GetAttachmentLinkFunc == nil {
+		panic("GetAttachmentLinkServiceMock.GetAttachmentLinkFunc: method is nil but GetAttachmentLinkService.GetAttachmentLink was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.AttachmentID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetAttachmentLink.Lock()
+	mock.calls.GetAttachmentLink = append(mock.calls.GetAttachmentLink, callInfo)
+	mock.lockGetAttachmentLink.Unlock()
+	return mock.GetAttachmentLinkFunc(ctx, id)
+}
+
+// GetAttachmentLinkCalls gets all the calls that were made to GetAttachmentLink.
+// Check the length with:
+//
+//	len(mockedGetAttachmentLinkService.GetAttachmentLinkCalls())
+func (mock *GetAttachmentLinkServiceMock) GetAttachmentLinkCalls() []struct {
+	Ctx context.Context
+	ID  entity.AttachmentID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.AttachmentID
+	}
+	mock.lockGetAttachmentLink.RLock()
+	calls = mock.calls.GetAttachmentLink
+	mock.lockGetAttachmentLink.RUnlock()
+	return calls
+}
+
+// Ensure, that DownloadAttachmentServiceMock does implement DownloadAttachmentService.
+// If this is not the case, regenerate this file with moq.
+var _ DownloadAttachmentService = &DownloadAttachmentServiceMock{}
+
+// DownloadAttachmentServiceMock is a mock implementation of DownloadAttachmentService.
+//
+//	func TestSomethingThatUsesDownloadAttachmentService(t *testing.T) {
+//
+//		// make and configure a mocked DownloadAttachmentService
+//		mockedDownloadAttachmentService := &DownloadAttachmentServiceMock{
+//			DownloadAttachmentFunc: func(ctx context.Context, id entity.AttachmentID, expires string, signature string) (*entity.Attachment, io.ReadCloser, error) {
+//				panic("mock out the DownloadAttachment method")
+//			},
+//		}
+//
+//		// use mockedDownloadAttachmentService in code that requires DownloadAttachmentService
+//		// and then make assertions.
+//
+//	}
+type DownloadAttachmentServiceMock struct {
+	// DownloadAttachmentFunc mocks the DownloadAttachment method.
+	DownloadAttachmentFunc func(ctx context.Context, id entity.AttachmentID, expires string, signature string) (*entity.Attachment, io.ReadCloser, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DownloadAttachment holds details about calls to the DownloadAttachment method.
+		DownloadAttachment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.AttachmentID
+			// Expires is the expires argument value.
+			Expires string
+			// Signature is the signature argument value.
+			Signature string
+		}
+	}
+	lockDownloadAttachment sync.RWMutex
+}
+
+// DownloadAttachment calls DownloadAttachmentFunc.
+func (mock *DownloadAttachmentServiceMock) DownloadAttachment(ctx context.Context, id entity.AttachmentID, expires string, signature string) (*entity.Attachment, io.ReadCloser, error) {
+	if mock.DownloadAttachmentFunc == nil {
+		panic("DownloadAttachmentServiceMock.DownloadAttachmentFunc: method is nil but DownloadAttachmentService.DownloadAttachment was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ID        entity.AttachmentID
+		Expires   string
+		Signature string
+	}{
+		Ctx:       ctx,
+		ID:        id,
+		Expires:   expires,
+		Signature: signature,
+	}
+	mock.lockDownloadAttachment.Lock()
+	mock.calls.DownloadAttachment = append(mock.calls.DownloadAttachment, callInfo)
+	mock.lockDownloadAttachment.Unlock()
+	return mock.DownloadAttachmentFunc(ctx, id, expires, signature)
+}
+
+// DownloadAttachmentCalls gets all the calls that were made to DownloadAttachment.
+// Check the length with:
+//
+//	len(mockedDownloadAttachmentService.DownloadAttachmentCalls())
+func (mock *DownloadAttachmentServiceMock) DownloadAttachmentCalls() []struct {
+	Ctx       context.Context
+	ID        entity.AttachmentID
+	Expires   string
+	Signature string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ID        entity.AttachmentID
+		Expires   string
+		Signature string
+	}
+	mock.lockDownloadAttachment.RLock()
+	calls = mock.calls.DownloadAttachment
+	mock.lockDownloadAttachment.RUnlock()
+	return calls
+}
+
+// Ensure, that AddReactionServiceMock does implement AddReactionService.
+// If this is not the case, regenerate this file with moq.
+var _ AddReactionService = &AddReactionServiceMock{}
+
+// AddReactionServiceMock is a mock implementation of AddReactionService.
+//
+//	func TestSomethingThatUsesAddReactionService(t *testing.T) {
+//
+//		// make and configure a mocked AddReactionService
+//		mockedAddReactionService := &AddReactionServiceMock{
+//			AddReactionFunc: func(ctx context.Context, messageID entity.MessageID, reaction string) error {
+//				panic("mock out the AddReaction method")
+//			},
+//		}
+//
+//		// use mockedAddReactionService in code that requires AddReactionService
+//		// and then make assertions.
+//
+//	}
+type AddReactionServiceMock struct {
+	// AddReactionFunc mocks the AddReaction method.
+	AddReactionFunc func(ctx context.Context, messageID entity.MessageID, reaction string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddReaction holds details about calls to the AddReaction method.
+		AddReaction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MessageID is the messageID argument value.
+			MessageID entity.MessageID
+			// Reaction is the reaction argument value.
+			Reaction string
+		}
+	}
+	lockAddReaction sync.RWMutex
+}
+
+// AddReaction calls AddReactionFunc.
+func (mock *AddReactionServiceMock) AddReaction(ctx context.Context, messageID entity.MessageID, reaction string) error {
+	if mock.AddReactionFunc == nil {
+		panic("AddReactionServiceMock.AddReactionFunc: method is nil but AddReactionService.AddReaction was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		MessageID entity.MessageID
+		Reaction  string
+	}{
+		Ctx:       ctx,
+		MessageID: messageID,
+		Reaction:  reaction,
+	}
+	mock.lockAddReaction.Lock()
+	mock.calls.AddReaction = append(mock.calls.AddReaction, callInfo)
+	mock.lockAddReaction.Unlock()
+	return mock.AddReactionFunc(ctx, messageID, reaction)
+}
+
+// AddReactionCalls gets all the calls that were made to AddReaction.
+// Check the length with:
+//
+//	len(mockedAddReactionService.AddReactionCalls())
+func (mock *AddReactionServiceMock) AddReactionCalls() []struct {
+	Ctx       context.Context
+	MessageID entity.MessageID
+	Reaction  string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		MessageID entity.MessageID
+		Reaction  string
+	}
+	mock.lockAddReaction.RLock()
+	calls = mock.calls.AddReaction
+	mock.lockAddReaction.RUnlock()
+	return calls
+}
+
+// Ensure, that DeleteReactionServiceMock does implement DeleteReactionService.
+// If this is not the case, regenerate this file with moq.
+var _ DeleteReactionService = &DeleteReactionServiceMock{}
+
+// DeleteReactionServiceMock is a mock implementation of DeleteReactionService.
+//
+//	func TestSomethingThatUsesDeleteReactionService(t *testing.T) {
+//
+//		// make and configure a mocked DeleteReactionService
+//		mockedDeleteReactionService := &DeleteReactionServiceMock{
+//			DeleteReactionFunc: func(ctx context.Context, messageID entity.MessageID, reaction string) error {
+//				panic("mock out the DeleteReaction method")
+//			},
+//		}
+//
+//		// use mockedDeleteReactionService in code that requires DeleteReactionService
+//		// and then make assertions.
+//
+//	}
+type DeleteReactionServiceMock struct {
+	// DeleteReactionFunc mocks the DeleteReaction method.
+	DeleteReactionFunc func(ctx context.Context, messageID entity.MessageID, reaction string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteReaction holds details about calls to the DeleteReaction method.
+		DeleteReaction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MessageID is the messageID argument value.
+			MessageID entity.MessageID
+			// Reaction is the reaction argument value.
+			Reaction string
+		}
+	}
+	lockDeleteReaction sync.RWMutex
+}
+
+// DeleteReaction calls DeleteReactionFunc.
+func (mock *DeleteReactionServiceMock) DeleteReaction(ctx context.Context, messageID entity.MessageID, reaction string) error {
+	if mock.DeleteReactionFunc == nil {
+		panic("DeleteReactionServiceMock.DeleteReactionFunc: method is nil but DeleteReactionService.DeleteReaction was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		MessageID entity.MessageID
+		Reaction  string
+	}{
+		Ctx:       ctx,
+		MessageID: messageID,
+		Reaction:  reaction,
+	}
+	mock.lockDeleteReaction.Lock()
+	mock.calls.DeleteReaction = append(mock.calls.DeleteReaction, callInfo)
+	mock.lockDeleteReaction.Unlock()
+	return mock.DeleteReactionFunc(ctx, messageID, reaction)
+}
+
+// DeleteReactionCalls gets all the calls that were made to DeleteReaction.
+// Check the length with:
+//
+//	len(mockedDeleteReactionService.DeleteReactionCalls())
+func (mock *DeleteReactionServiceMock) DeleteReactionCalls() []struct {
+	Ctx       context.Context
+	MessageID entity.MessageID
+	Reaction  string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		MessageID entity.MessageID
+		Reaction  string
+	}
+	mock.lockDeleteReaction.RLock()
+	calls = mock.calls.DeleteReaction
+	mock.lockDeleteReaction.RUnlock()
+	return calls
+}
+
+// Ensure, that GetMessageTemplateServiceMock does implement GetMessageTemplateService.
+// If this is not the case, regenerate this file with moq.
+var _ GetMessageTemplateService = &GetMessageTemplateServiceMock{}
+
+// GetMessageTemplateServiceMock is a mock implementation of GetMessageTemplateService.
+//
+//	func TestSomethingThatUsesGetMessageTemplateService(t *testing.T) {
+//
+//		// make and configure a mocked GetMessageTemplateService
+//		mockedGetMessageTemplateService := &GetMessageTemplateServiceMock{
+//			GetAllMessageTemplatesFunc: func(ctx context.Context) (entity.MessageTemplates, error) {
+//				panic("mock out the GetAllMessageTemplates method")
+//			},
+//		}
+//
+//		// use mockedGetMessageTemplateService in code that requires GetMessageTemplateService
+//		// and then make assertions.
+//
+//	}
+type GetMessageTemplateServiceMock struct {
+	// GetAllMessageTemplatesFunc mocks the GetAllMessageTemplates method.
+	GetAllMessageTemplatesFunc func(ctx context.Context) (entity.MessageTemplates, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetAllMessageTemplates holds details about calls to the GetAllMessageTemplates method.
+		GetAllMessageTemplates []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockGetAllMessageTemplates sync.RWMutex
+}
+
+// GetAllMessageTemplates calls GetAllMessageTemplatesFunc.
+func (mock *GetMessageTemplateServiceMock) GetAllMessageTemplates(ctx context.Context) (entity.MessageTemplates, error) {
+	if mock.GetAllMessageTemplatesFunc == nil {
+		panic("GetMessageTemplateServiceMock.GetAllMessageTemplatesFunc: method is nil but GetMessageTemplateService.GetAllMessageTemplates was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAllMessageTemplates.Lock()
+	mock.calls.GetAllMessageTemplates = append(mock.calls.GetAllMessageTemplates, callInfo)
+	mock.lockGetAllMessageTemplates.Unlock()
+	return mock.GetAllMessageTemplatesFunc(ctx)
+}
+
+// GetAllMessageTemplatesCalls gets all the calls that were made to GetAllMessageTemplates.
+// Check the length with:
+//
+//	len(mockedGetMessageTemplateService.GetAllMessageTemplatesCalls())
+func (mock *GetMessageTemplateServiceMock) GetAllMessageTemplatesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAllMessageTemplates.RLock()
+	calls = mock.calls.GetAllMessageTemplates
+	mock.lockGetAllMessageTemplates.RUnlock()
+	return calls
+}
+
+// Ensure, that AddMessageTemplateServiceMock does implement AddMessageTemplateService.
+// If this is not the case, regenerate this file with moq.
+var _ AddMessageTemplateService = &AddMessageTemplateServiceMock{}
+
+// AddMessageTemplateServiceMock is a mock implementation of AddMessageTemplateService.
+//
+//	func TestSomethingThatUsesAddMessageTemplateService(t *testing.T) {
+//
+//		// make and configure a mocked AddMessageTemplateService
+//		mockedAddMessageTemplateService := &AddMessageTemplateServiceMock{
+//			AddMessageTemplateFunc: func(ctx context.Context, name string, content string) (*entity.MessageTemplate, error) {
+//				panic("mock out the AddMessageTemplate method")
+//			},
+//		}
+//
+//		// use mockedAddMessageTemplateService in code that requires AddMessageTemplateService
+//		// and then make assertions.
+//
+//	}
+type AddMessageTemplateServiceMock struct {
+	// AddMessageTemplateFunc mocks the AddMessageTemplate method.
+	AddMessageTemplateFunc func(ctx context.Context, name string, content string) (*entity.MessageTemplate, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddMessageTemplate holds details about calls to the AddMessageTemplate method.
+		AddMessageTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Content is the content argument value.
+			Content string
+		}
+	}
+	lockAddMessageTemplate sync.RWMutex
+}
+
+// AddMessageTemplate calls AddMessageTemplateFunc.
+func (mock *AddMessageTemplateServiceMock) AddMessageTemplate(ctx context.Context, name string, content string) (*entity.MessageTemplate, error) {
+	if mock.AddMessageTemplateFunc == nil {
+		panic("AddMessageTemplateServiceMock.AddMessageTemplateFunc: method is nil but AddMessageTemplateService.AddMessageTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Name    string
+		Content string
+	}{
+		Ctx:     ctx,
+		Name:    name,
+		Content: content,
+	}
+	mock.lockAddMessageTemplate.Lock()
+	mock.calls.AddMessageTemplate = append(mock.calls.AddMessageTemplate, callInfo)
+	mock.lockAddMessageTemplate.Unlock()
+	return mock.AddMessageTemplateFunc(ctx, name, content)
+}
+
+// AddMessageTemplateCalls gets all the calls that were made to AddMessageTemplate.
+// Check the length with:
+//
+//	len(mockedAddMessageTemplateService.AddMessageTemplateCalls())
+func (mock *AddMessageTemplateServiceMock) AddMessageTemplateCalls() []struct {
+	Ctx     context.Context
+	Name    string
+	Content string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Name    string
+		Content string
+	}
+	mock.lockAddMessageTemplate.RLock()
+	calls = mock.calls.AddMessageTemplate
+	mock.lockAddMessageTemplate.RUnlock()
+	return calls
+}
+
+// Ensure, that EditMessageTemplateServiceMock does implement EditMessageTemplateService.
+// If this is not the case, regenerate this file with moq.
+var _ EditMessageTemplateService = &EditMessageTemplateServiceMock{}
+
+// EditMessageTemplateServiceMock is a mock implementation of EditMessageTemplateService.
+//
+//	func TestSomethingThatUsesEditMessageTemplateService(t *testing.T) {
+//
+//		// make and configure a mocked EditMessageTemplateService
+//		mockedEditMessageTemplateService := &EditMessageTemplateServiceMock{
+//			EditMessageTemplateFunc: func(ctx context.Context, id entity.MessageTemplateID, name string, content string) error {
+//				panic("mock out the EditMessageTemplate method")
+//			},
+//		}
+//
+//		// use mockedEditMessageTemplateService in code that requires EditMessageTemplateService
+//		// and then make assertions.
+//
+//	}
+type EditMessageTemplateServiceMock struct {
+	// EditMessageTemplateFunc mocks the EditMessageTemplate method.
+	EditMessageTemplateFunc func(ctx context.Context, id entity.MessageTemplateID, name string, content string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// EditMessageTemplate holds details about calls to the EditMessageTemplate method.
+		EditMessageTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.MessageTemplateID
+			// Name is the name argument value.
+			Name string
+			// Content is the content argument value.
+			Content string
+		}
+	}
+	lockEditMessageTemplate sync.RWMutex
+}
+
+// EditMessageTemplate calls EditMessageTemplateFunc.
+func (mock *EditMessageTemplateServiceMock) EditMessageTemplate(ctx context.Context, id entity.MessageTemplateID, name string, content string) error {
+	if mock.EditMessageTemplateFunc == nil {
+		panic("EditMessageTemplateServiceMock.EditMessageTemplateFunc: method is nil but EditMessageTemplateService.EditMessageTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      entity.MessageTemplateID
+		Name    string
+		Content string
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Name:    name,
+		Content: content,
+	}
+	mock.lockEditMessageTemplate.Lock()
+	mock.calls.EditMessageTemplate = append(mock.calls.EditMessageTemplate, callInfo)
+	mock.lockEditMessageTemplate.Unlock()
+	return mock.EditMessageTemplateFunc(ctx, id, name, content)
+}
+
+// EditMessageTemplateCalls gets all the calls that were made to EditMessageTemplate.
+// Check the length with:
+//
+//	len(mockedEditMessageTemplateService.EditMessageTemplateCalls())
+func (mock *EditMessageTemplateServiceMock) EditMessageTemplateCalls() []struct {
+	Ctx     context.Context
+	ID      entity.MessageTemplateID
+	Name    string
+	Content string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      entity.MessageTemplateID
+		Name    string
+		Content string
+	}
+	mock.lockEditMessageTemplate.RLock()
+	calls = mock.calls.EditMessageTemplate
+	mock.lockEditMessageTemplate.RUnlock()
+	return calls
+}
+
+// Ensure, that DeleteMessageTemplateServiceMock does implement DeleteMessageTemplateService.
+// If this is not the case, regenerate this file with moq.
+var _ DeleteMessageTemplateService = &DeleteMessageTemplateServiceMock{}
+
+// DeleteMessageTemplateServiceMock is a mock implementation of DeleteMessageTemplateService.
+//
+//	func TestSomethingThatUsesDeleteMessageTemplateService(t *testing.T) {
+//
+//		// make and configure a mocked DeleteMessageTemplateService
+//		mockedDeleteMessageTemplateService := &DeleteMessageTemplateServiceMock{
+//			DeleteMessageTemplateFunc: func(ctx context.Context, id entity.MessageTemplateID) error {
+//				panic("mock out the DeleteMessageTemplate method")
+//			},
+//		}
+//
+//		// use mockedDeleteMessageTemplateService in code that requires DeleteMessageTemplateService
+//		// and then make assertions.
+//
+//	}
+type DeleteMessageTemplateServiceMock struct {
+	// DeleteMessageTemplateFunc mocks the DeleteMessageTemplate method.
+	DeleteMessageTemplateFunc func(ctx context.Context, id entity.MessageTemplateID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteMessageTemplate holds details about calls to the DeleteMessageTemplate method.
+		DeleteMessageTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.MessageTemplateID
+		}
+	}
+	lockDeleteMessageTemplate sync.RWMutex
+}
+
+// DeleteMessageTemplate calls DeleteMessageTemplateFunc.
+func (mock *DeleteMessageTemplateServiceMock) DeleteMessageTemplate(ctx context.Context, id entity.MessageTemplateID) error {
+	if mock.DeleteMessageTemplateFunc == nil {
+		panic("DeleteMessageTemplateServiceMock.DeleteMessageTemplateFunc: method is nil but DeleteMessageTemplateService.DeleteMessageTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.MessageTemplateID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteMessageTemplate.Lock()
+	mock.calls.DeleteMessageTemplate = append(mock.calls.DeleteMessageTemplate, callInfo)
+	mock.lockDeleteMessageTemplate.Unlock()
+	return mock.DeleteMessageTemplateFunc(ctx, id)
+}
+
+// DeleteMessageTemplateCalls gets all the calls that were made to DeleteMessageTemplate.
+// Check the length with:
+//
+//	len(mockedDeleteMessageTemplateService.DeleteMessageTemplateCalls())
+func (mock *DeleteMessageTemplateServiceMock) DeleteMessageTemplateCalls() []struct {
+	Ctx context.Context
+	ID  entity.MessageTemplateID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.MessageTemplateID
+	}
+	mock.lockDeleteMessageTemplate.RLock()
+	calls = mock.calls.DeleteMessageTemplate
+	mock.lockDeleteMessageTemplate.RUnlock()
+	return calls
+}
+
+// Ensure, that RenderMessageTemplateServiceMock does implement RenderMessageTemplateService.
+// If this is not the case, regenerate this file with moq.
+var _ RenderMessageTemplateService = &RenderMessageTemplateServiceMock{}
+
+// RenderMessageTemplateServiceMock is a mock implementation of RenderMessageTemplateService.
+//
+//	func TestSomethingThatUsesRenderMessageTemplateService(t *testing.T) {
+//
+//		// make and configure a mocked RenderMessageTemplateService
+//		mockedRenderMessageTemplateService := &RenderMessageTemplateServiceMock{
+//			RenderMessageTemplateFunc: func(ctx context.Context, id entity.MessageTemplateID, variables map[string]string) (string, error) {
+//				panic("mock out the RenderMessageTemplate method")
+//			},
+//			RenderMessageTemplatesFunc: func(ctx context.Context, id entity.MessageTemplateID, variables []map[string]string) ([]string, error) {
+//				panic("mock out the RenderMessageTemplates method")
+//			},
+//		}
+//
+//		// use mockedRenderMessageTemplateService in code that requires RenderMessageTemplateService
+//		// and then make assertions.
+//
+//	}
+type RenderMessageTemplateServiceMock struct {
+	// RenderMessageTemplateFunc mocks the RenderMessageTemplate method.
+	RenderMessageTemplateFunc func(ctx context.Context, id entity.MessageTemplateID, variables map[string]string) (string, error)
+
+	// RenderMessageTemplatesFunc mocks the RenderMessageTemplates method.
+	RenderMessageTemplatesFunc func(ctx context.Context, id entity.MessageTemplateID, variables []map[string]string) ([]string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RenderMessageTemplate holds details about calls to the RenderMessageTemplate method.
+		RenderMessageTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.MessageTemplateID
+			// Variables is the variables argument value.
+			Variables map[string]string
+		}
+		// RenderMessageTemplates holds details about calls to the RenderMessageTemplates method.
+		RenderMessageTemplates []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.MessageTemplateID
+			// Variables is the variables argument value.
+			Variables []map[string]string
+		}
+	}
+	lockRenderMessageTemplate  sync.RWMutex
+	lockRenderMessageTemplates sync.RWMutex
+}
+
+// RenderMessageTemplate calls RenderMessageTemplateFunc.
+func (mock *RenderMessageTemplateServiceMock) RenderMessageTemplate(ctx context.Context, id entity.MessageTemplateID, variables map[string]string) (string, error) {
+	if mock.RenderMessageTemplateFunc == nil {
+		panic("RenderMessageTemplateServiceMock.RenderMessageTemplateFunc: method is nil but RenderMessageTemplateService.RenderMessageTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ID        entity.MessageTemplateID
+		Variables map[string]string
+	}{
+		Ctx:       ctx,
+		ID:        id,
+		Variables: variables,
+	}
+	mock.lockRenderMessageTemplate.Lock()
+	mock.calls.RenderMessageTemplate = append(mock.calls.RenderMessageTemplate, callInfo)
+	mock.lockRenderMessageTemplate.Unlock()
+	return mock.RenderMessageTemplateFunc(ctx, id, variables)
+}
+
+// RenderMessageTemplateCalls gets all the calls that were made to RenderMessageTemplate.
+// Check the length with:
+//
+//	len(mockedRenderMessageTemplateService.RenderMessageTemplateCalls())
+func (mock *RenderMessageTemplateServiceMock) RenderMessageTemplateCalls() []struct {
+	Ctx       context.Context
+	ID        entity.MessageTemplateID
+	Variables map[string]string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ID        entity.MessageTemplateID
+		Variables map[string]string
+	}
+	mock.lockRenderMessageTemplate.RLock()
+	calls = mock.calls.RenderMessageTemplate
+	mock.lockRenderMessageTemplate.RUnlock()
+	return calls
+}
+
+// RenderMessageTemplates calls RenderMessageTemplatesFunc.
+func (mock *RenderMessageTemplateServiceMock) RenderMessageTemplates(ctx context.Context, id entity.MessageTemplateID, variables []map[string]string) ([]string, error) {
+	if mock.RenderMessageTemplatesFunc == nil {
+		panic("RenderMessageTemplateServiceMock.RenderMessageTemplatesFunc: method is nil but RenderMessageTemplateService.RenderMessageTemplates was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ID        entity.MessageTemplateID
+		Variables []map[string]string
+	}{
+		Ctx:       ctx,
+		ID:        id,
+		Variables: variables,
+	}
+	mock.lockRenderMessageTemplates.Lock()
+	mock.calls.RenderMessageTemplates = append(mock.calls.RenderMessageTemplates, callInfo)
+	mock.lockRenderMessageTemplates.Unlock()
+	return mock.RenderMessageTemplatesFunc(ctx, id, variables)
+}
+
+// RenderMessageTemplatesCalls gets all the calls that were made to RenderMessageTemplates.
+// Check the length with:
+//
+//	len(mockedRenderMessageTemplateService.RenderMessageTemplatesCalls())
+func (mock *RenderMessageTemplateServiceMock) RenderMessageTemplatesCalls() []struct {
+	Ctx       context.Context
+	ID        entity.MessageTemplateID
+	Variables []map[string]string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ID        entity.MessageTemplateID
+		Variables []map[string]string
+	}
+	mock.lockRenderMessageTemplates.RLock()
+	calls = mock.calls.RenderMessageTemplates
+	mock.lockRenderMessageTemplates.RUnlock()
+	return calls
+}
+
+// Ensure, that BulkAddMessageServiceMock does implement BulkAddMessageService.
+// If this is not the case, regenerate this file with moq.
+var _ BulkAddMessageService = &BulkAddMessageServiceMock{}
+
+// BulkAddMessageServiceMock is a mock implementation of BulkAddMessageService.
+//
+//	func TestSomethingThatUsesBulkAddMessageService(t *testing.T) {
+//
+//		// make and configure a mocked BulkAddMessageService
+//		mockedBulkAddMessageService := &BulkAddMessageServiceMock{
+//			BulkAddMessagesFunc: func(ctx context.Context, messages []entity.BulkMessage, isSent int8, sentAt time.Time) (entity.BulkSendResults, error) {
+//				panic("mock out the BulkAddMessages method")
+//			},
+//		}
+//
+//		// use mockedBulkAddMessageService in code that requires BulkAddMessageService
+//		// and then make assertions.
+//
+//	}
+type BulkAddMessageServiceMock struct {
+	// BulkAddMessagesFunc mocks the BulkAddMessages method.
+	BulkAddMessagesFunc func(ctx context.Context, messages []entity.BulkMessage, isSent int8, sentAt time.Time) (entity.BulkSendResults, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// BulkAddMessages holds details about calls to the BulkAddMessages method.
+		BulkAddMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Messages is the messages argument value.
+			Messages []entity.BulkMessage
+			// IsSent is the isSent argument value.
+			IsSent int8
+			// SentAt is the sentAt argument value.
+			SentAt time.Time
+		}
+	}
+	lockBulkAddMessages sync.RWMutex
+}
+
+// BulkAddMessages calls BulkAddMessagesFunc.
+func (mock *BulkAddMessageServiceMock) BulkAddMessages(ctx context.Context, messages []entity.BulkMessage, isSent int8, sentAt time.Time) (entity.BulkSendResults, error) {
+	if mock.BulkAddMessagesFunc == nil {
+		panic("BulkAddMessageServiceMock.BulkAddMessagesFunc: method is nil but BulkAddMessageService.BulkAddMessages was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Messages []entity.BulkMessage
+		IsSent   int8
+		SentAt   time.Time
+	}{
+		Ctx:      ctx,
+		Messages: messages,
+		IsSent:   isSent,
+		SentAt:   sentAt,
+	}
+	mock.lockBulkAddMessages.Lock()
+	mock.calls.BulkAddMessages = append(mock.calls.BulkAddMessages, callInfo)
+	mock.lockBulkAddMessages.Unlock()
+	return mock.BulkAddMessagesFunc(ctx, messages, isSent, sentAt)
+}
+
+// BulkAddMessagesCalls gets all the calls that were made to BulkAddMessages.
+// Check the length with:
+//
+//	len(mockedBulkAddMessageService.BulkAddMessagesCalls())
+func (mock *BulkAddMessageServiceMock) BulkAddMessagesCalls() []struct {
+	Ctx      context.Context
+	Messages []entity.BulkMessage
+	IsSent   int8
+	SentAt   time.Time
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Messages []entity.BulkMessage
+		IsSent   int8
+		SentAt   time.Time
+	}
+	mock.lockBulkAddMessages.RLock()
+	calls = mock.calls.BulkAddMessages
+	mock.lockBulkAddMessages.RUnlock()
+	return calls
+}
+
+// Ensure, that ExportThreadServiceMock does implement ExportThreadService.
+// If this is not the case, regenerate this file with moq.
+var _ ExportThreadService = &ExportThreadServiceMock{}
+
+// ExportThreadServiceMock is a mock implementation of ExportThreadService.
+//
+//	func TestSomethingThatUsesExportThreadService(t *testing.T) {
+//
+//		// make and configure a mocked ExportThreadService
+//		mockedExportThreadService := &ExportThreadServiceMock{
+//			ExportThreadFunc: func(ctx context.Context, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+//				panic("mock out the ExportThread method")
+//			},
+//		}
+//
+//		// use mockedExportThreadService in code that requires ExportThreadService
+//		// and then make assertions.
+//
+//	}
+type ExportThreadServiceMock struct {
+	// ExportThreadFunc mocks the ExportThread method.
+	ExportThreadFunc func(ctx context.Context, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ExportThread holds details about calls to the ExportThread method.
+		ExportThread []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MessageThreadID is the messageThreadID argument value.
+			MessageThreadID entity.MessageThreadID
+			// Fn is the fn argument value.
+			Fn func(*entity.Message) error
+		}
+	}
+	lockExportThread sync.RWMutex
+}
+
+// ExportThread calls ExportThreadFunc.
+func (mock *ExportThreadServiceMock) ExportThread(ctx context.Context, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+	if mock.ExportThreadFunc == nil {
+		panic("ExportThreadServiceMock.ExportThreadFunc: method is nil but ExportThreadService.ExportThread was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		MessageThreadID entity.MessageThreadID
+		Fn              func(*entity.Message) error
+	}{
+		Ctx:             ctx,
+		MessageThreadID: messageThreadID,
+		Fn:              fn,
+	}
+	mock.lockExportThread.Lock()
+	mock.calls.ExportThread = append(mock.calls.ExportThread, callInfo)
+	mock.lockExportThread.Unlock()
+	return mock.ExportThreadFunc(ctx, messageThreadID, fn)
+}
+
+// ExportThreadCalls gets all the calls that were made to ExportThread.
+// Check the length with:
+//
+//	len(mockedExportThreadService.ExportThreadCalls())
+func (mock *ExportThreadServiceMock) ExportThreadCalls() []struct {
+	Ctx             context.Context
+	MessageThreadID entity.MessageThreadID
+	Fn              func(*entity.Message) error
+} {
+	var calls []struct {
+		Ctx             context.Context
+		MessageThreadID entity.MessageThreadID
+		Fn              func(*entity.Message) error
+	}
+	mock.lockExportThread.RLock()
+	calls = mock.calls.ExportThread
+	mock.lockExportThread.RUnlock()
+	return calls
+}
+
+// Ensure, that ExportUserDataServiceMock does implement ExportUserDataService.
+// If this is not the case, regenerate this file with moq.
+var _ ExportUserDataService = &ExportUserDataServiceMock{}
+
+// ExportUserDataServiceMock is a mock implementation of ExportUserDataService.
+//
+//	func TestSomethingThatUsesExportUserDataService(t *testing.T) {
+//
+//		// make and configure a mocked ExportUserDataService
+//		mockedExportUserDataService := &ExportUserDataServiceMock{
+//			ExportUserDataFunc: func(ctx context.Context, appKind string, userID int64) (*entity.UserData, error) {
+//				panic("mock out the ExportUserData method")
+//			},
+//			WriteUserDataArchiveFunc: func(ctx context.Context, w io.Writer, data *entity.UserData) error {
+//				panic("mock out the WriteUserDataArchive method")
+//			},
+//		}
+//
+//		// use mockedExportUserDataService in code that requires ExportUserDataService
+//		// and then make assertions.
+//
+//	}
+type ExportUserDataServiceMock struct {
+	// ExportUserDataFunc mocks the ExportUserData method.
+	ExportUserDataFunc func(ctx context.Context, appKind string, userID int64) (*entity.UserData, error)
+
+	// WriteUserDataArchiveFunc mocks the WriteUserDataArchive method.
+	WriteUserDataArchiveFunc func(ctx context.Context, w io.Writer, data *entity.UserData) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ExportUserData holds details about calls to the ExportUserData method.
+		ExportUserData []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AppKind is the appKind argument value.
+			AppKind string
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// WriteUserDataArchive holds details about calls to the WriteUserDataArchive method.
+		WriteUserDataArchive []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// W is the w argument value.
+			W io.Writer
+			// Data is the data argument value.
+			Data *entity.UserData
+		}
+	}
+	lockExportUserData       sync.RWMutex
+	lockWriteUserDataArchive sync.RWMutex
+}
+
+// ExportUserData calls ExportUserDataFunc.
+func (mock *ExportUserDataServiceMock) ExportUserData(ctx context.Context, appKind string, userID int64) (*entity.UserData, error) {
+	if mock.ExportUserDataFunc == nil {
+		panic("ExportUserDataServiceMock.ExportUserDataFunc: method is nil but ExportUserDataService.ExportUserData was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		AppKind string
+		UserID  int64
+	}{
+		Ctx:     ctx,
+		AppKind: appKind,
+		UserID:  userID,
+	}
+	mock.lockExportUserData.Lock()
+	mock.calls.ExportUserData = append(mock.calls.ExportUserData, callInfo)
+	mock.lockExportUserData.Unlock()
+	return mock.ExportUserDataFunc(ctx, appKind, userID)
+}
+
+// ExportUserDataCalls gets all the calls that were made to ExportUserData.
+// Check the length with:
+//
+//	len(mockedExportUserDataService.ExportUserDataCalls())
+func (mock *ExportUserDataServiceMock) ExportUserDataCalls() []struct {
+	Ctx     context.Context
+	AppKind string
+	UserID  int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		AppKind string
+		UserID  int64
+	}
+	mock.lockExportUserData.RLock()
+	calls = mock.calls.ExportUserData
+	mock.lockExportUserData.RUnlock()
+	return calls
+}
+
+// WriteUserDataArchive calls WriteUserDataArchiveFunc.
+func (mock *ExportUserDataServiceMock) WriteUserDataArchive(ctx context.Context, w io.Writer, data *entity.UserData) error {
+	if mock.WriteUserDataArchiveFunc == nil {
+		panic("ExportUserDataServiceMock.WriteUserDataArchiveFunc: method is nil but ExportUserDataService.WriteUserDataArchive was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		W    io.Writer
+		Data *entity.UserData
+	}{
+		Ctx:  ctx,
+		W:    w,
+		Data: data,
+	}
+	mock.lockWriteUserDataArchive.Lock()
+	mock.calls.WriteUserDataArchive = append(mock.calls.WriteUserDataArchive, callInfo)
+	mock.lockWriteUserDataArchive.Unlock()
+	return mock.WriteUserDataArchiveFunc(ctx, w, data)
+}
+
+// WriteUserDataArchiveCalls gets all the calls that were made to WriteUserDataArchive.
+// Check the length with:
+//
+//	len(mockedExportUserDataService.WriteUserDataArchiveCalls())
+func (mock *ExportUserDataServiceMock) WriteUserDataArchiveCalls() []struct {
+	Ctx  context.Context
+	W    io.Writer
+	Data *entity.UserData
+} {
+	var calls []struct {
+		Ctx  context.Context
+		W    io.Writer
+		Data *entity.UserData
+	}
+	mock.lockWriteUserDataArchive.RLock()
+	calls = mock.calls.WriteUserDataArchive
+	mock.lockWriteUserDataArchive.RUnlock()
+	return calls
+}
+
+// Ensure, that EraseUserDataServiceMock does implement EraseUserDataService.
+// If this is not the case, regenerate this file with moq.
+var _ EraseUserDataService = &EraseUserDataServiceMock{}
+
+// EraseUserDataServiceMock is a mock implementation of EraseUserDataService.
+//
+//	func TestSomethingThatUsesEraseUserDataService(t *testing.T) {
+//
+//		// make and configure a mocked EraseUserDataService
+//		mockedEraseUserDataService := &EraseUserDataServiceMock{
+//			EraseUserDataFunc: func(ctx context.Context, appKind string, userID int64, dryRun bool) (*entity.UserDataErasureReport, error) {
+//				panic("mock out the EraseUserData method")
+//			},
+//		}
+//
+//		// use mockedEraseUserDataService in code that requires EraseUserDataService
+//		// and then make assertions.
+//
+//	}
+type EraseUserDataServiceMock struct {
+	// EraseUserDataFunc mocks the EraseUserData method.
+	EraseUserDataFunc func(ctx context.Context, appKind string, userID int64, dryRun bool) (*entity.UserDataErasureReport, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// EraseUserData holds details about calls to the EraseUserData method.
+		EraseUserData []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AppKind is the appKind argument value.
+			AppKind string
+			// UserID is the userID argument value.
+			UserID int64
+			// DryRun is the dryRun argument value.
+			DryRun bool
+		}
+	}
+	lockEraseUserData sync.RWMutex
+}
+
+// EraseUserData calls EraseUserDataFunc.
+func (mock *EraseUserDataServiceMock) EraseUserData(ctx context.Context, appKind string, userID int64, dryRun bool) (*entity.UserDataErasureReport, error) {
+	if mock.EraseUserDataFunc == nil {
+		panic("EraseUserDataServiceMock.EraseUserDataFunc: method is nil but EraseUserDataService.EraseUserData was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		AppKind string
+		UserID  int64
+		DryRun  bool
+	}{
+		Ctx:     ctx,
+		AppKind: appKind,
+		UserID:  userID,
+		DryRun:  dryRun,
+	}
+	mock.lockEraseUserData.Lock()
+	mock.calls.EraseUserData = append(mock.calls.EraseUserData, callInfo)
+	mock.lockEraseUserData.Unlock()
+	return mock.EraseUserDataFunc(ctx, appKind, userID, dryRun)
+}
+
+// EraseUserDataCalls gets all the calls that were made to EraseUserData.
+// Check the length with:
+//
+//	len(mockedEraseUserDataService.EraseUserDataCalls())
+func (mock *EraseUserDataServiceMock) EraseUserDataCalls() []struct {
+	Ctx     context.Context
+	AppKind string
+	UserID  int64
+	DryRun  bool
+} {
+	var calls []struct {
+		Ctx     context.Context
+		AppKind string
+		UserID  int64
+		DryRun  bool
+	}
+	mock.lockEraseUserData.RLock()
+	calls = mock.calls.EraseUserData
+	mock.lockEraseUserData.RUnlock()
+	return calls
+}
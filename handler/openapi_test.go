@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"bytes"
+	"mime"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/openapi"
+)
+
+const openapiURL = "openapi.json"
+
+// openapiSpec は openapi.json を JSON Schema 2020-12 として読み込む。
+// Compiler は並行に使えないため、スキーマのコンパイルは mu で直列化する
+var openapiSpec struct {
+	once     sync.Once
+	mu       sync.Mutex
+	doc      any
+	compiler *jsonschema.Compiler
+	err      error
+}
+
+func loadOpenAPISpec(t *testing.T) any {
+	t.Helper()
+	openapiSpec.once.Do(func() {
+		openapiSpec.doc, openapiSpec.err = jsonschema.UnmarshalJSON(bytes.NewReader(openapi.Spec()))
+		if openapiSpec.err != nil {
+			return
+		}
+		openapiSpec.compiler = jsonschema.NewCompiler()
+		openapiSpec.err = openapiSpec.compiler.AddResource(openapiURL, openapiSpec.doc)
+	})
+	require.NoError(t, openapiSpec.err)
+	return openapiSpec.doc
+}
+
+// assertRequestConformsToSpec はリクエストボディが openapi.json の requestBody のスキーマに従うことを確認する
+func assertRequestConformsToSpec(t *testing.T, method, path string, body []byte) {
+	t.Helper()
+	doc := loadOpenAPISpec(t)
+	ptr, op := openapiOperation(t, doc, method, path)
+	rb, ok := op["requestBody"]
+	require.True(t, ok, "%s %s has no requestBody in openapi.json", method, path)
+	ptr, rbObj := resolveOpenAPIRef(t, doc, ptr+"/requestBody", rb)
+	validateOpenAPIContent(t, ptr, rbObj, "application/json", body)
+}
+
+// assertResponseConformsToSpec は JSON のレスポンスが openapi.json の responses のスキーマに従うことを確認する。
+// ステータスコードが定義されていない場合は default を使う
+func assertResponseConformsToSpec(t *testing.T, method, path string, w *httptest.ResponseRecorder) {
+	t.Helper()
+	doc := loadOpenAPISpec(t)
+	ptr, op := openapiOperation(t, doc, method, path)
+	responses, ok := op["responses"].(map[string]any)
+	require.True(t, ok, "%s %s has no responses in openapi.json", method, path)
+	status := strconv.Itoa(w.Code)
+	rsp, ok := responses[status]
+	if !ok {
+		status = "default"
+		rsp, ok = responses[status]
+	}
+	require.True(t, ok, "%s %s has no response for %d in openapi.json", method, path, w.Code)
+	ptr, rspObj := resolveOpenAPIRef(t, doc, ptr+"/responses/"+status, rsp)
+	mediaType, _, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	require.NoError(t, err)
+	validateOpenAPIContent(t, ptr, rspObj, mediaType, w.Body.Bytes())
+}
+
+func openapiOperation(t *testing.T, doc any, method, path string) (string, map[string]any) {
+	t.Helper()
+	paths := doc.(map[string]any)["paths"].(map[string]any)
+	item, ok := paths[path].(map[string]any)
+	require.True(t, ok, "path %s is not defined in openapi.json", path)
+	method = strings.ToLower(method)
+	op, ok := item[method].(map[string]any)
+	require.True(t, ok, "%s %s is not defined in openapi.json", method, path)
+	return "/paths/" + escapeJSONPointer(path) + "/" + method, op
+}
+
+// resolveOpenAPIRef は requestBody や response の $ref（#/components/... のみ）をたどり、参照先の JSON Pointer と値を返す
+func resolveOpenAPIRef(t *testing.T, doc any, ptr string, v any) (string, map[string]any) {
+	t.Helper()
+	obj, ok := v.(map[string]any)
+	require.True(t, ok, "%s is not an object", ptr)
+	ref, ok := obj["$ref"].(string)
+	if !ok {
+		return ptr, obj
+	}
+	require.True(t, strings.HasPrefix(ref, "#/"), "unsupported $ref %s", ref)
+	var cur any = doc
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		m, ok := cur.(map[string]any)
+		require.True(t, ok, "cannot resolve %s", ref)
+		cur = m[strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")]
+	}
+	return resolveOpenAPIRef(t, doc, strings.TrimPrefix(ref, "#"), cur)
+}
+
+func validateOpenAPIContent(t *testing.T, ptr string, obj map[string]any, mediaType string, body []byte) {
+	t.Helper()
+	content, ok := obj["content"].(map[string]any)
+	require.True(t, ok, "%s has no content", ptr)
+	// text/* や */* のような範囲指定のメディアタイプにも一致させる
+	key := mediaType
+	for _, k := range []string{mediaType, strings.SplitN(mediaType, "/", 2)[0] + "/*", "*/*"} {
+		if _, ok = content[k]; ok {
+			key = k
+			break
+		}
+	}
+	require.True(t, ok, "%s has no content for %s", ptr, mediaType)
+	openapiSpec.mu.Lock()
+	schema, err := openapiSpec.compiler.Compile(openapiURL + "#" + ptr + "/content/" + escapeJSONPointer(key) + "/schema")
+	openapiSpec.mu.Unlock()
+	require.NoError(t, err)
+	// JSON 以外（CSV や PDF など）は、本文を文字列として検証する
+	var instance any = string(body)
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		instance, err = jsonschema.UnmarshalJSON(bytes.NewReader(body))
+		require.NoError(t, err, string(body))
+	}
+	require.NoError(t, schema.Validate(instance), string(body))
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+// TestErrorCodes_OpenAPI は openapi.json の ErrResponse.code の enum がエラーコードのカタログと一致することを確認する
+func TestErrorCodes_OpenAPI(t *testing.T) {
+	doc := loadOpenAPISpec(t)
+	schema := doc.(map[string]any)["components"].(map[string]any)["schemas"].(map[string]any)["ErrResponse"].(map[string]any)
+	var documented []string
+	for _, c := range schema["properties"].(map[string]any)["code"].(map[string]any)["enum"].([]any) {
+		documented = append(documented, c.(string))
+	}
+	var codes []string
+	for _, c := range ErrorCodes() {
+		codes = append(codes, string(c))
+	}
+	assert.ElementsMatch(t, codes, documented)
+}
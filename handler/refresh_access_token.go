@@ -26,29 +26,16 @@ func (rat *RefreshAccessToken) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		ClientSecret string `json:"client_secret" validate:"required"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		RespondError(w, r, newDecodeError(err))
 		return
 	}
 	if err := rat.Validator.Struct(requestData); err != nil {
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: err.Error(),
-		}, http.StatusBadRequest)
+		RespondError(w, r, newValidationError(err))
 		return
 	}
 	accessToken, refreshToken, err := rat.Service.RefreshAccessToken(ctx, requestData.ClientID, requestData.ClientSecret)
 	if err != nil {
-		if serviceErr, ok := err.(*ServiceError); ok {
-			RespondJSON(ctx, w, &ErrResponse{
-				Message: serviceErr.Error(),
-				Detail:  serviceErr.DetailError(),
-			}, serviceErr.StatusCode)
-			return
-		}
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		RespondError(w, r, err)
 		return
 	}
 	rsp := struct {
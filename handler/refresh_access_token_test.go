@@ -26,7 +26,7 @@ func TestRefreshAccessToken_ServeHTTP(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Contains(t, errResp.Message, "invalid")
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
 	t.Run("validation error", func(t *testing.T) {
@@ -48,7 +48,7 @@ func TestRefreshAccessToken_ServeHTTP(t *testing.T) {
 		moq := &RefreshAccessTokenServiceMock{
 			RefreshAccessTokenFunc: func(ctx context.Context, client_id, client_secret string) (string, string, error) {
 				return "", "", NewServiceError(
-					http.StatusInternalServerError,
+					ErrCodeInternal,
 					"invalid credentials",
 					"please check your client_id or client_secret",
 				)
@@ -66,7 +66,7 @@ func TestRefreshAccessToken_ServeHTTP(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Equal(t, "invalid credentials", errResp.Message)
-		assert.Equal(t, "please check your client_id or client_secret", errResp.Detail)
+		assert.Empty(t, errResp.Detail)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
@@ -88,7 +88,8 @@ func TestRefreshAccessToken_ServeHTTP(t *testing.T) {
 		var errResp ErrResponse
 		err := json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
-		assert.Equal(t, "unexpected error", errResp.Message)
+		assert.Equal(t, ErrCodeInternal, errResp.Code)
+		assert.Empty(t, errResp.Detail)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
@@ -116,5 +117,6 @@ func TestRefreshAccessToken_ServeHTTP(t *testing.T) {
 		assert.Equal(t, "access-token-123", rsp.AccessToken)
 		assert.Equal(t, "refresh-token-456", rsp.RefreshToken)
 		assert.Equal(t, http.StatusOK, w.Code)
+		assertResponseConformsToSpec(t, http.MethodPost, "/messages/token", w)
 	})
 }
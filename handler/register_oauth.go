@@ -30,39 +30,23 @@ func (ro *RegisterOAuth) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	apiKey, err := extractAuthorizationHeader(r)
 	if err != nil {
-		RespondJSON(ctx, w, ErrResponse{
-			Message: "invalid_api_key",
-			Detail:  err.Error(),
-		}, http.StatusUnauthorized)
+		RespondError(w, r, NewServiceError(ErrCodeMissingAuthorization, "invalid_api_key", err.Error()))
 		return
 	}
 	requestData.APIKey = apiKey
 	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		RespondError(w, r, newDecodeError(err))
 		return
 	}
 	if err := ro.Validator.Struct(requestData); err != nil {
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: err.Error(),
-		}, http.StatusBadRequest)
+		RespondError(w, r, newValidationError(err))
 		return
 	}
 	ctx = request.SetAppKind(ctx, requestData.AppKind)
 	ctx = request.SetUserID(ctx, requestData.UserID)
 	err = ro.Service.RegisterOAuth(ctx, requestData.APIKey)
 	if err != nil {
-		if serviceErr, ok := err.(*ServiceError); ok {
-			RespondJSON(ctx, w, &ErrResponse{
-				Message: serviceErr.Error(),
-				Detail:  serviceErr.DetailError(),
-			}, serviceErr.StatusCode)
-			return
-		}
-		RespondJSON(ctx, w, &ErrResponse{
-			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		RespondError(w, r, err)
 		return
 	}
 	RespondJSON(ctx, w, &SuccessResponse{
@@ -71,7 +71,7 @@ func TestRegisterOAuth_ServeHTTP(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Contains(t, errResp.Message, "invalid")
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
 	t.Run("validation error", func(t *testing.T) {
@@ -97,7 +97,7 @@ func TestRegisterOAuth_ServeHTTP(t *testing.T) {
 		moq := &RegisterOAuthServiceMock{
 			RegisterOAuthFunc: func(ctx context.Context, apiKey string) error {
 				return NewServiceError(
-					http.StatusInternalServerError,
+					ErrCodeInternal,
 					"forbidden operation",
 					"cannot register OAuth",
 				)
@@ -116,7 +116,7 @@ func TestRegisterOAuth_ServeHTTP(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Equal(t, "forbidden operation", errResp.Message)
-		assert.Equal(t, "cannot register OAuth", errResp.Detail)
+		assert.Empty(t, errResp.Detail)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
@@ -139,7 +139,8 @@ func TestRegisterOAuth_ServeHTTP(t *testing.T) {
 		var errResp ErrResponse
 		err := json.Unmarshal(w.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
-		assert.Equal(t, "unexpected error", errResp.Message)
+		assert.Equal(t, ErrCodeInternal, errResp.Code)
+		assert.Empty(t, errResp.Detail)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
@@ -164,5 +165,7 @@ func TestRegisterOAuth_ServeHTTP(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "OAuth registration was successful", successResp.Message)
 		assert.Equal(t, http.StatusOK, w.Code)
+		assertRequestConformsToSpec(t, http.MethodPost, "/messages/register", body)
+		assertResponseConformsToSpec(t, http.MethodPost, "/messages/register", w)
 	})
 }
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+
+	"github.com/yuyacode/AppLiftMessageApi/request"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// 呼び出し元から受け取る X-Request-ID は、ログを汚さないよう英数字と一部の記号に限る
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestIDMiddleware は X-Request-ID を引き継ぐか新たに発行し、context とレスポンスヘッダーに設定する
+func RequestIDMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(requestIDHeader)
+			if !validRequestID.MatchString(requestID) {
+				requestID = newRequestID()
+			}
+			w.Header().Set(requestIDHeader, requestID)
+			ctx := request.SetRequestID(r.Context(), requestID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
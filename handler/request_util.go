@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -20,3 +21,20 @@ func extractAuthorizationHeader(r *http.Request) (string, error) {
 	}
 	return authorizationHeader, nil
 }
+
+// newDecodeError はリクエストボディの読み込みエラーを ServiceError に変換する。
+// BodyLimitMiddleware の上限を超えた場合は 413、それ以外は JSON として不正なリクエストとして 400 とする
+func newDecodeError(err error) *ServiceError {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return NewServiceError(ErrCodeRequestTooLarge, "request body is too large", fmt.Sprintf("request body must be at most %d bytes", maxBytesErr.Limit))
+	}
+	return NewServiceError(ErrCodeInvalidJSON, "invalid JSON format", err.Error())
+}
+
+// newValidationError は validator のエラーを、レスポンスの言語で翻訳できる ServiceError に変換する
+func newValidationError(err error) *ServiceError {
+	se := NewServiceError(ErrCodeValidationFailed, err.Error(), "")
+	errors.As(err, &se.validationErrors)
+	return se
+}
@@ -3,8 +3,12 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+
+	"github.com/yuyacode/AppLiftMessageApi/request"
 )
 
 type SuccessResponse struct {
@@ -12,26 +16,116 @@ type SuccessResponse struct {
 	Detail  string `json:"detail,omitempty"`
 }
 
+// ErrResponse は RFC 9457 の Problem Details。code, message, request_id は拡張メンバーで、
+// message は code の導入前からのエラーメッセージ（既存のクライアントのために残している）
 type ErrResponse struct {
-	Message string `json:"message"`
-	Detail  string `json:"detail,omitempty"`
+	Type      string    `json:"type"`
+	Title     string    `json:"title"`
+	Status    int       `json:"status"`
+	Detail    string    `json:"detail,omitempty"`
+	Instance  string    `json:"instance,omitempty"`
+	Code      ErrorCode `json:"code"`
+	Message   string    `json:"message"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+const (
+	problemContentType = "application/problem+json"
+	problemTypePrefix  = "urn:applift-message-api:error:"
+)
+
+// ErrorDetailMiddleware は expose が true の場合、5xx のエラーの詳細をレスポンスに含める。
+// 指定がない場合、5xx の詳細（DB のエラーなど内部の情報を含みうる）はログにのみ出力し、レスポンスからは除く
+func ErrorDetailMiddleware(expose bool) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if expose {
+				r = r.WithContext(request.SetExposeErrorDetail(r.Context()))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
 func RespondJSON(ctx context.Context, w http.ResponseWriter, body any, status int) {
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	writeJSON(ctx, w, "application/json; charset=utf-8", body, status)
+}
+
+// RespondError はエラーを Problem Details で、Accept-Language の言語で返す。ServiceError 以外のエラーは 500 の internal_error とし、
+// エラーの内容は Detail に入れる。ログには翻訳前のメッセージを出力する
+func RespondError(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+	var se *ServiceError
+	if !errors.As(err, &se) {
+		se = NewServiceError(ErrCodeInternal, "internal server error", err.Error())
+	}
+	trans := requestTranslator(r)
+	title, message := localize(trans, se)
+	rsp := &ErrResponse{
+		Type:     problemTypePrefix + string(se.Code),
+		Title:    title,
+		Status:   se.StatusCode,
+		Detail:   se.Detail,
+		Instance: r.URL.Path,
+		Code:     se.Code,
+		Message:  message,
+	}
+	if requestID, ok := request.GetRequestID(ctx); ok {
+		rsp.RequestID = requestID
+	}
+	level := slog.LevelWarn
+	if se.StatusCode >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	slog.LogAttrs(ctx, level, "error response",
+		slog.Int("status", se.StatusCode),
+		slog.String("code", string(se.Code)),
+		slog.String("message", se.Message),
+		slog.String("detail", se.Detail),
+	)
+	// 4xx の詳細（不足している変数名など）はクライアントが対処に使うため、常に返す
+	if se.StatusCode >= http.StatusInternalServerError && !request.GetExposeErrorDetail(ctx) {
+		rsp.Detail = ""
+	}
+	// 503 はデッドロックなど一時的なエラーのため、クライアントにリトライを促す
+	if se.StatusCode == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", "1")
+	}
+	w.Header().Set("Content-Language", trans.Locale())
+	w.Header().Add("Vary", "Accept-Language")
+	writeJSON(ctx, w, problemContentType, rsp, se.StatusCode)
+}
+
+func writeJSON(ctx context.Context, w http.ResponseWriter, contentType string, body any, status int) {
+	w.Header().Set("Content-Type", contentType)
 	bodyBytes, err := json.Marshal(body)
 	if err != nil {
+		slog.ErrorContext(ctx, "failed to marshal response", slog.String("error", err.Error()))
+		w.Header().Set("Content-Type", problemContentType)
 		w.WriteHeader(http.StatusInternalServerError)
 		rsp := ErrResponse{
+			Type:    problemTypePrefix + string(ErrCodeInternal),
+			Title:   ErrCodeInternal.Title(),
+			Status:  http.StatusInternalServerError,
+			Code:    ErrCodeInternal,
 			Message: http.StatusText(http.StatusInternalServerError),
 		}
 		if err := json.NewEncoder(w).Encode(rsp); err != nil {
-			fmt.Printf("write error response error: %v", err)
+			slog.ErrorContext(ctx, "failed to write error response", slog.String("error", err.Error()))
 		}
 		return
 	}
 	w.WriteHeader(status)
 	if _, err := fmt.Fprintf(w, "%s", bodyBytes); err != nil {
-		fmt.Printf("write response error: %v", err)
+		slog.ErrorContext(ctx, "failed to write response", slog.String("error", err.Error()))
 	}
 }
+
+// NotFound と MethodNotAllowed は、ルーターが返すエラーも Problem Details に揃える
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	RespondError(w, r, NewServiceError(ErrCodeNotFound, "not found", ""))
+}
+
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	RespondError(w, r, NewServiceError(ErrCodeMethodNotAllowed, "method not allowed", ""))
+}
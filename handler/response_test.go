@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/request"
+)
+
+func TestRespondError_ErrorDetail(t *testing.T) {
+	defaultLogger := slog.Default()
+	t.Cleanup(func() {
+		slog.SetDefault(defaultLogger)
+	})
+	internalErr := NewServiceError(ErrCodeInternal, "failed to get messages", "sql: connection refused")
+	tests := map[string]struct {
+		middleware func(http.Handler) http.Handler
+		err        *ServiceError
+		wantStatus int
+		wantDetail string
+	}{
+		"exposed": {
+			middleware: ErrorDetailMiddleware(true),
+			err:        internalErr,
+			wantStatus: http.StatusInternalServerError,
+			wantDetail: "sql: connection refused",
+		},
+		"hidden": {
+			middleware: ErrorDetailMiddleware(false),
+			err:        internalErr,
+			wantStatus: http.StatusInternalServerError,
+			wantDetail: "",
+		},
+		"hidden by default": {
+			err:        internalErr,
+			wantStatus: http.StatusInternalServerError,
+			wantDetail: "",
+		},
+		"4xx detail is not hidden": {
+			middleware: ErrorDetailMiddleware(false),
+			err:        NewServiceError(ErrCodeTemplateVariablesMissing, "missing template variables", "recipients[1]: interview_date"),
+			wantStatus: http.StatusBadRequest,
+			wantDetail: "recipients[1]: interview_date",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var logs bytes.Buffer
+			slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+			var h http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				RespondError(w, r, tt.err)
+			})
+			if tt.middleware != nil {
+				h = tt.middleware(h)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/messages", nil))
+
+			var errResp ErrResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.err.Message, errResp.Message)
+			assert.Equal(t, tt.wantDetail, errResp.Detail)
+
+			var log map[string]any
+			require.NoError(t, json.Unmarshal(logs.Bytes(), &log))
+			assert.Equal(t, string(tt.err.Code), log["code"])
+			assert.Equal(t, tt.err.Detail, log["detail"])
+		})
+	}
+
+	t.Run("success response is not logged", func(t *testing.T) {
+		var logs bytes.Buffer
+		slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+		w := httptest.NewRecorder()
+		RespondJSON(context.Background(), w, &SuccessResponse{Message: "ok", Detail: "kept"}, http.StatusOK)
+		assert.JSONEq(t, `{"message":"ok","detail":"kept"}`, w.Body.String())
+		assert.Empty(t, logs.String())
+	})
+}
+
+func TestRespondError_ProblemDetails(t *testing.T) {
+	t.Run("ServiceError", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPatch, "/messages/3", nil)
+		r = r.WithContext(request.SetRequestID(r.Context(), "req-1"))
+		RespondError(w, r, NewServiceError(ErrCodeMessageNotFound, "message not found", ""))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"type": "urn:applift-message-api:error:message_not_found",
+			"title": "Message not found",
+			"status": 404,
+			"instance": "/messages/3",
+			"code": "message_not_found",
+			"message": "message not found",
+			"request_id": "req-1"
+		}`, w.Body.String())
+	})
+
+	t.Run("other errors are internal_error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/messages", nil)
+		r = r.WithContext(request.SetExposeErrorDetail(r.Context()))
+		RespondError(w, r, errors.New("unexpected error"))
+
+		var errResp ErrResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, ErrCodeInternal, errResp.Code)
+		assert.Equal(t, "internal server error", errResp.Message)
+		assert.Equal(t, "unexpected error", errResp.Detail)
+	})
+
+	t.Run("503 has Retry-After", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/messages", nil)
+		RespondError(w, r, NewServiceError(ErrCodeTemporarilyUnavailable, "failed to get messages", "deadlock"))
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	})
+}
+
+func TestErrorCode(t *testing.T) {
+	for _, code := range ErrorCodes() {
+		assert.NotEmpty(t, code.Title(), code)
+		assert.GreaterOrEqual(t, code.Status(), http.StatusBadRequest, code)
+	}
+	assert.Equal(t, http.StatusInternalServerError, ErrorCode("unknown").Status())
+}
+
+func TestNotFoundAndMethodNotAllowed(t *testing.T) {
+	w := httptest.NewRecorder()
+	NotFound(w, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"not_found"`)
+
+	w = httptest.NewRecorder()
+	MethodNotAllowed(w, httptest.NewRequest(http.MethodPut, "/messages", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"method_not_allowed"`)
+}
@@ -1,9 +1,16 @@
 package handler
 
+import "github.com/go-playground/validator/v10"
+
+// ServiceError は code と、利用者向けのメッセージ、ログ向けの詳細を持つエラー。StatusCode は code から決まる
 type ServiceError struct {
+	Code       ErrorCode
 	StatusCode int
 	Message    string
 	Detail     string
+
+	// validationErrors はレスポンスの言語でメッセージを組み立てるために、バリデーションのエラーを保持する
+	validationErrors validator.ValidationErrors
 }
 
 func (se *ServiceError) Error() string {
@@ -14,9 +21,10 @@ func (se *ServiceError) DetailError() string {
 	return se.Detail
 }
 
-func NewServiceError(statusCode int, message, detail string) *ServiceError {
+func NewServiceError(code ErrorCode, message, detail string) *ServiceError {
 	return &ServiceError{
-		StatusCode: statusCode,
+		Code:       code,
+		StatusCode: code.Status(),
 		Message:    message,
 		Detail:     detail,
 	}
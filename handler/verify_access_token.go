@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 
+	"github.com/yuyacode/AppLiftMessageApi/metrics"
 	"github.com/yuyacode/AppLiftMessageApi/request"
 )
 
@@ -12,24 +13,14 @@ func VerifyAccessTokenMiddleware(vat VerifyAccessTokenService) func(next http.Ha
 			ctx := r.Context()
 			accessToken, err := extractAuthorizationHeader(r)
 			if err != nil {
-				RespondJSON(ctx, w, ErrResponse{
-					Message: "invalid_token",
-					Detail:  err.Error(),
-				}, http.StatusUnauthorized)
+				metrics.TokenVerificationFailures.WithLabelValues("access", string(ErrCodeMissingAuthorization)).Inc()
+				RespondError(w, r, NewServiceError(ErrCodeMissingAuthorization, "invalid_token", err.Error()))
 				return
 			}
 			appKind, userID, err := vat.VerifyAccessToken(ctx, accessToken)
 			if err != nil {
-				if serviceErr, ok := err.(*ServiceError); ok {
-					RespondJSON(ctx, w, &ErrResponse{
-						Message: serviceErr.Error(),
-						Detail:  serviceErr.DetailError(),
-					}, serviceErr.StatusCode)
-					return
-				}
-				RespondJSON(ctx, w, &ErrResponse{
-					Message: err.Error(),
-				}, http.StatusInternalServerError)
+				countTokenVerificationFailure("access", err)
+				RespondError(w, r, err)
 				return
 			}
 			ctx = request.SetAppKind(ctx, appKind)
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+)
+
+// VerifyAdminAPIKeyMiddleware は管理用APIを X-Admin-API-Key ヘッダーで保護する。
+// adminAPIKey が未設定の場合は全てのリクエストを拒否する
+func VerifyAdminAPIKeyMiddleware(adminAPIKey string) func(next http.Handler) http.Handler {
+	expected := sha256.Sum256([]byte(adminAPIKey))
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey := r.Header.Get("X-Admin-API-Key")
+			// 長さの違いから推測されないよう、ハッシュ同士を比較する
+			actual := sha256.Sum256([]byte(apiKey))
+			if adminAPIKey == "" || apiKey == "" || subtle.ConstantTimeCompare(expected[:], actual[:]) != 1 {
+				RespondError(w, r, NewServiceError(ErrCodeInvalidAPIKey, "invalid admin API key", ""))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
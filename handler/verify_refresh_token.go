@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/yuyacode/AppLiftMessageApi/metrics"
 	"github.com/yuyacode/AppLiftMessageApi/request"
 )
 
@@ -15,41 +16,25 @@ func VerifyRefreshTokenMiddleware(vrt VerifyRefreshTokenService) func(next http.
 			ctx := r.Context()
 			body, err := io.ReadAll(r.Body)
 			if err != nil {
-				RespondJSON(ctx, w, ErrResponse{
-					Message: "failed to read request body",
-					Detail:  err.Error(),
-				}, http.StatusInternalServerError)
+				RespondError(w, r, newDecodeError(err))
 				return
 			}
 			r.Body = io.NopCloser(bytes.NewBuffer(body))
 			var b map[string]interface{}
 			if err := json.Unmarshal(body, &b); err != nil {
-				RespondJSON(ctx, w, ErrResponse{
-					Message: "invalid JSON format",
-					Detail:  err.Error(),
-				}, http.StatusBadRequest)
+				RespondError(w, r, newDecodeError(err))
 				return
 			}
 			refresh_token, ok := b["refresh_token"].(string)
 			if !ok || refresh_token == "" {
-				RespondJSON(ctx, w, ErrResponse{
-					Message: "invalid_token",
-					Detail:  "invalid refresh token",
-				}, http.StatusUnauthorized)
+				metrics.TokenVerificationFailures.WithLabelValues("refresh", "invalid_token").Inc()
+				RespondError(w, r, NewServiceError(ErrCodeInvalidToken, "invalid_token", "invalid refresh token"))
 				return
 			}
 			appKind, userID, err := vrt.VerifyRefreshToken(ctx, refresh_token)
 			if err != nil {
-				if serviceErr, ok := err.(*ServiceError); ok {
-					RespondJSON(ctx, w, &ErrResponse{
-						Message: serviceErr.Error(),
-						Detail:  serviceErr.DetailError(),
-					}, serviceErr.StatusCode)
-					return
-				}
-				RespondJSON(ctx, w, &ErrResponse{
-					Message: err.Error(),
-				}, http.StatusInternalServerError)
+				countTokenVerificationFailure("refresh", err)
+				RespondError(w, r, err)
 				return
 			}
 			ctx = request.SetAppKind(ctx, appKind)
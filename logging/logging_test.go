@@ -0,0 +1,36 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/request"
+)
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "info")
+	require.NoError(t, err)
+
+	ctx := request.SetRequestID(context.Background(), "req-1")
+	logger.With(slog.String("component", "test")).DebugContext(ctx, "hidden")
+	logger.With(slog.String("component", "test")).InfoContext(ctx, "shown", slog.Int("status", 200))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "shown", got["msg"])
+	assert.Equal(t, "req-1", got["request_id"])
+	assert.Equal(t, "test", got["component"])
+	assert.Equal(t, float64(200), got["status"])
+}
+
+func TestNew_InvalidLevel(t *testing.T) {
+	_, err := New(&bytes.Buffer{}, "verbose")
+	assert.Error(t, err)
+}
@@ -2,13 +2,18 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"net"
 	"os"
 	"time"
 
 	"github.com/yuyacode/AppLiftMessageApi/config"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/logging"
+	"github.com/yuyacode/AppLiftMessageApi/tracing"
 )
 
 func init() {
@@ -21,21 +26,42 @@ func init() {
 
 func main() {
 	if err := run(context.Background()); err != nil {
-		log.Printf("failed to terminate server: %v", err)
+		slog.Error("failed to terminate server", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
 }
 
 func run(ctx context.Context) error {
-	cfg, err := config.NewConfig()
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML config file")
+	printConfig := flag.Bool("print-config", false, "print the effective config with secrets redacted and exit")
+	flag.Parse()
+	cfg, err := config.Load(*configFile)
 	if err != nil {
 		return err
 	}
+	if *printConfig {
+		return config.Print(os.Stdout, cfg)
+	}
+	logger, err := logging.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+	slog.SetDefault(logger)
+	shutdownTracing, err := tracing.Setup(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("failed to shutdown tracing", slog.String("error", err.Error()))
+		}
+	}()
 	l, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
 	if err != nil {
-		log.Fatalf("failed to listen port %d: %v", cfg.Port, err)
+		return fmt.Errorf("failed to listen port %d: %w", cfg.Port, err)
 	}
-	mux, dbCloseFuncs, err := NewMux(ctx, cfg)
+	readiness := handler.NewReadiness()
+	mux, dbCloseFuncs, err := NewMux(ctx, cfg, readiness)
 	if err != nil {
 		for _, f := range dbCloseFuncs {
 			f()
@@ -47,6 +73,10 @@ func run(ctx context.Context) error {
 			f()
 		}(f)
 	}
-	s := NewServer(l, mux)
+	s, err := NewServer(l, mux, readiness, cfg)
+	if err != nil {
+		_ = l.Close()
+		return err
+	}
 	return s.Run(ctx)
 }
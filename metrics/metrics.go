@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "applift_message_api"
+
+var (
+	// HTTPRequests と HTTPRequestDuration の route は chi のルートパターン（/messages/{id} など）
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by method, route pattern and status.",
+	}, []string{"method", "route", "status"})
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route pattern and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+	MessagesSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_sent_total",
+		Help:      "Number of messages sent (drafts are not counted).",
+	}, []string{"app_kind"})
+	// TokensIssued の grant は register（初回発行）か refresh（再発行）
+	TokensIssued = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tokens_issued_total",
+		Help:      "Number of access and refresh token pairs issued.",
+	}, []string{"app_kind", "grant"})
+	// TokenVerificationFailures の token は access か refresh、reason はエラーコード（token_expired や invalid_token など）
+	TokenVerificationFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "token_verification_failures_total",
+		Help:      "Number of rejected tokens by token type and reason.",
+	}, []string{"token", "reason"})
+	Registrations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "registrations_total",
+		Help:      "Number of successful OAuth registrations.",
+	}, []string{"app_kind"})
+)
+
+// NewRegistry はアプリケーションのメトリクスに加え、Go ランタイム、プロセス、DB コネクションプールの統計を集める Registry を返す。
+// 上記の変数は複数の Registry に登録できるため、NewMux を呼ぶたびに作り直してよい
+func NewRegistry(dbHandlers map[string]*sqlx.DB) (*prometheus.Registry, error) {
+	reg := prometheus.NewRegistry()
+	cs := []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		MessagesSent,
+		TokensIssued,
+		TokenVerificationFailures,
+		Registrations,
+	}
+	for name, db := range dbHandlers {
+		cs = append(cs, collectors.NewDBStatsCollector(db.DB, name))
+	}
+	for _, c := range cs {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return reg, nil
+}
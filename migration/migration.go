@@ -0,0 +1,254 @@
+package migration
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+)
+
+// sql/<ドライバー名>/<DB名>/<バージョン>_<名前>.(up|down).sql を埋め込む。
+// 1ファイルに複数の文を書く場合は、文末の ; で区切る（文字列やコメントの中に ; は書かない）
+//
+//go:embed sql
+var sqlFS embed.FS
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Load は DB ドライバー（mysql, sqlite）と対象DB（company, student, common）のマイグレーションをバージョンの昇順で返す
+func Load(driverName, targetDB string) ([]*Migration, error) {
+	if _, err := fs.Stat(sqlFS, path.Join("sql", driverName)); err != nil {
+		return nil, fmt.Errorf("unsupported db driver: %s", driverName)
+	}
+	dir := path.Join("sql", driverName, targetDB)
+	entries, err := fs.ReadDir(sqlFS, dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target db: %s", targetDB)
+	}
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		matches := fileNamePattern.FindStringSubmatch(e.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", e.Name())
+		}
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		content, err := fs.ReadFile(sqlFS, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		} else if m.Name != matches[2] {
+			return nil, fmt.Errorf("duplicate migration version: %d", version)
+		}
+		if matches[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Migrator は DB ごとの schema_migrations テーブルで適用済みのバージョンを管理する。
+// MySQL の DDL は暗黙的にコミットされるため、1つのマイグレーションの途中で失敗した場合は手動での復旧が必要になる
+type Migrator struct {
+	DB         *sqlx.DB
+	Migrations []*Migration
+	Clocker    clock.Clocker
+}
+
+func NewMigrator(db *sqlx.DB, migrations []*Migration, clocker clock.Clocker) *Migrator {
+	return &Migrator{
+		DB:         db,
+		Migrations: migrations,
+		Clocker:    clocker,
+	}
+}
+
+// Up は未適用のマイグレーションを全て適用する
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	if len(m.Migrations) == 0 {
+		return nil, nil
+	}
+	return m.To(ctx, m.Migrations[len(m.Migrations)-1].Version)
+}
+
+// Down は最後に適用したマイグレーションを1つ戻す。適用済みのものがなければ nil を返す
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(m.Migrations) - 1; i >= 0; i-- {
+		if _, ok := applied[m.Migrations[i].Version]; ok {
+			return m.Migrations[i], m.revert(ctx, m.Migrations[i])
+		}
+	}
+	return nil, nil
+}
+
+// To は version 以下を適用済み、version より後ろを未適用の状態にする。version が 0 の場合は全て戻す
+func (m *Migrator) To(ctx context.Context, version int64) ([]*Migration, error) {
+	if version != 0 && m.find(version) == nil {
+		return nil, fmt.Errorf("unknown migration version: %d", version)
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for v := range applied {
+		if v > version && m.find(v) == nil {
+			return nil, fmt.Errorf("applied migration %d is not found in this build", v)
+		}
+	}
+	var done []*Migration
+	for i := len(m.Migrations) - 1; i >= 0; i-- {
+		mg := m.Migrations[i]
+		if _, ok := applied[mg.Version]; ok && mg.Version > version {
+			if err := m.revert(ctx, mg); err != nil {
+				return done, err
+			}
+			done = append(done, mg)
+		}
+	}
+	for _, mg := range m.Migrations {
+		if _, ok := applied[mg.Version]; !ok && mg.Version <= version {
+			if err := m.apply(ctx, mg); err != nil {
+				return done, err
+			}
+			done = append(done, mg)
+		}
+	}
+	return done, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]*Status, 0, len(m.Migrations))
+	for _, mg := range m.Migrations {
+		appliedAt, ok := applied[mg.Version]
+		statuses = append(statuses, &Status{
+			Version:   mg.Version,
+			Name:      mg.Name,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		})
+	}
+	return statuses, nil
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for _, mg := range m.Migrations {
+		if mg.Version == version {
+			return mg
+		}
+	}
+	return nil
+}
+
+// applied は適用済みのバージョンと適用日時を返す。管理用のテーブルがなければ作成する
+func (m *Migrator) applied(ctx context.Context) (map[int64]time.Time, error) {
+	query := "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL, name VARCHAR(255) NOT NULL, applied_at DATETIME NOT NULL, PRIMARY KEY (version)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;"
+	if m.DB.DriverName() == "sqlite" {
+		query = "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL, name VARCHAR(255) NOT NULL, applied_at DATETIME NOT NULL, PRIMARY KEY (version));"
+	}
+	if _, err := m.DB.ExecContext(ctx, query); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	rows, err := m.DB.QueryxContext(ctx, "SELECT version, applied_at FROM schema_migrations ORDER BY version ASC;")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+	defer rows.Close()
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+func (m *Migrator) apply(ctx context.Context, mg *Migration) error {
+	for _, stmt := range splitStatements(mg.Up) {
+		if _, err := m.DB.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to apply migration %d_%s: %w", mg.Version, mg.Name, err)
+		}
+	}
+	query := "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?);"
+	if _, err := m.DB.ExecContext(ctx, query, mg.Version, mg.Name, m.Clocker.Now()); err != nil {
+		return fmt.Errorf("failed to record migration %d_%s: %w", mg.Version, mg.Name, err)
+	}
+	return nil
+}
+
+func (m *Migrator) revert(ctx context.Context, mg *Migration) error {
+	for _, stmt := range splitStatements(mg.Down) {
+		if _, err := m.DB.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to revert migration %d_%s: %w", mg.Version, mg.Name, err)
+		}
+	}
+	if _, err := m.DB.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?;", mg.Version); err != nil {
+		return fmt.Errorf("failed to record migration %d_%s: %w", mg.Version, mg.Name, err)
+	}
+	return nil
+}
+
+func splitStatements(s string) []string {
+	var stmts []string
+	for _, stmt := range strings.Split(s, ";") {
+		if stmt = strings.TrimSpace(stmt); stmt != "" {
+			stmts = append(stmts, stmt+";")
+		}
+	}
+	return stmts
+}
@@ -0,0 +1,159 @@
+package migration
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+)
+
+func TestLoad(t *testing.T) {
+	wantTables := map[string][]string{
+		"common":  {"message_threads", "messages", "message_attachments", "message_reactions"},
+		"company": {"message_api_keys", "message_api_credentials", "message_templates"},
+		"student": {"message_api_keys", "message_api_credentials"},
+	}
+	for _, driverName := range []string{"mysql", "sqlite"} {
+		for targetDB, tables := range wantTables {
+			t.Run(driverName+"/"+targetDB, func(t *testing.T) {
+				migrations, err := Load(driverName, targetDB)
+				require.NoError(t, err)
+				require.Len(t, migrations, len(tables))
+				for i, m := range migrations {
+					assert.Equal(t, int64(i+1), m.Version)
+					assert.Equal(t, "create_"+tables[i], m.Name)
+					assert.Contains(t, m.Up, "CREATE TABLE "+tables[i]+" (")
+					assert.Equal(t, []string{"DROP TABLE " + tables[i] + ";"}, splitStatements(m.Down))
+				}
+			})
+		}
+	}
+	t.Run("invalid target", func(t *testing.T) {
+		_, err := Load("mysql", "admin")
+		assert.Error(t, err)
+	})
+	t.Run("unsupported driver", func(t *testing.T) {
+		_, err := Load("memory", "common")
+		assert.EqualError(t, err, "unsupported db driver: memory")
+	})
+}
+
+func TestSplitStatements(t *testing.T) {
+	got := splitStatements("CREATE TABLE a (id INT);\n\nCREATE INDEX idx_a ON a (id);\n")
+	assert.Equal(t, []string{"CREATE TABLE a (id INT);", "CREATE INDEX idx_a ON a (id);"}, got)
+}
+
+func TestMigrator(t *testing.T) {
+	migrations := []*Migration{
+		{Version: 1, Name: "create_a", Up: "CREATE TABLE a (id INT);", Down: "DROP TABLE a;"},
+		{Version: 2, Name: "create_b", Up: "CREATE TABLE b (id INT);", Down: "DROP TABLE b;"},
+		{Version: 3, Name: "create_c", Up: "CREATE TABLE c (id INT);", Down: "DROP TABLE c;"},
+	}
+	appliedAt := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	expectApplied := func(mock sqlmock.Sqlmock, versions ...int64) {
+		mock.ExpectExec(`^CREATE TABLE IF NOT EXISTS schema_migrations `).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		rows := sqlmock.NewRows([]string{"version", "applied_at"})
+		for _, v := range versions {
+			rows.AddRow(v, appliedAt)
+		}
+		mock.ExpectQuery(`^SELECT version, applied_at FROM schema_migrations ORDER BY version ASC;$`).
+			WillReturnRows(rows)
+	}
+	expectUp := func(mock sqlmock.Sqlmock, m *Migration) {
+		mock.ExpectExec("^" + regexp.QuoteMeta(m.Up) + "$").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`^INSERT INTO schema_migrations \(version, name, applied_at\) VALUES \(\?, \?, \?\);$`).
+			WithArgs(m.Version, m.Name, clock.FixedClocker{}.Now()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	expectDown := func(mock sqlmock.Sqlmock, m *Migration) {
+		mock.ExpectExec("^" + regexp.QuoteMeta(m.Down) + "$").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`^DELETE FROM schema_migrations WHERE version = \?;$`).
+			WithArgs(m.Version).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	newMigrator := func(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+		return NewMigrator(sqlx.NewDb(db, "sqlmock"), migrations, clock.FixedClocker{}), mock
+	}
+
+	t.Run("up applies only pending migrations", func(t *testing.T) {
+		m, mock := newMigrator(t)
+		expectApplied(mock, 1)
+		expectUp(mock, migrations[1])
+		expectUp(mock, migrations[2])
+		done, err := m.Up(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, migrations[1:], done)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("down reverts the latest applied migration", func(t *testing.T) {
+		m, mock := newMigrator(t)
+		expectApplied(mock, 1, 2)
+		expectDown(mock, migrations[1])
+		done, err := m.Down(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, migrations[1], done)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("down does nothing when no migration is applied", func(t *testing.T) {
+		m, mock := newMigrator(t)
+		expectApplied(mock)
+		done, err := m.Down(context.Background())
+		assert.NoError(t, err)
+		assert.Nil(t, done)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("to reverts newer migrations in descending order", func(t *testing.T) {
+		m, mock := newMigrator(t)
+		expectApplied(mock, 1, 2, 3)
+		expectDown(mock, migrations[2])
+		expectDown(mock, migrations[1])
+		done, err := m.To(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []*Migration{migrations[2], migrations[1]}, done)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("to unknown version", func(t *testing.T) {
+		m, mock := newMigrator(t)
+		_, err := m.To(context.Background(), 9)
+		assert.EqualError(t, err, "unknown migration version: 9")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("to fails when an applied migration is missing", func(t *testing.T) {
+		m, mock := newMigrator(t)
+		expectApplied(mock, 1, 2, 3, 4)
+		_, err := m.To(context.Background(), 3)
+		assert.EqualError(t, err, "applied migration 4 is not found in this build")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("status", func(t *testing.T) {
+		m, mock := newMigrator(t)
+		expectApplied(mock, 1)
+		statuses, err := m.Status(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []*Status{
+			{Version: 1, Name: "create_a", Applied: true, AppliedAt: appliedAt},
+			{Version: 2, Name: "create_b"},
+			{Version: 3, Name: "create_c"},
+		}, statuses)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,11 @@
+CREATE TABLE message_threads (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    company_user_id BIGINT NOT NULL,
+    student_user_id BIGINT NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NULL,
+    deleted_at DATETIME NULL,
+    PRIMARY KEY (id),
+    KEY idx_message_threads_company_user_id (company_user_id),
+    KEY idx_message_threads_student_user_id (student_user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
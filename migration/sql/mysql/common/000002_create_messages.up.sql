@@ -0,0 +1,18 @@
+CREATE TABLE messages (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    message_thread_id BIGINT NOT NULL,
+    is_from_company TINYINT NOT NULL DEFAULT 0,
+    is_from_student TINYINT NOT NULL DEFAULT 0,
+    content TEXT NOT NULL,
+    is_sent TINYINT NOT NULL DEFAULT 0,
+    sent_at DATETIME NOT NULL,
+    reply_to_message_id BIGINT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NULL,
+    deleted_at DATETIME NULL,
+    PRIMARY KEY (id),
+    KEY idx_messages_message_thread_id_sent_at (message_thread_id, sent_at),
+    KEY idx_messages_reply_to_message_id (reply_to_message_id),
+    KEY idx_messages_deleted_at (deleted_at),
+    CONSTRAINT fk_messages_message_thread_id FOREIGN KEY (message_thread_id) REFERENCES message_threads (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
@@ -0,0 +1,11 @@
+CREATE TABLE message_reactions (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    message_id BIGINT NOT NULL,
+    is_from_company TINYINT NOT NULL DEFAULT 0,
+    is_from_student TINYINT NOT NULL DEFAULT 0,
+    reaction VARCHAR(64) NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_message_reactions_sender_reaction (message_id, is_from_company, is_from_student, reaction),
+    CONSTRAINT fk_message_reactions_message_id FOREIGN KEY (message_id) REFERENCES messages (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
@@ -0,0 +1,8 @@
+CREATE TABLE message_api_keys (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    api_key VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    deleted_at DATETIME NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_message_api_keys_api_key (api_key)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
@@ -0,0 +1,18 @@
+CREATE TABLE message_api_credentials (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    user_id BIGINT NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    client_secret VARCHAR(255) NOT NULL,
+    access_token VARCHAR(255) NULL,
+    refresh_token VARCHAR(255) NULL,
+    expires_at DATETIME NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NULL,
+    deleted_at DATETIME NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_message_api_credentials_client_id (client_id),
+    UNIQUE KEY uq_message_api_credentials_client_secret (client_secret),
+    KEY idx_message_api_credentials_user_id (user_id),
+    KEY idx_message_api_credentials_access_token (access_token),
+    KEY idx_message_api_credentials_refresh_token (refresh_token)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
@@ -0,0 +1,10 @@
+CREATE TABLE message_threads (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    company_user_id BIGINT NOT NULL,
+    student_user_id BIGINT NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NULL,
+    deleted_at DATETIME NULL
+);
+CREATE INDEX idx_message_threads_company_user_id ON message_threads (company_user_id);
+CREATE INDEX idx_message_threads_student_user_id ON message_threads (student_user_id);
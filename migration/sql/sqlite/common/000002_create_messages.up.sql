@@ -0,0 +1,17 @@
+CREATE TABLE messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    message_thread_id BIGINT NOT NULL,
+    is_from_company TINYINT NOT NULL DEFAULT 0,
+    is_from_student TINYINT NOT NULL DEFAULT 0,
+    content TEXT NOT NULL,
+    is_sent TINYINT NOT NULL DEFAULT 0,
+    sent_at DATETIME NOT NULL,
+    reply_to_message_id BIGINT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NULL,
+    deleted_at DATETIME NULL,
+    CONSTRAINT fk_messages_message_thread_id FOREIGN KEY (message_thread_id) REFERENCES message_threads (id)
+);
+CREATE INDEX idx_messages_message_thread_id_sent_at ON messages (message_thread_id, sent_at);
+CREATE INDEX idx_messages_reply_to_message_id ON messages (reply_to_message_id);
+CREATE INDEX idx_messages_deleted_at ON messages (deleted_at);
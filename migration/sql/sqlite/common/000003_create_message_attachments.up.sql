@@ -0,0 +1,13 @@
+CREATE TABLE message_attachments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    message_id BIGINT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    deleted_at DATETIME NULL,
+    CONSTRAINT uq_message_attachments_storage_key UNIQUE (storage_key),
+    CONSTRAINT fk_message_attachments_message_id FOREIGN KEY (message_id) REFERENCES messages (id)
+);
+CREATE INDEX idx_message_attachments_message_id ON message_attachments (message_id);
@@ -0,0 +1,17 @@
+CREATE TABLE message_api_credentials (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id BIGINT NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    client_secret VARCHAR(255) NOT NULL,
+    access_token VARCHAR(255) NULL,
+    refresh_token VARCHAR(255) NULL,
+    expires_at DATETIME NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NULL,
+    deleted_at DATETIME NULL,
+    CONSTRAINT uq_message_api_credentials_client_id UNIQUE (client_id),
+    CONSTRAINT uq_message_api_credentials_client_secret UNIQUE (client_secret)
+);
+CREATE INDEX idx_message_api_credentials_user_id ON message_api_credentials (user_id);
+CREATE INDEX idx_message_api_credentials_access_token ON message_api_credentials (access_token);
+CREATE INDEX idx_message_api_credentials_refresh_token ON message_api_credentials (refresh_token);
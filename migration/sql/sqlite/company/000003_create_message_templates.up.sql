@@ -0,0 +1,10 @@
+CREATE TABLE message_templates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    company_user_id BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    content TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NULL,
+    deleted_at DATETIME NULL
+);
+CREATE INDEX idx_message_templates_company_user_id ON message_templates (company_user_id);
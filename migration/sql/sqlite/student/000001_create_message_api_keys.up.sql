@@ -0,0 +1,7 @@
+CREATE TABLE message_api_keys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    api_key VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    deleted_at DATETIME NULL,
+    CONSTRAINT uq_message_api_keys_api_key UNIQUE (api_key)
+);
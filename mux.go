@@ -2,20 +2,27 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/go-playground/validator/v10"
 	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/yuyacode/AppLiftMessageApi/blob"
 	"github.com/yuyacode/AppLiftMessageApi/clock"
 	"github.com/yuyacode/AppLiftMessageApi/config"
+	"github.com/yuyacode/AppLiftMessageApi/credential"
 	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/metrics"
+	"github.com/yuyacode/AppLiftMessageApi/openapi"
 	"github.com/yuyacode/AppLiftMessageApi/service"
 	"github.com/yuyacode/AppLiftMessageApi/store"
 )
 
-func NewMux(ctx context.Context, cfg *config.Config) (http.Handler, map[string]func(), error) {
+func NewMux(ctx context.Context, cfg *config.Config, readiness *handler.Readiness) (http.Handler, map[string]func(), error) {
 	dbList := [3]string{"company", "student", "common"}
 	var dbHandlers = make(map[string]*sqlx.DB, len(dbList))
 	var dbCloseFuncs = make(map[string]func(), len(dbList))
@@ -26,39 +33,181 @@ func NewMux(ctx context.Context, cfg *config.Config) (http.Handler, map[string]f
 			return nil, dbCloseFuncs, err
 		}
 	}
-	v := validator.New()
+	// レプリカの DSN が設定されている DB のみ、読み取り専用の処理をレプリカに振り分ける
+	var replicaHandlers = make(map[string]*sqlx.DB, len(dbList))
+	for _, v := range dbList {
+		replica, closeFunc, err := store.NewReplica(ctx, cfg, v)
+		dbCloseFuncs[v+"_replica"] = closeFunc
+		if err != nil {
+			return nil, dbCloseFuncs, err
+		}
+		if replica != nil {
+			replicaHandlers[v] = replica
+		}
+	}
+	blobStorage, err := blob.New(cfg)
+	if err != nil {
+		return nil, dbCloseFuncs, err
+	}
+	attachmentURLSecretKey, err := base64.StdEncoding.DecodeString(cfg.AttachmentURLSecretKey)
+	if err != nil {
+		return nil, dbCloseFuncs, fmt.Errorf("failed to decode attachment url secret key: %w", err)
+	}
+	// 空の鍵でも HMAC は計算できてしまうため、未設定のまま起動しない
+	if len(attachmentURLSecretKey) == 0 {
+		return nil, dbCloseFuncs, errors.New("ATTACHMENT_URL_SECRET_KEY is not set")
+	}
+	if err := credential.SetSecretKeys(cfg.AccessTokenSecretKey, cfg.RefreshTokenSecretKey); err != nil {
+		return nil, dbCloseFuncs, err
+	}
+	for _, name := range dbList {
+		readiness.AddCheck(name, dbHandlers[name].PingContext)
+		if replica, ok := replicaHandlers[name]; ok {
+			readiness.AddCheck(name+"_replica", replica.PingContext)
+		}
+	}
+	readiness.AddCheck("access_token_secret_key", credential.CheckAccessTokenSecretKey)
+	readiness.AddCheck("refresh_token_secret_key", credential.CheckRefreshTokenSecretKey)
+	statsHandlers := make(map[string]*sqlx.DB, len(dbHandlers)+len(replicaHandlers))
+	for name, db := range dbHandlers {
+		statsHandlers[name] = db
+	}
+	for name, db := range replicaHandlers {
+		statsHandlers[name+"_replica"] = db
+	}
+	metricsRegistry, err := metrics.NewRegistry(statsHandlers)
+	if err != nil {
+		return nil, dbCloseFuncs, err
+	}
+	v, err := handler.NewValidator()
+	if err != nil {
+		return nil, dbCloseFuncs, err
+	}
 	clocker := clock.RealClocker{}
-	oAuthRepo := store.NewOAuthRepository(clocker)
+	repos, err := newRepositories(cfg, clocker)
+	if err != nil {
+		return nil, dbCloseFuncs, err
+	}
+	oAuthRepo := repos.oAuth
 	roService := service.NewRegisterOAuth(dbHandlers, oAuthRepo, oAuthRepo)
 	roHandler := handler.NewRegisterOAuth(roService, v)
 	vrtService := service.NewVerifyRefreshToken(dbHandlers, oAuthRepo)
 	ratService := service.NewRefreshAccessToken(dbHandlers, oAuthRepo, oAuthRepo)
 	ratHandler := handler.NewRefreshAccessToken(ratService, v)
 	vatService := service.NewVerifyAccessToken(dbHandlers, oAuthRepo)
-	messageRepo := store.NewMessageRepository(clocker)
-	gmService := service.NewGetMessage(dbHandlers, messageRepo, messageRepo)
+	messageRepo := repos.message
+	attachmentRepo := repos.attachment
+	reactionRepo := repos.reaction
+	gmService := service.NewGetMessage(dbHandlers, replicaHandlers, messageRepo, messageRepo, attachmentRepo, reactionRepo)
 	gmHandler := handler.NewGetMessage(gmService, v)
-	amService := service.NewAddMessage(dbHandlers, messageRepo, messageRepo)
-	amHandler := handler.NewAddMessage(amService, v)
+	etService := service.NewExportThread(dbHandlers, replicaHandlers, messageRepo, messageRepo)
+	etHandler := handler.NewExportThread(etService, v)
+	messageTemplateRepo := repos.messageTemplate
+	rmtService := service.NewRenderMessageTemplate(dbHandlers, messageTemplateRepo)
+	amService := service.NewAddMessage(dbHandlers, messageRepo, messageRepo, messageRepo)
+	amHandler := handler.NewAddMessage(amService, rmtService, v)
+	bamService := service.NewBulkAddMessage(dbHandlers, messageRepo, messageRepo)
+	bamHandler := handler.NewBulkAddMessage(bamService, rmtService, v)
 	emService := service.NewEditMessage(dbHandlers, messageRepo, messageRepo)
 	emHandler := handler.NewEditMessage(emService, v)
 	dmService := service.NewDeleteMessage(dbHandlers, messageRepo, messageRepo)
 	dmHandler := handler.NewDeleteMessage(dmService, v)
+	aaService := service.NewAddAttachment(dbHandlers, attachmentRepo, messageRepo, blobStorage, cfg.AttachmentMaxSize)
+	aaHandler := handler.NewAddAttachment(aaService, v, cfg.AttachmentMaxSize)
+	galService := service.NewGetAttachmentLink(dbHandlers, attachmentRepo, messageRepo, attachmentURLSecretKey, cfg.AttachmentURLTTL, clocker)
+	galHandler := handler.NewGetAttachmentLink(galService, v)
+	daService := service.NewDownloadAttachment(dbHandlers, attachmentRepo, blobStorage, attachmentURLSecretKey, clocker)
+	daHandler := handler.NewDownloadAttachment(daService, v)
+	arService := service.NewAddReaction(dbHandlers, reactionRepo, messageRepo)
+	arHandler := handler.NewAddReaction(arService, v)
+	drService := service.NewDeleteReaction(dbHandlers, reactionRepo, messageRepo)
+	drHandler := handler.NewDeleteReaction(drService, v)
+	gmtService := service.NewGetMessageTemplate(dbHandlers, messageTemplateRepo)
+	gmtHandler := handler.NewGetMessageTemplate(gmtService, v)
+	amtService := service.NewAddMessageTemplate(dbHandlers, messageTemplateRepo)
+	amtHandler := handler.NewAddMessageTemplate(amtService, v)
+	emtService := service.NewEditMessageTemplate(dbHandlers, messageTemplateRepo, messageTemplateRepo)
+	emtHandler := handler.NewEditMessageTemplate(emtService, v)
+	dmtService := service.NewDeleteMessageTemplate(dbHandlers, messageTemplateRepo, messageTemplateRepo)
+	dmtHandler := handler.NewDeleteMessageTemplate(dmtService, v)
+	userDataRepo := repos.userData
+	eudService := service.NewExportUserData(dbHandlers, userDataRepo, blobStorage)
+	eudHandler := handler.NewExportUserData(eudService, v)
+	erudService := service.NewEraseUserData(dbHandlers, userDataRepo, userDataRepo, blobStorage)
+	erudHandler := handler.NewEraseUserData(erudService, v)
+	corsMiddleware, err := handler.CORSMiddleware(cfg.AllowedOrigins, cfg.CORSAllowCredentials)
+	if err != nil {
+		return nil, dbCloseFuncs, err
+	}
 	mux := chi.NewRouter()
-	mux.Use(handler.CORSMiddleware())
+	// サブルーターに引き継がれるよう、ルートの登録より前に設定する
+	mux.NotFound(handler.NotFound)
+	mux.MethodNotAllowed(handler.MethodNotAllowed)
+	mux.Use(handler.RequestIDMiddleware())
+	// ローカル環境（ENV=dev）以外では、DB のエラーなどの詳細をレスポンスに含めずログにのみ出力する
+	mux.Use(handler.ErrorDetailMiddleware(cfg.Env == "dev"))
+	mux.Use(handler.TracingMiddleware())
+	mux.Use(handler.AccessLogMiddleware())
+	mux.Use(handler.MetricsMiddleware())
+	mux.Use(corsMiddleware)
+	mux.Use(handler.ConsistencyMiddleware())
+	mux.Get("/healthz", handler.Healthz)
+	mux.Handle("/readyz", readiness)
+	// メトリクスはルートごとのリクエスト数など内部の情報を含むため、管理用APIと同じく管理用APIキーで保護する
+	if cfg.AdminAPIKey != "" {
+		mux.With(handler.VerifyAdminAPIKeyMiddleware(cfg.AdminAPIKey)).Handle("/metrics", promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{}))
+	}
+	mux.Get("/openapi.json", openapi.Handler)
+	if cfg.Env == "dev" {
+		mux.Get("/docs", openapi.SwaggerUIHandler)
+	}
+	bodyLimit := handler.BodyLimitMiddleware(cfg.RequestBodyMaxSize)
+	// /messages と /threads で書き込みの記録を共有するため、1つだけ作る
+	stickyPrimary := handler.StickyPrimaryMiddleware(cfg.DBReplicaStickyWindow)
 	mux.Route("/messages", func(r chi.Router) {
-		r.Post("/register", roHandler.ServeHTTP)
+		r.With(bodyLimit).Post("/register", roHandler.ServeHTTP)
+		// 署名付きリンクでの認可のため、アクセストークンは不要
+		r.Get("/attachments/{id}", daHandler.ServeHTTP)
 		r.Group(func(r chi.Router) {
+			r.Use(bodyLimit)
 			r.Use(handler.VerifyRefreshTokenMiddleware(vrtService))
 			r.Post("/token", ratHandler.ServeHTTP)
 		})
 		r.Group(func(r chi.Router) {
 			r.Use(handler.VerifyAccessTokenMiddleware(vatService))
-			r.Get("/", gmHandler.ServeHTTP)
-			r.Post("/", amHandler.ServeHTTP)
-			r.Patch("/{id}", emHandler.ServeHTTP)
-			r.Delete("/{id}", dmHandler.ServeHTTP)
+			r.Use(stickyPrimary)
+			// 添付ファイルのアップロードは、AddAttachment が ATTACHMENT_MAX_SIZE で制限する
+			r.Post("/{id}/attachments", aaHandler.ServeHTTP)
+			r.Group(func(r chi.Router) {
+				r.Use(bodyLimit)
+				r.Get("/", gmHandler.ServeHTTP)
+				r.Post("/", amHandler.ServeHTTP)
+				r.Post("/bulk", bamHandler.ServeHTTP)
+				r.Patch("/{id}", emHandler.ServeHTTP)
+				r.Delete("/{id}", dmHandler.ServeHTTP)
+				r.Get("/attachments/{id}/link", galHandler.ServeHTTP)
+				r.Post("/{id}/reactions", arHandler.ServeHTTP)
+				r.Delete("/{id}/reactions", drHandler.ServeHTTP)
+				r.Get("/templates", gmtHandler.ServeHTTP)
+				r.Post("/templates", amtHandler.ServeHTTP)
+				r.Patch("/templates/{id}", emtHandler.ServeHTTP)
+				r.Delete("/templates/{id}", dmtHandler.ServeHTTP)
+			})
 		})
 	})
+	mux.Route("/threads", func(r chi.Router) {
+		r.Use(handler.VerifyAccessTokenMiddleware(vatService))
+		r.Use(stickyPrimary)
+		r.Get("/{id}/export", etHandler.ServeHTTP)
+	})
+	// 管理用APIキーが未設定の環境では、管理用APIを公開しない
+	if cfg.AdminAPIKey != "" {
+		mux.Route("/admin", func(r chi.Router) {
+			r.Use(bodyLimit)
+			r.Use(handler.VerifyAdminAPIKeyMiddleware(cfg.AdminAPIKey))
+			r.Get("/users/{app_kind}/{user_id}/export", eudHandler.ServeHTTP)
+			r.Post("/users/{app_kind}/{user_id}/erase", erudHandler.ServeHTTP)
+		})
+	}
 	return mux, dbCloseFuncs, nil
 }
@@ -0,0 +1,33 @@
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openapi.json はハンドラーに合わせて手で管理する。ルートの追加や削除は TestE2E_OpenAPIRoutes が、
+// レスポンスの形は handler パッケージのテストが openapi.json と突き合わせる
+//
+//go:embed openapi.json
+var spec []byte
+
+//go:embed swagger_ui.html
+var swaggerUI []byte
+
+// Spec は OpenAPI 3.1 のドキュメントを返す
+func Spec() []byte {
+	return spec
+}
+
+// Handler は /openapi.json で OpenAPI のドキュメントを返す
+func Handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	_, _ = w.Write(spec)
+}
+
+// SwaggerUIHandler は /openapi.json を表示する Swagger UI のページを返す。開発環境でのみ公開する
+func SwaggerUIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(swaggerUI)
+}
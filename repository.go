@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/config"
+	"github.com/yuyacode/AppLiftMessageApi/service"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+// repositories は DB_DRIVER に応じて選んだリポジトリの実装をまとめる
+type repositories struct {
+	oAuth interface {
+		service.CredentialGetter
+		service.CredentialSetter
+	}
+	message interface {
+		service.MessageOwnerGetter
+		service.MessageGetter
+		service.MessageAdder
+		service.MessageEditor
+		service.MessageDeleter
+	}
+	attachment interface {
+		service.AttachmentGetter
+		service.AttachmentAdder
+	}
+	reaction interface {
+		service.ReactionGetter
+		service.ReactionAdder
+		service.ReactionDeleter
+	}
+	messageTemplate interface {
+		service.MessageTemplateGetter
+		service.MessageTemplateAdder
+		service.MessageTemplateEditor
+		service.MessageTemplateDeleter
+	}
+	userData interface {
+		service.UserDataGetter
+		service.UserDataEraser
+	}
+}
+
+func newRepositories(cfg *config.Config, clocker clock.Clocker) (*repositories, error) {
+	if cfg.DBDriver != "memory" {
+		return &repositories{
+			oAuth:           store.NewOAuthRepository(clocker),
+			message:         store.NewMessageRepository(clocker),
+			attachment:      store.NewAttachmentRepository(clocker),
+			reaction:        store.NewReactionRepository(clocker),
+			messageTemplate: store.NewMessageTemplateRepository(clocker),
+			userData:        store.NewUserDataRepository(clocker),
+		}, nil
+	}
+	data := store.NewMemoryData()
+	if cfg.DBMemorySeedFile != "" {
+		f, err := os.Open(cfg.DBMemorySeedFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open memory seed file: %w", err)
+		}
+		defer f.Close()
+		if err := data.LoadSeed(f, clocker); err != nil {
+			return nil, fmt.Errorf("failed to load memory seed file: %w", err)
+		}
+	}
+	return &repositories{
+		oAuth:           store.NewMemoryOAuthRepository(data, clocker),
+		message:         store.NewMemoryMessageRepository(data, clocker),
+		attachment:      store.NewMemoryAttachmentRepository(data, clocker),
+		reaction:        store.NewMemoryReactionRepository(data, clocker),
+		messageTemplate: store.NewMemoryMessageTemplateRepository(data, clocker),
+		userData:        store.NewMemoryUserDataRepository(data, clocker),
+	}, nil
+}
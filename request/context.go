@@ -6,8 +6,22 @@ import (
 
 type appKindKey struct{}
 type userIDKey struct{}
+type requestIDKey struct{}
+type accessLogKey struct{}
+type readFromPrimaryKey struct{}
+type exposeErrorDetailKey struct{}
+
+// AccessLog はアクセスログに出力する値のうち、後続のミドルウェアやハンドラーで判明するもの。
+// context は下流にしか伝わらないため、アクセスログのミドルウェアが置いたポインタに書き戻す
+type AccessLog struct {
+	AppKind string
+	UserID  int64
+}
 
 func SetAppKind(ctx context.Context, appKind string) context.Context {
+	if al, ok := GetAccessLog(ctx); ok {
+		al.AppKind = appKind
+	}
 	return context.WithValue(ctx, appKindKey{}, appKind)
 }
 
@@ -17,6 +31,9 @@ func GetAppKind(ctx context.Context) (string, bool) {
 }
 
 func SetUserID(ctx context.Context, userID int64) context.Context {
+	if al, ok := GetAccessLog(ctx); ok {
+		al.UserID = userID
+	}
 	return context.WithValue(ctx, userIDKey{}, userID)
 }
 
@@ -24,3 +41,41 @@ func GetUserID(ctx context.Context) (int64, bool) {
 	userID, ok := ctx.Value(userIDKey{}).(int64)
 	return userID, ok
 }
+
+func SetRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func GetRequestID(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	return requestID, ok
+}
+
+func SetAccessLog(ctx context.Context, al *AccessLog) context.Context {
+	return context.WithValue(ctx, accessLogKey{}, al)
+}
+
+func GetAccessLog(ctx context.Context) (*AccessLog, bool) {
+	al, ok := ctx.Value(accessLogKey{}).(*AccessLog)
+	return al, ok
+}
+
+// SetReadFromPrimary は、レプリカの遅延で直前の書き込みが見えないことを避けるため、読み取りも primary で行うよう指定する
+func SetReadFromPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readFromPrimaryKey{}, true)
+}
+
+func GetReadFromPrimary(ctx context.Context) bool {
+	readFromPrimary, _ := ctx.Value(readFromPrimaryKey{}).(bool)
+	return readFromPrimary
+}
+
+// SetExposeErrorDetail は、5xx のエラーの詳細（DB のエラーなど内部の情報を含みうる）をレスポンスに含めるよう指定する
+func SetExposeErrorDetail(ctx context.Context) context.Context {
+	return context.WithValue(ctx, exposeErrorDetailKey{}, true)
+}
+
+func GetExposeErrorDetail(ctx context.Context) bool {
+	expose, _ := ctx.Value(exposeErrorDetailKey{}).(bool)
+	return expose
+}
@@ -2,28 +2,73 @@ package main
 
 import (
 	"context"
-	"log"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"golang.org/x/sync/errgroup"
+
+	"github.com/yuyacode/AppLiftMessageApi/config"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
 )
 
 type Server struct {
-	srv *http.Server
-	l   net.Listener
+	srv             *http.Server
+	l               net.Listener
+	readiness       *handler.Readiness
+	drainDelay      time.Duration
+	shutdownTimeout time.Duration
 }
 
-func NewServer(l net.Listener, mux http.Handler) *Server {
-	return &Server{
-		srv: &http.Server{
-			Handler: mux,
-		},
-		l: l,
+// NewServer は cfg のタイムアウトなどを設定した http.Server を作る。TLS_CERT_FILE と TLS_KEY_FILE を指定した場合は TLS で待ち受ける。
+// シャットダウン時は /readyz を not ready にして SHUTDOWN_DRAIN_DELAY の間リクエストを受け付け続け、
+// ロードバランサーが振り分け先から外すのを待ってからリスナーを閉じる
+func NewServer(l net.Listener, mux http.Handler, readiness *handler.Readiness, cfg *config.Config) (*Server, error) {
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: cfg.HTTPReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTPReadTimeout,
+		WriteTimeout:      cfg.HTTPWriteTimeout,
+		IdleTimeout:       cfg.HTTPIdleTimeout,
+		MaxHeaderBytes:    cfg.HTTPMaxHeaderBytes,
+		ErrorLog:          slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
+	}
+	useTLS := cfg.TLSCertFile != "" || cfg.TLSKeyFile != ""
+	if useTLS {
+		if cfg.TLSCertFile == "" || cfg.TLSKeyFile == "" {
+			return nil, errors.New("both TLS_CERT_FILE and TLS_KEY_FILE must be set to enable TLS")
+		}
+		cr, err := newCertReloader(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+		srv.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: cr.GetCertificate,
+		}
+	}
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	if cfg.HTTP2Enabled {
+		// TLS では ALPN で、平文では h2c（prior knowledge）で HTTP/2 を受け付ける
+		protocols.SetHTTP2(useTLS)
+		protocols.SetUnencryptedHTTP2(!useTLS)
 	}
+	srv.Protocols = protocols
+	return &Server{
+		srv:             srv,
+		l:               l,
+		readiness:       readiness,
+		drainDelay:      cfg.ShutdownDrainDelay,
+		shutdownTimeout: cfg.ShutdownTimeout,
+	}, nil
 }
 
 func (s *Server) Run(ctx context.Context) error {
@@ -31,15 +76,31 @@ func (s *Server) Run(ctx context.Context) error {
 	defer stop()
 	eg, ctx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
-		if err := s.srv.Serve(s.l); err != nil && err != http.ErrServerClosed {
-			log.Printf("failed to close server: %+v", err)
+		var err error
+		if s.srv.TLSConfig != nil {
+			// 証明書は TLSConfig.GetCertificate から読み込む
+			err = s.srv.ServeTLS(s.l, "", "")
+		} else {
+			err = s.srv.Serve(s.l)
+		}
+		if err != nil && err != http.ErrServerClosed {
+			slog.Error("failed to close server", slog.String("error", err.Error()))
 			return err
 		}
 		return nil
 	})
 	<-ctx.Done()
-	if err := s.srv.Shutdown(context.Background()); err != nil {
-		log.Printf("failed to shutdown server: %+v", err)
+	s.readiness.Drain()
+	slog.Info("draining before shutdown", slog.Duration("delay", s.drainDelay))
+	time.Sleep(s.drainDelay)
+	// 応答の遅いクライアントなどで終了が止まらないよう、猶予を過ぎたら残りの接続を強制的に閉じる
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	if err := s.srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed to shutdown server gracefully", slog.String("error", err.Error()))
+		if err := s.srv.Close(); err != nil {
+			slog.Error("failed to close server", slog.String("error", err.Error()))
+		}
 	}
 	return eg.Wait()
 }
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/config"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+)
+
+// writeSelfSignedCert は commonName を持つ自己署名証明書と秘密鍵を dir に書き出す
+func writeSelfSignedCert(t *testing.T, dir, commonName string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func newTestConfig(t *testing.T) *config.Config {
+	t.Helper()
+	t.Setenv("ENV", "test")
+	secretKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("k"), 32))
+	t.Setenv("ACCESS_TOKEN_SECRET_KEY", secretKey)
+	t.Setenv("REFRESH_TOKEN_SECRET_KEY", secretKey)
+	t.Setenv("ALLOWED_ORIGINS", "https://app.example.com")
+	cfg, err := config.NewConfig()
+	require.NoError(t, err)
+	cfg.ShutdownDrainDelay = 0
+	return cfg
+}
+
+func TestCertReloader_GetCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSignedCert(t, dir, "first")
+	cr, err := newCertReloader(certFile, keyFile)
+	require.NoError(t, err)
+	cert, err := cr.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "first", cert.Leaf.Subject.CommonName)
+
+	writeSelfSignedCert(t, dir, "second")
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	require.NoError(t, os.Chtimes(keyFile, later, later))
+	cert, err = cr.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "second", cert.Leaf.Subject.CommonName)
+
+	// 読み込めないファイルに差し替えられた場合は、直前の証明書を使い続ける
+	require.NoError(t, os.WriteFile(certFile, []byte("broken"), 0o600))
+	require.NoError(t, os.Chtimes(certFile, later.Add(time.Minute), later.Add(time.Minute)))
+	cert, err = cr.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "second", cert.Leaf.Subject.CommonName)
+}
+
+func TestNewServer_TLSRequiresBothFiles(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.TLSCertFile = "tls.crt"
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	_, err = NewServer(l, http.NotFoundHandler(), handler.NewReadiness(), cfg)
+	assert.Error(t, err)
+}
+
+func TestServer_RunTLSWithHTTP2(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.TLSCertFile, cfg.TLSKeyFile = writeSelfSignedCert(t, t.TempDir(), "localhost")
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s, err := NewServer(l, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}), handler.NewReadiness(), cfg)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Run(ctx) }()
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		ForceAttemptHTTP2: true,
+	}}
+	resp, err := client.Get("https://" + l.Addr().String())
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 2, resp.ProtoMajor)
+
+	cancel()
+	assert.NoError(t, <-errCh)
+}
+
+func TestServer_RunShutdownTimeout(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.ShutdownTimeout = 100 * time.Millisecond
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	defer close(unblock)
+	readiness := handler.NewReadiness()
+	s, err := NewServer(l, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-unblock
+	}), readiness, cfg)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Run(ctx) }()
+	go func() {
+		resp, err := http.Get("http://" + l.Addr().String())
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	cancel()
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the shutdown timeout")
+	}
+}
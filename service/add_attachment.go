@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+)
+
+// 履歴書や内定通知書のやり取りを想定し、文書と画像のみ受け付ける
+var allowedAttachmentTypes = []string{
+	"application/pdf",
+	"application/msword",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.ms-excel",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"text/plain",
+	"image/png",
+	"image/jpeg",
+}
+
+type AddAttachment struct {
+	DBHandlers         map[string]*sqlx.DB
+	AttachmentAdder    AttachmentAdder
+	MessageOwnerGetter MessageOwnerGetter
+	BlobStorage        BlobStorage
+	MaxSize            int64
+}
+
+func NewAddAttachment(dbHandlers map[string]*sqlx.DB, attachmentAdder AttachmentAdder, messageOwnerGetter MessageOwnerGetter, blobStorage BlobStorage, maxSize int64) *AddAttachment {
+	return &AddAttachment{
+		DBHandlers:         dbHandlers,
+		AttachmentAdder:    attachmentAdder,
+		MessageOwnerGetter: messageOwnerGetter,
+		BlobStorage:        blobStorage,
+		MaxSize:            maxSize,
+	}
+}
+
+func (aa *AddAttachment) AddAttachment(ctx context.Context, messageID entity.MessageID, fileName string, content []byte) (*entity.Attachment, error) {
+	ctx, span := tracer.Start(ctx, "AddAttachment.AddAttachment")
+	defer span.End()
+	appKind, ok := request.GetAppKind(ctx)
+	if !ok {
+		return nil, handler.NewServiceError(
+			handler.ErrCodeInternal,
+			"failed to get app kind",
+			"",
+		)
+	}
+	userID, ok := request.GetUserID(ctx)
+	if !ok {
+		return nil, handler.NewServiceError(
+			handler.ErrCodeInternal,
+			"failed to get userID",
+			"",
+		)
+	}
+	if appKind == "company" {
+		companyUserID, err := aa.MessageOwnerGetter.GetThreadCompanyOwnerByMessageID(ctx, aa.DBHandlers["common"], messageID)
+		if err != nil {
+			return nil, newLookupServiceError(handler.ErrCodeMessageNotFound, "message not found", "failed to get threadCompanyOwner", err)
+		}
+		if userID != companyUserID {
+			return nil, handler.NewServiceError(
+				handler.ErrCodeForbidden,
+				"unauthorized: lack the necessary permissions to add attachments",
+				"",
+			)
+		}
+	} else if appKind == "student" {
+		studentUserID, err := aa.MessageOwnerGetter.GetThreadStudentOwnerByMessageID(ctx, aa.DBHandlers["common"], messageID)
+		if err != nil {
+			return nil, newLookupServiceError(handler.ErrCodeMessageNotFound, "message not found", "failed to get threadStudentOwner", err)
+		}
+		if userID != studentUserID {
+			return nil, handler.NewServiceError(
+				handler.ErrCodeForbidden,
+				"unauthorized: lack the necessary permissions to add attachments",
+				"",
+			)
+		}
+	}
+	if len(content) == 0 {
+		return nil, handler.NewServiceError(
+			handler.ErrCodeAttachmentEmpty,
+			"attachment is empty",
+			"",
+		)
+	}
+	if int64(len(content)) > aa.MaxSize {
+		return nil, handler.NewServiceError(
+			handler.ErrCodeAttachmentTooLarge,
+			"attachment is too large",
+			fmt.Sprintf("attachment must be at most %d bytes", aa.MaxSize),
+		)
+	}
+	// クライアントが申告する Content-Type は信用せず、中身から判定する
+	mime := mimetype.Detect(content)
+	if !mimetype.EqualsAny(mime.String(), allowedAttachmentTypes...) {
+		return nil, handler.NewServiceError(
+			handler.ErrCodeUnsupportedAttachmentType,
+			"attachment type is not allowed",
+			mime.String(),
+		)
+	}
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return nil, newInternalServiceError("failed to generate storage key", err)
+	}
+	a := &entity.Attachment{
+		MessageID:   messageID,
+		FileName:    sanitizeFileName(fileName),
+		ContentType: mime.String(),
+		Size:        int64(len(content)),
+		StorageKey:  fmt.Sprintf("attachments/%d/%s", messageID, hex.EncodeToString(randomBytes)),
+	}
+	if err := aa.BlobStorage.Put(ctx, a.StorageKey, content, a.ContentType); err != nil {
+		return nil, newInternalServiceError("failed to store attachment", err)
+	}
+	if err := aa.AttachmentAdder.AddAttachment(ctx, aa.DBHandlers["common"], a); err != nil {
+		// メタデータを保存できなかった実体は参照されないため削除しておく
+		_ = aa.BlobStorage.Delete(ctx, a.StorageKey)
+		return nil, newInternalServiceError("failed to add attachment", err)
+	}
+	return a, nil
+}
+
+func sanitizeFileName(fileName string) string {
+	name := filepath.Base(filepath.Clean("/" + strings.ReplaceAll(fileName, "\\", "/")))
+	if name == "/" || name == "." {
+		return "attachment"
+	}
+	if r := []rune(name); len(r) > 255 {
+		return string(r[len(r)-255:])
+	}
+	return name
+}
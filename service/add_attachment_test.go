@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+func TestAddAttachment_AddAttachment(t *testing.T) {
+	pdf := []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\ntrailer\n<<>>\n%%EOF\n")
+	type testCase struct {
+		name             string
+		appKind          string
+		userID           int64
+		prepareOwnerMock func(*MessageOwnerGetterMock)
+		prepareAdderMock func(*AttachmentAdderMock)
+		prepareBlobMock  func(*BlobStorageMock)
+		messageID        entity.MessageID
+		fileName         string
+		content          []byte
+		wantFileName     string
+		wantContentType  string
+		wantErr          bool
+		wantErrStatus    int
+		wantErrMsg       string
+		wantBlobDeleted  bool
+	}
+	ownedByCompany := func(m *MessageOwnerGetterMock) {
+		m.GetThreadCompanyOwnerByMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+			return 1, nil
+		}
+	}
+	tests := []testCase{
+		{
+			name:          "fail if no appKind in context",
+			appKind:       "",
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get app kind",
+		},
+		{
+			name:          "fail if no userID in context",
+			appKind:       "company",
+			userID:        0,
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get userID",
+		},
+		{
+			name:    "company: fail to get thread owner",
+			appKind: "company",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerByMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 0, errors.New("owner query error")
+				}
+			},
+			messageID:     1,
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get threadCompanyOwner",
+		},
+		{
+			name:    "company: user is not thread owner => forbidden",
+			appKind: "company",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerByMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 2, nil
+				}
+			},
+			messageID:     1,
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "unauthorized: lack the necessary permissions to add attachments",
+		},
+		{
+			name:    "student: user is not thread owner => forbidden",
+			appKind: "student",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadStudentOwnerByMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 2, nil
+				}
+			},
+			messageID:     1,
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "unauthorized: lack the necessary permissions to add attachments",
+		},
+		{
+			name:             "empty attachment => bad request",
+			appKind:          "company",
+			userID:           1,
+			prepareOwnerMock: ownedByCompany,
+			messageID:        1,
+			fileName:         "empty.pdf",
+			content:          []byte{},
+			wantErr:          true,
+			wantErrStatus:    http.StatusBadRequest,
+			wantErrMsg:       "attachment is empty",
+		},
+		{
+			name:             "attachment exceeds max size",
+			appKind:          "company",
+			userID:           1,
+			prepareOwnerMock: ownedByCompany,
+			messageID:        1,
+			fileName:         "large.pdf",
+			content:          append(pdf, []byte(strings.Repeat("a", 1024))...),
+			wantErr:          true,
+			wantErrStatus:    http.StatusRequestEntityTooLarge,
+			wantErrMsg:       "attachment is too large",
+		},
+		{
+			name:             "disallowed content type",
+			appKind:          "company",
+			userID:           1,
+			prepareOwnerMock: ownedByCompany,
+			messageID:        1,
+			fileName:         "resume.pdf",
+			content:          []byte("<html><body>not a pdf</body></html>"),
+			wantErr:          true,
+			wantErrStatus:    http.StatusUnsupportedMediaType,
+			wantErrMsg:       "attachment type is not allowed",
+		},
+		{
+			name:             "fail to store blob",
+			appKind:          "company",
+			userID:           1,
+			prepareOwnerMock: ownedByCompany,
+			prepareBlobMock: func(m *BlobStorageMock) {
+				m.PutFunc = func(ctx context.Context, key string, content []byte, contentType string) error {
+					return errors.New("put error")
+				}
+			},
+			messageID:     1,
+			fileName:      "offer.pdf",
+			content:       pdf,
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to store attachment",
+		},
+		{
+			name:             "fail to add attachment => blob is deleted",
+			appKind:          "company",
+			userID:           1,
+			prepareOwnerMock: ownedByCompany,
+			prepareAdderMock: func(m *AttachmentAdderMock) {
+				m.AddAttachmentFunc = func(ctx context.Context, db store.Execer, param *entity.Attachment) error {
+					return errors.New("insert error")
+				}
+			},
+			prepareBlobMock: func(m *BlobStorageMock) {
+				m.PutFunc = func(ctx context.Context, key string, content []byte, contentType string) error {
+					return nil
+				}
+				m.DeleteFunc = func(ctx context.Context, key string) error {
+					return nil
+				}
+			},
+			messageID:       1,
+			fileName:        "offer.pdf",
+			content:         pdf,
+			wantErr:         true,
+			wantErrStatus:   http.StatusInternalServerError,
+			wantErrMsg:      "failed to add attachment",
+			wantBlobDeleted: true,
+		},
+		{
+			name:             "company: success",
+			appKind:          "company",
+			userID:           1,
+			prepareOwnerMock: ownedByCompany,
+			prepareAdderMock: func(m *AttachmentAdderMock) {
+				m.AddAttachmentFunc = func(ctx context.Context, db store.Execer, param *entity.Attachment) error {
+					param.ID = entity.AttachmentID(10)
+					return nil
+				}
+			},
+			prepareBlobMock: func(m *BlobStorageMock) {
+				m.PutFunc = func(ctx context.Context, key string, content []byte, contentType string) error {
+					return nil
+				}
+			},
+			messageID:       1,
+			fileName:        "../../offer.pdf",
+			content:         pdf,
+			wantFileName:    "offer.pdf",
+			wantContentType: "application/pdf",
+			wantErr:         false,
+		},
+		{
+			name:    "student: success",
+			appKind: "student",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadStudentOwnerByMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 1, nil
+				}
+			},
+			prepareAdderMock: func(m *AttachmentAdderMock) {
+				m.AddAttachmentFunc = func(ctx context.Context, db store.Execer, param *entity.Attachment) error {
+					param.ID = entity.AttachmentID(10)
+					return nil
+				}
+			},
+			prepareBlobMock: func(m *BlobStorageMock) {
+				m.PutFunc = func(ctx context.Context, key string, content []byte, contentType string) error {
+					return nil
+				}
+			},
+			messageID:       1,
+			fileName:        "resume.txt",
+			content:         []byte("職務経歴書"),
+			wantFileName:    "resume.txt",
+			wantContentType: "text/plain; charset=utf-8",
+			wantErr:         false,
+		},
+	}
+	dbHandlers := map[string]*sqlx.DB{
+		"common": nil,
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tc.appKind != "" {
+				ctx = request.SetAppKind(ctx, tc.appKind)
+			}
+			if tc.userID != 0 {
+				ctx = request.SetUserID(ctx, tc.userID)
+			}
+			ownerMock := &MessageOwnerGetterMock{}
+			adderMock := &AttachmentAdderMock{}
+			blobMock := &BlobStorageMock{}
+			if tc.prepareOwnerMock != nil {
+				tc.prepareOwnerMock(ownerMock)
+			}
+			if tc.prepareAdderMock != nil {
+				tc.prepareAdderMock(adderMock)
+			}
+			if tc.prepareBlobMock != nil {
+				tc.prepareBlobMock(blobMock)
+			}
+			svc := NewAddAttachment(dbHandlers, adderMock, ownerMock, blobMock, 256)
+			a, err := svc.AddAttachment(ctx, tc.messageID, tc.fileName, tc.content)
+			if tc.wantErr {
+				assert.Error(t, err, "error is expected but got nil")
+				se, ok := err.(*handler.ServiceError)
+				if assert.True(t, ok, "error should be *handler.ServiceError") {
+					assert.Equal(t, tc.wantErrStatus, se.StatusCode)
+					assert.Contains(t, se.Message, tc.wantErrMsg)
+				}
+				assert.Nil(t, a)
+				assert.Equal(t, tc.wantBlobDeleted, len(blobMock.DeleteCalls()) == 1)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.AttachmentID(10), a.ID)
+				assert.Equal(t, tc.messageID, a.MessageID)
+				assert.Equal(t, tc.wantFileName, a.FileName)
+				assert.Equal(t, tc.wantContentType, a.ContentType)
+				assert.Equal(t, int64(len(tc.content)), a.Size)
+				if assert.Len(t, blobMock.PutCalls(), 1) {
+					assert.Equal(t, a.StorageKey, blobMock.PutCalls()[0].Key)
+					assert.Equal(t, tc.content, blobMock.PutCalls()[0].Content)
+				}
+			}
+		})
+	}
+}
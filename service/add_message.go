@@ -2,13 +2,15 @@ package service
 
 import (
 	"context"
-	"net/http"
+	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 
 	"github.com/yuyacode/AppLiftMessageApi/entity"
 	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/metrics"
 	"github.com/yuyacode/AppLiftMessageApi/request"
 )
 
@@ -16,21 +18,25 @@ type AddMessage struct {
 	DBHandlers         map[string]*sqlx.DB
 	MessageAdder       MessageAdder
 	MessageOwnerGetter MessageOwnerGetter
+	MessageGetter      MessageGetter
 }
 
-func NewAddMessage(dbHandlers map[string]*sqlx.DB, messageAdder MessageAdder, messageOwnerGetter MessageOwnerGetter) *AddMessage {
+func NewAddMessage(dbHandlers map[string]*sqlx.DB, messageAdder MessageAdder, messageOwnerGetter MessageOwnerGetter, messageGetter MessageGetter) *AddMessage {
 	return &AddMessage{
 		DBHandlers:         dbHandlers,
 		MessageAdder:       messageAdder,
 		MessageOwnerGetter: messageOwnerGetter,
+		MessageGetter:      messageGetter,
 	}
 }
 
-func (am *AddMessage) AddMessage(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time) (*entity.Message, error) {
+func (am *AddMessage) AddMessage(ctx context.Context, messageThreadID entity.MessageThreadID, isFromCompany int8, isFromStudent int8, content string, isSent int8, sentAt time.Time, replyToMessageID *entity.MessageID) (*entity.Message, error) {
+	ctx, span := tracer.Start(ctx, "AddMessage.AddMessage")
+	defer span.End()
 	appKind, ok := request.GetAppKind(ctx)
 	if !ok {
 		return nil, handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to get app kind",
 			"",
 		)
@@ -38,7 +44,7 @@ func (am *AddMessage) AddMessage(ctx context.Context, messageThreadID entity.Mes
 	userID, ok := request.GetUserID(ctx)
 	if !ok {
 		return nil, handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to get userID",
 			"",
 		)
@@ -46,15 +52,11 @@ func (am *AddMessage) AddMessage(ctx context.Context, messageThreadID entity.Mes
 	if appKind == "company" {
 		companyUserID, err := am.MessageOwnerGetter.GetThreadCompanyOwner(ctx, am.DBHandlers["common"], messageThreadID)
 		if err != nil {
-			return nil, handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to get threadCompanyOwner",
-				err.Error(),
-			)
+			return nil, newLookupServiceError(handler.ErrCodeThreadNotFound, "message thread not found", "failed to get threadCompanyOwner", err)
 		}
 		if userID != companyUserID {
 			return nil, handler.NewServiceError(
-				http.StatusForbidden,
+				handler.ErrCodeForbidden,
 				"unauthorized: lack the necessary permissions to add messages",
 				"",
 			)
@@ -62,35 +64,51 @@ func (am *AddMessage) AddMessage(ctx context.Context, messageThreadID entity.Mes
 	} else if appKind == "student" {
 		studentUserID, err := am.MessageOwnerGetter.GetThreadStudentOwner(ctx, am.DBHandlers["common"], messageThreadID)
 		if err != nil {
-			return nil, handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to get threadStudentOwner",
-				err.Error(),
-			)
+			return nil, newLookupServiceError(handler.ErrCodeThreadNotFound, "message thread not found", "failed to get threadStudentOwner", err)
 		}
 		if userID != studentUserID {
 			return nil, handler.NewServiceError(
-				http.StatusForbidden,
+				handler.ErrCodeForbidden,
 				"unauthorized: lack the necessary permissions to add messages",
 				"",
 			)
 		}
 	}
+	if replyToMessageID != nil {
+		replyThreadID, err := am.MessageGetter.GetSentMessageThreadID(ctx, am.DBHandlers["common"], *replyToMessageID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, handler.NewServiceError(
+					handler.ErrCodeInvalidReplyTo,
+					"reply_to_message_id is invalid",
+					"",
+				)
+			}
+			return nil, newInternalServiceError("failed to get reply target message", err)
+		}
+		if replyThreadID != messageThreadID {
+			return nil, handler.NewServiceError(
+				handler.ErrCodeInvalidReplyTo,
+				"reply_to_message_id must belong to the same thread",
+				"",
+			)
+		}
+	}
 	m := &entity.Message{
-		MessageThreadID: messageThreadID,
-		IsFromCompany:   isFromCompany,
-		IsFromStudent:   isFromStudent,
-		Content:         content,
-		IsSent:          isSent,
-		SentAt:          sentAt,
+		MessageThreadID:  messageThreadID,
+		IsFromCompany:    isFromCompany,
+		IsFromStudent:    isFromStudent,
+		Content:          content,
+		IsSent:           isSent,
+		SentAt:           sentAt,
+		ReplyToMessageID: replyToMessageID,
 	}
 	err := am.MessageAdder.AddMessage(ctx, am.DBHandlers["common"], m)
 	if err != nil {
-		return nil, handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to add message",
-			err.Error(),
-		)
+		return nil, newInternalServiceError("failed to add message", err)
+	}
+	if isSent == 1 {
+		metrics.MessagesSent.WithLabelValues(appKind).Inc()
 	}
 	return m, nil
 }
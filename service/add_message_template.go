@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type AddMessageTemplate struct {
+	DBHandlers           map[string]*sqlx.DB
+	MessageTemplateAdder MessageTemplateAdder
+}
+
+func NewAddMessageTemplate(dbHandlers map[string]*sqlx.DB, messageTemplateAdder MessageTemplateAdder) *AddMessageTemplate {
+	return &AddMessageTemplate{
+		DBHandlers:           dbHandlers,
+		MessageTemplateAdder: messageTemplateAdder,
+	}
+}
+
+func (amt *AddMessageTemplate) AddMessageTemplate(ctx context.Context, name, content string) (*entity.MessageTemplate, error) {
+	ctx, span := tracer.Start(ctx, "AddMessageTemplate.AddMessageTemplate")
+	defer span.End()
+	companyUserID, err := getTemplateOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t := &entity.MessageTemplate{
+		CompanyUserID: companyUserID,
+		Name:          name,
+		Content:       content,
+	}
+	if err := amt.MessageTemplateAdder.AddMessageTemplate(ctx, amt.DBHandlers["company"], t); err != nil {
+		return nil, newInternalServiceError("failed to add message template", err)
+	}
+	return t, nil
+}
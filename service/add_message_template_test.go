@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+func TestAddMessageTemplate_AddMessageTemplate(t *testing.T) {
+	type testCase struct {
+		name             string
+		appKind          string
+		userID           int64
+		prepareAdderMock func(*MessageTemplateAdderMock)
+		wantErr          bool
+		wantErrStatus    int
+		wantErrMsg       string
+	}
+	tests := []testCase{
+		{
+			name:          "student => forbidden",
+			appKind:       "student",
+			userID:        1,
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "unauthorized: message templates are only available to company users",
+		},
+		{
+			name:    "fail to add template",
+			appKind: "company",
+			userID:  1,
+			prepareAdderMock: func(m *MessageTemplateAdderMock) {
+				m.AddMessageTemplateFunc = func(ctx context.Context, db store.Execer, param *entity.MessageTemplate) error {
+					return errors.New("insert error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to add message template",
+		},
+		{
+			name:    "deadlock => retryable",
+			appKind: "company",
+			userID:  1,
+			prepareAdderMock: func(m *MessageTemplateAdderMock) {
+				m.AddMessageTemplateFunc = func(ctx context.Context, db store.Execer, param *entity.MessageTemplate) error {
+					return fmt.Errorf("insert error: %w", &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"})
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusServiceUnavailable,
+			wantErrMsg:    "failed to add message template",
+		},
+		{
+			name:    "success",
+			appKind: "company",
+			userID:  1,
+			prepareAdderMock: func(m *MessageTemplateAdderMock) {
+				m.AddMessageTemplateFunc = func(ctx context.Context, db store.Execer, param *entity.MessageTemplate) error {
+					param.ID = entity.MessageTemplateID(5)
+					return nil
+				}
+			},
+			wantErr: false,
+		},
+	}
+	dbHandlers := map[string]*sqlx.DB{
+		"company": nil,
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tc.appKind != "" {
+				ctx = request.SetAppKind(ctx, tc.appKind)
+			}
+			if tc.userID != 0 {
+				ctx = request.SetUserID(ctx, tc.userID)
+			}
+			adderMock := &MessageTemplateAdderMock{}
+			if tc.prepareAdderMock != nil {
+				tc.prepareAdderMock(adderMock)
+			}
+			svc := NewAddMessageTemplate(dbHandlers, adderMock)
+			template, err := svc.AddMessageTemplate(ctx, "面接案内", "{{student_name}}様")
+			if tc.wantErr {
+				assert.Error(t, err, "error is expected but got nil")
+				se, ok := err.(*handler.ServiceError)
+				if assert.True(t, ok, "error should be *handler.ServiceError") {
+					assert.Equal(t, tc.wantErrStatus, se.StatusCode)
+					assert.Contains(t, se.Message, tc.wantErrMsg)
+				}
+				assert.Nil(t, template)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &entity.MessageTemplate{ID: 5, CompanyUserID: 1, Name: "面接案内", Content: "{{student_name}}様"}, template)
+			}
+		})
+	}
+}
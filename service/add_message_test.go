@@ -19,21 +19,23 @@ import (
 
 func TestAddMessage_AddMessage(t *testing.T) {
 	type testCase struct {
-		name             string
-		appKind          string
-		userID           int64
-		prepareOwnerMock func(*MessageOwnerGetterMock)
-		prepareAdderMock func(*MessageAdderMock)
-		messageThreadID  entity.MessageThreadID
-		isFromCompany    int8
-		isFromStudent    int8
-		content          string
-		isSent           int8
-		sentAt           time.Time
-		wantMsgID        entity.MessageID
-		wantErr          bool
-		wantErrStatus    int
-		wantErrMsg       string
+		name              string
+		appKind           string
+		userID            int64
+		prepareOwnerMock  func(*MessageOwnerGetterMock)
+		prepareAdderMock  func(*MessageAdderMock)
+		prepareGetterMock func(*MessageGetterMock)
+		messageThreadID   entity.MessageThreadID
+		isFromCompany     int8
+		isFromStudent     int8
+		content           string
+		isSent            int8
+		sentAt            time.Time
+		replyToMessageID  *entity.MessageID
+		wantMsgID         entity.MessageID
+		wantErr           bool
+		wantErrStatus     int
+		wantErrMsg        string
 	}
 	tests := []testCase{
 		{
@@ -64,6 +66,19 @@ func TestAddMessage_AddMessage(t *testing.T) {
 			wantErrStatus: http.StatusInternalServerError,
 			wantErrMsg:    "failed to get threadCompanyOwner",
 		},
+		{
+			name:    "company: thread not found => 404",
+			appKind: "company",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+					return 0, sql.ErrNoRows
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusNotFound,
+			wantErrMsg:    "message thread not found",
+		},
 		{
 			name:    "company: user is not thread owner => forbidden",
 			appKind: "company",
@@ -195,6 +210,100 @@ func TestAddMessage_AddMessage(t *testing.T) {
 			wantMsgID:       1,
 			wantErr:         false,
 		},
+		{
+			name:    "reply: fail to get reply target",
+			appKind: "student",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadStudentOwnerFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+					return 1, nil
+				}
+			},
+			prepareGetterMock: func(m *MessageGetterMock) {
+				m.GetSentMessageThreadIDFunc = func(ctx context.Context, db store.Queryer, id entity.MessageID) (entity.MessageThreadID, error) {
+					return 0, errors.New("reply target query error")
+				}
+			},
+			messageThreadID:  1,
+			replyToMessageID: replyTo(5),
+			wantErr:          true,
+			wantErrStatus:    http.StatusInternalServerError,
+			wantErrMsg:       "failed to get reply target message",
+		},
+		{
+			name:    "reply: reply target does not exist",
+			appKind: "student",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadStudentOwnerFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+					return 1, nil
+				}
+			},
+			prepareGetterMock: func(m *MessageGetterMock) {
+				m.GetSentMessageThreadIDFunc = func(ctx context.Context, db store.Queryer, id entity.MessageID) (entity.MessageThreadID, error) {
+					return 0, sql.ErrNoRows
+				}
+			},
+			messageThreadID:  1,
+			replyToMessageID: replyTo(5),
+			wantErr:          true,
+			wantErrStatus:    http.StatusBadRequest,
+			wantErrMsg:       "reply_to_message_id is invalid",
+		},
+		{
+			name:    "reply: reply target belongs to another thread",
+			appKind: "company",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+					return 1, nil
+				}
+			},
+			prepareGetterMock: func(m *MessageGetterMock) {
+				m.GetSentMessageThreadIDFunc = func(ctx context.Context, db store.Queryer, id entity.MessageID) (entity.MessageThreadID, error) {
+					return 2, nil
+				}
+			},
+			messageThreadID:  1,
+			replyToMessageID: replyTo(5),
+			wantErr:          true,
+			wantErrStatus:    http.StatusBadRequest,
+			wantErrMsg:       "reply_to_message_id must belong to the same thread",
+		},
+		{
+			name:    "reply: success",
+			appKind: "company",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+					return 1, nil
+				}
+			},
+			prepareGetterMock: func(m *MessageGetterMock) {
+				m.GetSentMessageThreadIDFunc = func(ctx context.Context, db store.Queryer, id entity.MessageID) (entity.MessageThreadID, error) {
+					return 1, nil
+				}
+			},
+			prepareAdderMock: func(m *MessageAdderMock) {
+				m.AddMessageFunc = func(ctx context.Context, db store.Execer, param *entity.Message) error {
+					param.CreatedAt = &sql.NullTime{
+						Time:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+						Valid: true,
+					}
+					param.ID = entity.MessageID(6)
+					return nil
+				}
+			},
+			messageThreadID:  1,
+			isFromCompany:    1,
+			isFromStudent:    0,
+			content:          "reply from company user",
+			isSent:           1,
+			sentAt:           time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			replyToMessageID: replyTo(5),
+			wantMsgID:        6,
+			wantErr:          false,
+		},
 	}
 	dbHandlers := map[string]*sqlx.DB{
 		"common": nil,
@@ -212,14 +321,18 @@ func TestAddMessage_AddMessage(t *testing.T) {
 			}
 			ownerMock := &MessageOwnerGetterMock{}
 			adderMock := &MessageAdderMock{}
+			getterMock := &MessageGetterMock{}
 			if tc.prepareOwnerMock != nil {
 				tc.prepareOwnerMock(ownerMock)
 			}
 			if tc.prepareAdderMock != nil {
 				tc.prepareAdderMock(adderMock)
 			}
-			svc := NewAddMessage(dbHandlers, adderMock, ownerMock)
-			msg, err := svc.AddMessage(ctx, tc.messageThreadID, tc.isFromCompany, tc.isFromStudent, tc.content, tc.isSent, tc.sentAt)
+			if tc.prepareGetterMock != nil {
+				tc.prepareGetterMock(getterMock)
+			}
+			svc := NewAddMessage(dbHandlers, adderMock, ownerMock, getterMock)
+			msg, err := svc.AddMessage(ctx, tc.messageThreadID, tc.isFromCompany, tc.isFromStudent, tc.content, tc.isSent, tc.sentAt, tc.replyToMessageID)
 			if tc.wantErr {
 				assert.Error(t, err, "error is expected but got nil")
 				se, ok := err.(*handler.ServiceError)
@@ -238,6 +351,7 @@ func TestAddMessage_AddMessage(t *testing.T) {
 				assert.Equal(t, tc.content, msg.Content)
 				assert.Equal(t, tc.isSent, msg.IsSent)
 				assert.Equal(t, tc.sentAt, msg.SentAt)
+				assert.Equal(t, tc.replyToMessageID, msg.ReplyToMessageID)
 				assert.Equal(t, &sql.NullTime{
 					Time:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
 					Valid: true,
@@ -246,3 +360,7 @@ func TestAddMessage_AddMessage(t *testing.T) {
 		})
 	}
 }
+
+func replyTo(id entity.MessageID) *entity.MessageID {
+	return &id
+}
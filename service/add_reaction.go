@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+)
+
+type AddReaction struct {
+	DBHandlers         map[string]*sqlx.DB
+	ReactionAdder      ReactionAdder
+	MessageOwnerGetter MessageOwnerGetter
+}
+
+func NewAddReaction(dbHandlers map[string]*sqlx.DB, reactionAdder ReactionAdder, messageOwnerGetter MessageOwnerGetter) *AddReaction {
+	return &AddReaction{
+		DBHandlers:         dbHandlers,
+		ReactionAdder:      reactionAdder,
+		MessageOwnerGetter: messageOwnerGetter,
+	}
+}
+
+func (ar *AddReaction) AddReaction(ctx context.Context, messageID entity.MessageID, reaction string) error {
+	ctx, span := tracer.Start(ctx, "AddReaction.AddReaction")
+	defer span.End()
+	if !entity.IsAllowedReaction(reaction) {
+		return handler.NewServiceError(
+			handler.ErrCodeReactionNotAllowed,
+			"reaction is not allowed",
+			"",
+		)
+	}
+	appKind, ok := request.GetAppKind(ctx)
+	if !ok {
+		return handler.NewServiceError(
+			handler.ErrCodeInternal,
+			"failed to get app kind",
+			"",
+		)
+	}
+	userID, ok := request.GetUserID(ctx)
+	if !ok {
+		return handler.NewServiceError(
+			handler.ErrCodeInternal,
+			"failed to get userID",
+			"",
+		)
+	}
+	param := &entity.Reaction{
+		MessageID: messageID,
+		Reaction:  reaction,
+	}
+	if appKind == "company" {
+		companyUserID, err := ar.MessageOwnerGetter.GetThreadCompanyOwnerByVisibleMessageID(ctx, ar.DBHandlers["common"], messageID)
+		if err != nil {
+			return newLookupServiceError(handler.ErrCodeMessageNotFound, "message not found", "failed to get threadCompanyOwner", err)
+		}
+		if userID != companyUserID {
+			return handler.NewServiceError(
+				handler.ErrCodeForbidden,
+				"unauthorized: lack the necessary permissions to react to message",
+				"",
+			)
+		}
+		param.IsFromCompany = 1
+	} else if appKind == "student" {
+		studentUserID, err := ar.MessageOwnerGetter.GetThreadStudentOwnerByVisibleMessageID(ctx, ar.DBHandlers["common"], messageID)
+		if err != nil {
+			return newLookupServiceError(handler.ErrCodeMessageNotFound, "message not found", "failed to get threadStudentOwner", err)
+		}
+		if userID != studentUserID {
+			return handler.NewServiceError(
+				handler.ErrCodeForbidden,
+				"unauthorized: lack the necessary permissions to react to message",
+				"",
+			)
+		}
+		param.IsFromStudent = 1
+	}
+	// 同じリアクションを重ねて付けても 1 件として扱う。重複はリポジトリが無視する
+	if err := ar.ReactionAdder.AddReaction(ctx, ar.DBHandlers["common"], param); err != nil {
+		return newInternalServiceError("failed to add reaction", err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+func TestAddReaction_AddReaction(t *testing.T) {
+	type testCase struct {
+		name             string
+		appKind          string
+		userID           int64
+		reaction         string
+		prepareOwnerMock func(*MessageOwnerGetterMock)
+		prepareAdderMock func(*ReactionAdderMock)
+		wantAdded        *entity.Reaction
+		wantErr          bool
+		wantErrStatus    int
+		wantErrMsg       string
+	}
+	companyOwner := func(m *MessageOwnerGetterMock) {
+		m.GetThreadCompanyOwnerByVisibleMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+			return 1, nil
+		}
+	}
+	studentOwner := func(m *MessageOwnerGetterMock) {
+		m.GetThreadStudentOwnerByVisibleMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+			return 1, nil
+		}
+	}
+	addSuccess := func(m *ReactionAdderMock) {
+		m.AddReactionFunc = func(ctx context.Context, db store.Execer, param *entity.Reaction) error {
+			return nil
+		}
+	}
+	tests := []testCase{
+		{
+			name:          "reaction is not allowed",
+			appKind:       "company",
+			userID:        1,
+			reaction:      "😡",
+			wantErr:       true,
+			wantErrStatus: http.StatusBadRequest,
+			wantErrMsg:    "reaction is not allowed",
+		},
+		{
+			name:          "fail if no appKind in context",
+			appKind:       "",
+			reaction:      "👍",
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get app kind",
+		},
+		{
+			name:          "fail if no userID in context",
+			appKind:       "company",
+			userID:        0,
+			reaction:      "👍",
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get userID",
+		},
+		{
+			name:     "company: fail to get thread owner",
+			appKind:  "company",
+			userID:   1,
+			reaction: "👍",
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerByVisibleMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 0, errors.New("owner query error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get threadCompanyOwner",
+		},
+		{
+			name:     "student: user is not thread owner => forbidden",
+			appKind:  "student",
+			userID:   1,
+			reaction: "👍",
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadStudentOwnerByVisibleMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 2, nil
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "unauthorized: lack the necessary permissions to react to message",
+		},
+		{
+			name:             "fail to add reaction",
+			appKind:          "company",
+			userID:           1,
+			reaction:         "👍",
+			prepareOwnerMock: companyOwner,
+			prepareAdderMock: func(m *ReactionAdderMock) {
+				m.AddReactionFunc = func(ctx context.Context, db store.Execer, param *entity.Reaction) error {
+					return errors.New("insert error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to add reaction",
+		},
+		{
+			name:             "company: success",
+			appKind:          "company",
+			userID:           1,
+			reaction:         "👍",
+			prepareOwnerMock: companyOwner,
+			prepareAdderMock: addSuccess,
+			wantAdded:        &entity.Reaction{MessageID: 5, IsFromCompany: 1, IsFromStudent: 0, Reaction: "👍"},
+			wantErr:          false,
+		},
+		{
+			name:             "student: success",
+			appKind:          "student",
+			userID:           1,
+			reaction:         "ありがとうございます",
+			prepareOwnerMock: studentOwner,
+			prepareAdderMock: addSuccess,
+			wantAdded:        &entity.Reaction{MessageID: 5, IsFromCompany: 0, IsFromStudent: 1, Reaction: "ありがとうございます"},
+			wantErr:          false,
+		},
+	}
+	dbHandlers := map[string]*sqlx.DB{
+		"common": nil,
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tc.appKind != "" {
+				ctx = request.SetAppKind(ctx, tc.appKind)
+			}
+			if tc.userID != 0 {
+				ctx = request.SetUserID(ctx, tc.userID)
+			}
+			ownerMock := &MessageOwnerGetterMock{}
+			adderMock := &ReactionAdderMock{}
+			if tc.prepareOwnerMock != nil {
+				tc.prepareOwnerMock(ownerMock)
+			}
+			if tc.prepareAdderMock != nil {
+				tc.prepareAdderMock(adderMock)
+			}
+			svc := NewAddReaction(dbHandlers, adderMock, ownerMock)
+			err := svc.AddReaction(ctx, entity.MessageID(5), tc.reaction)
+			if tc.wantErr {
+				assert.Error(t, err, "error is expected but got nil")
+				se, ok := err.(*handler.ServiceError)
+				if assert.True(t, ok, "error should be *handler.ServiceError") {
+					assert.Equal(t, tc.wantErrStatus, se.StatusCode)
+					assert.Contains(t, se.Message, tc.wantErrMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+				if tc.wantAdded == nil {
+					assert.Empty(t, adderMock.AddReactionCalls())
+				} else if assert.Len(t, adderMock.AddReactionCalls(), 1) {
+					assert.Equal(t, tc.wantAdded, adderMock.AddReactionCalls()[0].Param)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/metrics"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+// 1トランザクションで挿入する件数。ロックの保持時間を抑えるためバッチごとにコミットする
+const bulkAddMessageBatchSize = 100
+
+type BulkAddMessage struct {
+	DBHandlers         map[string]*sqlx.DB
+	MessageAdder       MessageAdder
+	MessageOwnerGetter MessageOwnerGetter
+}
+
+func NewBulkAddMessage(dbHandlers map[string]*sqlx.DB, messageAdder MessageAdder, messageOwnerGetter MessageOwnerGetter) *BulkAddMessage {
+	return &BulkAddMessage{
+		DBHandlers:         dbHandlers,
+		MessageAdder:       messageAdder,
+		MessageOwnerGetter: messageOwnerGetter,
+	}
+}
+
+// BulkAddMessages は messages をスレッドごとの本文で送信する。同じスレッドが複数ある場合は最初のものを送る
+func (bam *BulkAddMessage) BulkAddMessages(ctx context.Context, messages []entity.BulkMessage, isSent int8, sentAt time.Time) (entity.BulkSendResults, error) {
+	ctx, span := tracer.Start(ctx, "BulkAddMessage.BulkAddMessages")
+	defer span.End()
+	appKind, ok := request.GetAppKind(ctx)
+	if !ok {
+		return nil, handler.NewServiceError(
+			handler.ErrCodeInternal,
+			"failed to get app kind",
+			"",
+		)
+	}
+	userID, ok := request.GetUserID(ctx)
+	if !ok {
+		return nil, handler.NewServiceError(
+			handler.ErrCodeInternal,
+			"failed to get userID",
+			"",
+		)
+	}
+	if appKind != "company" {
+		return nil, handler.NewServiceError(
+			handler.ErrCodeForbidden,
+			"unauthorized: bulk send is only available to company users",
+			"",
+		)
+	}
+	ids, contents := uniqueBulkMessages(messages)
+	threads, err := bam.MessageOwnerGetter.GetThreadsByIDs(ctx, bam.DBHandlers["common"], ids)
+	if err != nil {
+		return nil, newInternalServiceError("failed to get message threads", err)
+	}
+	companyOwners := make(map[entity.MessageThreadID]int64, len(threads))
+	for _, mt := range threads {
+		companyOwners[mt.ID] = mt.CompanyUserID
+	}
+	results := make(entity.BulkSendResults, 0, len(ids))
+	var targets entity.BulkSendResults
+	for _, id := range ids {
+		r := &entity.BulkSendResult{MessageThreadID: id}
+		companyUserID, ok := companyOwners[id]
+		if !ok {
+			r.Status = entity.BulkSendStatusNotFound
+		} else if companyUserID != userID {
+			r.Status = entity.BulkSendStatusForbidden
+		} else {
+			targets = append(targets, r)
+		}
+		results = append(results, r)
+	}
+	for start := 0; start < len(targets); start += bulkAddMessageBatchSize {
+		end := min(start+bulkAddMessageBatchSize, len(targets))
+		batch := targets[start:end]
+		// 失敗したバッチはロールバックされるため、そのバッチのスレッドのみ failed とし、後続のバッチは続行する
+		if err := bam.addBatch(ctx, batch, contents, isSent, sentAt); err != nil {
+			code := handler.ErrCodeInternal
+			if store.IsRetryable(err) {
+				code = handler.ErrCodeTemporarilyUnavailable
+			}
+			failedIDs := make([]int64, 0, len(batch))
+			for _, r := range batch {
+				r.Status = entity.BulkSendStatusFailed
+				r.MessageID = nil
+				r.Code = string(code)
+				failedIDs = append(failedIDs, int64(r.MessageThreadID))
+			}
+			slog.ErrorContext(ctx, "failed to add bulk messages",
+				slog.String("code", string(code)),
+				slog.Any("message_thread_ids", failedIDs),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	return results, nil
+}
+
+func (bam *BulkAddMessage) addBatch(ctx context.Context, batch entity.BulkSendResults, contents map[entity.MessageThreadID]string, isSent int8, sentAt time.Time) error {
+	tx, err := bam.DBHandlers["common"].BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, r := range batch {
+		m := &entity.Message{
+			MessageThreadID: r.MessageThreadID,
+			IsFromCompany:   1,
+			IsFromStudent:   0,
+			Content:         contents[r.MessageThreadID],
+			IsSent:          isSent,
+			SentAt:          sentAt,
+		}
+		if err := bam.MessageAdder.AddMessage(ctx, tx, m); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		r.Status = entity.BulkSendStatusSent
+		r.MessageID = &m.ID
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if isSent == 1 {
+		metrics.MessagesSent.WithLabelValues("company").Add(float64(len(batch)))
+	}
+	return nil
+}
+
+// uniqueBulkMessages は重複を除いたスレッドIDを元の順序で、本文をスレッドIDごとに返す
+func uniqueBulkMessages(messages []entity.BulkMessage) ([]entity.MessageThreadID, map[entity.MessageThreadID]string) {
+	contents := make(map[entity.MessageThreadID]string, len(messages))
+	ids := make([]entity.MessageThreadID, 0, len(messages))
+	for _, m := range messages {
+		if _, ok := contents[m.MessageThreadID]; ok {
+			continue
+		}
+		contents[m.MessageThreadID] = m.Content
+		ids = append(ids, m.MessageThreadID)
+	}
+	return ids, contents
+}
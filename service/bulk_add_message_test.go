@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+func TestBulkAddMessage_BulkAddMessages(t *testing.T) {
+	type testCase struct {
+		name                   string
+		appKind                string
+		userID                 int64
+		messageThreadIDs       []entity.MessageThreadID
+		prepareOwnerGetterMock func(*MessageOwnerGetterMock)
+		prepareAdderMock       func(*MessageAdderMock)
+		prepareSQLMock         func(sqlmock.Sqlmock)
+		wantStatuses           map[entity.MessageThreadID]string
+		wantOrder              []entity.MessageThreadID
+		wantErr                bool
+		wantErrStatus          int
+		wantErrMsg             string
+	}
+	ownThreads := func(m *MessageOwnerGetterMock) {
+		m.GetThreadsByIDsFunc = func(ctx context.Context, db store.Queryer, ids []entity.MessageThreadID) ([]*entity.MessageThread, error) {
+			var threads []*entity.MessageThread
+			for _, id := range ids {
+				switch {
+				case id == 2:
+					threads = append(threads, &entity.MessageThread{ID: id, CompanyUserID: 99})
+				case id == 3:
+					// 存在しないスレッド
+				default:
+					threads = append(threads, &entity.MessageThread{ID: id, CompanyUserID: 1})
+				}
+			}
+			return threads, nil
+		}
+	}
+	// スレッドごとに異なる本文を送る
+	contentFor := func(id entity.MessageThreadID) string {
+		return fmt.Sprintf("スレッド%dへのご案内", id)
+	}
+	manyThreadIDs := make([]entity.MessageThreadID, 0, bulkAddMessageBatchSize+1)
+	for i := 0; i < bulkAddMessageBatchSize+1; i++ {
+		manyThreadIDs = append(manyThreadIDs, entity.MessageThreadID(100+i))
+	}
+	tests := []testCase{
+		{
+			name:          "fail if no appKind in context",
+			appKind:       "",
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get app kind",
+		},
+		{
+			name:          "student => forbidden",
+			appKind:       "student",
+			userID:        1,
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "unauthorized: bulk send is only available to company users",
+		},
+		{
+			name:             "fail to get threads",
+			appKind:          "company",
+			userID:           1,
+			messageThreadIDs: []entity.MessageThreadID{1},
+			prepareOwnerGetterMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadsByIDsFunc = func(ctx context.Context, db store.Queryer, ids []entity.MessageThreadID) ([]*entity.MessageThread, error) {
+					return nil, errors.New("thread query error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get message threads",
+		},
+		{
+			name:                   "mixed results with duplicated thread IDs",
+			appKind:                "company",
+			userID:                 1,
+			messageThreadIDs:       []entity.MessageThreadID{1, 2, 3, 4, 1},
+			prepareOwnerGetterMock: ownThreads,
+			prepareAdderMock: func(m *MessageAdderMock) {
+				m.AddMessageFunc = func(ctx context.Context, db store.Execer, param *entity.Message) error {
+					if param.Content != contentFor(param.MessageThreadID) {
+						return fmt.Errorf("unexpected content: %s", param.Content)
+					}
+					param.ID = entity.MessageID(param.MessageThreadID) * 10
+					return nil
+				}
+			},
+			prepareSQLMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			wantStatuses: map[entity.MessageThreadID]string{
+				1: entity.BulkSendStatusSent,
+				2: entity.BulkSendStatusForbidden,
+				3: entity.BulkSendStatusNotFound,
+				4: entity.BulkSendStatusSent,
+			},
+			wantOrder: []entity.MessageThreadID{1, 2, 3, 4},
+			wantErr:   false,
+		},
+		{
+			name:                   "insert failure rolls back the batch",
+			appKind:                "company",
+			userID:                 1,
+			messageThreadIDs:       []entity.MessageThreadID{1, 4},
+			prepareOwnerGetterMock: ownThreads,
+			prepareAdderMock: func(m *MessageAdderMock) {
+				m.AddMessageFunc = func(ctx context.Context, db store.Execer, param *entity.Message) error {
+					if param.MessageThreadID == 4 {
+						return errors.New("insert error")
+					}
+					return nil
+				}
+			},
+			prepareSQLMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantStatuses: map[entity.MessageThreadID]string{
+				1: entity.BulkSendStatusFailed,
+				4: entity.BulkSendStatusFailed,
+			},
+			wantOrder: []entity.MessageThreadID{1, 4},
+			wantErr:   false,
+		},
+		{
+			name:                   "only the failed batch is marked as failed",
+			appKind:                "company",
+			userID:                 1,
+			messageThreadIDs:       manyThreadIDs,
+			prepareOwnerGetterMock: ownThreads,
+			prepareAdderMock: func(m *MessageAdderMock) {
+				m.AddMessageFunc = func(ctx context.Context, db store.Execer, param *entity.Message) error {
+					return nil
+				}
+			},
+			prepareSQLMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectCommit().WillReturnError(errors.New("commit error"))
+			},
+			wantStatuses: map[entity.MessageThreadID]string{
+				100: entity.BulkSendStatusSent,
+				entity.MessageThreadID(100 + bulkAddMessageBatchSize): entity.BulkSendStatusFailed,
+			},
+			wantOrder: manyThreadIDs,
+			wantErr:   false,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = db.Close() })
+			dbHandlers := map[string]*sqlx.DB{
+				"common": sqlx.NewDb(db, "sqlmock"),
+			}
+			ctx := context.Background()
+			if tc.appKind != "" {
+				ctx = request.SetAppKind(ctx, tc.appKind)
+			}
+			if tc.userID != 0 {
+				ctx = request.SetUserID(ctx, tc.userID)
+			}
+			ownerGetterMock := &MessageOwnerGetterMock{}
+			adderMock := &MessageAdderMock{}
+			if tc.prepareOwnerGetterMock != nil {
+				tc.prepareOwnerGetterMock(ownerGetterMock)
+			}
+			if tc.prepareAdderMock != nil {
+				tc.prepareAdderMock(adderMock)
+			}
+			if tc.prepareSQLMock != nil {
+				tc.prepareSQLMock(mock)
+			}
+			svc := NewBulkAddMessage(dbHandlers, adderMock, ownerGetterMock)
+			messages := make([]entity.BulkMessage, 0, len(tc.messageThreadIDs))
+			for _, id := range tc.messageThreadIDs {
+				messages = append(messages, entity.BulkMessage{MessageThreadID: id, Content: contentFor(id)})
+			}
+			results, err := svc.BulkAddMessages(ctx, messages, 1, time.Now())
+			if tc.wantErr {
+				assert.Error(t, err, "error is expected but got nil")
+				se, ok := err.(*handler.ServiceError)
+				if assert.True(t, ok, "error should be *handler.ServiceError") {
+					assert.Equal(t, tc.wantErrStatus, se.StatusCode)
+					assert.Contains(t, se.Message, tc.wantErrMsg)
+				}
+				assert.Nil(t, results)
+			} else {
+				assert.NoError(t, err)
+				if assert.Len(t, results, len(tc.wantOrder)) {
+					for i, r := range results {
+						assert.Equal(t, tc.wantOrder[i], r.MessageThreadID)
+						if want, ok := tc.wantStatuses[r.MessageThreadID]; ok {
+							assert.Equal(t, want, r.Status, "thread %d", r.MessageThreadID)
+						}
+						if r.Status == entity.BulkSendStatusSent {
+							assert.NotNil(t, r.MessageID)
+						} else {
+							assert.Nil(t, r.MessageID)
+						}
+						// DB のエラーの内容はレスポンスに含めず、エラーコードのみ返す
+						if r.Status == entity.BulkSendStatusFailed {
+							assert.Equal(t, string(handler.ErrCodeInternal), r.Code)
+						} else {
+							assert.Empty(t, r.Code)
+						}
+					}
+				}
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
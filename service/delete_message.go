@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/jmoiron/sqlx"
 
@@ -26,10 +25,12 @@ func NewDeleteMessage(dbHandlers map[string]*sqlx.DB, messageDeleter MessageDele
 }
 
 func (dm *DeleteMessage) DeleteMessage(ctx context.Context, id entity.MessageID) error {
+	ctx, span := tracer.Start(ctx, "DeleteMessage.DeleteMessage")
+	defer span.End()
 	appKind, ok := request.GetAppKind(ctx)
 	if !ok {
 		return handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to get app kind",
 			"",
 		)
@@ -37,7 +38,7 @@ func (dm *DeleteMessage) DeleteMessage(ctx context.Context, id entity.MessageID)
 	userID, ok := request.GetUserID(ctx)
 	if !ok {
 		return handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to get userID",
 			"",
 		)
@@ -45,15 +46,11 @@ func (dm *DeleteMessage) DeleteMessage(ctx context.Context, id entity.MessageID)
 	if appKind == "company" {
 		companyUserID, err := dm.MessageOwnerGetter.GetThreadCompanyOwnerByMessageID(ctx, dm.DBHandlers["common"], id)
 		if err != nil {
-			return handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to get threadCompanyOwner",
-				err.Error(),
-			)
+			return newLookupServiceError(handler.ErrCodeMessageNotFound, "message not found", "failed to get threadCompanyOwner", err)
 		}
 		if userID != companyUserID {
 			return handler.NewServiceError(
-				http.StatusForbidden,
+				handler.ErrCodeForbidden,
 				"unauthorized: lack the necessary permissions to delete message",
 				"",
 			)
@@ -61,15 +58,11 @@ func (dm *DeleteMessage) DeleteMessage(ctx context.Context, id entity.MessageID)
 	} else if appKind == "student" {
 		studentUserID, err := dm.MessageOwnerGetter.GetThreadStudentOwnerByMessageID(ctx, dm.DBHandlers["common"], id)
 		if err != nil {
-			return handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to get threadStudentOwner",
-				err.Error(),
-			)
+			return newLookupServiceError(handler.ErrCodeMessageNotFound, "message not found", "failed to get threadStudentOwner", err)
 		}
 		if userID != studentUserID {
 			return handler.NewServiceError(
-				http.StatusForbidden,
+				handler.ErrCodeForbidden,
 				"unauthorized: lack the necessary permissions to delete message",
 				"",
 			)
@@ -77,11 +70,7 @@ func (dm *DeleteMessage) DeleteMessage(ctx context.Context, id entity.MessageID)
 	}
 	err := dm.MessageDeleter.DeleteMessage(ctx, dm.DBHandlers["common"], id)
 	if err != nil {
-		return handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to delete message",
-			err.Error(),
-		)
+		return newInternalServiceError("failed to delete message", err)
 	}
 	return nil
 }
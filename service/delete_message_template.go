@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type DeleteMessageTemplate struct {
+	DBHandlers             map[string]*sqlx.DB
+	MessageTemplateGetter  MessageTemplateGetter
+	MessageTemplateDeleter MessageTemplateDeleter
+}
+
+func NewDeleteMessageTemplate(dbHandlers map[string]*sqlx.DB, messageTemplateGetter MessageTemplateGetter, messageTemplateDeleter MessageTemplateDeleter) *DeleteMessageTemplate {
+	return &DeleteMessageTemplate{
+		DBHandlers:             dbHandlers,
+		MessageTemplateGetter:  messageTemplateGetter,
+		MessageTemplateDeleter: messageTemplateDeleter,
+	}
+}
+
+func (dmt *DeleteMessageTemplate) DeleteMessageTemplate(ctx context.Context, id entity.MessageTemplateID) error {
+	ctx, span := tracer.Start(ctx, "DeleteMessageTemplate.DeleteMessageTemplate")
+	defer span.End()
+	companyUserID, err := getTemplateOwnerID(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := getOwnMessageTemplate(ctx, dmt.MessageTemplateGetter, dmt.DBHandlers["company"], id, companyUserID); err != nil {
+		return err
+	}
+	if err := dmt.MessageTemplateDeleter.DeleteMessageTemplate(ctx, dmt.DBHandlers["company"], id); err != nil {
+		return newInternalServiceError("failed to delete message template", err)
+	}
+	return nil
+}
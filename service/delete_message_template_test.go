@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+func TestDeleteMessageTemplate_DeleteMessageTemplate(t *testing.T) {
+	type testCase struct {
+		name               string
+		appKind            string
+		userID             int64
+		prepareGetterMock  func(*MessageTemplateGetterMock)
+		prepareDeleterMock func(*MessageTemplateDeleterMock)
+		wantErr            bool
+		wantErrStatus      int
+		wantErrMsg         string
+	}
+	ownTemplate := func(m *MessageTemplateGetterMock) {
+		m.GetMessageTemplateFunc = func(ctx context.Context, db store.Queryer, id entity.MessageTemplateID) (*entity.MessageTemplate, error) {
+			return &entity.MessageTemplate{ID: id, CompanyUserID: 1}, nil
+		}
+	}
+	tests := []testCase{
+		{
+			name:          "student => forbidden",
+			appKind:       "student",
+			userID:        1,
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "unauthorized: message templates are only available to company users",
+		},
+		{
+			name:              "template of another company user => forbidden",
+			appKind:           "company",
+			userID:            2,
+			prepareGetterMock: ownTemplate,
+			wantErr:           true,
+			wantErrStatus:     http.StatusForbidden,
+			wantErrMsg:        "unauthorized: lack the necessary permissions to use message template",
+		},
+		{
+			name:              "fail to delete template",
+			appKind:           "company",
+			userID:            1,
+			prepareGetterMock: ownTemplate,
+			prepareDeleterMock: func(m *MessageTemplateDeleterMock) {
+				m.DeleteMessageTemplateFunc = func(ctx context.Context, db store.Execer, id entity.MessageTemplateID) error {
+					return errors.New("delete error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to delete message template",
+		},
+		{
+			name:              "success",
+			appKind:           "company",
+			userID:            1,
+			prepareGetterMock: ownTemplate,
+			prepareDeleterMock: func(m *MessageTemplateDeleterMock) {
+				m.DeleteMessageTemplateFunc = func(ctx context.Context, db store.Execer, id entity.MessageTemplateID) error {
+					return nil
+				}
+			},
+			wantErr: false,
+		},
+	}
+	dbHandlers := map[string]*sqlx.DB{
+		"company": nil,
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tc.appKind != "" {
+				ctx = request.SetAppKind(ctx, tc.appKind)
+			}
+			if tc.userID != 0 {
+				ctx = request.SetUserID(ctx, tc.userID)
+			}
+			getterMock := &MessageTemplateGetterMock{}
+			deleterMock := &MessageTemplateDeleterMock{}
+			if tc.prepareGetterMock != nil {
+				tc.prepareGetterMock(getterMock)
+			}
+			if tc.prepareDeleterMock != nil {
+				tc.prepareDeleterMock(deleterMock)
+			}
+			svc := NewDeleteMessageTemplate(dbHandlers, getterMock, deleterMock)
+			err := svc.DeleteMessageTemplate(ctx, entity.MessageTemplateID(3))
+			if tc.wantErr {
+				assert.Error(t, err, "error is expected but got nil")
+				se, ok := err.(*handler.ServiceError)
+				if assert.True(t, ok, "error should be *handler.ServiceError") {
+					assert.Equal(t, tc.wantErrStatus, se.StatusCode)
+					assert.Contains(t, se.Message, tc.wantErrMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+				if assert.Len(t, deleterMock.DeleteMessageTemplateCalls(), 1) {
+					assert.Equal(t, entity.MessageTemplateID(3), deleterMock.DeleteMessageTemplateCalls()[0].ID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+)
+
+type DeleteReaction struct {
+	DBHandlers         map[string]*sqlx.DB
+	ReactionDeleter    ReactionDeleter
+	MessageOwnerGetter MessageOwnerGetter
+}
+
+func NewDeleteReaction(dbHandlers map[string]*sqlx.DB, reactionDeleter ReactionDeleter, messageOwnerGetter MessageOwnerGetter) *DeleteReaction {
+	return &DeleteReaction{
+		DBHandlers:         dbHandlers,
+		ReactionDeleter:    reactionDeleter,
+		MessageOwnerGetter: messageOwnerGetter,
+	}
+}
+
+func (dr *DeleteReaction) DeleteReaction(ctx context.Context, messageID entity.MessageID, reaction string) error {
+	ctx, span := tracer.Start(ctx, "DeleteReaction.DeleteReaction")
+	defer span.End()
+	if !entity.IsAllowedReaction(reaction) {
+		return handler.NewServiceError(
+			handler.ErrCodeReactionNotAllowed,
+			"reaction is not allowed",
+			"",
+		)
+	}
+	appKind, ok := request.GetAppKind(ctx)
+	if !ok {
+		return handler.NewServiceError(
+			handler.ErrCodeInternal,
+			"failed to get app kind",
+			"",
+		)
+	}
+	userID, ok := request.GetUserID(ctx)
+	if !ok {
+		return handler.NewServiceError(
+			handler.ErrCodeInternal,
+			"failed to get userID",
+			"",
+		)
+	}
+	param := &entity.Reaction{
+		MessageID: messageID,
+		Reaction:  reaction,
+	}
+	if appKind == "company" {
+		companyUserID, err := dr.MessageOwnerGetter.GetThreadCompanyOwnerByVisibleMessageID(ctx, dr.DBHandlers["common"], messageID)
+		if err != nil {
+			return newLookupServiceError(handler.ErrCodeMessageNotFound, "message not found", "failed to get threadCompanyOwner", err)
+		}
+		if userID != companyUserID {
+			return handler.NewServiceError(
+				handler.ErrCodeForbidden,
+				"unauthorized: lack the necessary permissions to delete reaction",
+				"",
+			)
+		}
+		param.IsFromCompany = 1
+	} else if appKind == "student" {
+		studentUserID, err := dr.MessageOwnerGetter.GetThreadStudentOwnerByVisibleMessageID(ctx, dr.DBHandlers["common"], messageID)
+		if err != nil {
+			return newLookupServiceError(handler.ErrCodeMessageNotFound, "message not found", "failed to get threadStudentOwner", err)
+		}
+		if userID != studentUserID {
+			return handler.NewServiceError(
+				handler.ErrCodeForbidden,
+				"unauthorized: lack the necessary permissions to delete reaction",
+				"",
+			)
+		}
+		param.IsFromStudent = 1
+	}
+	if err := dr.ReactionDeleter.DeleteReaction(ctx, dr.DBHandlers["common"], param); err != nil {
+		return newInternalServiceError("failed to delete reaction", err)
+	}
+	return nil
+}
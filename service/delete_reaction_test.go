@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+func TestDeleteReaction_DeleteReaction(t *testing.T) {
+	type testCase struct {
+		name               string
+		appKind            string
+		userID             int64
+		reaction           string
+		prepareOwnerMock   func(*MessageOwnerGetterMock)
+		prepareDeleterMock func(*ReactionDeleterMock)
+		wantDeleted        *entity.Reaction
+		wantErr            bool
+		wantErrStatus      int
+		wantErrMsg         string
+	}
+	tests := []testCase{
+		{
+			name:          "reaction is not allowed",
+			appKind:       "student",
+			userID:        1,
+			reaction:      "unknown",
+			wantErr:       true,
+			wantErrStatus: http.StatusBadRequest,
+			wantErrMsg:    "reaction is not allowed",
+		},
+		{
+			name:          "fail if no appKind in context",
+			appKind:       "",
+			reaction:      "👍",
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get app kind",
+		},
+		{
+			name:     "student: fail to get thread owner",
+			appKind:  "student",
+			userID:   1,
+			reaction: "👍",
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadStudentOwnerByVisibleMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 0, errors.New("owner query error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get threadStudentOwner",
+		},
+		{
+			name:     "company: user is not thread owner => forbidden",
+			appKind:  "company",
+			userID:   1,
+			reaction: "👍",
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerByVisibleMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 2, nil
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "unauthorized: lack the necessary permissions to delete reaction",
+		},
+		{
+			name:     "fail to delete reaction",
+			appKind:  "company",
+			userID:   1,
+			reaction: "👍",
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerByVisibleMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 1, nil
+				}
+			},
+			prepareDeleterMock: func(m *ReactionDeleterMock) {
+				m.DeleteReactionFunc = func(ctx context.Context, db store.Execer, param *entity.Reaction) error {
+					return errors.New("delete error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to delete reaction",
+		},
+		{
+			name:     "student: success",
+			appKind:  "student",
+			userID:   1,
+			reaction: "確認しました",
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadStudentOwnerByVisibleMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 1, nil
+				}
+			},
+			prepareDeleterMock: func(m *ReactionDeleterMock) {
+				m.DeleteReactionFunc = func(ctx context.Context, db store.Execer, param *entity.Reaction) error {
+					return nil
+				}
+			},
+			wantDeleted: &entity.Reaction{MessageID: 5, IsFromCompany: 0, IsFromStudent: 1, Reaction: "確認しました"},
+			wantErr:     false,
+		},
+	}
+	dbHandlers := map[string]*sqlx.DB{
+		"common": nil,
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tc.appKind != "" {
+				ctx = request.SetAppKind(ctx, tc.appKind)
+			}
+			if tc.userID != 0 {
+				ctx = request.SetUserID(ctx, tc.userID)
+			}
+			ownerMock := &MessageOwnerGetterMock{}
+			deleterMock := &ReactionDeleterMock{}
+			if tc.prepareOwnerMock != nil {
+				tc.prepareOwnerMock(ownerMock)
+			}
+			if tc.prepareDeleterMock != nil {
+				tc.prepareDeleterMock(deleterMock)
+			}
+			svc := NewDeleteReaction(dbHandlers, deleterMock, ownerMock)
+			err := svc.DeleteReaction(ctx, entity.MessageID(5), tc.reaction)
+			if tc.wantErr {
+				assert.Error(t, err, "error is expected but got nil")
+				se, ok := err.(*handler.ServiceError)
+				if assert.True(t, ok, "error should be *handler.ServiceError") {
+					assert.Equal(t, tc.wantErrStatus, se.StatusCode)
+					assert.Contains(t, se.Message, tc.wantErrMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+				if assert.Len(t, deleterMock.DeleteReactionCalls(), 1) {
+					assert.Equal(t, tc.wantDeleted, deleterMock.DeleteReactionCalls()[0].Param)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/blob"
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/credential"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+)
+
+type DownloadAttachment struct {
+	DBHandlers       map[string]*sqlx.DB
+	AttachmentGetter AttachmentGetter
+	BlobStorage      BlobStorage
+	SecretKey        []byte
+	Clocker          clock.Clocker
+}
+
+func NewDownloadAttachment(dbHandlers map[string]*sqlx.DB, attachmentGetter AttachmentGetter, blobStorage BlobStorage, secretKey []byte, clocker clock.Clocker) *DownloadAttachment {
+	return &DownloadAttachment{
+		DBHandlers:       dbHandlers,
+		AttachmentGetter: attachmentGetter,
+		BlobStorage:      blobStorage,
+		SecretKey:        secretKey,
+		Clocker:          clocker,
+	}
+}
+
+// 署名付きリンクは GetAttachmentLink がスレッドの所有者確認を済ませた上で発行しているため、
+// ここでは署名と有効期限のみを検証する
+func (da *DownloadAttachment) DownloadAttachment(ctx context.Context, id entity.AttachmentID, expires, signature string) (*entity.Attachment, io.ReadCloser, error) {
+	ctx, span := tracer.Start(ctx, "DownloadAttachment.DownloadAttachment")
+	defer span.End()
+	if err := credential.VerifyAttachmentSignature(da.SecretKey, id, expires, signature, da.Clocker.Now().Time); err != nil {
+		return nil, nil, handler.NewServiceError(
+			handler.ErrCodeInvalidSignature,
+			"invalid_signature",
+			err.Error(),
+		)
+	}
+	a, err := da.AttachmentGetter.GetAttachment(ctx, da.DBHandlers["common"], id)
+	if err != nil {
+		return nil, nil, newLookupServiceError(handler.ErrCodeAttachmentNotFound, "attachment not found", "failed to get attachment", err)
+	}
+	body, err := da.BlobStorage.Get(ctx, a.StorageKey)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, nil, handler.NewServiceError(
+				handler.ErrCodeAttachmentNotFound,
+				"attachment not found",
+				err.Error(),
+			)
+		}
+		return nil, nil, newInternalServiceError("failed to read attachment", err)
+	}
+	return a, body, nil
+}
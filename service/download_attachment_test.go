@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/blob"
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/credential"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+func TestDownloadAttachment_DownloadAttachment(t *testing.T) {
+	secretKey := []byte("attachment-url-secret-key")
+	signedQuery := func(id entity.AttachmentID, expiresAt time.Time) url.Values {
+		u, _ := url.Parse(credential.SignAttachmentURL(secretKey, id, expiresAt))
+		return u.Query()
+	}
+	now := clock.FixedClocker{}.Now().Time
+	valid := signedQuery(1, now.Add(5*time.Minute))
+	expired := signedQuery(1, now.Add(-1*time.Minute))
+	foundAttachment := func(m *AttachmentGetterMock) {
+		m.GetAttachmentFunc = func(ctx context.Context, db store.Queryer, id entity.AttachmentID) (*entity.Attachment, error) {
+			return &entity.Attachment{ID: id, MessageID: 5, StorageKey: "attachments/5/abc", ContentType: "application/pdf"}, nil
+		}
+	}
+	type testCase struct {
+		name              string
+		id                entity.AttachmentID
+		expires           string
+		signature         string
+		prepareGetterMock func(*AttachmentGetterMock)
+		prepareBlobMock   func(*BlobStorageMock)
+		wantErr           bool
+		wantErrStatus     int
+		wantErrMsg        string
+	}
+	tests := []testCase{
+		{
+			name:          "tampered signature",
+			id:            1,
+			expires:       valid.Get("expires"),
+			signature:     "tampered",
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "invalid_signature",
+		},
+		{
+			name:          "signature for another attachment",
+			id:            2,
+			expires:       valid.Get("expires"),
+			signature:     valid.Get("signature"),
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "invalid_signature",
+		},
+		{
+			name:          "expired link",
+			id:            1,
+			expires:       expired.Get("expires"),
+			signature:     expired.Get("signature"),
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "invalid_signature",
+		},
+		{
+			name:      "fail to get attachment",
+			id:        1,
+			expires:   valid.Get("expires"),
+			signature: valid.Get("signature"),
+			prepareGetterMock: func(m *AttachmentGetterMock) {
+				m.GetAttachmentFunc = func(ctx context.Context, db store.Queryer, id entity.AttachmentID) (*entity.Attachment, error) {
+					return nil, errors.New("attachment query error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get attachment",
+		},
+		{
+			name:              "blob not found",
+			id:                1,
+			expires:           valid.Get("expires"),
+			signature:         valid.Get("signature"),
+			prepareGetterMock: foundAttachment,
+			prepareBlobMock: func(m *BlobStorageMock) {
+				m.GetFunc = func(ctx context.Context, key string) (io.ReadCloser, error) {
+					return nil, blob.ErrNotFound
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusNotFound,
+			wantErrMsg:    "attachment not found",
+		},
+		{
+			name:              "fail to read blob",
+			id:                1,
+			expires:           valid.Get("expires"),
+			signature:         valid.Get("signature"),
+			prepareGetterMock: foundAttachment,
+			prepareBlobMock: func(m *BlobStorageMock) {
+				m.GetFunc = func(ctx context.Context, key string) (io.ReadCloser, error) {
+					return nil, errors.New("storage error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to read attachment",
+		},
+		{
+			name:              "success",
+			id:                1,
+			expires:           valid.Get("expires"),
+			signature:         valid.Get("signature"),
+			prepareGetterMock: foundAttachment,
+			prepareBlobMock: func(m *BlobStorageMock) {
+				m.GetFunc = func(ctx context.Context, key string) (io.ReadCloser, error) {
+					return io.NopCloser(strings.NewReader("%PDF-1.4")), nil
+				}
+			},
+			wantErr: false,
+		},
+	}
+	dbHandlers := map[string]*sqlx.DB{
+		"common": nil,
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			getterMock := &AttachmentGetterMock{}
+			blobMock := &BlobStorageMock{}
+			if tc.prepareGetterMock != nil {
+				tc.prepareGetterMock(getterMock)
+			}
+			if tc.prepareBlobMock != nil {
+				tc.prepareBlobMock(blobMock)
+			}
+			svc := NewDownloadAttachment(dbHandlers, getterMock, blobMock, secretKey, clock.FixedClocker{})
+			a, body, err := svc.DownloadAttachment(context.Background(), tc.id, tc.expires, tc.signature)
+			if tc.wantErr {
+				assert.Error(t, err, "error is expected but got nil")
+				se, ok := err.(*handler.ServiceError)
+				if assert.True(t, ok, "error should be *handler.ServiceError") {
+					assert.Equal(t, tc.wantErrStatus, se.StatusCode)
+					assert.Contains(t, se.Message, tc.wantErrMsg)
+				}
+				assert.Nil(t, a)
+				assert.Nil(t, body)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "attachments/5/abc", a.StorageKey)
+				content, err := io.ReadAll(body)
+				assert.NoError(t, err)
+				assert.Equal(t, "%PDF-1.4", string(content))
+				assert.Equal(t, "attachments/5/abc", blobMock.GetCalls()[0].Key)
+			}
+		})
+	}
+}
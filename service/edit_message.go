@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/jmoiron/sqlx"
 
@@ -26,10 +25,12 @@ func NewEditMessage(dbHandlers map[string]*sqlx.DB, messageEditor MessageEditor,
 }
 
 func (em *EditMessage) EditMessage(ctx context.Context, id entity.MessageID, content string) error {
+	ctx, span := tracer.Start(ctx, "EditMessage.EditMessage")
+	defer span.End()
 	appKind, ok := request.GetAppKind(ctx)
 	if !ok {
 		return handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to get app kind",
 			"",
 		)
@@ -37,7 +38,7 @@ func (em *EditMessage) EditMessage(ctx context.Context, id entity.MessageID, con
 	userID, ok := request.GetUserID(ctx)
 	if !ok {
 		return handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to get userID",
 			"",
 		)
@@ -45,15 +46,11 @@ func (em *EditMessage) EditMessage(ctx context.Context, id entity.MessageID, con
 	if appKind == "company" {
 		companyUserID, err := em.MessageOwnerGetter.GetThreadCompanyOwnerByMessageID(ctx, em.DBHandlers["common"], id)
 		if err != nil {
-			return handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to get threadCompanyOwner",
-				err.Error(),
-			)
+			return newLookupServiceError(handler.ErrCodeMessageNotFound, "message not found", "failed to get threadCompanyOwner", err)
 		}
 		if userID != companyUserID {
 			return handler.NewServiceError(
-				http.StatusForbidden,
+				handler.ErrCodeForbidden,
 				"unauthorized: lack the necessary permissions to edit message",
 				"",
 			)
@@ -61,15 +58,11 @@ func (em *EditMessage) EditMessage(ctx context.Context, id entity.MessageID, con
 	} else if appKind == "student" {
 		studentUserID, err := em.MessageOwnerGetter.GetThreadStudentOwnerByMessageID(ctx, em.DBHandlers["common"], id)
 		if err != nil {
-			return handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to get threadStudentOwner",
-				err.Error(),
-			)
+			return newLookupServiceError(handler.ErrCodeMessageNotFound, "message not found", "failed to get threadStudentOwner", err)
 		}
 		if userID != studentUserID {
 			return handler.NewServiceError(
-				http.StatusForbidden,
+				handler.ErrCodeForbidden,
 				"unauthorized: lack the necessary permissions to edit message",
 				"",
 			)
@@ -81,11 +74,7 @@ func (em *EditMessage) EditMessage(ctx context.Context, id entity.MessageID, con
 	}
 	err := em.MessageEditor.EditMessage(ctx, em.DBHandlers["common"], m)
 	if err != nil {
-		return handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to edit message",
-			err.Error(),
-		)
+		return newInternalServiceError("failed to edit message", err)
 	}
 	return nil
 }
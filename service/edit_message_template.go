@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type EditMessageTemplate struct {
+	DBHandlers            map[string]*sqlx.DB
+	MessageTemplateGetter MessageTemplateGetter
+	MessageTemplateEditor MessageTemplateEditor
+}
+
+func NewEditMessageTemplate(dbHandlers map[string]*sqlx.DB, messageTemplateGetter MessageTemplateGetter, messageTemplateEditor MessageTemplateEditor) *EditMessageTemplate {
+	return &EditMessageTemplate{
+		DBHandlers:            dbHandlers,
+		MessageTemplateGetter: messageTemplateGetter,
+		MessageTemplateEditor: messageTemplateEditor,
+	}
+}
+
+func (emt *EditMessageTemplate) EditMessageTemplate(ctx context.Context, id entity.MessageTemplateID, name, content string) error {
+	ctx, span := tracer.Start(ctx, "EditMessageTemplate.EditMessageTemplate")
+	defer span.End()
+	companyUserID, err := getTemplateOwnerID(ctx)
+	if err != nil {
+		return err
+	}
+	t, err := getOwnMessageTemplate(ctx, emt.MessageTemplateGetter, emt.DBHandlers["company"], id, companyUserID)
+	if err != nil {
+		return err
+	}
+	t.Name = name
+	t.Content = content
+	if err := emt.MessageTemplateEditor.EditMessageTemplate(ctx, emt.DBHandlers["company"], t); err != nil {
+		return newInternalServiceError("failed to edit message template", err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+func TestEditMessageTemplate_EditMessageTemplate(t *testing.T) {
+	type testCase struct {
+		name              string
+		appKind           string
+		userID            int64
+		prepareGetterMock func(*MessageTemplateGetterMock)
+		prepareEditorMock func(*MessageTemplateEditorMock)
+		wantErr           bool
+		wantErrStatus     int
+		wantErrMsg        string
+	}
+	ownTemplate := func(m *MessageTemplateGetterMock) {
+		m.GetMessageTemplateFunc = func(ctx context.Context, db store.Queryer, id entity.MessageTemplateID) (*entity.MessageTemplate, error) {
+			return &entity.MessageTemplate{ID: id, CompanyUserID: 1, Name: "old", Content: "old"}, nil
+		}
+	}
+	tests := []testCase{
+		{
+			name:          "student => forbidden",
+			appKind:       "student",
+			userID:        1,
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "unauthorized: message templates are only available to company users",
+		},
+		{
+			name:    "template not found",
+			appKind: "company",
+			userID:  1,
+			prepareGetterMock: func(m *MessageTemplateGetterMock) {
+				m.GetMessageTemplateFunc = func(ctx context.Context, db store.Queryer, id entity.MessageTemplateID) (*entity.MessageTemplate, error) {
+					return nil, sql.ErrNoRows
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusNotFound,
+			wantErrMsg:    "message template not found",
+		},
+		{
+			name:    "fail to get template",
+			appKind: "company",
+			userID:  1,
+			prepareGetterMock: func(m *MessageTemplateGetterMock) {
+				m.GetMessageTemplateFunc = func(ctx context.Context, db store.Queryer, id entity.MessageTemplateID) (*entity.MessageTemplate, error) {
+					return nil, errors.New("template query error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get message template",
+		},
+		{
+			name:              "template of another company user => forbidden",
+			appKind:           "company",
+			userID:            2,
+			prepareGetterMock: ownTemplate,
+			wantErr:           true,
+			wantErrStatus:     http.StatusForbidden,
+			wantErrMsg:        "unauthorized: lack the necessary permissions to use message template",
+		},
+		{
+			name:              "fail to edit template",
+			appKind:           "company",
+			userID:            1,
+			prepareGetterMock: ownTemplate,
+			prepareEditorMock: func(m *MessageTemplateEditorMock) {
+				m.EditMessageTemplateFunc = func(ctx context.Context, db store.Execer, param *entity.MessageTemplate) error {
+					return errors.New("update error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to edit message template",
+		},
+		{
+			name:              "success",
+			appKind:           "company",
+			userID:            1,
+			prepareGetterMock: ownTemplate,
+			prepareEditorMock: func(m *MessageTemplateEditorMock) {
+				m.EditMessageTemplateFunc = func(ctx context.Context, db store.Execer, param *entity.MessageTemplate) error {
+					return nil
+				}
+			},
+			wantErr: false,
+		},
+	}
+	dbHandlers := map[string]*sqlx.DB{
+		"company": nil,
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tc.appKind != "" {
+				ctx = request.SetAppKind(ctx, tc.appKind)
+			}
+			if tc.userID != 0 {
+				ctx = request.SetUserID(ctx, tc.userID)
+			}
+			getterMock := &MessageTemplateGetterMock{}
+			editorMock := &MessageTemplateEditorMock{}
+			if tc.prepareGetterMock != nil {
+				tc.prepareGetterMock(getterMock)
+			}
+			if tc.prepareEditorMock != nil {
+				tc.prepareEditorMock(editorMock)
+			}
+			svc := NewEditMessageTemplate(dbHandlers, getterMock, editorMock)
+			err := svc.EditMessageTemplate(ctx, entity.MessageTemplateID(3), "面接案内", "{{student_name}}様")
+			if tc.wantErr {
+				assert.Error(t, err, "error is expected but got nil")
+				se, ok := err.(*handler.ServiceError)
+				if assert.True(t, ok, "error should be *handler.ServiceError") {
+					assert.Equal(t, tc.wantErrStatus, se.StatusCode)
+					assert.Contains(t, se.Message, tc.wantErrMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+				if assert.Len(t, editorMock.EditMessageTemplateCalls(), 1) {
+					param := editorMock.EditMessageTemplateCalls()[0].Param
+					assert.Equal(t, entity.MessageTemplateID(3), param.ID)
+					assert.Equal(t, "面接案内", param.Name)
+					assert.Equal(t, "{{student_name}}様", param.Content)
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"net/http"
 	"testing"
@@ -57,6 +58,21 @@ func TestEditMessage_EditMessage(t *testing.T) {
 			wantErrStatus: http.StatusInternalServerError,
 			wantErrMsg:    "failed to get threadCompanyOwner",
 		},
+		{
+			name:    "company: message not found or not own message => 404",
+			appKind: "company",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerByMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 0, sql.ErrNoRows
+				}
+			},
+			messageID:     1,
+			content:       "edited content",
+			wantErr:       true,
+			wantErrStatus: http.StatusNotFound,
+			wantErrMsg:    "message not found",
+		},
 		{
 			name:    "company: user mismatch => forbidden",
 			appKind: "company",
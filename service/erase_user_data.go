@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type EraseUserData struct {
+	DBHandlers     map[string]*sqlx.DB
+	UserDataGetter UserDataGetter
+	UserDataEraser UserDataEraser
+	BlobStorage    BlobStorage
+}
+
+func NewEraseUserData(dbHandlers map[string]*sqlx.DB, userDataGetter UserDataGetter, userDataEraser UserDataEraser, blobStorage BlobStorage) *EraseUserData {
+	return &EraseUserData{
+		DBHandlers:     dbHandlers,
+		UserDataGetter: userDataGetter,
+		UserDataEraser: userDataEraser,
+		BlobStorage:    blobStorage,
+	}
+}
+
+// EraseUserData は利用者が送ったメッセージ本文を匿名化し、その添付ファイルと認証情報を物理削除する。
+// dryRun の場合は対象件数のみを返し、データは変更しない
+func (eud *EraseUserData) EraseUserData(ctx context.Context, appKind string, userID int64, dryRun bool) (*entity.UserDataErasureReport, error) {
+	ctx, span := tracer.Start(ctx, "EraseUserData.EraseUserData")
+	defer span.End()
+	data, err := collectUserData(ctx, eud.DBHandlers, eud.UserDataGetter, appKind, userID)
+	if err != nil {
+		return nil, err
+	}
+	var messageIDs []entity.MessageID
+	ownMessages := make(map[entity.MessageID]struct{})
+	for _, m := range data.Messages {
+		if isOwnMessage(m, appKind) {
+			messageIDs = append(messageIDs, m.ID)
+			ownMessages[m.ID] = struct{}{}
+		}
+	}
+	var attachments entity.Attachments
+	var attachmentIDs []entity.AttachmentID
+	for _, a := range data.Attachments {
+		if _, ok := ownMessages[a.MessageID]; ok {
+			attachments = append(attachments, a)
+			attachmentIDs = append(attachmentIDs, a.ID)
+		}
+	}
+	report := &entity.UserDataErasureReport{
+		AppKind:            appKind,
+		UserID:             userID,
+		DryRun:             dryRun,
+		Threads:            len(data.Threads),
+		AnonymizedMessages: len(messageIDs),
+		DeletedAttachments: len(attachmentIDs),
+		DeletedCredentials: len(data.Credentials),
+	}
+	if dryRun {
+		return report, nil
+	}
+	tx, err := eud.DBHandlers["common"].BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, newInternalServiceError("failed to begin transaction", err)
+	}
+	if err := eud.UserDataEraser.AnonymizeMessages(ctx, tx, messageIDs); err != nil {
+		_ = tx.Rollback()
+		return nil, newInternalServiceError("failed to anonymize messages", err)
+	}
+	if err := eud.UserDataEraser.DeleteAttachments(ctx, tx, attachmentIDs); err != nil {
+		_ = tx.Rollback()
+		return nil, newInternalServiceError("failed to delete attachments", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, newInternalServiceError("failed to commit transaction", err)
+	}
+	if err := eud.UserDataEraser.DeleteCredentialsByUserID(ctx, eud.DBHandlers[appKind], userID); err != nil {
+		return nil, newInternalServiceError("failed to delete credentials", err)
+	}
+	// DBの削除が確定した後にファイルを消す。失敗したキーは手動で削除できるよう報告に含める
+	for _, a := range attachments {
+		if err := eud.BlobStorage.Delete(ctx, a.StorageKey); err != nil {
+			report.BlobDeleteFailures = append(report.BlobDeleteFailures, a.StorageKey)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+func TestEraseUserData_EraseUserData(t *testing.T) {
+	type testCase struct {
+		name              string
+		dryRun            bool
+		prepareEraserMock func(*UserDataEraserMock)
+		prepareBlobMock   func(*BlobStorageMock)
+		prepareSQLMock    func(sqlmock.Sqlmock)
+		wantReport        *entity.UserDataErasureReport
+		wantErr           bool
+		wantErrStatus     int
+		wantErrMsg        string
+	}
+	succeedingEraser := func(m *UserDataEraserMock) {
+		m.AnonymizeMessagesFunc = func(ctx context.Context, db store.Execer, ids []entity.MessageID) error {
+			return nil
+		}
+		m.DeleteAttachmentsFunc = func(ctx context.Context, db store.Execer, ids []entity.AttachmentID) error {
+			return nil
+		}
+		m.DeleteCredentialsByUserIDFunc = func(ctx context.Context, db store.Execer, userID int64) error {
+			return nil
+		}
+	}
+	tests := []testCase{
+		{
+			name:   "dry run does not modify data",
+			dryRun: true,
+			wantReport: &entity.UserDataErasureReport{
+				AppKind:            "student",
+				UserID:             1,
+				DryRun:             true,
+				Threads:            1,
+				AnonymizedMessages: 1,
+				DeletedAttachments: 1,
+				DeletedCredentials: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "fail to anonymize messages",
+			prepareEraserMock: func(m *UserDataEraserMock) {
+				m.AnonymizeMessagesFunc = func(ctx context.Context, db store.Execer, ids []entity.MessageID) error {
+					return errors.New("update error")
+				}
+			},
+			prepareSQLMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to anonymize messages",
+		},
+		{
+			name: "fail to delete credentials",
+			prepareEraserMock: func(m *UserDataEraserMock) {
+				succeedingEraser(m)
+				m.DeleteCredentialsByUserIDFunc = func(ctx context.Context, db store.Execer, userID int64) error {
+					return errors.New("delete error")
+				}
+			},
+			prepareSQLMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to delete credentials",
+		},
+		{
+			name:              "success with blob delete failure reported",
+			prepareEraserMock: succeedingEraser,
+			prepareBlobMock: func(m *BlobStorageMock) {
+				m.DeleteFunc = func(ctx context.Context, key string) error {
+					return errors.New("blob error")
+				}
+			},
+			prepareSQLMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			wantReport: &entity.UserDataErasureReport{
+				AppKind:            "student",
+				UserID:             1,
+				Threads:            1,
+				AnonymizedMessages: 1,
+				DeletedAttachments: 1,
+				DeletedCredentials: 1,
+				BlobDeleteFailures: []string{"attachments/2/def"},
+			},
+			wantErr: false,
+		},
+		{
+			name:              "success",
+			prepareEraserMock: succeedingEraser,
+			prepareBlobMock: func(m *BlobStorageMock) {
+				m.DeleteFunc = func(ctx context.Context, key string) error {
+					return nil
+				}
+			},
+			prepareSQLMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			wantReport: &entity.UserDataErasureReport{
+				AppKind:            "student",
+				UserID:             1,
+				Threads:            1,
+				AnonymizedMessages: 1,
+				DeletedAttachments: 1,
+				DeletedCredentials: 1,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = db.Close() })
+			dbHandlers := map[string]*sqlx.DB{
+				"common":  sqlx.NewDb(db, "sqlmock"),
+				"student": nil,
+			}
+			eraserMock := &UserDataEraserMock{}
+			blobMock := &BlobStorageMock{}
+			if tc.prepareEraserMock != nil {
+				tc.prepareEraserMock(eraserMock)
+			}
+			if tc.prepareBlobMock != nil {
+				tc.prepareBlobMock(blobMock)
+			}
+			if tc.prepareSQLMock != nil {
+				tc.prepareSQLMock(mock)
+			}
+			svc := NewEraseUserData(dbHandlers, newUserDataGetterMock(), eraserMock, blobMock)
+			report, err := svc.EraseUserData(context.Background(), "student", 1, tc.dryRun)
+			if tc.wantErr {
+				assert.Error(t, err, "error is expected but got nil")
+				se, ok := err.(*handler.ServiceError)
+				if assert.True(t, ok, "error should be *handler.ServiceError") {
+					assert.Equal(t, tc.wantErrStatus, se.StatusCode)
+					assert.Contains(t, se.Message, tc.wantErrMsg)
+				}
+				assert.Nil(t, report)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantReport, report)
+				if !tc.dryRun {
+					// 学生本人が送ったメッセージ・添付ファイルのみが対象となる
+					if assert.Len(t, eraserMock.AnonymizeMessagesCalls(), 1) {
+						assert.Equal(t, []entity.MessageID{2}, eraserMock.AnonymizeMessagesCalls()[0].Ids)
+					}
+					if assert.Len(t, eraserMock.DeleteAttachmentsCalls(), 1) {
+						assert.Equal(t, []entity.AttachmentID{8}, eraserMock.DeleteAttachmentsCalls()[0].Ids)
+					}
+				} else {
+					assert.Empty(t, eraserMock.AnonymizeMessagesCalls())
+					assert.Empty(t, eraserMock.DeleteCredentialsByUserIDCalls())
+				}
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
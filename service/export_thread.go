@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+)
+
+type ExportThread struct {
+	DBHandlers         map[string]*sqlx.DB
+	ReplicaHandlers    map[string]*sqlx.DB
+	MessageGetter      MessageGetter
+	MessageOwnerGetter MessageOwnerGetter
+}
+
+func NewExportThread(dbHandlers map[string]*sqlx.DB, replicaHandlers map[string]*sqlx.DB, messageGetter MessageGetter, messageOwnerGetter MessageOwnerGetter) *ExportThread {
+	return &ExportThread{
+		DBHandlers:         dbHandlers,
+		ReplicaHandlers:    replicaHandlers,
+		MessageGetter:      messageGetter,
+		MessageOwnerGetter: messageOwnerGetter,
+	}
+}
+
+// ExportThread は GetMessage と同じ閲覧権限・表示範囲で、スレッドのメッセージを1件ずつ fn に渡す。
+// スレッド全体をメモリに載せずに書き出すためで、権限がない場合は fn を呼ばずにエラーを返す
+func (et *ExportThread) ExportThread(ctx context.Context, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+	ctx, span := tracer.Start(ctx, "ExportThread.ExportThread")
+	defer span.End()
+	appKind, ok := request.GetAppKind(ctx)
+	if !ok {
+		return handler.NewServiceError(
+			handler.ErrCodeInternal,
+			"failed to get app kind",
+			"",
+		)
+	}
+	userID, ok := request.GetUserID(ctx)
+	if !ok {
+		return handler.NewServiceError(
+			handler.ErrCodeInternal,
+			"failed to get userID",
+			"",
+		)
+	}
+	db := readDB(ctx, et.DBHandlers, et.ReplicaHandlers, "common")
+	if err := checkThreadOwner(ctx, db, et.MessageOwnerGetter, appKind, userID, messageThreadID, "export messages"); err != nil {
+		return err
+	}
+	var err error
+	if appKind == "company" {
+		err = et.MessageGetter.EachMessageForCompanyUser(ctx, db, messageThreadID, fn)
+	} else if appKind == "student" {
+		err = et.MessageGetter.EachMessageForStudentUser(ctx, db, messageThreadID, fn)
+	}
+	if err != nil {
+		return newInternalServiceError("failed to export messages", err)
+	}
+	return nil
+}
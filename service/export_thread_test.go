@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+func TestExportThread_ExportThread(t *testing.T) {
+	type testCase struct {
+		name              string
+		appKind           string
+		userID            int64
+		prepareOwnerMock  func(*MessageOwnerGetterMock)
+		prepareGetterMock func(*MessageGetterMock)
+		wantMessages      entity.Messages
+		wantErr           bool
+		wantErrStatus     int
+		wantErrMsg        string
+	}
+	sentAt := time.Date(2025, 4, 1, 10, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	messages := entity.Messages{
+		&entity.Message{ID: 1, IsFromCompany: 1, Content: "面接日程のご案内", IsSent: 1, SentAt: sentAt},
+		&entity.Message{ID: 2, IsFromStudent: 1, Content: "承知しました", IsSent: 1, SentAt: sentAt.Add(time.Hour), UpdatedAt: &sql.NullTime{Time: sentAt.Add(2 * time.Hour), Valid: true}},
+	}
+	tests := []testCase{
+		{
+			name:          "fail if no appKind in context",
+			appKind:       "",
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get app kind",
+		},
+		{
+			name:    "company: not owner",
+			appKind: "company",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+					return 2, nil
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "unauthorized: lack the necessary permissions to export messages",
+		},
+		{
+			name:    "company: success",
+			appKind: "company",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+					return 1, nil
+				}
+			},
+			prepareGetterMock: func(m *MessageGetterMock) {
+				m.EachMessageForCompanyUserFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+					for _, m := range messages {
+						if err := fn(m); err != nil {
+							return err
+						}
+					}
+					return nil
+				}
+			},
+			wantMessages: messages,
+			wantErr:      false,
+		},
+		{
+			name:    "company: db error",
+			appKind: "company",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+					return 1, nil
+				}
+			},
+			prepareGetterMock: func(m *MessageGetterMock) {
+				m.EachMessageForCompanyUserFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+					return errors.New("db error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to export messages",
+		},
+		{
+			name:    "student: success",
+			appKind: "student",
+			userID:  5,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadStudentOwnerFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+					return 5, nil
+				}
+			},
+			prepareGetterMock: func(m *MessageGetterMock) {
+				m.EachMessageForStudentUserFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+					for _, m := range messages {
+						if err := fn(m); err != nil {
+							return err
+						}
+					}
+					return nil
+				}
+			},
+			wantMessages: messages,
+			wantErr:      false,
+		},
+	}
+	dbHandlers := map[string]*sqlx.DB{
+		"common": nil,
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tc.appKind != "" {
+				ctx = request.SetAppKind(ctx, tc.appKind)
+			}
+			if tc.userID != 0 {
+				ctx = request.SetUserID(ctx, tc.userID)
+			}
+			ownerMock := &MessageOwnerGetterMock{}
+			getterMock := &MessageGetterMock{}
+			if tc.prepareOwnerMock != nil {
+				tc.prepareOwnerMock(ownerMock)
+			}
+			if tc.prepareGetterMock != nil {
+				tc.prepareGetterMock(getterMock)
+			}
+			svc := NewExportThread(dbHandlers, nil, getterMock, ownerMock)
+			var got entity.Messages
+			err := svc.ExportThread(ctx, entity.MessageThreadID(1), func(m *entity.Message) error {
+				got = append(got, m)
+				return nil
+			})
+			if tc.wantErr {
+				assert.Error(t, err, "error is expected but got nil")
+				se, ok := err.(*handler.ServiceError)
+				if assert.True(t, ok, "error should be *handler.ServiceError") {
+					assert.Equal(t, tc.wantErrStatus, se.StatusCode)
+					assert.Contains(t, se.Message, tc.wantErrMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantMessages, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/export"
+)
+
+type ExportUserData struct {
+	DBHandlers     map[string]*sqlx.DB
+	UserDataGetter UserDataGetter
+	BlobStorage    BlobStorage
+}
+
+func NewExportUserData(dbHandlers map[string]*sqlx.DB, userDataGetter UserDataGetter, blobStorage BlobStorage) *ExportUserData {
+	return &ExportUserData{
+		DBHandlers:     dbHandlers,
+		UserDataGetter: userDataGetter,
+		BlobStorage:    blobStorage,
+	}
+}
+
+func (eud *ExportUserData) ExportUserData(ctx context.Context, appKind string, userID int64) (*entity.UserData, error) {
+	ctx, span := tracer.Start(ctx, "ExportUserData.ExportUserData")
+	defer span.End()
+	return collectUserData(ctx, eud.DBHandlers, eud.UserDataGetter, appKind, userID)
+}
+
+// WriteUserDataArchive は ExportUserData で集めたデータを、添付ファイルの実体とともに zip で書き出す
+func (eud *ExportUserData) WriteUserDataArchive(ctx context.Context, w io.Writer, data *entity.UserData) error {
+	ctx, span := tracer.Start(ctx, "ExportUserData.WriteUserDataArchive")
+	defer span.End()
+	return export.WriteUserDataArchive(w, data, func(a *entity.Attachment) (io.ReadCloser, error) {
+		return eud.BlobStorage.Get(ctx, a.StorageKey)
+	})
+}
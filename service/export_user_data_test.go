@@ -0,0 +1,150 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+// newUserDataGetterMock はスレッド・メッセージ・添付ファイル・認証情報をそれぞれ1件以上返すモックを作る
+func newUserDataGetterMock() *UserDataGetterMock {
+	return &UserDataGetterMock{
+		GetThreadsByUserIDFunc: func(ctx context.Context, db store.Queryer, appKind string, userID int64) ([]*entity.MessageThread, error) {
+			return []*entity.MessageThread{{ID: 5, CompanyUserID: 10, StudentUserID: userID}}, nil
+		},
+		GetMessagesByThreadIDsFunc: func(ctx context.Context, db store.Queryer, appKind string, threadIDs []entity.MessageThreadID) (entity.Messages, error) {
+			return entity.Messages{
+				&entity.Message{ID: 1, MessageThreadID: 5, IsFromCompany: 1, Content: "面接のご案内"},
+				&entity.Message{ID: 2, MessageThreadID: 5, IsFromStudent: 1, Content: "承知しました"},
+			}, nil
+		},
+		GetAttachmentsByMessageIDsFunc: func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+			return entity.Attachments{
+				&entity.Attachment{ID: 7, MessageID: 1, FileName: "guide.pdf", StorageKey: "attachments/1/abc"},
+				&entity.Attachment{ID: 8, MessageID: 2, FileName: "resume.pdf", StorageKey: "attachments/2/def"},
+			}, nil
+		},
+		GetCredentialsByUserIDFunc: func(ctx context.Context, db store.Queryer, userID int64) ([]*entity.MessageAPICredential, error) {
+			return []*entity.MessageAPICredential{{ID: 3, UserID: userID, ClientID: "client"}}, nil
+		},
+	}
+}
+
+func TestExportUserData_ExportUserData(t *testing.T) {
+	type testCase struct {
+		name          string
+		appKind       string
+		prepareMock   func(*UserDataGetterMock)
+		wantErr       bool
+		wantErrStatus int
+		wantErrMsg    string
+	}
+	tests := []testCase{
+		{
+			name:          "invalid app kind",
+			appKind:       "admin",
+			wantErr:       true,
+			wantErrStatus: http.StatusBadRequest,
+			wantErrMsg:    "app_kind must be company or student",
+		},
+		{
+			name:    "fail to get threads",
+			appKind: "student",
+			prepareMock: func(m *UserDataGetterMock) {
+				m.GetThreadsByUserIDFunc = func(ctx context.Context, db store.Queryer, appKind string, userID int64) ([]*entity.MessageThread, error) {
+					return nil, errors.New("thread query error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get message threads",
+		},
+		{
+			name:    "fail to get credentials",
+			appKind: "student",
+			prepareMock: func(m *UserDataGetterMock) {
+				m.GetCredentialsByUserIDFunc = func(ctx context.Context, db store.Queryer, userID int64) ([]*entity.MessageAPICredential, error) {
+					return nil, errors.New("credential query error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get credentials",
+		},
+		{
+			name:    "success",
+			appKind: "student",
+			wantErr: false,
+		},
+	}
+	dbHandlers := map[string]*sqlx.DB{
+		"common":  nil,
+		"student": nil,
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			getterMock := newUserDataGetterMock()
+			if tc.prepareMock != nil {
+				tc.prepareMock(getterMock)
+			}
+			svc := NewExportUserData(dbHandlers, getterMock, &BlobStorageMock{})
+			data, err := svc.ExportUserData(context.Background(), tc.appKind, 1)
+			if tc.wantErr {
+				assert.Error(t, err, "error is expected but got nil")
+				se, ok := err.(*handler.ServiceError)
+				if assert.True(t, ok, "error should be *handler.ServiceError") {
+					assert.Equal(t, tc.wantErrStatus, se.StatusCode)
+					assert.Contains(t, se.Message, tc.wantErrMsg)
+				}
+				assert.Nil(t, data)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "student", data.AppKind)
+				assert.Equal(t, int64(1), data.UserID)
+				assert.Len(t, data.Threads, 1)
+				assert.Len(t, data.Messages, 2)
+				assert.Len(t, data.Attachments, 2)
+				assert.Len(t, data.Credentials, 1)
+				if assert.Len(t, getterMock.GetAttachmentsByMessageIDsCalls(), 1) {
+					assert.Equal(t, []entity.MessageID{1, 2}, getterMock.GetAttachmentsByMessageIDsCalls()[0].MessageIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestExportUserData_WriteUserDataArchive(t *testing.T) {
+	blobMock := &BlobStorageMock{
+		GetFunc: func(ctx context.Context, key string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("content of " + key)), nil
+		},
+	}
+	svc := NewExportUserData(map[string]*sqlx.DB{}, newUserDataGetterMock(), blobMock)
+	data := &entity.UserData{
+		AppKind: "student",
+		UserID:  1,
+		Attachments: entity.Attachments{
+			&entity.Attachment{ID: 8, MessageID: 2, FileName: "resume.pdf", StorageKey: "attachments/2/def"},
+		},
+	}
+	var buf bytes.Buffer
+	err := svc.WriteUserDataArchive(context.Background(), &buf, data)
+	assert.NoError(t, err)
+	if assert.Len(t, blobMock.GetCalls(), 1) {
+		assert.Equal(t, "attachments/2/def", blobMock.GetCalls()[0].Key)
+	}
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("PK")))
+}
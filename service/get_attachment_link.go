@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/credential"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+)
+
+type GetAttachmentLink struct {
+	DBHandlers         map[string]*sqlx.DB
+	AttachmentGetter   AttachmentGetter
+	MessageOwnerGetter MessageOwnerGetter
+	SecretKey          []byte
+	TTL                time.Duration
+	Clocker            clock.Clocker
+}
+
+func NewGetAttachmentLink(dbHandlers map[string]*sqlx.DB, attachmentGetter AttachmentGetter, messageOwnerGetter MessageOwnerGetter, secretKey []byte, ttl time.Duration, clocker clock.Clocker) *GetAttachmentLink {
+	return &GetAttachmentLink{
+		DBHandlers:         dbHandlers,
+		AttachmentGetter:   attachmentGetter,
+		MessageOwnerGetter: messageOwnerGetter,
+		SecretKey:          secretKey,
+		TTL:                ttl,
+		Clocker:            clocker,
+	}
+}
+
+func (gal *GetAttachmentLink) GetAttachmentLink(ctx context.Context, id entity.AttachmentID) (string, time.Time, error) {
+	ctx, span := tracer.Start(ctx, "GetAttachmentLink.GetAttachmentLink")
+	defer span.End()
+	appKind, ok := request.GetAppKind(ctx)
+	if !ok {
+		return "", time.Time{}, handler.NewServiceError(
+			handler.ErrCodeInternal,
+			"failed to get app kind",
+			"",
+		)
+	}
+	userID, ok := request.GetUserID(ctx)
+	if !ok {
+		return "", time.Time{}, handler.NewServiceError(
+			handler.ErrCodeInternal,
+			"failed to get userID",
+			"",
+		)
+	}
+	a, err := gal.AttachmentGetter.GetAttachment(ctx, gal.DBHandlers["common"], id)
+	if err != nil {
+		return "", time.Time{}, newLookupServiceError(handler.ErrCodeAttachmentNotFound, "attachment not found", "failed to get attachment", err)
+	}
+	// 添付ファイルはスレッドの参加者であれば、送信者以外も閲覧できる
+	if appKind == "company" {
+		companyUserID, err := gal.MessageOwnerGetter.GetThreadCompanyOwnerByVisibleMessageID(ctx, gal.DBHandlers["common"], a.MessageID)
+		if err != nil {
+			return "", time.Time{}, newLookupServiceError(handler.ErrCodeMessageNotFound, "message not found", "failed to get threadCompanyOwner", err)
+		}
+		if userID != companyUserID {
+			return "", time.Time{}, handler.NewServiceError(
+				handler.ErrCodeForbidden,
+				"unauthorized: lack the necessary permissions to download attachment",
+				"",
+			)
+		}
+	} else if appKind == "student" {
+		studentUserID, err := gal.MessageOwnerGetter.GetThreadStudentOwnerByVisibleMessageID(ctx, gal.DBHandlers["common"], a.MessageID)
+		if err != nil {
+			return "", time.Time{}, newLookupServiceError(handler.ErrCodeMessageNotFound, "message not found", "failed to get threadStudentOwner", err)
+		}
+		if userID != studentUserID {
+			return "", time.Time{}, handler.NewServiceError(
+				handler.ErrCodeForbidden,
+				"unauthorized: lack the necessary permissions to download attachment",
+				"",
+			)
+		}
+	}
+	expiresAt := gal.Clocker.Now().Time.Add(gal.TTL)
+	return credential.SignAttachmentURL(gal.SecretKey, a.ID, expiresAt), expiresAt, nil
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/credential"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+func TestGetAttachmentLink_GetAttachmentLink(t *testing.T) {
+	secretKey := []byte("attachment-url-secret-key")
+	now := clock.FixedClocker{}.Now().Time
+	type testCase struct {
+		name              string
+		appKind           string
+		userID            int64
+		prepareGetterMock func(*AttachmentGetterMock)
+		prepareOwnerMock  func(*MessageOwnerGetterMock)
+		id                entity.AttachmentID
+		wantErr           bool
+		wantErrStatus     int
+		wantErrMsg        string
+	}
+	foundAttachment := func(m *AttachmentGetterMock) {
+		m.GetAttachmentFunc = func(ctx context.Context, db store.Queryer, id entity.AttachmentID) (*entity.Attachment, error) {
+			return &entity.Attachment{ID: id, MessageID: 5}, nil
+		}
+	}
+	tests := []testCase{
+		{
+			name:          "fail if no appKind in context",
+			appKind:       "",
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get app kind",
+		},
+		{
+			name:          "fail if no userID in context",
+			appKind:       "company",
+			userID:        0,
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get userID",
+		},
+		{
+			name:    "fail to get attachment",
+			appKind: "company",
+			userID:  1,
+			prepareGetterMock: func(m *AttachmentGetterMock) {
+				m.GetAttachmentFunc = func(ctx context.Context, db store.Queryer, id entity.AttachmentID) (*entity.Attachment, error) {
+					return nil, errors.New("attachment query error")
+				}
+			},
+			id:            1,
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get attachment",
+		},
+		{
+			name:              "company: fail to get thread owner",
+			appKind:           "company",
+			userID:            1,
+			prepareGetterMock: foundAttachment,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerByVisibleMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 0, errors.New("owner query error")
+				}
+			},
+			id:            1,
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get threadCompanyOwner",
+		},
+		{
+			name:              "company: user is not thread owner => forbidden",
+			appKind:           "company",
+			userID:            1,
+			prepareGetterMock: foundAttachment,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerByVisibleMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 2, nil
+				}
+			},
+			id:            1,
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "unauthorized: lack the necessary permissions to download attachment",
+		},
+		{
+			name:              "student: user is not thread owner => forbidden",
+			appKind:           "student",
+			userID:            1,
+			prepareGetterMock: foundAttachment,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadStudentOwnerByVisibleMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 2, nil
+				}
+			},
+			id:            1,
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "unauthorized: lack the necessary permissions to download attachment",
+		},
+		{
+			name:              "student: success",
+			appKind:           "student",
+			userID:            1,
+			prepareGetterMock: foundAttachment,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadStudentOwnerByVisibleMessageIDFunc = func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+					return 1, nil
+				}
+			},
+			id:      3,
+			wantErr: false,
+		},
+	}
+	dbHandlers := map[string]*sqlx.DB{
+		"common": nil,
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tc.appKind != "" {
+				ctx = request.SetAppKind(ctx, tc.appKind)
+			}
+			if tc.userID != 0 {
+				ctx = request.SetUserID(ctx, tc.userID)
+			}
+			getterMock := &AttachmentGetterMock{}
+			ownerMock := &MessageOwnerGetterMock{}
+			if tc.prepareGetterMock != nil {
+				tc.prepareGetterMock(getterMock)
+			}
+			if tc.prepareOwnerMock != nil {
+				tc.prepareOwnerMock(ownerMock)
+			}
+			svc := NewGetAttachmentLink(dbHandlers, getterMock, ownerMock, secretKey, 5*time.Minute, clock.FixedClocker{})
+			link, expiresAt, err := svc.GetAttachmentLink(ctx, tc.id)
+			if tc.wantErr {
+				assert.Error(t, err, "error is expected but got nil")
+				se, ok := err.(*handler.ServiceError)
+				if assert.True(t, ok, "error should be *handler.ServiceError") {
+					assert.Equal(t, tc.wantErrStatus, se.StatusCode)
+					assert.Contains(t, se.Message, tc.wantErrMsg)
+				}
+				assert.Empty(t, link)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, now.Add(5*time.Minute), expiresAt)
+				u, err := url.Parse(link)
+				if assert.NoError(t, err) {
+					assert.Equal(t, "/messages/attachments/3", u.Path)
+					err := credential.VerifyAttachmentSignature(secretKey, tc.id, u.Query().Get("expires"), u.Query().Get("signature"), now)
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
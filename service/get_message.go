@@ -2,7 +2,7 @@ package service
 
 import (
 	"context"
-	"net/http"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 
@@ -13,23 +13,31 @@ import (
 
 type GetMessage struct {
 	DBHandlers         map[string]*sqlx.DB
+	ReplicaHandlers    map[string]*sqlx.DB
 	MessageGetter      MessageGetter
 	MessageOwnerGetter MessageOwnerGetter
+	AttachmentGetter   AttachmentGetter
+	ReactionGetter     ReactionGetter
 }
 
-func NewGetMessage(dbHandlers map[string]*sqlx.DB, messageGetter MessageGetter, messageOwnerGetter MessageOwnerGetter) *GetMessage {
+func NewGetMessage(dbHandlers map[string]*sqlx.DB, replicaHandlers map[string]*sqlx.DB, messageGetter MessageGetter, messageOwnerGetter MessageOwnerGetter, attachmentGetter AttachmentGetter, reactionGetter ReactionGetter) *GetMessage {
 	return &GetMessage{
 		DBHandlers:         dbHandlers,
+		ReplicaHandlers:    replicaHandlers,
 		MessageGetter:      messageGetter,
 		MessageOwnerGetter: messageOwnerGetter,
+		AttachmentGetter:   attachmentGetter,
+		ReactionGetter:     reactionGetter,
 	}
 }
 
 func (gm *GetMessage) GetAllMessages(ctx context.Context, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
+	ctx, span := tracer.Start(ctx, "GetMessage.GetAllMessages")
+	defer span.End()
 	appKind, ok := request.GetAppKind(ctx)
 	if !ok {
 		return nil, handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to get app kind",
 			"",
 		)
@@ -37,60 +45,126 @@ func (gm *GetMessage) GetAllMessages(ctx context.Context, messageThreadID entity
 	userID, ok := request.GetUserID(ctx)
 	if !ok {
 		return nil, handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to get userID",
 			"",
 		)
 	}
-	var m entity.Messages
-	if appKind == "company" {
-		companyUserID, err := gm.MessageOwnerGetter.GetThreadCompanyOwner(ctx, gm.DBHandlers["common"], messageThreadID)
+	db := readDB(ctx, gm.DBHandlers, gm.ReplicaHandlers, "common")
+	m, err := getVisibleThreadMessages(ctx, db, gm.MessageOwnerGetter, gm.MessageGetter, appKind, userID, messageThreadID, "retrieve messages")
+	if err != nil {
+		return nil, err
+	}
+	if len(m) == 0 {
+		return m, nil
+	}
+	messageIDs := make([]entity.MessageID, 0, len(m))
+	for _, message := range m {
+		messageIDs = append(messageIDs, message.ID)
+	}
+	attachments, err := gm.AttachmentGetter.GetAttachmentsByMessageIDs(ctx, db, messageIDs)
+	if err != nil {
+		return nil, newInternalServiceError("failed to get attachments", err)
+	}
+	attachmentsByMessageID := make(map[entity.MessageID]entity.Attachments, len(attachments))
+	for _, a := range attachments {
+		attachmentsByMessageID[a.MessageID] = append(attachmentsByMessageID[a.MessageID], a)
+	}
+	reactions, err := gm.ReactionGetter.GetReactionsByMessageIDs(ctx, db, messageIDs)
+	if err != nil {
+		return nil, newInternalServiceError("failed to get reactions", err)
+	}
+	reactionsByMessageID := summarizeReactions(reactions, appKind)
+	var replyToIDs []entity.MessageID
+	for _, message := range m {
+		if message.ReplyToMessageID != nil {
+			replyToIDs = append(replyToIDs, *message.ReplyToMessageID)
+		}
+	}
+	replyTargetByID := make(map[entity.MessageID]*entity.Message, len(replyToIDs))
+	if len(replyToIDs) > 0 {
+		replyTargets, err := gm.MessageGetter.GetMessagesByIDs(ctx, db, replyToIDs)
 		if err != nil {
-			return nil, handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to get threadCompanyOwner",
-				err.Error(),
-			)
+			return nil, newInternalServiceError("failed to get reply target messages", err)
 		}
-		if userID != companyUserID {
-			return nil, handler.NewServiceError(
-				http.StatusForbidden,
-				"unauthorized: lack the necessary permissions to retrieve messages",
-				"",
-			)
+		for _, target := range replyTargets {
+			replyTargetByID[target.ID] = target
 		}
-		m, err = gm.MessageGetter.GetAllMessagesForCompanyUser(ctx, gm.DBHandlers["common"], messageThreadID)
-		if err != nil {
-			return nil, handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to get message",
-				err.Error(),
-			)
+	}
+	for _, message := range m {
+		if message.ReplyToMessageID != nil {
+			message.ReplyTo = newMessageReply(*message.ReplyToMessageID, replyTargetByID[*message.ReplyToMessageID])
 		}
-	} else if appKind == "student" {
-		studentUserID, err := gm.MessageOwnerGetter.GetThreadStudentOwner(ctx, gm.DBHandlers["common"], messageThreadID)
-		if err != nil {
-			return nil, handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to get threadStudentOwner",
-				err.Error(),
-			)
+		message.Attachments = attachmentsByMessageID[message.ID]
+		message.Reactions = reactionsByMessageID[message.ID]
+	}
+	return m, nil
+}
+
+// 引用表示用の抜粋の最大文字数
+const replyExcerptLength = 50
+
+// newMessageReply は返信先メッセージから引用表示用の参照を作る
+// 返信先が削除済みの場合は、抜粋を含めずに削除済みであることだけを返す
+func newMessageReply(id entity.MessageID, target *entity.Message) *entity.MessageReply {
+	if target == nil {
+		return &entity.MessageReply{
+			MessageID: id,
+			IsDeleted: true,
 		}
-		if userID != studentUserID {
-			return nil, handler.NewServiceError(
-				http.StatusForbidden,
-				"unauthorized: lack the necessary permissions to retrieve messages",
-				"",
-			)
+	}
+	return &entity.MessageReply{
+		MessageID:     id,
+		IsFromCompany: target.IsFromCompany,
+		IsFromStudent: target.IsFromStudent,
+		Excerpt:       excerpt(target.Content, replyExcerptLength),
+	}
+}
+
+// excerpt は改行や連続する空白を1つの空白にまとめ、max 文字を超える分を省略する
+func excerpt(content string, max int) string {
+	runes := []rune(strings.Join(strings.Fields(content), " "))
+	if len(runes) <= max {
+		return string(runes)
+	}
+	return string(runes[:max]) + "…"
+}
+
+// summarizeReactions はリアクションをメッセージ・種類ごとに集計する
+// 並び順は entity.AllowedReactions に従う
+func summarizeReactions(reactions entity.Reactions, appKind string) map[entity.MessageID]entity.ReactionSummaries {
+	type key struct {
+		messageID entity.MessageID
+		reaction  string
+	}
+	counts := make(map[key]*entity.ReactionSummary, len(reactions))
+	for _, r := range reactions {
+		k := key{messageID: r.MessageID, reaction: r.Reaction}
+		summary, ok := counts[k]
+		if !ok {
+			summary = &entity.ReactionSummary{Reaction: r.Reaction}
+			counts[k] = summary
 		}
-		m, err = gm.MessageGetter.GetAllMessagesForStudentUser(ctx, gm.DBHandlers["common"], messageThreadID)
-		if err != nil {
-			return nil, handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to get message",
-				err.Error(),
-			)
+		summary.Count++
+		if (appKind == "company" && r.IsFromCompany == 1) || (appKind == "student" && r.IsFromStudent == 1) {
+			summary.ReactedByMe = true
 		}
 	}
-	return m, nil
+	messageIDs := make([]entity.MessageID, 0, len(reactions))
+	seen := make(map[entity.MessageID]bool, len(reactions))
+	for _, r := range reactions {
+		if !seen[r.MessageID] {
+			seen[r.MessageID] = true
+			messageIDs = append(messageIDs, r.MessageID)
+		}
+	}
+	summaries := make(map[entity.MessageID]entity.ReactionSummaries, len(messageIDs))
+	for _, messageID := range messageIDs {
+		for _, reaction := range entity.AllowedReactions {
+			if summary, ok := counts[key{messageID: messageID, reaction: reaction}]; ok {
+				summaries[messageID] = append(summaries[messageID], summary)
+			}
+		}
+	}
+	return summaries
 }
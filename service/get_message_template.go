@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type GetMessageTemplate struct {
+	DBHandlers            map[string]*sqlx.DB
+	MessageTemplateGetter MessageTemplateGetter
+}
+
+func NewGetMessageTemplate(dbHandlers map[string]*sqlx.DB, messageTemplateGetter MessageTemplateGetter) *GetMessageTemplate {
+	return &GetMessageTemplate{
+		DBHandlers:            dbHandlers,
+		MessageTemplateGetter: messageTemplateGetter,
+	}
+}
+
+func (gmt *GetMessageTemplate) GetAllMessageTemplates(ctx context.Context) (entity.MessageTemplates, error) {
+	ctx, span := tracer.Start(ctx, "GetMessageTemplate.GetAllMessageTemplates")
+	defer span.End()
+	companyUserID, err := getTemplateOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := gmt.MessageTemplateGetter.GetAllMessageTemplates(ctx, gmt.DBHandlers["company"], companyUserID)
+	if err != nil {
+		return nil, newInternalServiceError("failed to get message templates", err)
+	}
+	return templates, nil
+}
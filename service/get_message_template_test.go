@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+func TestGetMessageTemplate_GetAllMessageTemplates(t *testing.T) {
+	type testCase struct {
+		name              string
+		appKind           string
+		userID            int64
+		prepareGetterMock func(*MessageTemplateGetterMock)
+		wantTemplates     entity.MessageTemplates
+		wantErr           bool
+		wantErrStatus     int
+		wantErrMsg        string
+	}
+	tests := []testCase{
+		{
+			name:          "fail if no appKind in context",
+			appKind:       "",
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get app kind",
+		},
+		{
+			name:          "fail if no userID in context",
+			appKind:       "company",
+			userID:        0,
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get userID",
+		},
+		{
+			name:          "student => forbidden",
+			appKind:       "student",
+			userID:        1,
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "unauthorized: message templates are only available to company users",
+		},
+		{
+			name:    "fail to get templates",
+			appKind: "company",
+			userID:  1,
+			prepareGetterMock: func(m *MessageTemplateGetterMock) {
+				m.GetAllMessageTemplatesFunc = func(ctx context.Context, db store.Queryer, companyUserID int64) (entity.MessageTemplates, error) {
+					return nil, errors.New("template query error")
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusInternalServerError,
+			wantErrMsg:    "failed to get message templates",
+		},
+		{
+			name:    "success",
+			appKind: "company",
+			userID:  1,
+			prepareGetterMock: func(m *MessageTemplateGetterMock) {
+				m.GetAllMessageTemplatesFunc = func(ctx context.Context, db store.Queryer, companyUserID int64) (entity.MessageTemplates, error) {
+					return entity.MessageTemplates{
+						&entity.MessageTemplate{ID: 1, CompanyUserID: companyUserID, Name: "面接案内", Content: "{{student_name}}様"},
+					}, nil
+				}
+			},
+			wantTemplates: entity.MessageTemplates{
+				&entity.MessageTemplate{ID: 1, CompanyUserID: 1, Name: "面接案内", Content: "{{student_name}}様"},
+			},
+			wantErr: false,
+		},
+	}
+	dbHandlers := map[string]*sqlx.DB{
+		"company": nil,
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tc.appKind != "" {
+				ctx = request.SetAppKind(ctx, tc.appKind)
+			}
+			if tc.userID != 0 {
+				ctx = request.SetUserID(ctx, tc.userID)
+			}
+			getterMock := &MessageTemplateGetterMock{}
+			if tc.prepareGetterMock != nil {
+				tc.prepareGetterMock(getterMock)
+			}
+			svc := NewGetMessageTemplate(dbHandlers, getterMock)
+			templates, err := svc.GetAllMessageTemplates(ctx)
+			if tc.wantErr {
+				assert.Error(t, err, "error is expected but got nil")
+				se, ok := err.(*handler.ServiceError)
+				if assert.True(t, ok, "error should be *handler.ServiceError") {
+					assert.Equal(t, tc.wantErrStatus, se.StatusCode)
+					assert.Contains(t, se.Message, tc.wantErrMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantTemplates, templates)
+			}
+		})
+	}
+}
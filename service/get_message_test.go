@@ -23,6 +23,8 @@ func TestGetMessage_GetAllMessages(t *testing.T) {
 		userID            int64
 		prepareOwnerMock  func(*MessageOwnerGetterMock)
 		prepareGetterMock func(*MessageGetterMock)
+		prepareAttachMock func(*AttachmentGetterMock)
+		prepareReactMock  func(*ReactionGetterMock)
 		messageThreadID   entity.MessageThreadID
 		wantMessages      entity.Messages
 		wantErr           bool
@@ -123,6 +125,20 @@ func TestGetMessage_GetAllMessages(t *testing.T) {
 					}, nil
 				}
 			},
+			prepareAttachMock: func(m *AttachmentGetterMock) {
+				m.GetAttachmentsByMessageIDsFunc = func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+					return nil, nil
+				}
+			},
+			prepareReactMock: func(m *ReactionGetterMock) {
+				m.GetReactionsByMessageIDsFunc = func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Reactions, error) {
+					return entity.Reactions{
+						&entity.Reaction{ID: 1, MessageID: 1, IsFromCompany: 0, IsFromStudent: 1, Reaction: "確認しました"},
+						&entity.Reaction{ID: 2, MessageID: 1, IsFromCompany: 0, IsFromStudent: 1, Reaction: "👍"},
+						&entity.Reaction{ID: 3, MessageID: 1, IsFromCompany: 1, IsFromStudent: 0, Reaction: "👍"},
+					}, nil
+				}
+			},
 			messageThreadID: 1,
 			wantMessages: entity.Messages{
 				&entity.Message{
@@ -132,6 +148,10 @@ func TestGetMessage_GetAllMessages(t *testing.T) {
 					Content:       "normal message from company user",
 					IsSent:        1,
 					SentAt:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					Reactions: entity.ReactionSummaries{
+						&entity.ReactionSummary{Reaction: "👍", Count: 2, ReactedByMe: true},
+						&entity.ReactionSummary{Reaction: "確認しました", Count: 1, ReactedByMe: false},
+					},
 				},
 				&entity.Message{
 					ID:            entity.MessageID(2),
@@ -144,6 +164,171 @@ func TestGetMessage_GetAllMessages(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "company: fail to get attachments",
+			appKind: "company",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+					return 1, nil
+				}
+			},
+			prepareGetterMock: func(m *MessageGetterMock) {
+				m.GetAllMessagesForCompanyUserFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
+					return entity.Messages{
+						&entity.Message{
+							ID:            entity.MessageID(1),
+							IsFromCompany: 1,
+							IsFromStudent: 0,
+							Content:       "normal message from company user",
+							IsSent:        1,
+							SentAt:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+						},
+					}, nil
+				}
+			},
+			prepareAttachMock: func(m *AttachmentGetterMock) {
+				m.GetAttachmentsByMessageIDsFunc = func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+					return nil, errors.New("get attachments error")
+				}
+			},
+			messageThreadID: 1,
+			wantErr:         true,
+			wantErrStatus:   http.StatusInternalServerError,
+			wantErrMsg:      "failed to get attachments",
+		},
+		{
+			name:    "company: fail to get reactions",
+			appKind: "company",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+					return 1, nil
+				}
+			},
+			prepareGetterMock: func(m *MessageGetterMock) {
+				m.GetAllMessagesForCompanyUserFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
+					return entity.Messages{
+						&entity.Message{
+							ID:            entity.MessageID(1),
+							IsFromCompany: 1,
+							IsFromStudent: 0,
+							Content:       "normal message from company user",
+							IsSent:        1,
+							SentAt:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+						},
+					}, nil
+				}
+			},
+			prepareAttachMock: func(m *AttachmentGetterMock) {
+				m.GetAttachmentsByMessageIDsFunc = func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+					return nil, nil
+				}
+			},
+			prepareReactMock: func(m *ReactionGetterMock) {
+				m.GetReactionsByMessageIDsFunc = func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Reactions, error) {
+					return nil, errors.New("get reactions error")
+				}
+			},
+			messageThreadID: 1,
+			wantErr:         true,
+			wantErrStatus:   http.StatusInternalServerError,
+			wantErrMsg:      "failed to get reactions",
+		},
+		{
+			name:    "company: fail to get reply target messages",
+			appKind: "company",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+					return 1, nil
+				}
+			},
+			prepareGetterMock: func(m *MessageGetterMock) {
+				m.GetAllMessagesForCompanyUserFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
+					return entity.Messages{
+						&entity.Message{ID: entity.MessageID(2), IsFromCompany: 1, Content: "reply", IsSent: 1, ReplyToMessageID: replyTo(1)},
+					}, nil
+				}
+				m.GetMessagesByIDsFunc = func(ctx context.Context, db store.Queryer, ids []entity.MessageID) (entity.Messages, error) {
+					return nil, errors.New("get reply targets error")
+				}
+			},
+			prepareAttachMock: func(m *AttachmentGetterMock) {
+				m.GetAttachmentsByMessageIDsFunc = func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+					return nil, nil
+				}
+			},
+			prepareReactMock: func(m *ReactionGetterMock) {
+				m.GetReactionsByMessageIDsFunc = func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Reactions, error) {
+					return nil, nil
+				}
+			},
+			messageThreadID: 1,
+			wantErr:         true,
+			wantErrStatus:   http.StatusInternalServerError,
+			wantErrMsg:      "failed to get reply target messages",
+		},
+		{
+			name:    "company: success with replies",
+			appKind: "company",
+			userID:  1,
+			prepareOwnerMock: func(m *MessageOwnerGetterMock) {
+				m.GetThreadCompanyOwnerFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+					return 1, nil
+				}
+			},
+			prepareGetterMock: func(m *MessageGetterMock) {
+				m.GetAllMessagesForCompanyUserFunc = func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
+					return entity.Messages{
+						&entity.Message{ID: entity.MessageID(2), IsFromCompany: 1, Content: "面接日程はいかがでしょうか", IsSent: 1, ReplyToMessageID: replyTo(1)},
+						&entity.Message{ID: entity.MessageID(3), IsFromStudent: 1, Content: "承知しました", IsSent: 1, ReplyToMessageID: replyTo(9)},
+					}, nil
+				}
+				m.GetMessagesByIDsFunc = func(ctx context.Context, db store.Queryer, ids []entity.MessageID) (entity.Messages, error) {
+					return entity.Messages{
+						&entity.Message{ID: entity.MessageID(1), IsFromStudent: 1, Content: "よろしくお願いいたします。\n履歴書を添付しましたのでご確認ください。書類選考の結果はいつ頃わかりますでしょうか。"},
+					}, nil
+				}
+			},
+			prepareAttachMock: func(m *AttachmentGetterMock) {
+				m.GetAttachmentsByMessageIDsFunc = func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+					return nil, nil
+				}
+			},
+			prepareReactMock: func(m *ReactionGetterMock) {
+				m.GetReactionsByMessageIDsFunc = func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Reactions, error) {
+					return nil, nil
+				}
+			},
+			messageThreadID: 1,
+			wantMessages: entity.Messages{
+				&entity.Message{
+					ID:               entity.MessageID(2),
+					IsFromCompany:    1,
+					Content:          "面接日程はいかがでしょうか",
+					IsSent:           1,
+					ReplyToMessageID: replyTo(1),
+					ReplyTo: &entity.MessageReply{
+						MessageID:     entity.MessageID(1),
+						IsFromStudent: 1,
+						Excerpt:       "よろしくお願いいたします。 履歴書を添付しましたのでご確認ください。書類選考の結果はいつ頃わかります…",
+					},
+				},
+				&entity.Message{
+					ID:               entity.MessageID(3),
+					IsFromStudent:    1,
+					Content:          "承知しました",
+					IsSent:           1,
+					ReplyToMessageID: replyTo(9),
+					ReplyTo: &entity.MessageReply{
+						MessageID: entity.MessageID(9),
+						IsDeleted: true,
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name:    "student: fail to get thread owner",
 			appKind: "student",
@@ -222,6 +407,26 @@ func TestGetMessage_GetAllMessages(t *testing.T) {
 					}, nil
 				}
 			},
+			prepareAttachMock: func(m *AttachmentGetterMock) {
+				m.GetAttachmentsByMessageIDsFunc = func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+					return entity.Attachments{
+						&entity.Attachment{
+							ID:          entity.AttachmentID(10),
+							MessageID:   entity.MessageID(1),
+							FileName:    "offer.pdf",
+							ContentType: "application/pdf",
+							Size:        1024,
+						},
+					}, nil
+				}
+			},
+			prepareReactMock: func(m *ReactionGetterMock) {
+				m.GetReactionsByMessageIDsFunc = func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Reactions, error) {
+					return entity.Reactions{
+						&entity.Reaction{ID: 1, MessageID: 1, IsFromCompany: 0, IsFromStudent: 1, Reaction: "確認しました"},
+					}, nil
+				}
+			},
 			messageThreadID: 1,
 			wantMessages: entity.Messages{
 				&entity.Message{
@@ -231,6 +436,18 @@ func TestGetMessage_GetAllMessages(t *testing.T) {
 					Content:       "normal message from company user",
 					IsSent:        1,
 					SentAt:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					Attachments: entity.Attachments{
+						&entity.Attachment{
+							ID:          entity.AttachmentID(10),
+							MessageID:   entity.MessageID(1),
+							FileName:    "offer.pdf",
+							ContentType: "application/pdf",
+							Size:        1024,
+						},
+					},
+					Reactions: entity.ReactionSummaries{
+						&entity.ReactionSummary{Reaction: "確認しました", Count: 1, ReactedByMe: true},
+					},
 				},
 				&entity.Message{
 					ID:            entity.MessageID(2),
@@ -260,13 +477,21 @@ func TestGetMessage_GetAllMessages(t *testing.T) {
 			}
 			ownerMock := &MessageOwnerGetterMock{}
 			getterMock := &MessageGetterMock{}
+			attachMock := &AttachmentGetterMock{}
+			reactMock := &ReactionGetterMock{}
 			if tc.prepareOwnerMock != nil {
 				tc.prepareOwnerMock(ownerMock)
 			}
 			if tc.prepareGetterMock != nil {
 				tc.prepareGetterMock(getterMock)
 			}
-			svc := NewGetMessage(dbHandlers, getterMock, ownerMock)
+			if tc.prepareAttachMock != nil {
+				tc.prepareAttachMock(attachMock)
+			}
+			if tc.prepareReactMock != nil {
+				tc.prepareReactMock(reactMock)
+			}
+			svc := NewGetMessage(dbHandlers, nil, getterMock, ownerMock, attachMock, reactMock)
 			messages, err := svc.GetAllMessages(ctx, tc.messageThreadID)
 			if tc.wantErr {
 				assert.Error(t, err, "error is expected but got nil")
@@ -282,3 +507,46 @@ func TestGetMessage_GetAllMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMessage_GetAllMessages_ReadReplica(t *testing.T) {
+	primary := &sqlx.DB{}
+	replica := &sqlx.DB{}
+	tests := []struct {
+		name            string
+		replicas        map[string]*sqlx.DB
+		readFromPrimary bool
+		want            *sqlx.DB
+	}{
+		{name: "no replica => primary", replicas: nil, want: primary},
+		{name: "replica", replicas: map[string]*sqlx.DB{"common": replica}, want: replica},
+		{name: "read-your-writes => primary", replicas: map[string]*sqlx.DB{"common": replica}, readFromPrimary: true, want: primary},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := request.SetUserID(request.SetAppKind(context.Background(), "company"), 1)
+			if tc.readFromPrimary {
+				ctx = request.SetReadFromPrimary(ctx)
+			}
+			var used []store.Queryer
+			ownerMock := &MessageOwnerGetterMock{
+				GetThreadCompanyOwnerFunc: func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+					used = append(used, db)
+					return 1, nil
+				},
+			}
+			getterMock := &MessageGetterMock{
+				GetAllMessagesForCompanyUserFunc: func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
+					used = append(used, db)
+					return entity.Messages{}, nil
+				},
+			}
+			svc := NewGetMessage(map[string]*sqlx.DB{"common": primary}, tc.replicas, getterMock, ownerMock, &AttachmentGetterMock{}, &ReactionGetterMock{})
+			_, err := svc.GetAllMessages(ctx, 1)
+			assert.NoError(t, err)
+			if assert.Len(t, used, 2) {
+				assert.Same(t, tc.want, used[0])
+				assert.Same(t, tc.want, used[1])
+			}
+		})
+	}
+}
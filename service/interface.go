@@ -3,12 +3,14 @@ package service
 import (
 	"context"
 	"database/sql"
+	"io"
+	"time"
 
 	"github.com/yuyacode/AppLiftMessageApi/entity"
 	"github.com/yuyacode/AppLiftMessageApi/store"
 )
 
-//go:generate go run github.com/matryer/moq -out moq_test.go . CredentialGetter CredentialSetter MessageOwnerGetter MessageGetter MessageAdder MessageEditor MessageDeleter
+//go:generate go run github.com/matryer/moq -out moq_test.go . CredentialGetter CredentialSetter MessageOwnerGetter MessageGetter MessageAdder MessageEditor MessageDeleter AttachmentGetter AttachmentAdder BlobStorage ReactionGetter ReactionAdder ReactionDeleter MessageTemplateGetter MessageTemplateAdder MessageTemplateEditor MessageTemplateDeleter UserDataGetter UserDataEraser Purger
 
 type CredentialGetter interface {
 	GetAPIKey(ctx context.Context, db store.Queryer) (string, error)
@@ -30,13 +32,20 @@ type CredentialSetter interface {
 type MessageOwnerGetter interface {
 	GetThreadCompanyOwner(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error)
 	GetThreadStudentOwner(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error)
+	GetThreadsByIDs(ctx context.Context, db store.Queryer, ids []entity.MessageThreadID) ([]*entity.MessageThread, error)
 	GetThreadCompanyOwnerByMessageID(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error)
 	GetThreadStudentOwnerByMessageID(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error)
+	GetThreadCompanyOwnerByVisibleMessageID(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error)
+	GetThreadStudentOwnerByVisibleMessageID(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error)
 }
 
 type MessageGetter interface {
 	GetAllMessagesForCompanyUser(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error)
 	GetAllMessagesForStudentUser(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error)
+	EachMessageForCompanyUser(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error
+	EachMessageForStudentUser(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error
+	GetSentMessageThreadID(ctx context.Context, db store.Queryer, id entity.MessageID) (entity.MessageThreadID, error)
+	GetMessagesByIDs(ctx context.Context, db store.Queryer, ids []entity.MessageID) (entity.Messages, error)
 }
 
 type MessageAdder interface {
@@ -50,3 +59,70 @@ type MessageEditor interface {
 type MessageDeleter interface {
 	DeleteMessage(ctx context.Context, db store.Execer, id entity.MessageID) error
 }
+
+type AttachmentGetter interface {
+	GetAttachment(ctx context.Context, db store.Queryer, id entity.AttachmentID) (*entity.Attachment, error)
+	GetAttachmentsByMessageIDs(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error)
+}
+
+type AttachmentAdder interface {
+	AddAttachment(ctx context.Context, db store.Execer, param *entity.Attachment) error
+}
+
+type BlobStorage interface {
+	Put(ctx context.Context, key string, content []byte, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type ReactionGetter interface {
+	GetReactionsByMessageIDs(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Reactions, error)
+}
+
+type ReactionAdder interface {
+	AddReaction(ctx context.Context, db store.Execer, param *entity.Reaction) error
+}
+
+type ReactionDeleter interface {
+	DeleteReaction(ctx context.Context, db store.Execer, param *entity.Reaction) error
+}
+
+type MessageTemplateGetter interface {
+	GetAllMessageTemplates(ctx context.Context, db store.Queryer, companyUserID int64) (entity.MessageTemplates, error)
+	GetMessageTemplate(ctx context.Context, db store.Queryer, id entity.MessageTemplateID) (*entity.MessageTemplate, error)
+}
+
+type MessageTemplateAdder interface {
+	AddMessageTemplate(ctx context.Context, db store.Execer, param *entity.MessageTemplate) error
+}
+
+type MessageTemplateEditor interface {
+	EditMessageTemplate(ctx context.Context, db store.Execer, param *entity.MessageTemplate) error
+}
+
+type MessageTemplateDeleter interface {
+	DeleteMessageTemplate(ctx context.Context, db store.Execer, id entity.MessageTemplateID) error
+}
+
+type UserDataGetter interface {
+	GetThreadsByUserID(ctx context.Context, db store.Queryer, appKind string, userID int64) ([]*entity.MessageThread, error)
+	GetMessagesByThreadIDs(ctx context.Context, db store.Queryer, appKind string, threadIDs []entity.MessageThreadID) (entity.Messages, error)
+	GetAttachmentsByMessageIDs(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error)
+	GetCredentialsByUserID(ctx context.Context, db store.Queryer, userID int64) ([]*entity.MessageAPICredential, error)
+}
+
+type UserDataEraser interface {
+	AnonymizeMessages(ctx context.Context, db store.Execer, ids []entity.MessageID) error
+	DeleteAttachments(ctx context.Context, db store.Execer, ids []entity.AttachmentID) error
+	DeleteCredentialsByUserID(ctx context.Context, db store.Execer, userID int64) error
+}
+
+type Purger interface {
+	GetDeletedMessageIDs(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageID, limit int) ([]entity.MessageID, error)
+	GetAbandonedDraftIDs(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageID, limit int) ([]entity.MessageID, error)
+	GetDeletedCredentialIDs(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageAPICredentialID, limit int) ([]entity.MessageAPICredentialID, error)
+	GetAttachmentsByMessageIDs(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error)
+	CountReactionsByMessageIDs(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (int64, error)
+	DeleteMessages(ctx context.Context, db store.Execer, ids []entity.MessageID) error
+	DeleteCredentials(ctx context.Context, db store.Execer, ids []entity.MessageAPICredentialID) error
+}
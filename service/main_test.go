@@ -0,0 +1,18 @@
+package service
+
+import (
+	"encoding/base64"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/yuyacode/AppLiftMessageApi/credential"
+)
+
+func TestMain(m *testing.M) {
+	secretKey := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	if err := credential.SetSecretKeys(secretKey, secretKey); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+// getTemplateOwnerID はテンプレートを扱える企業ユーザーのIDを返す
+// テンプレートは企業ユーザー専用の機能のため、学生ユーザーからの操作は拒否する
+func getTemplateOwnerID(ctx context.Context) (int64, error) {
+	appKind, ok := request.GetAppKind(ctx)
+	if !ok {
+		return 0, handler.NewServiceError(
+			handler.ErrCodeInternal,
+			"failed to get app kind",
+			"",
+		)
+	}
+	userID, ok := request.GetUserID(ctx)
+	if !ok {
+		return 0, handler.NewServiceError(
+			handler.ErrCodeInternal,
+			"failed to get userID",
+			"",
+		)
+	}
+	if appKind != "company" {
+		return 0, handler.NewServiceError(
+			handler.ErrCodeForbidden,
+			"unauthorized: message templates are only available to company users",
+			"",
+		)
+	}
+	return userID, nil
+}
+
+// getOwnMessageTemplate は companyUserID が所有するテンプレートを取得する
+func getOwnMessageTemplate(ctx context.Context, getter MessageTemplateGetter, db store.Queryer, id entity.MessageTemplateID, companyUserID int64) (*entity.MessageTemplate, error) {
+	t, err := getter.GetMessageTemplate(ctx, db, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, handler.NewServiceError(
+				handler.ErrCodeTemplateNotFound,
+				"message template not found",
+				"",
+			)
+		}
+		return nil, newInternalServiceError("failed to get message template", err)
+	}
+	if t.CompanyUserID != companyUserID {
+		return nil, handler.NewServiceError(
+			handler.ErrCodeForbidden,
+			"unauthorized: lack the necessary permissions to use message template",
+			"",
+		)
+	}
+	return t, nil
+}
@@ -8,7 +8,9 @@ import (
 	"database/sql"
 	"github.com/yuyacode/AppLiftMessageApi/entity"
 	"github.com/yuyacode/AppLiftMessageApi/store"
+	"io"
 	"sync"
+	"time"
 )
 
 // Ensure, that CredentialGetterMock does implement CredentialGetter.
@@ -681,12 +683,21 @@ var _ MessageOwnerGetter = &MessageOwnerGetterMock{}
 //			GetThreadCompanyOwnerByMessageIDFunc: func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
 //				panic("mock out the GetThreadCompanyOwnerByMessageID method")
 //			},
+//			GetThreadCompanyOwnerByVisibleMessageIDFunc: func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+//				panic("mock out the GetThreadCompanyOwnerByVisibleMessageID method")
+//			},
 //			GetThreadStudentOwnerFunc: func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
 //				panic("mock out the GetThreadStudentOwner method")
 //			},
 //			GetThreadStudentOwnerByMessageIDFunc: func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
 //				panic("mock out the GetThreadStudentOwnerByMessageID method")
 //			},
+//			GetThreadStudentOwnerByVisibleMessageIDFunc: func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+//				panic("mock out the GetThreadStudentOwnerByVisibleMessageID method")
+//			},
+//			GetThreadsByIDsFunc: func(ctx context.Context, db store.Queryer, ids []entity.MessageThreadID) ([]*entity.MessageThread, error) {
+//				panic("mock out the GetThreadsByIDs method")
+//			},
 //		}
 //
 //		// use mockedMessageOwnerGetter in code that requires MessageOwnerGetter
@@ -700,12 +711,21 @@ type MessageOwnerGetterMock struct {
 	// GetThreadCompanyOwnerByMessageIDFunc mocks the GetThreadCompanyOwnerByMessageID method.
 	GetThreadCompanyOwnerByMessageIDFunc func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error)
 
+	// GetThreadCompanyOwnerByVisibleMessageIDFunc mocks the GetThreadCompanyOwnerByVisibleMessageID method.
+	GetThreadCompanyOwnerByVisibleMessageIDFunc func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error)
+
 	// GetThreadStudentOwnerFunc mocks the GetThreadStudentOwner method.
 	GetThreadStudentOwnerFunc func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error)
 
 	// GetThreadStudentOwnerByMessageIDFunc mocks the GetThreadStudentOwnerByMessageID method.
 	GetThreadStudentOwnerByMessageIDFunc func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error)
 
+	// GetThreadStudentOwnerByVisibleMessageIDFunc mocks the GetThreadStudentOwnerByVisibleMessageID method.
+	GetThreadStudentOwnerByVisibleMessageIDFunc func(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error)
+
+	// GetThreadsByIDsFunc mocks the GetThreadsByIDs method.
+	GetThreadsByIDsFunc func(ctx context.Context, db store.Queryer, ids []entity.MessageThreadID) ([]*entity.MessageThread, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// GetThreadCompanyOwner holds details about calls to the GetThreadCompanyOwner method.
@@ -726,6 +746,15 @@ type MessageOwnerGetterMock struct {
 			// MessageID is the messageID argument value.
 			MessageID entity.MessageID
 		}
+		// GetThreadCompanyOwnerByVisibleMessageID holds details about calls to the GetThreadCompanyOwnerByVisibleMessageID method.
+		GetThreadCompanyOwnerByVisibleMessageID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// MessageID is the messageID argument value.
+			MessageID entity.MessageID
+		}
 		// GetThreadStudentOwner holds details about calls to the GetThreadStudentOwner method.
 		GetThreadStudentOwner []struct {
 			// Ctx is the ctx argument value.
@@ -744,11 +773,32 @@ type MessageOwnerGetterMock struct {
 			// MessageID is the messageID argument value.
 			MessageID entity.MessageID
 		}
+		// GetThreadStudentOwnerByVisibleMessageID holds details about calls to the GetThreadStudentOwnerByVisibleMessageID method.
+		GetThreadStudentOwnerByVisibleMessageID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// MessageID is the messageID argument value.
+			MessageID entity.MessageID
+		}
+		// GetThreadsByIDs holds details about calls to the GetThreadsByIDs method.
+		GetThreadsByIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// Ids is the ids argument value.
+			Ids []entity.MessageThreadID
+		}
 	}
-	lockGetThreadCompanyOwner            sync.RWMutex
-	lockGetThreadCompanyOwnerByMessageID sync.RWMutex
-	lockGetThreadStudentOwner            sync.RWMutex
-	lockGetThreadStudentOwnerByMessageID sync.RWMutex
+	lockGetThreadCompanyOwner                   sync.RWMutex
+	lockGetThreadCompanyOwnerByMessageID        sync.RWMutex
+	lockGetThreadCompanyOwnerByVisibleMessageID sync.RWMutex
+	lockGetThreadStudentOwner                   sync.RWMutex
+	lockGetThreadStudentOwnerByMessageID        sync.RWMutex
+	lockGetThreadStudentOwnerByVisibleMessageID sync.RWMutex
+	lockGetThreadsByIDs                         sync.RWMutex
 }
 
 // GetThreadCompanyOwner calls GetThreadCompanyOwnerFunc.
@@ -831,6 +881,46 @@ func (mock *MessageOwnerGetterMock) GetThreadCompanyOwnerByMessageIDCalls() []st
 	return calls
 }
 
+// GetThreadCompanyOwnerByVisibleMessageID calls GetThreadCompanyOwnerByVisibleMessageIDFunc.
+func (mock *MessageOwnerGetterMock) GetThreadCompanyOwnerByVisibleMessageID(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+	if mock.GetThreadCompanyOwnerByVisibleMessageIDFunc == nil {
+		panic("MessageOwnerGetterMock.GetThreadCompanyOwnerByVisibleMessageIDFunc: method is nil but MessageOwnerGetter.GetThreadCompanyOwnerByVisibleMessageID was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Db        store.Queryer
+		MessageID entity.MessageID
+	}{
+		Ctx:       ctx,
+		Db:        db,
+		MessageID: messageID,
+	}
+	mock.lockGetThreadCompanyOwnerByVisibleMessageID.Lock()
+	mock.calls.GetThreadCompanyOwnerByVisibleMessageID = append(mock.calls.GetThreadCompanyOwnerByVisibleMessageID, callInfo)
+	mock.lockGetThreadCompanyOwnerByVisibleMessageID.Unlock()
+	return mock.GetThreadCompanyOwnerByVisibleMessageIDFunc(ctx, db, messageID)
+}
+
+// GetThreadCompanyOwnerByVisibleMessageIDCalls gets all the calls that were made to GetThreadCompanyOwnerByVisibleMessageID.
+// Check the length with:
+//
+//	len(mockedMessageOwnerGetter.GetThreadCompanyOwnerByVisibleMessageIDCalls())
+func (mock *MessageOwnerGetterMock) GetThreadCompanyOwnerByVisibleMessageIDCalls() []struct {
+	Ctx       context.Context
+	Db        store.Queryer
+	MessageID entity.MessageID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Db        store.Queryer
+		MessageID entity.MessageID
+	}
+	mock.lockGetThreadCompanyOwnerByVisibleMessageID.RLock()
+	calls = mock.calls.GetThreadCompanyOwnerByVisibleMessageID
+	mock.lockGetThreadCompanyOwnerByVisibleMessageID.RUnlock()
+	return calls
+}
+
 // GetThreadStudentOwner calls GetThreadStudentOwnerFunc.
 func (mock *MessageOwnerGetterMock) GetThreadStudentOwner(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
 	if mock.GetThreadStudentOwnerFunc == nil {
@@ -911,6 +1001,86 @@ func (mock *MessageOwnerGetterMock) GetThreadStudentOwnerByMessageIDCalls() []st
 	return calls
 }
 
+// GetThreadStudentOwnerByVisibleMessageID calls GetThreadStudentOwnerByVisibleMessageIDFunc.
+func (mock *MessageOwnerGetterMock) GetThreadStudentOwnerByVisibleMessageID(ctx context.Context, db store.Queryer, messageID entity.MessageID) (int64, error) {
+	if mock.GetThreadStudentOwnerByVisibleMessageIDFunc == nil {
+		panic("MessageOwnerGetterMock.GetThreadStudentOwnerByVisibleMessageIDFunc: method is nil but MessageOwnerGetter.GetThreadStudentOwnerByVisibleMessageID was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Db        store.Queryer
+		MessageID entity.MessageID
+	}{
+		Ctx:       ctx,
+		Db:        db,
+		MessageID: messageID,
+	}
+	mock.lockGetThreadStudentOwnerByVisibleMessageID.Lock()
+	mock.calls.GetThreadStudentOwnerByVisibleMessageID = append(mock.calls.GetThreadStudentOwnerByVisibleMessageID, callInfo)
+	mock.lockGetThreadStudentOwnerByVisibleMessageID.Unlock()
+	return mock.GetThreadStudentOwnerByVisibleMessageIDFunc(ctx, db, messageID)
+}
+
+// GetThreadStudentOwnerByVisibleMessageIDCalls gets all the calls that were made to GetThreadStudentOwnerByVisibleMessageID.
+// Check the length with:
+//
+//	len(mockedMessageOwnerGetter.GetThreadStudentOwnerByVisibleMessageIDCalls())
+func (mock *MessageOwnerGetterMock) GetThreadStudentOwnerByVisibleMessageIDCalls() []struct {
+	Ctx       context.Context
+	Db        store.Queryer
+	MessageID entity.MessageID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Db        store.Queryer
+		MessageID entity.MessageID
+	}
+	mock.lockGetThreadStudentOwnerByVisibleMessageID.RLock()
+	calls = mock.calls.GetThreadStudentOwnerByVisibleMessageID
+	mock.lockGetThreadStudentOwnerByVisibleMessageID.RUnlock()
+	return calls
+}
+
+// GetThreadsByIDs calls GetThreadsByIDsFunc.
+func (mock *MessageOwnerGetterMock) GetThreadsByIDs(ctx context.Context, db store.Queryer, ids []entity.MessageThreadID) ([]*entity.MessageThread, error) {
+	if mock.GetThreadsByIDsFunc == nil {
+		panic("MessageOwnerGetterMock.GetThreadsByIDsFunc: method is nil but MessageOwnerGetter.GetThreadsByIDs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		Ids []entity.MessageThreadID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		Ids: ids,
+	}
+	mock.lockGetThreadsByIDs.Lock()
+	mock.calls.GetThreadsByIDs = append(mock.calls.GetThreadsByIDs, callInfo)
+	mock.lockGetThreadsByIDs.Unlock()
+	return mock.GetThreadsByIDsFunc(ctx, db, ids)
+}
+
+// GetThreadsByIDsCalls gets all the calls that were made to GetThreadsByIDs.
+// Check the length with:
+//
+//	len(mockedMessageOwnerGetter.GetThreadsByIDsCalls())
+func (mock *MessageOwnerGetterMock) GetThreadsByIDsCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	Ids []entity.MessageThreadID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		Ids []entity.MessageThreadID
+	}
+	mock.lockGetThreadsByIDs.RLock()
+	calls = mock.calls.GetThreadsByIDs
+	mock.lockGetThreadsByIDs.RUnlock()
+	return calls
+}
+
 // Ensure, that MessageGetterMock does implement MessageGetter.
 // If this is not the case, regenerate this file with moq.
 var _ MessageGetter = &MessageGetterMock{}
@@ -921,12 +1091,24 @@ var _ MessageGetter = &MessageGetterMock{}
 //
 //		// make and configure a mocked MessageGetter
 //		mockedMessageGetter := &MessageGetterMock{
+//			EachMessageForCompanyUserFunc: func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+//				panic("mock out the EachMessageForCompanyUser method")
+//			},
+//			EachMessageForStudentUserFunc: func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+//				panic("mock out the EachMessageForStudentUser method")
+//			},
 //			GetAllMessagesForCompanyUserFunc: func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
 //				panic("mock out the GetAllMessagesForCompanyUser method")
 //			},
 //			GetAllMessagesForStudentUserFunc: func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
 //				panic("mock out the GetAllMessagesForStudentUser method")
 //			},
+//			GetMessagesByIDsFunc: func(ctx context.Context, db store.Queryer, ids []entity.MessageID) (entity.Messages, error) {
+//				panic("mock out the GetMessagesByIDs method")
+//			},
+//			GetSentMessageThreadIDFunc: func(ctx context.Context, db store.Queryer, id entity.MessageID) (entity.MessageThreadID, error) {
+//				panic("mock out the GetSentMessageThreadID method")
+//			},
 //		}
 //
 //		// use mockedMessageGetter in code that requires MessageGetter
@@ -934,14 +1116,48 @@ var _ MessageGetter = &MessageGetterMock{}
 //
 //	}
 type MessageGetterMock struct {
+	// EachMessageForCompanyUserFunc mocks the EachMessageForCompanyUser method.
+	EachMessageForCompanyUserFunc func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error
+
+	// EachMessageForStudentUserFunc mocks the EachMessageForStudentUser method.
+	EachMessageForStudentUserFunc func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error
+
 	// GetAllMessagesForCompanyUserFunc mocks the GetAllMessagesForCompanyUser method.
 	GetAllMessagesForCompanyUserFunc func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error)
 
 	// GetAllMessagesForStudentUserFunc mocks the GetAllMessagesForStudentUser method.
 	GetAllMessagesForStudentUserFunc func(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error)
 
+	// GetMessagesByIDsFunc mocks the GetMessagesByIDs method.
+	GetMessagesByIDsFunc func(ctx context.Context, db store.Queryer, ids []entity.MessageID) (entity.Messages, error)
+
+	// GetSentMessageThreadIDFunc mocks the GetSentMessageThreadID method.
+	GetSentMessageThreadIDFunc func(ctx context.Context, db store.Queryer, id entity.MessageID) (entity.MessageThreadID, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// EachMessageForCompanyUser holds details about calls to the EachMessageForCompanyUser method.
+		EachMessageForCompanyUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// MessageThreadID is the messageThreadID argument value.
+			MessageThreadID entity.MessageThreadID
+			// Fn is the fn argument value.
+			Fn func(*entity.Message) error
+		}
+		// EachMessageForStudentUser holds details about calls to the EachMessageForStudentUser method.
+		EachMessageForStudentUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// MessageThreadID is the messageThreadID argument value.
+			MessageThreadID entity.MessageThreadID
+			// Fn is the fn argument value.
+			Fn func(*entity.Message) error
+		}
 		// GetAllMessagesForCompanyUser holds details about calls to the GetAllMessagesForCompanyUser method.
 		GetAllMessagesForCompanyUser []struct {
 			// Ctx is the ctx argument value.
@@ -960,9 +1176,119 @@ type MessageGetterMock struct {
 			// MessageThreadID is the messageThreadID argument value.
 			MessageThreadID entity.MessageThreadID
 		}
+		// GetMessagesByIDs holds details about calls to the GetMessagesByIDs method.
+		GetMessagesByIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// Ids is the ids argument value.
+			Ids []entity.MessageID
+		}
+		// GetSentMessageThreadID holds details about calls to the GetSentMessageThreadID method.
+		GetSentMessageThreadID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.MessageID
+		}
 	}
+	lockEachMessageForCompanyUser    sync.RWMutex
+	lockEachMessageForStudentUser    sync.RWMutex
 	lockGetAllMessagesForCompanyUser sync.RWMutex
 	lockGetAllMessagesForStudentUser sync.RWMutex
+	lockGetMessagesByIDs             sync.RWMutex
+	lockGetSentMessageThreadID       sync.RWMutex
+}
+
+// EachMessageForCompanyUser calls EachMessageForCompanyUserFunc.
+func (mock *MessageGetterMock) EachMessageForCompanyUser(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+	if mock.EachMessageForCompanyUserFunc == nil {
+		panic("MessageGetterMock.EachMessageForCompanyUserFunc: method is nil but MessageGetter.EachMessageForCompanyUser was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		Db              store.Queryer
+		MessageThreadID entity.MessageThreadID
+		Fn              func(*entity.Message) error
+	}{
+		Ctx:             ctx,
+		Db:              db,
+		MessageThreadID: messageThreadID,
+		Fn:              fn,
+	}
+	mock.lockEachMessageForCompanyUser.Lock()
+	mock.calls.EachMessageForCompanyUser = append(mock.calls.EachMessageForCompanyUser, callInfo)
+	mock.lockEachMessageForCompanyUser.Unlock()
+	return mock.EachMessageForCompanyUserFunc(ctx, db, messageThreadID, fn)
+}
+
+// EachMessageForCompanyUserCalls gets all the calls that were made to EachMessageForCompanyUser.
+// Check the length with:
+//
+//	len(mockedMessageGetter.EachMessageForCompanyUserCalls())
+func (mock *MessageGetterMock) EachMessageForCompanyUserCalls() []struct {
+	Ctx             context.Context
+	Db              store.Queryer
+	MessageThreadID entity.MessageThreadID
+	Fn              func(*entity.Message) error
+} {
+	var calls []struct {
+		Ctx             context.Context
+		Db              store.Queryer
+		MessageThreadID entity.MessageThreadID
+		Fn              func(*entity.Message) error
+	}
+	mock.lockEachMessageForCompanyUser.RLock()
+	calls = mock.calls.EachMessageForCompanyUser
+	mock.lockEachMessageForCompanyUser.RUnlock()
+	return calls
+}
+
+// EachMessageForStudentUser calls EachMessageForStudentUserFunc.
+func (mock *MessageGetterMock) EachMessageForStudentUser(ctx context.Context, db store.Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+	if mock.EachMessageForStudentUserFunc == nil {
+		panic("MessageGetterMock.EachMessageForStudentUserFunc: method is nil but MessageGetter.EachMessageForStudentUser was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		Db              store.Queryer
+		MessageThreadID entity.MessageThreadID
+		Fn              func(*entity.Message) error
+	}{
+		Ctx:             ctx,
+		Db:              db,
+		MessageThreadID: messageThreadID,
+		Fn:              fn,
+	}
+	mock.lockEachMessageForStudentUser.Lock()
+	mock.calls.EachMessageForStudentUser = append(mock.calls.EachMessageForStudentUser, callInfo)
+	mock.lockEachMessageForStudentUser.Unlock()
+	return mock.EachMessageForStudentUserFunc(ctx, db, messageThreadID, fn)
+}
+
+// EachMessageForStudentUserCalls gets all the calls that were made to EachMessageForStudentUser.
+// Check the length with:
+//
+//	len(mockedMessageGetter.EachMessageForStudentUserCalls())
+func (mock *MessageGetterMock) EachMessageForStudentUserCalls() []struct {
+	Ctx             context.Context
+	Db              store.Queryer
+	MessageThreadID entity.MessageThreadID
+	Fn              func(*entity.Message) error
+} {
+	var calls []struct {
+		Ctx             context.Context
+		Db              store.Queryer
+		MessageThreadID entity.MessageThreadID
+		Fn              func(*entity.Message) error
+	}
+	mock.lockEachMessageForStudentUser.RLock()
+	calls = mock.calls.EachMessageForStudentUser
+	mock.lockEachMessageForStudentUser.RUnlock()
+	return calls
 }
 
 // GetAllMessagesForCompanyUser calls GetAllMessagesForCompanyUserFunc.
@@ -1045,6 +1371,86 @@ func (mock *MessageGetterMock) GetAllMessagesForStudentUserCalls() []struct {
 	return calls
 }
 
+// GetMessagesByIDs calls GetMessagesByIDsFunc.
+func (mock *MessageGetterMock) GetMessagesByIDs(ctx context.Context, db store.Queryer, ids []entity.MessageID) (entity.Messages, error) {
+	if mock.GetMessagesByIDsFunc == nil {
+		panic("MessageGetterMock.GetMessagesByIDsFunc: method is nil but MessageGetter.GetMessagesByIDs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		Ids []entity.MessageID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		Ids: ids,
+	}
+	mock.lockGetMessagesByIDs.Lock()
+	mock.calls.GetMessagesByIDs = append(mock.calls.GetMessagesByIDs, callInfo)
+	mock.lockGetMessagesByIDs.Unlock()
+	return mock.GetMessagesByIDsFunc(ctx, db, ids)
+}
+
+// GetMessagesByIDsCalls gets all the calls that were made to GetMessagesByIDs.
+// Check the length with:
+//
+//	len(mockedMessageGetter.GetMessagesByIDsCalls())
+func (mock *MessageGetterMock) GetMessagesByIDsCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	Ids []entity.MessageID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		Ids []entity.MessageID
+	}
+	mock.lockGetMessagesByIDs.RLock()
+	calls = mock.calls.GetMessagesByIDs
+	mock.lockGetMessagesByIDs.RUnlock()
+	return calls
+}
+
+// GetSentMessageThreadID calls GetSentMessageThreadIDFunc.
+func (mock *MessageGetterMock) GetSentMessageThreadID(ctx context.Context, db store.Queryer, id entity.MessageID) (entity.MessageThreadID, error) {
+	if mock.GetSentMessageThreadIDFunc == nil {
+		panic("MessageGetterMock.GetSentMessageThreadIDFunc: method is nil but MessageGetter.GetSentMessageThreadID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.MessageID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetSentMessageThreadID.Lock()
+	mock.calls.GetSentMessageThreadID = append(mock.calls.GetSentMessageThreadID, callInfo)
+	mock.lockGetSentMessageThreadID.Unlock()
+	return mock.GetSentMessageThreadIDFunc(ctx, db, id)
+}
+
+// GetSentMessageThreadIDCalls gets all the calls that were made to GetSentMessageThreadID.
+// Check the length with:
+//
+//	len(mockedMessageGetter.GetSentMessageThreadIDCalls())
+func (mock *MessageGetterMock) GetSentMessageThreadIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.MessageID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.MessageID
+	}
+	mock.lockGetSentMessageThreadID.RLock()
+	calls = mock.calls.GetSentMessageThreadID
+	mock.lockGetSentMessageThreadID.RUnlock()
+	return calls
+}
+
 // Ensure, that MessageAdderMock does implement MessageAdder.
 // If this is not the case, regenerate this file with moq.
 var _ MessageAdder = &MessageAdderMock{}
@@ -1278,3 +1684,1899 @@ func (mock *MessageDeleterMock) DeleteMessageCalls() []struct {
 	mock.lockDeleteMessage.RUnlock()
 	return calls
 }
+
+// Ensure, that AttachmentGetterMock does implement AttachmentGetter.
+// If this is not the case, regenerate this file with moq.
+var _ AttachmentGetter = &AttachmentGetterMock{}
+
+// AttachmentGetterMock is a mock implementation of AttachmentGetter.
+//
+//	func TestSomethingThatUsesAttachmentGetter(t *testing.T) {
+//
+//		// make and configure a mocked AttachmentGetter
+//		mockedAttachmentGetter := &AttachmentGetterMock{
+//			GetAttachmentFunc: func(ctx context.Context, db store.Queryer, id entity.AttachmentID) (*entity.Attachment, error) {
+//				panic("mock out the GetAttachment method")
+//			},
+//			GetAttachmentsByMessageIDsFunc: func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+//				panic("mock out the GetAttachmentsByMessageIDs method")
+//			},
+//		}
+//
+//		// use mockedAttachmentGetter in code that requires AttachmentGetter
+//		// and then make assertions.
+//
+//	}
+type AttachmentGetterMock struct {
+	// GetAttachmentFunc mocks the GetAttachment method.
+	GetAttachmentFunc func(ctx context.Context, db store.Queryer, id entity.AttachmentID) (*entity.Attachment, error)
+
+	// GetAttachmentsByMessageIDsFunc mocks the GetAttachmentsByMessageIDs method.
+	GetAttachmentsByMessageIDsFunc func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetAttachment holds details about calls to the GetAttachment method.
+		GetAttachment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.AttachmentID
+		}
+		// GetAttachmentsByMessageIDs holds details about calls to the GetAttachmentsByMessageIDs method.
+		GetAttachmentsByMessageIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// MessageIDs is the messageIDs argument value.
+			MessageIDs []entity.MessageID
+		}
+	}
+	lockGetAttachment              sync.RWMutex
+	lockGetAttachmentsByMessageIDs sync.RWMutex
+}
+
+// GetAttachment calls GetAttachmentFunc.
+func (mock *AttachmentGetterMock) GetAttachment(ctx context.Context, db store.Queryer, id entity.AttachmentID) (*entity.Attachment, error) {
+	if mock.GetAttachmentFunc == nil {
+		panic("AttachmentGetterMock.GetAttachmentFunc: method is nil but AttachmentGetter.GetAttachment was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.AttachmentID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetAttachment.Lock()
+	mock.calls.GetAttachment = append(mock.calls.GetAttachment, callInfo)
+	mock.lockGetAttachment.Unlock()
+	return mock.GetAttachmentFunc(ctx, db, id)
+}
+
+// GetAttachmentCalls gets all the calls that were made to GetAttachment.
+// Check the length with:
+//
+//	len(mockedAttachmentGetter.GetAttachmentCalls())
+func (mock *AttachmentGetterMock) GetAttachmentCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.AttachmentID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.AttachmentID
+	}
+	mock.lockGetAttachment.RLock()
+	calls = mock.calls.GetAttachment
+	mock.lockGetAttachment.RUnlock()
+	return calls
+}
+
+// GetAttachmentsByMessageIDs calls GetAttachmentsByMessageIDsFunc.
+func (mock *AttachmentGetterMock) GetAttachmentsByMessageIDs(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+	if mock.GetAttachmentsByMessageIDsFunc == nil {
+		panic("AttachmentGetterMock.GetAttachmentsByMessageIDsFunc: method is nil but AttachmentGetter.GetAttachmentsByMessageIDs was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		MessageIDs []entity.MessageID
+	}{
+		Ctx:        ctx,
+		Db:         db,
+		MessageIDs: messageIDs,
+	}
+	mock.lockGetAttachmentsByMessageIDs.Lock()
+	mock.calls.GetAttachmentsByMessageIDs = append(mock.calls.GetAttachmentsByMessageIDs, callInfo)
+	mock.lockGetAttachmentsByMessageIDs.Unlock()
+	return mock.GetAttachmentsByMessageIDsFunc(ctx, db, messageIDs)
+}
+
+// GetAttachmentsByMessageIDsCalls gets all the calls that were made to GetAttachmentsByMessageIDs.
+// Check the length with:
+//
+//	len(mockedAttachmentGetter.GetAttachmentsByMessageIDsCalls())
+func (mock *AttachmentGetterMock) GetAttachmentsByMessageIDsCalls() []struct {
+	Ctx        context.Context
+	Db         store.Queryer
+	MessageIDs []entity.MessageID
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		MessageIDs []entity.MessageID
+	}
+	mock.lockGetAttachmentsByMessageIDs.RLock()
+	calls = mock.calls.GetAttachmentsByMessageIDs
+	mock.lockGetAttachmentsByMessageIDs.RUnlock()
+	return calls
+}
+
+// Ensure, that AttachmentAdderMock does implement AttachmentAdder.
+// If this is not the case, regenerate this file with moq.
+var _ AttachmentAdder = &AttachmentAdderMock{}
+
+// AttachmentAdderMock is a mock implementation of AttachmentAdder.
+//
+//	func TestSomethingThatUsesAttachmentAdder(t *testing.T) {
+//
+//		// make and configure a mocked AttachmentAdder
+//		mockedAttachmentAdder := &AttachmentAdderMock{
+//			AddAttachmentFunc: func(ctx context.Context, db store.Execer, param *entity.Attachment) error {
+//				panic("mock out the AddAttachment method")
+//			},
+//		}
+//
+//		// use mockedAttachmentAdder in code that requires AttachmentAdder
+//		// and then make assertions.
+//
+//	}
+type AttachmentAdderMock struct {
+	// AddAttachmentFunc mocks the AddAttachment method.
+	AddAttachmentFunc func(ctx context.Context, db store.Execer, param *entity.Attachment) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddAttachment holds details about calls to the AddAttachment method.
+		AddAttachment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Param is the param argument value.
+			Param *entity.Attachment
+		}
+	}
+	lockAddAttachment sync.RWMutex
+}
+
+// AddAttachment calls AddAttachmentFunc.
+func (mock *AttachmentAdderMock) AddAttachment(ctx context.Context, db store.Execer, param *entity.Attachment) error {
+	if mock.AddAttachmentFunc == nil {
+		panic("AttachmentAdderMock.AddAttachmentFunc: method is nil but AttachmentAdder.AddAttachment was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Db    store.Execer
+		Param *entity.Attachment
+	}{
+		Ctx:   ctx,
+		Db:    db,
+		Param: param,
+	}
+	mock.lockAddAttachment.Lock()
+	mock.calls.AddAttachment = append(mock.calls.AddAttachment, callInfo)
+	mock.lockAddAttachment.Unlock()
+	return mock.AddAttachmentFunc(ctx, db, param)
+}
+
+// AddAttachmentCalls gets all the calls that were made to AddAttachment.
+// Check the length with:
+//
+//	len(mockedAttachmentAdder.AddAttachmentCalls())
+func (mock *AttachmentAdderMock) AddAttachmentCalls() []struct {
+	Ctx   context.Context
+	Db    store.Execer
+	Param *entity.Attachment
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Db    store.Execer
+		Param *entity.Attachment
+	}
+	mock.lockAddAttachment.RLock()
+	calls = mock.calls.AddAttachment
+	mock.lockAddAttachment.RUnlock()
+	return calls
+}
+
+// Ensure, that BlobStorageMock does implement BlobStorage.
+// If this is not the case, regenerate this file with moq.
+var _ BlobStorage = &BlobStorageMock{}
+
+// BlobStorageMock is a mock implementation of BlobStorage.
+//
+//	func TestSomethingThatUsesBlobStorage(t *testing.T) {
+//
+//		// make and configure a mocked BlobStorage
+//		mockedBlobStorage := &BlobStorageMock{
+//			DeleteFunc: func(ctx context.Context, key string) error {
+//				panic("mock out the Delete method")
+//			},
+//			GetFunc: func(ctx context.Context, key string) (io.ReadCloser, error) {
+//				panic("mock out the Get method")
+//			},
+//			PutFunc: func(ctx context.Context, key string, content []byte, contentType string) error {
+//				panic("mock out the Put method")
+//			},
+//		}
+//
+//		// use mockedBlobStorage in code that requires BlobStorage
+//		// and then make assertions.
+//
+//	}
+type BlobStorageMock struct {
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, key string) error
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// PutFunc mocks the Put method.
+	PutFunc func(ctx context.Context, key string, content []byte, contentType string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// Put holds details about calls to the Put method.
+		Put []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Content is the content argument value.
+			Content []byte
+			// ContentType is the contentType argument value.
+			ContentType string
+		}
+	}
+	lockDelete sync.RWMutex
+	lockGet    sync.RWMutex
+	lockPut    sync.RWMutex
+}
+
+// Delete calls DeleteFunc.
+func (mock *BlobStorageMock) Delete(ctx context.Context, key string) error {
+	if mock.DeleteFunc == nil {
+		panic("BlobStorageMock.DeleteFunc: method is nil but BlobStorage.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, key)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedBlobStorage.DeleteCalls())
+func (mock *BlobStorageMock) DeleteCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *BlobStorageMock) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if mock.GetFunc == nil {
+		panic("BlobStorageMock.GetFunc: method is nil but BlobStorage.Get was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx, key)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedBlobStorage.GetCalls())
+func (mock *BlobStorageMock) GetCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// Put calls PutFunc.
+func (mock *BlobStorageMock) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	if mock.PutFunc == nil {
+		panic("BlobStorageMock.PutFunc: method is nil but BlobStorage.Put was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Key         string
+		Content     []byte
+		ContentType string
+	}{
+		Ctx:         ctx,
+		Key:         key,
+		Content:     content,
+		ContentType: contentType,
+	}
+	mock.lockPut.Lock()
+	mock.calls.Put = append(mock.calls.Put, callInfo)
+	mock.lockPut.Unlock()
+	return mock.PutFunc(ctx, key, content, contentType)
+}
+
+// PutCalls gets all the calls that were made to Put.
+// Check the length with:
+//
+//	len(mockedBlobStorage.PutCalls())
+func (mock *BlobStorageMock) PutCalls() []struct {
+	Ctx         context.Context
+	Key         string
+	Content     []byte
+	ContentType string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Key         string
+		Content     []byte
+		ContentType string
+	}
+	mock.lockPut.RLock()
+	calls = mock.calls.Put
+	mock.lockPut.RUnlock()
+	return calls
+}
+
+// Ensure, that ReactionGetterMock does implement ReactionGetter.
+// If this is not the case, regenerate this file with moq.
+var _ ReactionGetter = &ReactionGetterMock{}
+
+// ReactionGetterMock is a mock implementation of ReactionGetter.
+//
+//	func TestSomethingThatUsesReactionGetter(t *testing.T) {
+//
+//		// make and configure a mocked ReactionGetter
+//		mockedReactionGetter := &ReactionGetterMock{
+//			GetReactionsByMessageIDsFunc: func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Reactions, error) {
+//				panic("mock out the GetReactionsByMessageIDs method")
+//			},
+//		}
+//
+//		// use mockedReactionGetter in code that requires ReactionGetter
+//		// and then make assertions.
+//
+//	}
+type ReactionGetterMock struct {
+	// GetReactionsByMessageIDsFunc mocks the GetReactionsByMessageIDs method.
+	GetReactionsByMessageIDsFunc func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Reactions, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetReactionsByMessageIDs holds details about calls to the GetReactionsByMessageIDs method.
+		GetReactionsByMessageIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// MessageIDs is the messageIDs argument value.
+			MessageIDs []entity.MessageID
+		}
+	}
+	lockGetReactionsByMessageIDs sync.RWMutex
+}
+
+// GetReactionsByMessageIDs calls GetReactionsByMessageIDsFunc.
+func (mock *ReactionGetterMock) GetReactionsByMessageIDs(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Reactions, error) {
+	if mock.GetReactionsByMessageIDsFunc == nil {
+		panic("ReactionGetterMock.GetReactionsByMessageIDsFunc: method is nil but ReactionGetter.GetReactionsByMessageIDs was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		MessageIDs []entity.MessageID
+	}{
+		Ctx:        ctx,
+		Db:         db,
+		MessageIDs: messageIDs,
+	}
+	mock.lockGetReactionsByMessageIDs.Lock()
+	mock.calls.GetReactionsByMessageIDs = append(mock.calls.GetReactionsByMessageIDs, callInfo)
+	mock.lockGetReactionsByMessageIDs.Unlock()
+	return mock.GetReactionsByMessageIDsFunc(ctx, db, messageIDs)
+}
+
+// GetReactionsByMessageIDsCalls gets all the calls that were made to GetReactionsByMessageIDs.
+// Check the length with:
+//
+//	len(mockedReactionGetter.GetReactionsByMessageIDsCalls())
+func (mock *ReactionGetterMock) GetReactionsByMessageIDsCalls() []struct {
+	Ctx        context.Context
+	Db         store.Queryer
+	MessageIDs []entity.MessageID
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		MessageIDs []entity.MessageID
+	}
+	mock.lockGetReactionsByMessageIDs.RLock()
+	calls = mock.calls.GetReactionsByMessageIDs
+	mock.lockGetReactionsByMessageIDs.RUnlock()
+	return calls
+}
+
+// Ensure, that ReactionAdderMock does implement ReactionAdder.
+// If this is not the case, regenerate this file with moq.
+var _ ReactionAdder = &ReactionAdderMock{}
+
+// ReactionAdderMock is a mock implementation of ReactionAdder.
+//
+//	func TestSomethingThatUsesReactionAdder(t *testing.T) {
+//
+//		// make and configure a mocked ReactionAdder
+//		mockedReactionAdder := &ReactionAdderMock{
+//			AddReactionFunc: func(ctx context.Context, db store.Execer, param *entity.Reaction) error {
+//				panic("mock out the AddReaction method")
+//			},
+//		}
+//
+//		// use mockedReactionAdder in code that requires ReactionAdder
+//		// and then make assertions.
+//
+//	}
+type ReactionAdderMock struct {
+	// AddReactionFunc mocks the AddReaction method.
+	AddReactionFunc func(ctx context.Context, db store.Execer, param *entity.Reaction) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddReaction holds details about calls to the AddReaction method.
+		AddReaction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Param is the param argument value.
+			Param *entity.Reaction
+		}
+	}
+	lockAddReaction sync.RWMutex
+}
+
+// AddReaction calls AddReactionFunc.
+func (mock *ReactionAdderMock) AddReaction(ctx context.Context, db store.Execer, param *entity.Reaction) error {
+	if mock.AddReactionFunc == nil {
+		panic("ReactionAdderMock.AddReactionFunc: method is nil but ReactionAdder.AddReaction was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Db    store.Execer
+		Param *entity.Reaction
+	}{
+		Ctx:   ctx,
+		Db:    db,
+		Param: param,
+	}
+	mock.lockAddReaction.Lock()
+	mock.calls.AddReaction = append(mock.calls.AddReaction, callInfo)
+	mock.lockAddReaction.Unlock()
+	return mock.AddReactionFunc(ctx, db, param)
+}
+
+// AddReactionCalls gets all the calls that were made to AddReaction.
+// Check the length with:
+//
+//	len(mockedReactionAdder.AddReactionCalls())
+func (mock *ReactionAdderMock) AddReactionCalls() []struct {
+	Ctx   context.Context
+	Db    store.Execer
+	Param *entity.Reaction
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Db    store.Execer
+		Param *entity.Reaction
+	}
+	mock.lockAddReaction.RLock()
+	calls = mock.calls.AddReaction
+	mock.lockAddReaction.RUnlock()
+	return calls
+}
+
+// Ensure, that ReactionDeleterMock does implement ReactionDeleter.
+// If this is not the case, regenerate this file with moq.
+var _ ReactionDeleter = &ReactionDeleterMock{}
+
+// ReactionDeleterMock is a mock implementation of ReactionDeleter.
+//
+//	func TestSomethingThatUsesReactionDeleter(t *testing.T) {
+//
+//		// make and configure a mocked ReactionDeleter
+//		mockedReactionDeleter := &ReactionDeleterMock{
+//			DeleteReactionFunc: func(ctx context.Context, db store.Execer, param *entity.Reaction) error {
+//				panic("mock out the DeleteReaction method")
+//			},
+//		}
+//
+//		// use mockedReactionDeleter in code that requires ReactionDeleter
+//		// and then make assertions.
+//
+//	}
+type ReactionDeleterMock struct {
+	// DeleteReactionFunc mocks the DeleteReaction method.
+	DeleteReactionFunc func(ctx context.Context, db store.Execer, param *entity.Reaction) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteReaction holds details about calls to the DeleteReaction method.
+		DeleteReaction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Param is the param argument value.
+			Param *entity.Reaction
+		}
+	}
+	lockDeleteReaction sync.RWMutex
+}
+
+// DeleteReaction calls DeleteReactionFunc.
+func (mock *ReactionDeleterMock) DeleteReaction(ctx context.Context, db store.Execer, param *entity.Reaction) error {
+	if mock.DeleteReactionFunc == nil {
+		panic("ReactionDeleterMock.DeleteReactionFunc: method is nil but ReactionDeleter.DeleteReaction was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Db    store.Execer
+		Param *entity.Reaction
+	}{
+		Ctx:   ctx,
+		Db:    db,
+		Param: param,
+	}
+	mock.lockDeleteReaction.Lock()
+	mock.calls.DeleteReaction = append(mock.calls.DeleteReaction, callInfo)
+	mock.lockDeleteReaction.Unlock()
+	return mock.DeleteReactionFunc(ctx, db, param)
+}
+
+// DeleteReactionCalls gets all the calls that were made to DeleteReaction.
+// Check the length with:
+//
+//	len(mockedReactionDeleter.DeleteReactionCalls())
+func (mock *ReactionDeleterMock) DeleteReactionCalls() []struct {
+	Ctx   context.Context
+	Db    store.Execer
+	Param *entity.Reaction
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Db    store.Execer
+		Param *entity.Reaction
+	}
+	mock.lockDeleteReaction.RLock()
+	calls = mock.calls.DeleteReaction
+	mock.lockDeleteReaction.RUnlock()
+	return calls
+}
+
+// Ensure, that MessageTemplateGetterMock does implement MessageTemplateGetter.
+// If this is not the case, regenerate this file with moq.
+var _ MessageTemplateGetter = &MessageTemplateGetterMock{}
+
+// MessageTemplateGetterMock is a mock implementation of MessageTemplateGetter.
+//
+//	func TestSomethingThatUsesMessageTemplateGetter(t *testing.T) {
+//
+//		// make and configure a mocked MessageTemplateGetter
+//		mockedMessageTemplateGetter := &MessageTemplateGetterMock{
+//			GetAllMessageTemplatesFunc: func(ctx context.Context, db store.Queryer, companyUserID int64) (entity.MessageTemplates, error) {
+//				panic("mock out the GetAllMessageTemplates method")
+//			},
+//			GetMessageTemplateFunc: func(ctx context.Context, db store.Queryer, id entity.MessageTemplateID) (*entity.MessageTemplate, error) {
+//				panic("mock out the GetMessageTemplate method")
+//			},
+//		}
+//
+//		// use mockedMessageTemplateGetter in code that requires MessageTemplateGetter
+//		// and then make assertions.
+//
+//	}
+type MessageTemplateGetterMock struct {
+	// GetAllMessageTemplatesFunc mocks the GetAllMessageTemplates method.
+	GetAllMessageTemplatesFunc func(ctx context.Context, db store.Queryer, companyUserID int64) (entity.MessageTemplates, error)
+
+	// GetMessageTemplateFunc mocks the GetMessageTemplate method.
+	GetMessageTemplateFunc func(ctx context.Context, db store.Queryer, id entity.MessageTemplateID) (*entity.MessageTemplate, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetAllMessageTemplates holds details about calls to the GetAllMessageTemplates method.
+		GetAllMessageTemplates []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// CompanyUserID is the companyUserID argument value.
+			CompanyUserID int64
+		}
+		// GetMessageTemplate holds details about calls to the GetMessageTemplate method.
+		GetMessageTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.MessageTemplateID
+		}
+	}
+	lockGetAllMessageTemplates sync.RWMutex
+	lockGetMessageTemplate     sync.RWMutex
+}
+
+// GetAllMessageTemplates calls GetAllMessageTemplatesFunc.
+func (mock *MessageTemplateGetterMock) GetAllMessageTemplates(ctx context.Context, db store.Queryer, companyUserID int64) (entity.MessageTemplates, error) {
+	if mock.GetAllMessageTemplatesFunc == nil {
+		panic("MessageTemplateGetterMock.GetAllMessageTemplatesFunc: method is nil but MessageTemplateGetter.GetAllMessageTemplates was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		Db            store.Queryer
+		CompanyUserID int64
+	}{
+		Ctx:           ctx,
+		Db:            db,
+		CompanyUserID: companyUserID,
+	}
+	mock.lockGetAllMessageTemplates.Lock()
+	mock.calls.GetAllMessageTemplates = append(mock.calls.GetAllMessageTemplates, callInfo)
+	mock.lockGetAllMessageTemplates.Unlock()
+	return mock.GetAllMessageTemplatesFunc(ctx, db, companyUserID)
+}
+
+// GetAllMessageTemplatesCalls gets all the calls that were made to GetAllMessageTemplates.
+// Check the length with:
+//
+//	len(mockedMessageTemplateGetter.GetAllMessageTemplatesCalls())
+func (mock *MessageTemplateGetterMock) GetAllMessageTemplatesCalls() []struct {
+	Ctx           context.Context
+	Db            store.Queryer
+	CompanyUserID int64
+} {
+	var calls []struct {
+		Ctx           context.Context
+		Db            store.Queryer
+		CompanyUserID int64
+	}
+	mock.lockGetAllMessageTemplates.RLock()
+	calls = mock.calls.GetAllMessageTemplates
+	mock.lockGetAllMessageTemplates.RUnlock()
+	return calls
+}
+
+// GetMessageTemplate calls GetMessageTemplateFunc.
+func (mock *MessageTemplateGetterMock) GetMessageTemplate(ctx context.Context, db store.Queryer, id entity.MessageTemplateID) (*entity.MessageTemplate, error) {
+	if mock.GetMessageTemplateFunc == nil {
+		panic("MessageTemplateGetterMock.GetMessageTemplateFunc: method is nil but MessageTemplateGetter.GetMessageTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.MessageTemplateID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetMessageTemplate.Lock()
+	mock.calls.GetMessageTemplate = append(mock.calls.GetMessageTemplate, callInfo)
+	mock.lockGetMessageTemplate.Unlock()
+	return mock.GetMessageTemplateFunc(ctx, db, id)
+}
+
+// GetMessageTemplateCalls gets all the calls that were made to GetMessageTemplate.
+// Check the length with:
+//
+//	len(mockedMessageTemplateGetter.GetMessageTemplateCalls())
+func (mock *MessageTemplateGetterMock) GetMessageTemplateCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.MessageTemplateID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.MessageTemplateID
+	}
+	mock.lockGetMessageTemplate.RLock()
+	calls = mock.calls.GetMessageTemplate
+	mock.lockGetMessageTemplate.RUnlock()
+	return calls
+}
+
+// Ensure, that MessageTemplateAdderMock does implement MessageTemplateAdder.
+// If this is not the case, regenerate this file with moq.
+var _ MessageTemplateAdder = &MessageTemplateAdderMock{}
+
+// MessageTemplateAdderMock is a mock implementation of MessageTemplateAdder.
+//
+//	func TestSomethingThatUsesMessageTemplateAdder(t *testing.T) {
+//
+//		// make and configure a mocked MessageTemplateAdder
+//		mockedMessageTemplateAdder := &MessageTemplateAdderMock{
+//			AddMessageTemplateFunc: func(ctx context.Context, db store.Execer, param *entity.MessageTemplate) error {
+//				panic("mock out the AddMessageTemplate method")
+//			},
+//		}
+//
+//		// use mockedMessageTemplateAdder in code that requires MessageTemplateAdder
+//		// and then make assertions.
+//
+//	}
+type MessageTemplateAdderMock struct {
+	// AddMessageTemplateFunc mocks the AddMessageTemplate method.
+	AddMessageTemplateFunc func(ctx context.Context, db store.Execer, param *entity.MessageTemplate) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddMessageTemplate holds details about calls to the AddMessageTemplate method.
+		AddMessageTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Param is the param argument value.
+			Param *entity.MessageTemplate
+		}
+	}
+	lockAddMessageTemplate sync.RWMutex
+}
+
+// AddMessageTemplate calls AddMessageTemplateFunc.
+func (mock *MessageTemplateAdderMock) AddMessageTemplate(ctx context.Context, db store.Execer, param *entity.MessageTemplate) error {
+	if mock.AddMessageTemplateFunc == nil {
+		panic("MessageTemplateAdderMock.AddMessageTemplateFunc: method is nil but MessageTemplateAdder.AddMessageTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Db    store.Execer
+		Param *entity.MessageTemplate
+	}{
+		Ctx:   ctx,
+		Db:    db,
+		Param: param,
+	}
+	mock.lockAddMessageTemplate.Lock()
+	mock.calls.AddMessageTemplate = append(mock.calls.AddMessageTemplate, callInfo)
+	mock.lockAddMessageTemplate.Unlock()
+	return mock.AddMessageTemplateFunc(ctx, db, param)
+}
+
+// AddMessageTemplateCalls gets all the calls that were made to AddMessageTemplate.
+// Check the length with:
+//
+//	len(mockedMessageTemplateAdder.AddMessageTemplateCalls())
+func (mock *MessageTemplateAdderMock) AddMessageTemplateCalls() []struct {
+	Ctx   context.Context
+	Db    store.Execer
+	Param *entity.MessageTemplate
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Db    store.Execer
+		Param *entity.MessageTemplate
+	}
+	mock.lockAddMessageTemplate.RLock()
+	calls = mock.calls.AddMessageTemplate
+	mock.lockAddMessageTemplate.RUnlock()
+	return calls
+}
+
+// Ensure, that MessageTemplateEditorMock does implement MessageTemplateEditor.
+// If this is not the case, regenerate this file with moq.
+var _ MessageTemplateEditor = &MessageTemplateEditorMock{}
+
+// MessageTemplateEditorMock is a mock implementation of MessageTemplateEditor.
+//
+//	func TestSomethingThatUsesMessageTemplateEditor(t *testing.T) {
+//
+//		// make and configure a mocked MessageTemplateEditor
+//		mockedMessageTemplateEditor := &MessageTemplateEditorMock{
+//			EditMessageTemplateFunc: func(ctx context.Context, db store.Execer, param *entity.MessageTemplate) error {
+//				panic("mock out the EditMessageTemplate method")
+//			},
+//		}
+//
+//		// use mockedMessageTemplateEditor in code that requires MessageTemplateEditor
+//		// and then make assertions.
+//
+//	}
+type MessageTemplateEditorMock struct {
+	// EditMessageTemplateFunc mocks the EditMessageTemplate method.
+	EditMessageTemplateFunc func(ctx context.Context, db store.Execer, param *entity.MessageTemplate) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// EditMessageTemplate holds details about calls to the EditMessageTemplate method.
+		EditMessageTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Param is the param argument value.
+			Param *entity.MessageTemplate
+		}
+	}
+	lockEditMessageTemplate sync.RWMutex
+}
+
+// EditMessageTemplate calls EditMessageTemplateFunc.
+func (mock *MessageTemplateEditorMock) EditMessageTemplate(ctx context.Context, db store.Execer, param *entity.MessageTemplate) error {
+	if mock.EditMessageTemplateFunc == nil {
+		panic("MessageTemplateEditorMock.EditMessageTemplateFunc: method is nil but MessageTemplateEditor.EditMessageTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Db    store.Execer
+		Param *entity.MessageTemplate
+	}{
+		Ctx:   ctx,
+		Db:    db,
+		Param: param,
+	}
+	mock.lockEditMessageTemplate.Lock()
+	mock.calls.EditMessageTemplate = append(mock.calls.EditMessageTemplate, callInfo)
+	mock.lockEditMessageTemplate.Unlock()
+	return mock.EditMessageTemplateFunc(ctx, db, param)
+}
+
+// EditMessageTemplateCalls gets all the calls that were made to EditMessageTemplate.
+// Check the length with:
+//
+//	len(mockedMessageTemplateEditor.EditMessageTemplateCalls())
+func (mock *MessageTemplateEditorMock) EditMessageTemplateCalls() []struct {
+	Ctx   context.Context
+	Db    store.Execer
+	Param *entity.MessageTemplate
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Db    store.Execer
+		Param *entity.MessageTemplate
+	}
+	mock.lockEditMessageTemplate.RLock()
+	calls = mock.calls.EditMessageTemplate
+	mock.lockEditMessageTemplate.RUnlock()
+	return calls
+}
+
+// Ensure, that MessageTemplateDeleterMock does implement MessageTemplateDeleter.
+// If this is not the case, regenerate this file with moq.
+var _ MessageTemplateDeleter = &MessageTemplateDeleterMock{}
+
+// MessageTemplateDeleterMock is a mock implementation of MessageTemplateDeleter.
+//
+//	func TestSomethingThatUsesMessageTemplateDeleter(t *testing.T) {
+//
+//		// make and configure a mocked MessageTemplateDeleter
+//		mockedMessageTemplateDeleter := &MessageTemplateDeleterMock{
+//			DeleteMessageTemplateFunc: func(ctx context.Context, db store.Execer, id entity.MessageTemplateID) error {
+//				panic("mock out the DeleteMessageTemplate method")
+//			},
+//		}
+//
+//		// use mockedMessageTemplateDeleter in code that requires MessageTemplateDeleter
+//		// and then make assertions.
+//
+//	}
+type MessageTemplateDeleterMock struct {
+	// DeleteMessageTemplateFunc mocks the DeleteMessageTemplate method.
+	DeleteMessageTemplateFunc func(ctx context.Context, db store.Execer, id entity.MessageTemplateID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteMessageTemplate holds details about calls to the DeleteMessageTemplate method.
+		DeleteMessageTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// ID is the id argument value.
+			ID entity.MessageTemplateID
+		}
+	}
+	lockDeleteMessageTemplate sync.RWMutex
+}
+
+// DeleteMessageTemplate calls DeleteMessageTemplateFunc.
+func (mock *MessageTemplateDeleterMock) DeleteMessageTemplate(ctx context.Context, db store.Execer, id entity.MessageTemplateID) error {
+	if mock.DeleteMessageTemplateFunc == nil {
+		panic("MessageTemplateDeleterMock.DeleteMessageTemplateFunc: method is nil but MessageTemplateDeleter.DeleteMessageTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		ID  entity.MessageTemplateID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockDeleteMessageTemplate.Lock()
+	mock.calls.DeleteMessageTemplate = append(mock.calls.DeleteMessageTemplate, callInfo)
+	mock.lockDeleteMessageTemplate.Unlock()
+	return mock.DeleteMessageTemplateFunc(ctx, db, id)
+}
+
+// DeleteMessageTemplateCalls gets all the calls that were made to DeleteMessageTemplate.
+// Check the length with:
+//
+//	len(mockedMessageTemplateDeleter.DeleteMessageTemplateCalls())
+func (mock *MessageTemplateDeleterMock) DeleteMessageTemplateCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	ID  entity.MessageTemplateID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		ID  entity.MessageTemplateID
+	}
+	mock.lockDeleteMessageTemplate.RLock()
+	calls = mock.calls.DeleteMessageTemplate
+	mock.lockDeleteMessageTemplate.RUnlock()
+	return calls
+}
+
+// Ensure, that UserDataGetterMock does implement UserDataGetter.
+// If this is not the case, regenerate this file with moq.
+var _ UserDataGetter = &UserDataGetterMock{}
+
+// UserDataGetterMock is a mock implementation of UserDataGetter.
+//
+//	func TestSomethingThatUsesUserDataGetter(t *testing.T) {
+//
+//		// make and configure a mocked UserDataGetter
+//		mockedUserDataGetter := &UserDataGetterMock{
+//			GetAttachmentsByMessageIDsFunc: func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+//				panic("mock out the GetAttachmentsByMessageIDs method")
+//			},
+//			GetCredentialsByUserIDFunc: func(ctx context.Context, db store.Queryer, userID int64) ([]*entity.MessageAPICredential, error) {
+//				panic("mock out the GetCredentialsByUserID method")
+//			},
+//			GetMessagesByThreadIDsFunc: func(ctx context.Context, db store.Queryer, appKind string, threadIDs []entity.MessageThreadID) (entity.Messages, error) {
+//				panic("mock out the GetMessagesByThreadIDs method")
+//			},
+//			GetThreadsByUserIDFunc: func(ctx context.Context, db store.Queryer, appKind string, userID int64) ([]*entity.MessageThread, error) {
+//				panic("mock out the GetThreadsByUserID method")
+//			},
+//		}
+//
+//		// use mockedUserDataGetter in code that requires UserDataGetter
+//		// and then make assertions.
+//
+//	}
+type UserDataGetterMock struct {
+	// GetAttachmentsByMessageIDsFunc mocks the GetAttachmentsByMessageIDs method.
+	GetAttachmentsByMessageIDsFunc func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error)
+
+	// GetCredentialsByUserIDFunc mocks the GetCredentialsByUserID method.
+	GetCredentialsByUserIDFunc func(ctx context.Context, db store.Queryer, userID int64) ([]*entity.MessageAPICredential, error)
+
+	// GetMessagesByThreadIDsFunc mocks the GetMessagesByThreadIDs method.
+	GetMessagesByThreadIDsFunc func(ctx context.Context, db store.Queryer, appKind string, threadIDs []entity.MessageThreadID) (entity.Messages, error)
+
+	// GetThreadsByUserIDFunc mocks the GetThreadsByUserID method.
+	GetThreadsByUserIDFunc func(ctx context.Context, db store.Queryer, appKind string, userID int64) ([]*entity.MessageThread, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetAttachmentsByMessageIDs holds details about calls to the GetAttachmentsByMessageIDs method.
+		GetAttachmentsByMessageIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// MessageIDs is the messageIDs argument value.
+			MessageIDs []entity.MessageID
+		}
+		// GetCredentialsByUserID holds details about calls to the GetCredentialsByUserID method.
+		GetCredentialsByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetMessagesByThreadIDs holds details about calls to the GetMessagesByThreadIDs method.
+		GetMessagesByThreadIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// AppKind is the appKind argument value.
+			AppKind string
+			// ThreadIDs is the threadIDs argument value.
+			ThreadIDs []entity.MessageThreadID
+		}
+		// GetThreadsByUserID holds details about calls to the GetThreadsByUserID method.
+		GetThreadsByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// AppKind is the appKind argument value.
+			AppKind string
+			// UserID is the userID argument value.
+			UserID int64
+		}
+	}
+	lockGetAttachmentsByMessageIDs sync.RWMutex
+	lockGetCredentialsByUserID     sync.RWMutex
+	lockGetMessagesByThreadIDs     sync.RWMutex
+	lockGetThreadsByUserID         sync.RWMutex
+}
+
+// GetAttachmentsByMessageIDs calls GetAttachmentsByMessageIDsFunc.
+func (mock *UserDataGetterMock) GetAttachmentsByMessageIDs(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+	if mock.GetAttachmentsByMessageIDsFunc == nil {
+		panic("UserDataGetterMock.GetAttachmentsByMessageIDsFunc: method is nil but UserDataGetter.GetAttachmentsByMessageIDs was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		MessageIDs []entity.MessageID
+	}{
+		Ctx:        ctx,
+		Db:         db,
+		MessageIDs: messageIDs,
+	}
+	mock.lockGetAttachmentsByMessageIDs.Lock()
+	mock.calls.GetAttachmentsByMessageIDs = append(mock.calls.GetAttachmentsByMessageIDs, callInfo)
+	mock.lockGetAttachmentsByMessageIDs.Unlock()
+	return mock.GetAttachmentsByMessageIDsFunc(ctx, db, messageIDs)
+}
+
+// GetAttachmentsByMessageIDsCalls gets all the calls that were made to GetAttachmentsByMessageIDs.
+// Check the length with:
+//
+//	len(mockedUserDataGetter.GetAttachmentsByMessageIDsCalls())
+func (mock *UserDataGetterMock) GetAttachmentsByMessageIDsCalls() []struct {
+	Ctx        context.Context
+	Db         store.Queryer
+	MessageIDs []entity.MessageID
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		MessageIDs []entity.MessageID
+	}
+	mock.lockGetAttachmentsByMessageIDs.RLock()
+	calls = mock.calls.GetAttachmentsByMessageIDs
+	mock.lockGetAttachmentsByMessageIDs.RUnlock()
+	return calls
+}
+
+// GetCredentialsByUserID calls GetCredentialsByUserIDFunc.
+func (mock *UserDataGetterMock) GetCredentialsByUserID(ctx context.Context, db store.Queryer, userID int64) ([]*entity.MessageAPICredential, error) {
+	if mock.GetCredentialsByUserIDFunc == nil {
+		panic("UserDataGetterMock.GetCredentialsByUserIDFunc: method is nil but UserDataGetter.GetCredentialsByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID int64
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockGetCredentialsByUserID.Lock()
+	mock.calls.GetCredentialsByUserID = append(mock.calls.GetCredentialsByUserID, callInfo)
+	mock.lockGetCredentialsByUserID.Unlock()
+	return mock.GetCredentialsByUserIDFunc(ctx, db, userID)
+}
+
+// GetCredentialsByUserIDCalls gets all the calls that were made to GetCredentialsByUserID.
+// Check the length with:
+//
+//	len(mockedUserDataGetter.GetCredentialsByUserIDCalls())
+func (mock *UserDataGetterMock) GetCredentialsByUserIDCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID int64
+	}
+	mock.lockGetCredentialsByUserID.RLock()
+	calls = mock.calls.GetCredentialsByUserID
+	mock.lockGetCredentialsByUserID.RUnlock()
+	return calls
+}
+
+// GetMessagesByThreadIDs calls GetMessagesByThreadIDsFunc.
+func (mock *UserDataGetterMock) GetMessagesByThreadIDs(ctx context.Context, db store.Queryer, appKind string, threadIDs []entity.MessageThreadID) (entity.Messages, error) {
+	if mock.GetMessagesByThreadIDsFunc == nil {
+		panic("UserDataGetterMock.GetMessagesByThreadIDsFunc: method is nil but UserDataGetter.GetMessagesByThreadIDs was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Db        store.Queryer
+		AppKind   string
+		ThreadIDs []entity.MessageThreadID
+	}{
+		Ctx:       ctx,
+		Db:        db,
+		AppKind:   appKind,
+		ThreadIDs: threadIDs,
+	}
+	mock.lockGetMessagesByThreadIDs.Lock()
+	mock.calls.GetMessagesByThreadIDs = append(mock.calls.GetMessagesByThreadIDs, callInfo)
+	mock.lockGetMessagesByThreadIDs.Unlock()
+	return mock.GetMessagesByThreadIDsFunc(ctx, db, appKind, threadIDs)
+}
+
+// GetMessagesByThreadIDsCalls gets all the calls that were made to GetMessagesByThreadIDs.
+// Check the length with:
+//
+//	len(mockedUserDataGetter.GetMessagesByThreadIDsCalls())
+func (mock *UserDataGetterMock) GetMessagesByThreadIDsCalls() []struct {
+	Ctx       context.Context
+	Db        store.Queryer
+	AppKind   string
+	ThreadIDs []entity.MessageThreadID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Db        store.Queryer
+		AppKind   string
+		ThreadIDs []entity.MessageThreadID
+	}
+	mock.lockGetMessagesByThreadIDs.RLock()
+	calls = mock.calls.GetMessagesByThreadIDs
+	mock.lockGetMessagesByThreadIDs.RUnlock()
+	return calls
+}
+
+// GetThreadsByUserID calls GetThreadsByUserIDFunc.
+func (mock *UserDataGetterMock) GetThreadsByUserID(ctx context.Context, db store.Queryer, appKind string, userID int64) ([]*entity.MessageThread, error) {
+	if mock.GetThreadsByUserIDFunc == nil {
+		panic("UserDataGetterMock.GetThreadsByUserIDFunc: method is nil but UserDataGetter.GetThreadsByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		AppKind string
+		UserID  int64
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		AppKind: appKind,
+		UserID:  userID,
+	}
+	mock.lockGetThreadsByUserID.Lock()
+	mock.calls.GetThreadsByUserID = append(mock.calls.GetThreadsByUserID, callInfo)
+	mock.lockGetThreadsByUserID.Unlock()
+	return mock.GetThreadsByUserIDFunc(ctx, db, appKind, userID)
+}
+
+// GetThreadsByUserIDCalls gets all the calls that were made to GetThreadsByUserID.
+// Check the length with:
+//
+//	len(mockedUserDataGetter.GetThreadsByUserIDCalls())
+func (mock *UserDataGetterMock) GetThreadsByUserIDCalls() []struct {
+	Ctx     context.Context
+	Db      store.Queryer
+	AppKind string
+	UserID  int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		AppKind string
+		UserID  int64
+	}
+	mock.lockGetThreadsByUserID.RLock()
+	calls = mock.calls.GetThreadsByUserID
+	mock.lockGetThreadsByUserID.RUnlock()
+	return calls
+}
+
+// Ensure, that UserDataEraserMock does implement UserDataEraser.
+// If this is not the case, regenerate this file with moq.
+var _ UserDataEraser = &UserDataEraserMock{}
+
+// UserDataEraserMock is a mock implementation of UserDataEraser.
+//
+//	func TestSomethingThatUsesUserDataEraser(t *testing.T) {
+//
+//		// make and configure a mocked UserDataEraser
+//		mockedUserDataEraser := &UserDataEraserMock{
+//			AnonymizeMessagesFunc: func(ctx context.Context, db store.Execer, ids []entity.MessageID) error {
+//				panic("mock out the AnonymizeMessages method")
+//			},
+//			DeleteAttachmentsFunc: func(ctx context.Context, db store.Execer, ids []entity.AttachmentID) error {
+//				panic("mock out the DeleteAttachments method")
+//			},
+//			DeleteCredentialsByUserIDFunc: func(ctx context.Context, db store.Execer, userID int64) error {
+//				panic("mock out the DeleteCredentialsByUserID method")
+//			},
+//		}
+//
+//		// use mockedUserDataEraser in code that requires UserDataEraser
+//		// and then make assertions.
+//
+//	}
+type UserDataEraserMock struct {
+	// AnonymizeMessagesFunc mocks the AnonymizeMessages method.
+	AnonymizeMessagesFunc func(ctx context.Context, db store.Execer, ids []entity.MessageID) error
+
+	// DeleteAttachmentsFunc mocks the DeleteAttachments method.
+	DeleteAttachmentsFunc func(ctx context.Context, db store.Execer, ids []entity.AttachmentID) error
+
+	// DeleteCredentialsByUserIDFunc mocks the DeleteCredentialsByUserID method.
+	DeleteCredentialsByUserIDFunc func(ctx context.Context, db store.Execer, userID int64) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AnonymizeMessages holds details about calls to the AnonymizeMessages method.
+		AnonymizeMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Ids is the ids argument value.
+			Ids []entity.MessageID
+		}
+		// DeleteAttachments holds details about calls to the DeleteAttachments method.
+		DeleteAttachments []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Ids is the ids argument value.
+			Ids []entity.AttachmentID
+		}
+		// DeleteCredentialsByUserID holds details about calls to the DeleteCredentialsByUserID method.
+		DeleteCredentialsByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UserID is the userID argument value.
+			UserID int64
+		}
+	}
+	lockAnonymizeMessages         sync.RWMutex
+	lockDeleteAttachments         sync.RWMutex
+	lockDeleteCredentialsByUserID sync.RWMutex
+}
+
+// AnonymizeMessages calls AnonymizeMessagesFunc.
+func (mock *UserDataEraserMock) AnonymizeMessages(ctx context.Context, db store.Execer, ids []entity.MessageID) error {
+	if mock.AnonymizeMessagesFunc == nil {
+		panic("UserDataEraserMock.AnonymizeMessagesFunc: method is nil but UserDataEraser.AnonymizeMessages was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		Ids []entity.MessageID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		Ids: ids,
+	}
+	mock.lockAnonymizeMessages.Lock()
+	mock.calls.AnonymizeMessages = append(mock.calls.AnonymizeMessages, callInfo)
+	mock.lockAnonymizeMessages.Unlock()
+	return mock.AnonymizeMessagesFunc(ctx, db, ids)
+}
+
+// AnonymizeMessagesCalls gets all the calls that were made to AnonymizeMessages.
+// Check the length with:
+//
+//	len(mockedUserDataEraser.AnonymizeMessagesCalls())
+func (mock *UserDataEraserMock) AnonymizeMessagesCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	Ids []entity.MessageID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		Ids []entity.MessageID
+	}
+	mock.lockAnonymizeMessages.RLock()
+	calls = mock.calls.AnonymizeMessages
+	mock.lockAnonymizeMessages.RUnlock()
+	return calls
+}
+
+// DeleteAttachments calls DeleteAttachmentsFunc.
+func (mock *UserDataEraserMock) DeleteAttachments(ctx context.Context, db store.Execer, ids []entity.AttachmentID) error {
+	if mock.DeleteAttachmentsFunc == nil {
+		panic("UserDataEraserMock.DeleteAttachmentsFunc: method is nil but UserDataEraser.DeleteAttachments was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		Ids []entity.AttachmentID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		Ids: ids,
+	}
+	mock.lockDeleteAttachments.Lock()
+	mock.calls.DeleteAttachments = append(mock.calls.DeleteAttachments, callInfo)
+	mock.lockDeleteAttachments.Unlock()
+	return mock.DeleteAttachmentsFunc(ctx, db, ids)
+}
+
+// DeleteAttachmentsCalls gets all the calls that were made to DeleteAttachments.
+// Check the length with:
+//
+//	len(mockedUserDataEraser.DeleteAttachmentsCalls())
+func (mock *UserDataEraserMock) DeleteAttachmentsCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	Ids []entity.AttachmentID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		Ids []entity.AttachmentID
+	}
+	mock.lockDeleteAttachments.RLock()
+	calls = mock.calls.DeleteAttachments
+	mock.lockDeleteAttachments.RUnlock()
+	return calls
+}
+
+// DeleteCredentialsByUserID calls DeleteCredentialsByUserIDFunc.
+func (mock *UserDataEraserMock) DeleteCredentialsByUserID(ctx context.Context, db store.Execer, userID int64) error {
+	if mock.DeleteCredentialsByUserIDFunc == nil {
+		panic("UserDataEraserMock.DeleteCredentialsByUserIDFunc: method is nil but UserDataEraser.DeleteCredentialsByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID int64
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockDeleteCredentialsByUserID.Lock()
+	mock.calls.DeleteCredentialsByUserID = append(mock.calls.DeleteCredentialsByUserID, callInfo)
+	mock.lockDeleteCredentialsByUserID.Unlock()
+	return mock.DeleteCredentialsByUserIDFunc(ctx, db, userID)
+}
+
+// DeleteCredentialsByUserIDCalls gets all the calls that were made to DeleteCredentialsByUserID.
+// Check the length with:
+//
+//	len(mockedUserDataEraser.DeleteCredentialsByUserIDCalls())
+func (mock *UserDataEraserMock) DeleteCredentialsByUserIDCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID int64
+	}
+	mock.lockDeleteCredentialsByUserID.RLock()
+	calls = mock.calls.DeleteCredentialsByUserID
+	mock.lockDeleteCredentialsByUserID.RUnlock()
+	return calls
+}
+
+// Ensure, that PurgerMock does implement Purger.
+// If this is not the case, regenerate this file with moq.
+var _ Purger = &PurgerMock{}
+
+// PurgerMock is a mock implementation of Purger.
+//
+//	func TestSomethingThatUsesPurger(t *testing.T) {
+//
+//		// make and configure a mocked Purger
+//		mockedPurger := &PurgerMock{
+//			CountReactionsByMessageIDsFunc: func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (int64, error) {
+//				panic("mock out the CountReactionsByMessageIDs method")
+//			},
+//			DeleteCredentialsFunc: func(ctx context.Context, db store.Execer, ids []entity.MessageAPICredentialID) error {
+//				panic("mock out the DeleteCredentials method")
+//			},
+//			DeleteMessagesFunc: func(ctx context.Context, db store.Execer, ids []entity.MessageID) error {
+//				panic("mock out the DeleteMessages method")
+//			},
+//			GetAbandonedDraftIDsFunc: func(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageID, limit int) ([]entity.MessageID, error) {
+//				panic("mock out the GetAbandonedDraftIDs method")
+//			},
+//			GetAttachmentsByMessageIDsFunc: func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+//				panic("mock out the GetAttachmentsByMessageIDs method")
+//			},
+//			GetDeletedCredentialIDsFunc: func(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageAPICredentialID, limit int) ([]entity.MessageAPICredentialID, error) {
+//				panic("mock out the GetDeletedCredentialIDs method")
+//			},
+//			GetDeletedMessageIDsFunc: func(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageID, limit int) ([]entity.MessageID, error) {
+//				panic("mock out the GetDeletedMessageIDs method")
+//			},
+//		}
+//
+//		// use mockedPurger in code that requires Purger
+//		// and then make assertions.
+//
+//	}
+type PurgerMock struct {
+	// CountReactionsByMessageIDsFunc mocks the CountReactionsByMessageIDs method.
+	CountReactionsByMessageIDsFunc func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (int64, error)
+
+	// DeleteCredentialsFunc mocks the DeleteCredentials method.
+	DeleteCredentialsFunc func(ctx context.Context, db store.Execer, ids []entity.MessageAPICredentialID) error
+
+	// DeleteMessagesFunc mocks the DeleteMessages method.
+	DeleteMessagesFunc func(ctx context.Context, db store.Execer, ids []entity.MessageID) error
+
+	// GetAbandonedDraftIDsFunc mocks the GetAbandonedDraftIDs method.
+	GetAbandonedDraftIDsFunc func(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageID, limit int) ([]entity.MessageID, error)
+
+	// GetAttachmentsByMessageIDsFunc mocks the GetAttachmentsByMessageIDs method.
+	GetAttachmentsByMessageIDsFunc func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error)
+
+	// GetDeletedCredentialIDsFunc mocks the GetDeletedCredentialIDs method.
+	GetDeletedCredentialIDsFunc func(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageAPICredentialID, limit int) ([]entity.MessageAPICredentialID, error)
+
+	// GetDeletedMessageIDsFunc mocks the GetDeletedMessageIDs method.
+	GetDeletedMessageIDsFunc func(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageID, limit int) ([]entity.MessageID, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CountReactionsByMessageIDs holds details about calls to the CountReactionsByMessageIDs method.
+		CountReactionsByMessageIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// MessageIDs is the messageIDs argument value.
+			MessageIDs []entity.MessageID
+		}
+		// DeleteCredentials holds details about calls to the DeleteCredentials method.
+		DeleteCredentials []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Ids is the ids argument value.
+			Ids []entity.MessageAPICredentialID
+		}
+		// DeleteMessages holds details about calls to the DeleteMessages method.
+		DeleteMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Ids is the ids argument value.
+			Ids []entity.MessageID
+		}
+		// GetAbandonedDraftIDs holds details about calls to the GetAbandonedDraftIDs method.
+		GetAbandonedDraftIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// Before is the before argument value.
+			Before time.Time
+			// AfterID is the afterID argument value.
+			AfterID entity.MessageID
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetAttachmentsByMessageIDs holds details about calls to the GetAttachmentsByMessageIDs method.
+		GetAttachmentsByMessageIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// MessageIDs is the messageIDs argument value.
+			MessageIDs []entity.MessageID
+		}
+		// GetDeletedCredentialIDs holds details about calls to the GetDeletedCredentialIDs method.
+		GetDeletedCredentialIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// Before is the before argument value.
+			Before time.Time
+			// AfterID is the afterID argument value.
+			AfterID entity.MessageAPICredentialID
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetDeletedMessageIDs holds details about calls to the GetDeletedMessageIDs method.
+		GetDeletedMessageIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// Before is the before argument value.
+			Before time.Time
+			// AfterID is the afterID argument value.
+			AfterID entity.MessageID
+			// Limit is the limit argument value.
+			Limit int
+		}
+	}
+	lockCountReactionsByMessageIDs sync.RWMutex
+	lockDeleteCredentials          sync.RWMutex
+	lockDeleteMessages             sync.RWMutex
+	lockGetAbandonedDraftIDs       sync.RWMutex
+	lockGetAttachmentsByMessageIDs sync.RWMutex
+	lockGetDeletedCredentialIDs    sync.RWMutex
+	lockGetDeletedMessageIDs       sync.RWMutex
+}
+
+// CountReactionsByMessageIDs calls CountReactionsByMessageIDsFunc.
+func (mock *PurgerMock) CountReactionsByMessageIDs(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (int64, error) {
+	if mock.CountReactionsByMessageIDsFunc == nil {
+		panic("PurgerMock.CountReactionsByMessageIDsFunc: method is nil but Purger.CountReactionsByMessageIDs was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		MessageIDs []entity.MessageID
+	}{
+		Ctx:        ctx,
+		Db:         db,
+		MessageIDs: messageIDs,
+	}
+	mock.lockCountReactionsByMessageIDs.Lock()
+	mock.calls.CountReactionsByMessageIDs = append(mock.calls.CountReactionsByMessageIDs, callInfo)
+	mock.lockCountReactionsByMessageIDs.Unlock()
+	return mock.CountReactionsByMessageIDsFunc(ctx, db, messageIDs)
+}
+
+// CountReactionsByMessageIDsCalls gets all the calls that were made to CountReactionsByMessageIDs.
+// Check the length with:
+//
+//	len(mockedPurger.CountReactionsByMessageIDsCalls())
+func (mock *PurgerMock) CountReactionsByMessageIDsCalls() []struct {
+	Ctx        context.Context
+	Db         store.Queryer
+	MessageIDs []entity.MessageID
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		MessageIDs []entity.MessageID
+	}
+	mock.lockCountReactionsByMessageIDs.RLock()
+	calls = mock.calls.CountReactionsByMessageIDs
+	mock.lockCountReactionsByMessageIDs.RUnlock()
+	return calls
+}
+
+// DeleteCredentials calls DeleteCredentialsFunc.
+func (mock *PurgerMock) DeleteCredentials(ctx context.Context, db store.Execer, ids []entity.MessageAPICredentialID) error {
+	if mock.DeleteCredentialsFunc == nil {
+		panic("PurgerMock.DeleteCredentialsFunc: method is nil but Purger.DeleteCredentials was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		Ids []entity.MessageAPICredentialID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		Ids: ids,
+	}
+	mock.lockDeleteCredentials.Lock()
+	mock.calls.DeleteCredentials = append(mock.calls.DeleteCredentials, callInfo)
+	mock.lockDeleteCredentials.Unlock()
+	return mock.DeleteCredentialsFunc(ctx, db, ids)
+}
+
+// DeleteCredentialsCalls gets all the calls that were made to DeleteCredentials.
+// Check the length with:
+//
+//	len(mockedPurger.DeleteCredentialsCalls())
+func (mock *PurgerMock) DeleteCredentialsCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	Ids []entity.MessageAPICredentialID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		Ids []entity.MessageAPICredentialID
+	}
+	mock.lockDeleteCredentials.RLock()
+	calls = mock.calls.DeleteCredentials
+	mock.lockDeleteCredentials.RUnlock()
+	return calls
+}
+
+// DeleteMessages calls DeleteMessagesFunc.
+func (mock *PurgerMock) DeleteMessages(ctx context.Context, db store.Execer, ids []entity.MessageID) error {
+	if mock.DeleteMessagesFunc == nil {
+		panic("PurgerMock.DeleteMessagesFunc: method is nil but Purger.DeleteMessages was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		Ids []entity.MessageID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		Ids: ids,
+	}
+	mock.lockDeleteMessages.Lock()
+	mock.calls.DeleteMessages = append(mock.calls.DeleteMessages, callInfo)
+	mock.lockDeleteMessages.Unlock()
+	return mock.DeleteMessagesFunc(ctx, db, ids)
+}
+
+// DeleteMessagesCalls gets all the calls that were made to DeleteMessages.
+// Check the length with:
+//
+//	len(mockedPurger.DeleteMessagesCalls())
+func (mock *PurgerMock) DeleteMessagesCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	Ids []entity.MessageID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		Ids []entity.MessageID
+	}
+	mock.lockDeleteMessages.RLock()
+	calls = mock.calls.DeleteMessages
+	mock.lockDeleteMessages.RUnlock()
+	return calls
+}
+
+// GetAbandonedDraftIDs calls GetAbandonedDraftIDsFunc.
+func (mock *PurgerMock) GetAbandonedDraftIDs(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageID, limit int) ([]entity.MessageID, error) {
+	if mock.GetAbandonedDraftIDsFunc == nil {
+		panic("PurgerMock.GetAbandonedDraftIDsFunc: method is nil but Purger.GetAbandonedDraftIDs was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		Before  time.Time
+		AfterID entity.MessageID
+		Limit   int
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		Before:  before,
+		AfterID: afterID,
+		Limit:   limit,
+	}
+	mock.lockGetAbandonedDraftIDs.Lock()
+	mock.calls.GetAbandonedDraftIDs = append(mock.calls.GetAbandonedDraftIDs, callInfo)
+	mock.lockGetAbandonedDraftIDs.Unlock()
+	return mock.GetAbandonedDraftIDsFunc(ctx, db, before, afterID, limit)
+}
+
+// GetAbandonedDraftIDsCalls gets all the calls that were made to GetAbandonedDraftIDs.
+// Check the length with:
+//
+//	len(mockedPurger.GetAbandonedDraftIDsCalls())
+func (mock *PurgerMock) GetAbandonedDraftIDsCalls() []struct {
+	Ctx     context.Context
+	Db      store.Queryer
+	Before  time.Time
+	AfterID entity.MessageID
+	Limit   int
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		Before  time.Time
+		AfterID entity.MessageID
+		Limit   int
+	}
+	mock.lockGetAbandonedDraftIDs.RLock()
+	calls = mock.calls.GetAbandonedDraftIDs
+	mock.lockGetAbandonedDraftIDs.RUnlock()
+	return calls
+}
+
+// GetAttachmentsByMessageIDs calls GetAttachmentsByMessageIDsFunc.
+func (mock *PurgerMock) GetAttachmentsByMessageIDs(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+	if mock.GetAttachmentsByMessageIDsFunc == nil {
+		panic("PurgerMock.GetAttachmentsByMessageIDsFunc: method is nil but Purger.GetAttachmentsByMessageIDs was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		MessageIDs []entity.MessageID
+	}{
+		Ctx:        ctx,
+		Db:         db,
+		MessageIDs: messageIDs,
+	}
+	mock.lockGetAttachmentsByMessageIDs.Lock()
+	mock.calls.GetAttachmentsByMessageIDs = append(mock.calls.GetAttachmentsByMessageIDs, callInfo)
+	mock.lockGetAttachmentsByMessageIDs.Unlock()
+	return mock.GetAttachmentsByMessageIDsFunc(ctx, db, messageIDs)
+}
+
+// GetAttachmentsByMessageIDsCalls gets all the calls that were made to GetAttachmentsByMessageIDs.
+// Check the length with:
+//
+//	len(mockedPurger.GetAttachmentsByMessageIDsCalls())
+func (mock *PurgerMock) GetAttachmentsByMessageIDsCalls() []struct {
+	Ctx        context.Context
+	Db         store.Queryer
+	MessageIDs []entity.MessageID
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		MessageIDs []entity.MessageID
+	}
+	mock.lockGetAttachmentsByMessageIDs.RLock()
+	calls = mock.calls.GetAttachmentsByMessageIDs
+	mock.lockGetAttachmentsByMessageIDs.RUnlock()
+	return calls
+}
+
+// GetDeletedCredentialIDs calls GetDeletedCredentialIDsFunc.
+func (mock *PurgerMock) GetDeletedCredentialIDs(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageAPICredentialID, limit int) ([]entity.MessageAPICredentialID, error) {
+	if mock.GetDeletedCredentialIDsFunc == nil {
+		panic("PurgerMock.GetDeletedCredentialIDsFunc: method is nil but Purger.GetDeletedCredentialIDs was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		Before  time.Time
+		AfterID entity.MessageAPICredentialID
+		Limit   int
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		Before:  before,
+		AfterID: afterID,
+		Limit:   limit,
+	}
+	mock.lockGetDeletedCredentialIDs.Lock()
+	mock.calls.GetDeletedCredentialIDs = append(mock.calls.GetDeletedCredentialIDs, callInfo)
+	mock.lockGetDeletedCredentialIDs.Unlock()
+	return mock.GetDeletedCredentialIDsFunc(ctx, db, before, afterID, limit)
+}
+
+// GetDeletedCredentialIDsCalls gets all the calls that were made to GetDeletedCredentialIDs.
+// Check the length with:
+//
+//	len(mockedPurger.GetDeletedCredentialIDsCalls())
+func (mock *PurgerMock) GetDeletedCredentialIDsCalls() []struct {
+	Ctx     context.Context
+	Db      store.Queryer
+	Before  time.Time
+	AfterID entity.MessageAPICredentialID
+	Limit   int
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		Before  time.Time
+		AfterID entity.MessageAPICredentialID
+		Limit   int
+	}
+	mock.lockGetDeletedCredentialIDs.RLock()
+	calls = mock.calls.GetDeletedCredentialIDs
+	mock.lockGetDeletedCredentialIDs.RUnlock()
+	return calls
+}
+
+// GetDeletedMessageIDs calls GetDeletedMessageIDsFunc.
+func (mock *PurgerMock) GetDeletedMessageIDs(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageID, limit int) ([]entity.MessageID, error) {
+	if mock.GetDeletedMessageIDsFunc == nil {
+		panic("PurgerMock.GetDeletedMessageIDsFunc: method is nil but Purger.GetDeletedMessageIDs was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		Before  time.Time
+		AfterID entity.MessageID
+		Limit   int
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		Before:  before,
+		AfterID: afterID,
+		Limit:   limit,
+	}
+	mock.lockGetDeletedMessageIDs.Lock()
+	mock.calls.GetDeletedMessageIDs = append(mock.calls.GetDeletedMessageIDs, callInfo)
+	mock.lockGetDeletedMessageIDs.Unlock()
+	return mock.GetDeletedMessageIDsFunc(ctx, db, before, afterID, limit)
+}
+
+// GetDeletedMessageIDsCalls gets all the calls that were made to GetDeletedMessageIDs.
+// Check the length with:
+//
+//	len(mockedPurger.GetDeletedMessageIDsCalls())
+func (mock *PurgerMock) GetDeletedMessageIDsCalls() []struct {
+	Ctx     context.Context
+	Db      store.Queryer
+	Before  time.Time
+	AfterID entity.MessageID
+	Limit   int
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		Before  time.Time
+		AfterID entity.MessageID
+		Limit   int
+	}
+	mock.lockGetDeletedMessageIDs.RLock()
+	calls = mock.calls.GetDeletedMessageIDs
+	mock.lockGetDeletedMessageIDs.RUnlock()
+	return calls
+}
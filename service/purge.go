@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+type Purge struct {
+	DBHandlers  map[string]*sqlx.DB
+	Purger      Purger
+	BlobStorage BlobStorage
+}
+
+func NewPurge(dbHandlers map[string]*sqlx.DB, purger Purger, blobStorage BlobStorage) *Purge {
+	return &Purge{
+		DBHandlers:  dbHandlers,
+		Purger:      purger,
+		BlobStorage: blobStorage,
+	}
+}
+
+// Purge は保持期限を過ぎたデータを chunkSize 件ずつ、チャンクごとのトランザクションで物理削除する。
+// 途中で失敗した場合も、それまでにコミットした件数を含む報告を返す
+func (p *Purge) Purge(ctx context.Context, policy entity.RetentionPolicy, chunkSize int, dryRun bool) (*entity.PurgeReport, error) {
+	ctx, span := tracer.Start(ctx, "Purge.Purge")
+	defer span.End()
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("chunk size must be positive: %d", chunkSize)
+	}
+	report := &entity.PurgeReport{DryRun: dryRun}
+	var err error
+	report.DeletedMessages, err = p.purgeMessages(ctx, report, chunkSize, dryRun, func(ctx context.Context, db store.Queryer, afterID entity.MessageID) ([]entity.MessageID, error) {
+		return p.Purger.GetDeletedMessageIDs(ctx, db, policy.DeletedMessagesBefore, afterID, chunkSize)
+	})
+	if err != nil {
+		return report, fmt.Errorf("failed to purge deleted messages: %w", err)
+	}
+	report.AbandonedDrafts, err = p.purgeMessages(ctx, report, chunkSize, dryRun, func(ctx context.Context, db store.Queryer, afterID entity.MessageID) ([]entity.MessageID, error) {
+		return p.Purger.GetAbandonedDraftIDs(ctx, db, policy.AbandonedDraftsBefore, afterID, chunkSize)
+	})
+	if err != nil {
+		return report, fmt.Errorf("failed to purge abandoned drafts: %w", err)
+	}
+	report.CompanyAPICredentials, err = p.purgeCredentials(ctx, p.DBHandlers["company"], policy.DeletedCredentialsBefore, chunkSize, dryRun)
+	if err != nil {
+		return report, fmt.Errorf("failed to purge company credentials: %w", err)
+	}
+	report.StudentAPICredentials, err = p.purgeCredentials(ctx, p.DBHandlers["student"], policy.DeletedCredentialsBefore, chunkSize, dryRun)
+	if err != nil {
+		return report, fmt.Errorf("failed to purge student credentials: %w", err)
+	}
+	return report, nil
+}
+
+func (p *Purge) purgeMessages(ctx context.Context, report *entity.PurgeReport, chunkSize int, dryRun bool, getIDs func(ctx context.Context, db store.Queryer, afterID entity.MessageID) ([]entity.MessageID, error)) (int64, error) {
+	var purged int64
+	var afterID entity.MessageID
+	for {
+		tx, err := p.DBHandlers["common"].BeginTxx(ctx, nil)
+		if err != nil {
+			return purged, err
+		}
+		ids, err := getIDs(ctx, tx, afterID)
+		if err != nil || len(ids) == 0 {
+			_ = tx.Rollback()
+			return purged, err
+		}
+		attachments, err := p.Purger.GetAttachmentsByMessageIDs(ctx, tx, ids)
+		if err != nil {
+			_ = tx.Rollback()
+			return purged, err
+		}
+		reactions, err := p.Purger.CountReactionsByMessageIDs(ctx, tx, ids)
+		if err != nil {
+			_ = tx.Rollback()
+			return purged, err
+		}
+		if dryRun {
+			_ = tx.Rollback()
+		} else {
+			if err := p.Purger.DeleteMessages(ctx, tx, ids); err != nil {
+				_ = tx.Rollback()
+				return purged, err
+			}
+			if err := tx.Commit(); err != nil {
+				return purged, err
+			}
+			// DBの削除が確定した後にファイルを消す。失敗したキーは手動で削除できるよう報告に含める
+			for _, a := range attachments {
+				if err := p.BlobStorage.Delete(ctx, a.StorageKey); err != nil {
+					report.BlobDeleteFailures = append(report.BlobDeleteFailures, a.StorageKey)
+				}
+			}
+		}
+		purged += int64(len(ids))
+		report.MessageAttachments += int64(len(attachments))
+		report.MessageReactions += reactions
+		if len(ids) < chunkSize {
+			return purged, nil
+		}
+		afterID = ids[len(ids)-1]
+	}
+}
+
+func (p *Purge) purgeCredentials(ctx context.Context, db *sqlx.DB, before time.Time, chunkSize int, dryRun bool) (int64, error) {
+	var purged int64
+	var afterID entity.MessageAPICredentialID
+	for {
+		tx, err := db.BeginTxx(ctx, nil)
+		if err != nil {
+			return purged, err
+		}
+		ids, err := p.Purger.GetDeletedCredentialIDs(ctx, tx, before, afterID, chunkSize)
+		if err != nil || len(ids) == 0 {
+			_ = tx.Rollback()
+			return purged, err
+		}
+		if dryRun {
+			_ = tx.Rollback()
+		} else {
+			if err := p.Purger.DeleteCredentials(ctx, tx, ids); err != nil {
+				_ = tx.Rollback()
+				return purged, err
+			}
+			if err := tx.Commit(); err != nil {
+				return purged, err
+			}
+		}
+		purged += int64(len(ids))
+		if len(ids) < chunkSize {
+			return purged, nil
+		}
+		afterID = ids[len(ids)-1]
+	}
+}
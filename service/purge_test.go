@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+func TestPurge_Purge(t *testing.T) {
+	policy := entity.RetentionPolicy{
+		DeletedMessagesBefore:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		AbandonedDraftsBefore:    time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+		DeletedCredentialsBefore: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+	}
+	// 削除済みメッセージは 1, 2 と 3 の2チャンク、下書きは 4、企業の認証情報は 5 を返す
+	newPurgerMock := func() *PurgerMock {
+		return &PurgerMock{
+			GetDeletedMessageIDsFunc: func(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageID, limit int) ([]entity.MessageID, error) {
+				assert.Equal(t, policy.DeletedMessagesBefore, before)
+				if afterID == 0 {
+					return []entity.MessageID{1, 2}, nil
+				}
+				return []entity.MessageID{3}, nil
+			},
+			GetAbandonedDraftIDsFunc: func(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageID, limit int) ([]entity.MessageID, error) {
+				assert.Equal(t, policy.AbandonedDraftsBefore, before)
+				return []entity.MessageID{4}, nil
+			},
+			GetDeletedCredentialIDsFunc: func(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageAPICredentialID, limit int) ([]entity.MessageAPICredentialID, error) {
+				return nil, nil
+			},
+			GetAttachmentsByMessageIDsFunc: func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+				if messageIDs[0] == 1 {
+					return entity.Attachments{{ID: 9, MessageID: 2, StorageKey: "attachments/9"}}, nil
+				}
+				return nil, nil
+			},
+			CountReactionsByMessageIDsFunc: func(ctx context.Context, db store.Queryer, messageIDs []entity.MessageID) (int64, error) {
+				return int64(len(messageIDs)), nil
+			},
+			DeleteMessagesFunc: func(ctx context.Context, db store.Execer, ids []entity.MessageID) error {
+				return nil
+			},
+			DeleteCredentialsFunc: func(ctx context.Context, db store.Execer, ids []entity.MessageAPICredentialID) error {
+				return nil
+			},
+		}
+	}
+	type testCase struct {
+		name            string
+		dryRun          bool
+		preparePurger   func(*PurgerMock)
+		prepareBlobMock func(*BlobStorageMock)
+		prepareSQLMock  func(common, company, student sqlmock.Sqlmock)
+		wantReport      *entity.PurgeReport
+		wantErrMsg      string
+	}
+	tests := []testCase{
+		{
+			name:   "dry run rolls back every chunk",
+			dryRun: true,
+			preparePurger: func(m *PurgerMock) {
+				m.GetDeletedCredentialIDsFunc = func(ctx context.Context, db store.Queryer, before time.Time, afterID entity.MessageAPICredentialID, limit int) ([]entity.MessageAPICredentialID, error) {
+					return []entity.MessageAPICredentialID{5}, nil
+				}
+			},
+			prepareSQLMock: func(common, company, student sqlmock.Sqlmock) {
+				for i := 0; i < 3; i++ {
+					common.ExpectBegin()
+					common.ExpectRollback()
+				}
+				company.ExpectBegin()
+				company.ExpectRollback()
+				student.ExpectBegin()
+				student.ExpectRollback()
+			},
+			wantReport: &entity.PurgeReport{
+				DryRun:                true,
+				DeletedMessages:       3,
+				AbandonedDrafts:       1,
+				MessageAttachments:    1,
+				MessageReactions:      4,
+				CompanyAPICredentials: 1,
+				StudentAPICredentials: 1,
+			},
+		},
+		{
+			name: "delete and report blob failures",
+			prepareBlobMock: func(m *BlobStorageMock) {
+				m.DeleteFunc = func(ctx context.Context, key string) error {
+					return errors.New("storage error")
+				}
+			},
+			prepareSQLMock: func(common, company, student sqlmock.Sqlmock) {
+				for i := 0; i < 3; i++ {
+					common.ExpectBegin()
+					common.ExpectCommit()
+				}
+				company.ExpectBegin()
+				company.ExpectRollback()
+				student.ExpectBegin()
+				student.ExpectRollback()
+			},
+			wantReport: &entity.PurgeReport{
+				DeletedMessages:    3,
+				AbandonedDrafts:    1,
+				MessageAttachments: 1,
+				MessageReactions:   4,
+				BlobDeleteFailures: []string{"attachments/9"},
+			},
+		},
+		{
+			name: "fail to delete messages keeps committed counts",
+			preparePurger: func(m *PurgerMock) {
+				m.DeleteMessagesFunc = func(ctx context.Context, db store.Execer, ids []entity.MessageID) error {
+					if ids[0] == 3 {
+						return errors.New("delete error")
+					}
+					return nil
+				}
+			},
+			prepareBlobMock: func(m *BlobStorageMock) {
+				m.DeleteFunc = func(ctx context.Context, key string) error {
+					return nil
+				}
+			},
+			prepareSQLMock: func(common, company, student sqlmock.Sqlmock) {
+				common.ExpectBegin()
+				common.ExpectCommit()
+				common.ExpectBegin()
+				common.ExpectRollback()
+			},
+			wantReport: &entity.PurgeReport{
+				DeletedMessages:    2,
+				MessageAttachments: 1,
+				MessageReactions:   2,
+			},
+			wantErrMsg: "failed to purge deleted messages",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			dbHandlers := make(map[string]*sqlx.DB, 3)
+			mocks := make(map[string]sqlmock.Sqlmock, 3)
+			for _, v := range []string{"common", "company", "student"} {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				t.Cleanup(func() { _ = db.Close() })
+				dbHandlers[v] = sqlx.NewDb(db, "sqlmock")
+				mocks[v] = mock
+			}
+			purgerMock := newPurgerMock()
+			blobMock := &BlobStorageMock{}
+			if tc.preparePurger != nil {
+				tc.preparePurger(purgerMock)
+			}
+			if tc.prepareBlobMock != nil {
+				tc.prepareBlobMock(blobMock)
+			}
+			tc.prepareSQLMock(mocks["common"], mocks["company"], mocks["student"])
+			svc := NewPurge(dbHandlers, purgerMock, blobMock)
+			report, err := svc.Purge(context.Background(), policy, 2, tc.dryRun)
+			if tc.wantErrMsg != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.wantErrMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantReport, report)
+			if tc.dryRun {
+				assert.Empty(t, purgerMock.DeleteMessagesCalls())
+				assert.Empty(t, purgerMock.DeleteCredentialsCalls())
+				assert.Empty(t, blobMock.DeleteCalls())
+			}
+			for _, mock := range mocks {
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"database/sql"
-	"net/http"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -11,6 +10,7 @@ import (
 	"github.com/yuyacode/AppLiftMessageApi/credential"
 	"github.com/yuyacode/AppLiftMessageApi/entity"
 	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/metrics"
 	"github.com/yuyacode/AppLiftMessageApi/request"
 )
 
@@ -29,10 +29,12 @@ func NewRefreshAccessToken(dbHandlers map[string]*sqlx.DB, credentialGetter Cred
 }
 
 func (rat *RefreshAccessToken) RefreshAccessToken(ctx context.Context, client_id, client_secret string) (string, string, error) {
+	ctx, span := tracer.Start(ctx, "RefreshAccessToken.RefreshAccessToken")
+	defer span.End()
 	appKind, ok := request.GetAppKind(ctx)
 	if !ok {
 		return "", "", handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to get app kind",
 			"",
 		)
@@ -40,37 +42,29 @@ func (rat *RefreshAccessToken) RefreshAccessToken(ctx context.Context, client_id
 	userID, ok := request.GetUserID(ctx)
 	if !ok {
 		return "", "", handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to get user_id",
 			"",
 		)
 	}
 	validClientID, err := rat.CredentialGetter.GetClientID(ctx, rat.DBHandlers[appKind], userID)
 	if err != nil {
-		return "", "", handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to get client_id",
-			err.Error(),
-		)
+		return "", "", newLookupServiceError(handler.ErrCodeInvalidClient, "client_id is invalid", "failed to get client_id", err)
 	}
 	if client_id != validClientID {
 		return "", "", handler.NewServiceError(
-			http.StatusUnauthorized,
+			handler.ErrCodeInvalidClient,
 			"client_id is invalid",
 			"",
 		)
 	}
 	validClientSecret, err := rat.CredentialGetter.GetClientSecret(ctx, rat.DBHandlers[appKind], userID)
 	if err != nil {
-		return "", "", handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to get client_secret",
-			err.Error(),
-		)
+		return "", "", newLookupServiceError(handler.ErrCodeInvalidClient, "client_secret is invalid", "failed to get client_secret", err)
 	}
 	if client_secret != validClientSecret {
 		return "", "", handler.NewServiceError(
-			http.StatusUnauthorized,
+			handler.ErrCodeInvalidClient,
 			"client_secret is invalid",
 			"",
 		)
@@ -80,26 +74,18 @@ func (rat *RefreshAccessToken) RefreshAccessToken(ctx context.Context, client_id
 		var err error
 		accessToken, err = credential.GenerateAccessToken(appKind, userID)
 		if err != nil {
-			return "", "", handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to generate access_token",
-				err.Error(),
-			)
+			return "", "", newInternalServiceError("failed to generate access_token", err)
 		}
 		exist, err := rat.CredentialGetter.SearchByAccessToken(ctx, rat.DBHandlers[appKind], accessToken)
 		if err != nil {
-			return "", "", handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to search access_token",
-				err.Error(),
-			)
+			return "", "", newInternalServiceError("failed to search access_token", err)
 		}
 		if !exist {
 			break
 		}
 		if i == 4 {
 			return "", "", handler.NewServiceError(
-				http.StatusInternalServerError,
+				handler.ErrCodeInternal,
 				"failed to generate access_token 5 times",
 				"",
 			)
@@ -110,26 +96,18 @@ func (rat *RefreshAccessToken) RefreshAccessToken(ctx context.Context, client_id
 		var err error
 		refreshToken, err = credential.GenerateRefreshToken(appKind, userID)
 		if err != nil {
-			return "", "", handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to generate refresh_token",
-				err.Error(),
-			)
+			return "", "", newInternalServiceError("failed to generate refresh_token", err)
 		}
 		exist, err := rat.CredentialGetter.SearchByRefreshToken(ctx, rat.DBHandlers[appKind], refreshToken)
 		if err != nil {
-			return "", "", handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to search refresh_token",
-				err.Error(),
-			)
+			return "", "", newInternalServiceError("failed to search refresh_token", err)
 		}
 		if !exist {
 			break
 		}
 		if i == 4 {
 			return "", "", handler.NewServiceError(
-				http.StatusInternalServerError,
+				handler.ErrCodeInternal,
 				"failed to generate refresh_token 5 times",
 				"",
 			)
@@ -147,11 +125,8 @@ func (rat *RefreshAccessToken) RefreshAccessToken(ctx context.Context, client_id
 	}
 	err = rat.CredentialSetter.SaveToken(ctx, rat.DBHandlers[appKind], param)
 	if err != nil {
-		return "", "", handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to save token",
-			err.Error(),
-		)
+		return "", "", newInternalServiceError("failed to save token", err)
 	}
+	metrics.TokensIssued.WithLabelValues(appKind, "refresh").Inc()
 	return accessToken, refreshToken, nil
 }
@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"database/sql"
-	"net/http"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -11,6 +10,7 @@ import (
 	"github.com/yuyacode/AppLiftMessageApi/credential"
 	"github.com/yuyacode/AppLiftMessageApi/entity"
 	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/metrics"
 	"github.com/yuyacode/AppLiftMessageApi/request"
 )
 
@@ -29,26 +29,24 @@ func NewRegisterOAuth(dbHandlers map[string]*sqlx.DB, credentialGetter Credentia
 }
 
 func (ro *RegisterOAuth) RegisterOAuth(ctx context.Context, apiKey string) error {
+	ctx, span := tracer.Start(ctx, "RegisterOAuth.RegisterOAuth")
+	defer span.End()
 	appKind, ok := request.GetAppKind(ctx)
 	if !ok {
 		return handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to get app kind",
 			"",
 		)
 	}
 	validAPIKey, err := ro.CredentialGetter.GetAPIKey(ctx, ro.DBHandlers[appKind])
 	if err != nil {
-		return handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to get API Key",
-			err.Error(),
-		)
+		return newLookupServiceError(handler.ErrCodeInvalidAPIKey, "API Key is invalid", "failed to get API Key", err)
 	}
 	hashedAPIKey := credential.HashAPIKey(apiKey)
 	if hashedAPIKey != validAPIKey {
 		return handler.NewServiceError(
-			http.StatusUnauthorized,
+			handler.ErrCodeInvalidAPIKey,
 			"API Key is invalid",
 			"",
 		)
@@ -58,26 +56,18 @@ func (ro *RegisterOAuth) RegisterOAuth(ctx context.Context, apiKey string) error
 		var err error
 		clientID, err = credential.GenerateClientID()
 		if err != nil {
-			return handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to generate client_id",
-				err.Error(),
-			)
+			return newInternalServiceError("failed to generate client_id", err)
 		}
 		exist, err := ro.CredentialGetter.SearchByClientID(ctx, ro.DBHandlers[appKind], clientID)
 		if err != nil {
-			return handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to search client_id",
-				err.Error(),
-			)
+			return newInternalServiceError("failed to search client_id", err)
 		}
 		if !exist {
 			break
 		}
 		if i == 4 {
 			return handler.NewServiceError(
-				http.StatusInternalServerError,
+				handler.ErrCodeInternal,
 				"failed to generate client_id 5 times",
 				"",
 			)
@@ -88,26 +78,18 @@ func (ro *RegisterOAuth) RegisterOAuth(ctx context.Context, apiKey string) error
 		var err error
 		clientSecret, err = credential.GenerateClientSecret()
 		if err != nil {
-			return handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to generate client_secret",
-				err.Error(),
-			)
+			return newInternalServiceError("failed to generate client_secret", err)
 		}
 		exist, err := ro.CredentialGetter.SearchByClientSecret(ctx, ro.DBHandlers[appKind], clientSecret)
 		if err != nil {
-			return handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to search client_secret",
-				err.Error(),
-			)
+			return newInternalServiceError("failed to search client_secret", err)
 		}
 		if !exist {
 			break
 		}
 		if i == 4 {
 			return handler.NewServiceError(
-				http.StatusInternalServerError,
+				handler.ErrCodeInternal,
 				"failed to generate client_secret 5 times",
 				"",
 			)
@@ -116,7 +98,7 @@ func (ro *RegisterOAuth) RegisterOAuth(ctx context.Context, apiKey string) error
 	userID, ok := request.GetUserID(ctx)
 	if !ok {
 		return handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"failed to get userID",
 			"",
 		)
@@ -128,37 +110,25 @@ func (ro *RegisterOAuth) RegisterOAuth(ctx context.Context, apiKey string) error
 	}
 	err = ro.CredentialSetter.SaveClientIDSecret(ctx, ro.DBHandlers[appKind], param)
 	if err != nil {
-		return handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to insert message api client_id and client_secret",
-			err.Error(),
-		)
+		return newInternalServiceError("failed to insert message api client_id and client_secret", err)
 	}
 	var accessToken string
 	for i := 0; i < 5; i++ {
 		var err error
 		accessToken, err = credential.GenerateAccessToken(appKind, userID)
 		if err != nil {
-			return handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to generate access_token",
-				err.Error(),
-			)
+			return newInternalServiceError("failed to generate access_token", err)
 		}
 		exist, err := ro.CredentialGetter.SearchByAccessToken(ctx, ro.DBHandlers[appKind], accessToken)
 		if err != nil {
-			return handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to search access_token",
-				err.Error(),
-			)
+			return newInternalServiceError("failed to search access_token", err)
 		}
 		if !exist {
 			break
 		}
 		if i == 4 {
 			return handler.NewServiceError(
-				http.StatusInternalServerError,
+				handler.ErrCodeInternal,
 				"failed to generate access_token 5 times",
 				"",
 			)
@@ -169,26 +139,18 @@ func (ro *RegisterOAuth) RegisterOAuth(ctx context.Context, apiKey string) error
 		var err error
 		refreshToken, err = credential.GenerateRefreshToken(appKind, userID)
 		if err != nil {
-			return handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to generate refresh_token",
-				err.Error(),
-			)
+			return newInternalServiceError("failed to generate refresh_token", err)
 		}
 		exist, err := ro.CredentialGetter.SearchByRefreshToken(ctx, ro.DBHandlers[appKind], refreshToken)
 		if err != nil {
-			return handler.NewServiceError(
-				http.StatusInternalServerError,
-				"failed to search refresh_token",
-				err.Error(),
-			)
+			return newInternalServiceError("failed to search refresh_token", err)
 		}
 		if !exist {
 			break
 		}
 		if i == 4 {
 			return handler.NewServiceError(
-				http.StatusInternalServerError,
+				handler.ErrCodeInternal,
 				"failed to generate refresh_token 5 times",
 				"",
 			)
@@ -202,11 +164,9 @@ func (ro *RegisterOAuth) RegisterOAuth(ctx context.Context, apiKey string) error
 	}
 	err = ro.CredentialSetter.SaveToken(ctx, ro.DBHandlers[appKind], param)
 	if err != nil {
-		return handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to save token",
-			err.Error(),
-		)
+		return newInternalServiceError("failed to save token", err)
 	}
+	metrics.Registrations.WithLabelValues(appKind).Inc()
+	metrics.TokensIssued.WithLabelValues(appKind, "register").Inc()
 	return nil
 }
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+)
+
+// {{student_name}} や {{ interview_date }} のようなプレースホルダーにマッチする
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_]+)\s*\}\}`)
+
+type RenderMessageTemplate struct {
+	DBHandlers            map[string]*sqlx.DB
+	MessageTemplateGetter MessageTemplateGetter
+}
+
+func NewRenderMessageTemplate(dbHandlers map[string]*sqlx.DB, messageTemplateGetter MessageTemplateGetter) *RenderMessageTemplate {
+	return &RenderMessageTemplate{
+		DBHandlers:            dbHandlers,
+		MessageTemplateGetter: messageTemplateGetter,
+	}
+}
+
+func (rmt *RenderMessageTemplate) RenderMessageTemplate(ctx context.Context, id entity.MessageTemplateID, variables map[string]string) (string, error) {
+	ctx, span := tracer.Start(ctx, "RenderMessageTemplate.RenderMessageTemplate")
+	defer span.End()
+	companyUserID, err := getTemplateOwnerID(ctx)
+	if err != nil {
+		return "", err
+	}
+	t, err := getOwnMessageTemplate(ctx, rmt.MessageTemplateGetter, rmt.DBHandlers["company"], id, companyUserID)
+	if err != nil {
+		return "", err
+	}
+	return renderMessage(t.Content, variables, "")
+}
+
+// RenderMessageTemplates はテンプレートを variables の変数ごとにレンダリングし、同じ順序で返す。
+// 一括送信で宛先ごとに変数が異なる場合に使う。変数が不足している場合は detail にその添字を含める
+func (rmt *RenderMessageTemplate) RenderMessageTemplates(ctx context.Context, id entity.MessageTemplateID, variables []map[string]string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "RenderMessageTemplate.RenderMessageTemplates")
+	defer span.End()
+	companyUserID, err := getTemplateOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := getOwnMessageTemplate(ctx, rmt.MessageTemplateGetter, rmt.DBHandlers["company"], id, companyUserID)
+	if err != nil {
+		return nil, err
+	}
+	contents := make([]string, 0, len(variables))
+	for i, v := range variables {
+		content, err := renderMessage(t.Content, v, fmt.Sprintf("recipients[%d]: ", i))
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, content)
+	}
+	return contents, nil
+}
+
+// renderMessage はテンプレートをレンダリングし、変数の不足や空の本文をエラーにする。detailPrefix は不足した変数名の前に付ける
+func renderMessage(template string, variables map[string]string, detailPrefix string) (string, error) {
+	content, missing := renderTemplate(template, variables)
+	if len(missing) > 0 {
+		return "", handler.NewServiceError(
+			handler.ErrCodeTemplateVariablesMissing,
+			"missing template variables",
+			detailPrefix+strings.Join(missing, ", "),
+		)
+	}
+	if strings.TrimSpace(content) == "" {
+		return "", handler.NewServiceError(
+			handler.ErrCodeEmptyContent,
+			"rendered content is empty",
+			"",
+		)
+	}
+	return content, nil
+}
+
+// renderTemplate はプレースホルダーを variables の値で置き換える
+// 値が渡されなかったプレースホルダー名は missing として返す
+func renderTemplate(content string, variables map[string]string) (string, []string) {
+	missingSet := map[string]bool{}
+	rendered := templatePlaceholder.ReplaceAllStringFunc(content, func(placeholder string) string {
+		name := templatePlaceholder.FindStringSubmatch(placeholder)[1]
+		value, ok := variables[name]
+		if !ok {
+			missingSet[name] = true
+			return placeholder
+		}
+		return value
+	})
+	missing := make([]string, 0, len(missingSet))
+	for name := range missingSet {
+		missing = append(missing, name)
+	}
+	sort.Strings(missing)
+	return rendered, missing
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/request"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+func TestRenderMessageTemplate_RenderMessageTemplate(t *testing.T) {
+	type testCase struct {
+		name          string
+		appKind       string
+		userID        int64
+		content       string
+		variables     map[string]string
+		wantContent   string
+		wantErr       bool
+		wantErrStatus int
+		wantErrMsg    string
+		wantErrDetail string
+	}
+	tests := []testCase{
+		{
+			name:          "student => forbidden",
+			appKind:       "student",
+			userID:        1,
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "unauthorized: message templates are only available to company users",
+		},
+		{
+			name:          "template of another company user => forbidden",
+			appKind:       "company",
+			userID:        2,
+			content:       "{{student_name}}様",
+			wantErr:       true,
+			wantErrStatus: http.StatusForbidden,
+			wantErrMsg:    "unauthorized: lack the necessary permissions to use message template",
+		},
+		{
+			name:          "missing variables",
+			appKind:       "company",
+			userID:        1,
+			content:       "{{student_name}}様\n面接日: {{interview_date}} {{ interview_place }}",
+			variables:     map[string]string{"student_name": "山田太郎"},
+			wantErr:       true,
+			wantErrStatus: http.StatusBadRequest,
+			wantErrMsg:    "missing template variables",
+			wantErrDetail: "interview_date, interview_place",
+		},
+		{
+			name:          "rendered content is empty",
+			appKind:       "company",
+			userID:        1,
+			content:       "{{note}}",
+			variables:     map[string]string{"note": " "},
+			wantErr:       true,
+			wantErrStatus: http.StatusBadRequest,
+			wantErrMsg:    "rendered content is empty",
+		},
+		{
+			name:        "success",
+			appKind:     "company",
+			userID:      1,
+			content:     "{{student_name}}様\n面接日: {{ interview_date }}\n{{student_name}}様のご来社をお待ちしております",
+			variables:   map[string]string{"student_name": "山田太郎", "interview_date": "2025年4月1日 10:00", "unused": "x"},
+			wantContent: "山田太郎様\n面接日: 2025年4月1日 10:00\n山田太郎様のご来社をお待ちしております",
+			wantErr:     false,
+		},
+	}
+	dbHandlers := map[string]*sqlx.DB{
+		"company": nil,
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tc.appKind != "" {
+				ctx = request.SetAppKind(ctx, tc.appKind)
+			}
+			if tc.userID != 0 {
+				ctx = request.SetUserID(ctx, tc.userID)
+			}
+			getterMock := &MessageTemplateGetterMock{
+				GetMessageTemplateFunc: func(ctx context.Context, db store.Queryer, id entity.MessageTemplateID) (*entity.MessageTemplate, error) {
+					return &entity.MessageTemplate{ID: id, CompanyUserID: 1, Content: tc.content}, nil
+				},
+			}
+			svc := NewRenderMessageTemplate(dbHandlers, getterMock)
+			content, err := svc.RenderMessageTemplate(ctx, entity.MessageTemplateID(3), tc.variables)
+			if tc.wantErr {
+				assert.Error(t, err, "error is expected but got nil")
+				se, ok := err.(*handler.ServiceError)
+				if assert.True(t, ok, "error should be *handler.ServiceError") {
+					assert.Equal(t, tc.wantErrStatus, se.StatusCode)
+					assert.Contains(t, se.Message, tc.wantErrMsg)
+					assert.Equal(t, tc.wantErrDetail, se.DetailError())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantContent, content)
+			}
+		})
+	}
+}
+
+func TestRenderMessageTemplate_RenderMessageTemplates(t *testing.T) {
+	ctx := request.SetUserID(request.SetAppKind(context.Background(), "company"), 1)
+	getterMock := &MessageTemplateGetterMock{
+		GetMessageTemplateFunc: func(ctx context.Context, db store.Queryer, id entity.MessageTemplateID) (*entity.MessageTemplate, error) {
+			return &entity.MessageTemplate{ID: id, CompanyUserID: 1, Content: "{{student_name}}様 面接日: {{interview_date}}"}, nil
+		},
+	}
+	svc := NewRenderMessageTemplate(map[string]*sqlx.DB{"company": nil}, getterMock)
+
+	t.Run("renders per recipient", func(t *testing.T) {
+		contents, err := svc.RenderMessageTemplates(ctx, entity.MessageTemplateID(3), []map[string]string{
+			{"student_name": "山田太郎", "interview_date": "4月1日"},
+			{"student_name": "佐藤花子", "interview_date": "4月2日"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"山田太郎様 面接日: 4月1日", "佐藤花子様 面接日: 4月2日"}, contents)
+	})
+
+	t.Run("missing variables of a recipient", func(t *testing.T) {
+		_, err := svc.RenderMessageTemplates(ctx, entity.MessageTemplateID(3), []map[string]string{
+			{"student_name": "山田太郎", "interview_date": "4月1日"},
+			{"student_name": "佐藤花子"},
+		})
+		se, ok := err.(*handler.ServiceError)
+		if assert.True(t, ok, "error should be *handler.ServiceError") {
+			assert.Equal(t, handler.ErrCodeTemplateVariablesMissing, se.Code)
+			assert.Equal(t, "recipients[1]: interview_date", se.DetailError())
+		}
+	})
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/request"
+)
+
+// readDB は読み取りのみの処理で使う DB を返す。
+// name のレプリカが設定されていればレプリカを使い、リクエストが primary からの読み込みを求めている場合（read-your-writes）や
+// レプリカがない場合は primary を使う
+func readDB(ctx context.Context, dbHandlers, replicaHandlers map[string]*sqlx.DB, name string) *sqlx.DB {
+	if replica, ok := replicaHandlers[name]; ok && replica != nil && !request.GetReadFromPrimary(ctx) {
+		return replica
+	}
+	return dbHandlers[name]
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+// newInternalServiceError は予期しないエラーを 500 の ServiceError に変換する。
+// ただしデッドロックやロック待ちのタイムアウトは、クライアントがリトライすれば成功しうるため 503 とする
+func newInternalServiceError(message string, err error) *handler.ServiceError {
+	code := handler.ErrCodeInternal
+	if store.IsRetryable(err) {
+		code = handler.ErrCodeTemporarilyUnavailable
+	}
+	return handler.NewServiceError(code, message, err.Error())
+}
+
+// newLookupServiceError は行が見つからない（sql.ErrNoRows）場合を code の ServiceError に、
+// それ以外を newInternalServiceError に変換する
+func newLookupServiceError(code handler.ErrorCode, notFoundMessage, message string, err error) *handler.ServiceError {
+	if errors.Is(err, sql.ErrNoRows) {
+		return handler.NewServiceError(code, notFoundMessage, "")
+	}
+	return newInternalServiceError(message, err)
+}
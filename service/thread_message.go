@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+	"github.com/yuyacode/AppLiftMessageApi/store"
+)
+
+// getVisibleThreadMessages はスレッドの所有者を検証したうえで、利用者から見えるメッセージを返す
+func getVisibleThreadMessages(ctx context.Context, db store.Queryer, messageOwnerGetter MessageOwnerGetter, messageGetter MessageGetter, appKind string, userID int64, messageThreadID entity.MessageThreadID, action string) (entity.Messages, error) {
+	if err := checkThreadOwner(ctx, db, messageOwnerGetter, appKind, userID, messageThreadID, action); err != nil {
+		return nil, err
+	}
+	var m entity.Messages
+	var err error
+	if appKind == "company" {
+		m, err = messageGetter.GetAllMessagesForCompanyUser(ctx, db, messageThreadID)
+	} else if appKind == "student" {
+		m, err = messageGetter.GetAllMessagesForStudentUser(ctx, db, messageThreadID)
+	}
+	if err != nil {
+		return nil, newInternalServiceError("failed to get message", err)
+	}
+	return m, nil
+}
+
+// checkThreadOwner は利用者がスレッドの当事者であることを検証する
+func checkThreadOwner(ctx context.Context, db store.Queryer, messageOwnerGetter MessageOwnerGetter, appKind string, userID int64, messageThreadID entity.MessageThreadID, action string) error {
+	var ownerID int64
+	var err error
+	if appKind == "company" {
+		ownerID, err = messageOwnerGetter.GetThreadCompanyOwner(ctx, db, messageThreadID)
+		if err != nil {
+			return newLookupServiceError(handler.ErrCodeThreadNotFound, "message thread not found", "failed to get threadCompanyOwner", err)
+		}
+	} else if appKind == "student" {
+		ownerID, err = messageOwnerGetter.GetThreadStudentOwner(ctx, db, messageThreadID)
+		if err != nil {
+			return newLookupServiceError(handler.ErrCodeThreadNotFound, "message thread not found", "failed to get threadStudentOwner", err)
+		}
+	} else {
+		return nil
+	}
+	if userID != ownerID {
+		return handler.NewServiceError(
+			handler.ErrCodeForbidden,
+			"unauthorized: lack the necessary permissions to "+action,
+			"",
+		)
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package service
+
+import (
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("github.com/yuyacode/AppLiftMessageApi/service")
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/handler"
+)
+
+// collectUserData は company / student / common の各DBから、利用者1人分の個人データを集める
+func collectUserData(ctx context.Context, dbHandlers map[string]*sqlx.DB, userDataGetter UserDataGetter, appKind string, userID int64) (*entity.UserData, error) {
+	if appKind != "company" && appKind != "student" {
+		return nil, handler.NewServiceError(
+			handler.ErrCodeInvalidParameter,
+			"app_kind must be company or student",
+			"",
+		)
+	}
+	threads, err := userDataGetter.GetThreadsByUserID(ctx, dbHandlers["common"], appKind, userID)
+	if err != nil {
+		return nil, newInternalServiceError("failed to get message threads", err)
+	}
+	threadIDs := make([]entity.MessageThreadID, 0, len(threads))
+	for _, mt := range threads {
+		threadIDs = append(threadIDs, mt.ID)
+	}
+	messages, err := userDataGetter.GetMessagesByThreadIDs(ctx, dbHandlers["common"], appKind, threadIDs)
+	if err != nil {
+		return nil, newInternalServiceError("failed to get messages", err)
+	}
+	messageIDs := make([]entity.MessageID, 0, len(messages))
+	for _, m := range messages {
+		messageIDs = append(messageIDs, m.ID)
+	}
+	attachments, err := userDataGetter.GetAttachmentsByMessageIDs(ctx, dbHandlers["common"], messageIDs)
+	if err != nil {
+		return nil, newInternalServiceError("failed to get attachments", err)
+	}
+	credentials, err := userDataGetter.GetCredentialsByUserID(ctx, dbHandlers[appKind], userID)
+	if err != nil {
+		return nil, newInternalServiceError("failed to get credentials", err)
+	}
+	return &entity.UserData{
+		AppKind:     appKind,
+		UserID:      userID,
+		ExportedAt:  time.Now(),
+		Threads:     threads,
+		Messages:    messages,
+		Attachments: attachments,
+		Credentials: credentials,
+	}, nil
+}
+
+func isOwnMessage(m *entity.Message, appKind string) bool {
+	return (appKind == "company" && m.IsFromCompany == 1) || (appKind == "student" && m.IsFromStudent == 1)
+}
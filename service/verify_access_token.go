@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"net/http"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -24,28 +23,26 @@ func NewVerifyAccessToken(dbHandlers map[string]*sqlx.DB, credentialGetter Crede
 }
 
 func (vat *VerifyAccessToken) VerifyAccessToken(ctx context.Context, accessToken string) (string, int64, error) {
+	ctx, span := tracer.Start(ctx, "VerifyAccessToken.VerifyAccessToken")
+	defer span.End()
 	appKind, userID, err := credential.DecryptAccessToken(accessToken)
 	if err != nil {
 		return "", 0, err
 	}
 	validAccessToken, expiresAt, err := vat.CredentialGetter.GetAccessToken(ctx, vat.DBHandlers[appKind], userID)
 	if err != nil {
-		return "", 0, handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to get access_token",
-			err.Error(),
-		)
+		return "", 0, newLookupServiceError(handler.ErrCodeInvalidToken, "invalid_token", "failed to get access_token", err)
 	}
 	if expiresAt == nil || !expiresAt.Valid {
 		return "", 0, handler.NewServiceError(
-			http.StatusInternalServerError,
+			handler.ErrCodeInternal,
 			"the expiresAt is not set.",
 			"the access token expiration date is invalid",
 		)
 	}
 	if accessToken != validAccessToken {
 		return "", 0, handler.NewServiceError(
-			http.StatusUnauthorized,
+			handler.ErrCodeInvalidToken,
 			"invalid_token",
 			"invalid access token",
 		)
@@ -53,7 +50,7 @@ func (vat *VerifyAccessToken) VerifyAccessToken(ctx context.Context, accessToken
 	currentTime := time.Now()
 	if currentTime.After(expiresAt.Time) {
 		return "", 0, handler.NewServiceError(
-			http.StatusUnauthorized,
+			handler.ErrCodeTokenExpired,
 			"token_expired",
 			"The access token has expired",
 		)
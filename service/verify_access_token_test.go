@@ -52,6 +52,20 @@ func TestVerifyAccessToken_VerifyAccessToken(t *testing.T) {
 			wantErrStatus: http.StatusInternalServerError,
 			wantErrMsg:    "failed to get access_token",
 		},
+		{
+			name:             "no credential for user => invalid_token",
+			decryptedAppKind: appKind,
+			decryptedUserID:  userID,
+			accessToken:      accessToken,
+			prepareGetterMock: func(m *CredentialGetterMock) {
+				m.GetAccessTokenFunc = func(ctx context.Context, db store.Queryer, userID int64) (string, *sql.NullTime, error) {
+					return "", nil, sql.ErrNoRows
+				}
+			},
+			wantErr:       true,
+			wantErrStatus: http.StatusUnauthorized,
+			wantErrMsg:    "invalid_token",
+		},
 		{
 			name:             "expiresAt is nil or invalid",
 			decryptedAppKind: appKind,
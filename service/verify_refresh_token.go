@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/jmoiron/sqlx"
 
@@ -23,21 +22,19 @@ func NewVerifyRefreshToken(dbHandlers map[string]*sqlx.DB, credentialGetter Cred
 }
 
 func (vrt *VerifyRefreshToken) VerifyRefreshToken(ctx context.Context, refreshToken string) (string, int64, error) {
+	ctx, span := tracer.Start(ctx, "VerifyRefreshToken.VerifyRefreshToken")
+	defer span.End()
 	appKind, userID, err := credential.DecryptRefreshToken(refreshToken)
 	if err != nil {
 		return "", 0, err
 	}
 	validRefreshToken, err := vrt.CredentialGetter.GetRefreshToken(ctx, vrt.DBHandlers[appKind], userID)
 	if err != nil {
-		return "", 0, handler.NewServiceError(
-			http.StatusInternalServerError,
-			"failed to get refresh_token",
-			err.Error(),
-		)
+		return "", 0, newLookupServiceError(handler.ErrCodeInvalidToken, "invalid_token", "failed to get refresh_token", err)
 	}
 	if refreshToken != validRefreshToken {
 		return "", 0, handler.NewServiceError(
-			http.StatusUnauthorized,
+			handler.ErrCodeInvalidToken,
 			"invalid_token",
 			"invalid refresh token",
 		)
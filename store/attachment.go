@@ -0,0 +1,71 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type AttachmentRepository struct {
+	Clocker clock.Clocker
+}
+
+func NewAttachmentRepository(clocker clock.Clocker) *AttachmentRepository {
+	return &AttachmentRepository{
+		Clocker: clocker,
+	}
+}
+
+func (ar *AttachmentRepository) GetAttachment(ctx context.Context, db Queryer, id entity.AttachmentID) (*entity.Attachment, error) {
+	query := "SELECT id, message_id, file_name, content_type, size, storage_key FROM message_attachments WHERE id = ? AND deleted_at IS NULL;"
+	var a entity.Attachment
+	if err := db.GetContext(ctx, &a, query, id); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (ar *AttachmentRepository) GetAttachmentsByMessageIDs(ctx context.Context, db Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+	query, args, err := sqlx.In("SELECT id, message_id, file_name, content_type, size, storage_key FROM message_attachments WHERE message_id IN (?) AND deleted_at IS NULL ORDER BY id ASC;", messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var attachments entity.Attachments
+	for rows.Next() {
+		var a entity.Attachment
+		if err := rows.StructScan(&a); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (ar *AttachmentRepository) AddAttachment(ctx context.Context, db Execer, param *entity.Attachment) error {
+	param.CreatedAt = ar.Clocker.Now()
+	query := "INSERT INTO message_attachments (message_id, file_name, content_type, size, storage_key, created_at) VALUES (:message_id, :file_name, :content_type, :size, :storage_key, :created_at);"
+	result, err := db.NamedExecContext(ctx, query, param)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	param.ID = entity.AttachmentID(id)
+	return nil
+}
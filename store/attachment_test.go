@@ -0,0 +1,225 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestAttachmentRepository_GetAttachment(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	ar := NewAttachmentRepository(clock.FixedClocker{})
+	query := `^SELECT id, message_id, file_name, content_type, size, storage_key FROM message_attachments WHERE id = \? AND deleted_at IS NULL;$`
+	tests := map[string]struct {
+		id             entity.AttachmentID
+		mockSetup      func()
+		wantErr        bool
+		wantAttachment *entity.Attachment
+	}{
+		"DB error": {
+			id: 1,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"No rows": {
+			id: 2,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(2)).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+		"Success": {
+			id: 3,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(3)).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "message_id", "file_name", "content_type", "size", "storage_key"}).
+							AddRow(int64(3), int64(10), "resume.pdf", "application/pdf", int64(2048), "attachments/10/abc"),
+					)
+			},
+			wantErr: false,
+			wantAttachment: &entity.Attachment{
+				ID:          3,
+				MessageID:   10,
+				FileName:    "resume.pdf",
+				ContentType: "application/pdf",
+				Size:        2048,
+				StorageKey:  "attachments/10/abc",
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := ar.GetAttachment(context.Background(), sqlxDB, tc.id)
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantAttachment, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAttachmentRepository_GetAttachmentsByMessageIDs(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	ar := NewAttachmentRepository(clock.FixedClocker{})
+	query := `^SELECT id, message_id, file_name, content_type, size, storage_key FROM message_attachments WHERE message_id IN \(\?, \?\) AND deleted_at IS NULL ORDER BY id ASC;$`
+	tests := map[string]struct {
+		messageIDs      []entity.MessageID
+		mockSetup       func()
+		wantErr         bool
+		wantAttachments entity.Attachments
+	}{
+		"Empty message IDs": {
+			messageIDs:      nil,
+			mockSetup:       func() {},
+			wantErr:         false,
+			wantAttachments: nil,
+		},
+		"DB error": {
+			messageIDs: []entity.MessageID{1, 2},
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1), int64(2)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Multiple rows": {
+			messageIDs: []entity.MessageID{1, 2},
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1), int64(2)).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "message_id", "file_name", "content_type", "size", "storage_key"}).
+							AddRow(int64(5), int64(1), "resume.pdf", "application/pdf", int64(2048), "attachments/1/abc").
+							AddRow(int64(6), int64(2), "photo.png", "image/png", int64(512), "attachments/2/def"),
+					)
+			},
+			wantErr: false,
+			wantAttachments: entity.Attachments{
+				&entity.Attachment{
+					ID:          5,
+					MessageID:   1,
+					FileName:    "resume.pdf",
+					ContentType: "application/pdf",
+					Size:        2048,
+					StorageKey:  "attachments/1/abc",
+				},
+				&entity.Attachment{
+					ID:          6,
+					MessageID:   2,
+					FileName:    "photo.png",
+					ContentType: "image/png",
+					Size:        512,
+					StorageKey:  "attachments/2/def",
+				},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := ar.GetAttachmentsByMessageIDs(context.Background(), sqlxDB, tc.messageIDs)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantAttachments, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAttachmentRepository_AddAttachment(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	ar := NewAttachmentRepository(clock.FixedClocker{})
+	query := `^INSERT INTO message_attachments \(message_id, file_name, content_type, size, storage_key, created_at\) VALUES \(\?, \?, \?, \?, \?, \?\);$`
+	tests := map[string]struct {
+		input     *entity.Attachment
+		mockSetup func(*entity.Attachment)
+		wantErr   bool
+		wantID    entity.AttachmentID
+	}{
+		"DB error on Exec": {
+			input: &entity.Attachment{
+				MessageID:   1,
+				FileName:    "resume.pdf",
+				ContentType: "application/pdf",
+				Size:        2048,
+				StorageKey:  "attachments/1/abc",
+			},
+			mockSetup: func(param *entity.Attachment) {
+				mock.ExpectExec(query).
+					WithArgs(param.MessageID, param.FileName, param.ContentType, param.Size, param.StorageKey, clock.FixedClocker{}.Now()).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"LastInsertId error": {
+			input: &entity.Attachment{
+				MessageID:   1,
+				FileName:    "resume.pdf",
+				ContentType: "application/pdf",
+				Size:        2048,
+				StorageKey:  "attachments/1/abc",
+			},
+			mockSetup: func(param *entity.Attachment) {
+				mock.ExpectExec(query).
+					WithArgs(param.MessageID, param.FileName, param.ContentType, param.Size, param.StorageKey, clock.FixedClocker{}.Now()).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("cannot get lastInsertID")))
+			},
+			wantErr: true,
+		},
+		"Success": {
+			input: &entity.Attachment{
+				MessageID:   1,
+				FileName:    "resume.pdf",
+				ContentType: "application/pdf",
+				Size:        2048,
+				StorageKey:  "attachments/1/abc",
+			},
+			mockSetup: func(param *entity.Attachment) {
+				mock.ExpectExec(query).
+					WithArgs(param.MessageID, param.FileName, param.ContentType, param.Size, param.StorageKey, clock.FixedClocker{}.Now()).
+					WillReturnResult(sqlmock.NewResult(77, 1))
+			},
+			wantErr: false,
+			wantID:  77,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup(tc.input)
+			err := ar.AddAttachment(context.Background(), sqlxDB, tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantID, tc.input.ID)
+				assert.Equal(t, clock.FixedClocker{}.Now(), tc.input.CreatedAt)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"deadlock", &mysql.MySQLError{Number: 1213}, true},
+		{"lock wait timeout", &mysql.MySQLError{Number: 1205}, true},
+		{"wrapped deadlock", fmt.Errorf("failed to update: %w", &mysql.MySQLError{Number: 1213}), true},
+		{"duplicate entry", &mysql.MySQLError{Number: 1062}, false},
+		{"other error", errors.New("connection refused"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetryable(tt.err))
+		})
+	}
+}
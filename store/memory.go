@@ -0,0 +1,182 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/credential"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+// DB_DRIVER=memory のとき、サービスに渡す DB ハンドルは SQL を実行せず、トランザクションも開始できない。
+// インメモリのデータはロールバックで元に戻せないため、複数の書き込みをまとめて行う処理（一括送信・ユーザーデータの消去・パージ）はエラーになる。
+// どの DB（company, student, common）のハンドルかはドライバー名で判別する
+const memoryDriverPrefix = "memory:"
+
+var (
+	errMemoryNoSQL = errors.New("memory driver does not execute SQL")
+	errMemoryNoTx  = errors.New("memory driver does not support transactions")
+)
+
+type memoryConnector struct{}
+
+func (memoryConnector) Connect(context.Context) (driver.Conn, error) { return memoryConn{}, nil }
+func (memoryConnector) Driver() driver.Driver                        { return memoryDriver{} }
+
+type memoryDriver struct{}
+
+func (memoryDriver) Open(string) (driver.Conn, error) { return memoryConn{}, nil }
+
+type memoryConn struct{}
+
+func (memoryConn) Prepare(string) (driver.Stmt, error) { return nil, errMemoryNoSQL }
+func (memoryConn) Close() error                        { return nil }
+func (memoryConn) Begin() (driver.Tx, error)           { return nil, errMemoryNoTx }
+func (memoryConn) Ping(context.Context) error          { return nil }
+
+func newMemoryDB(targetDB string) *sqlx.DB {
+	return sqlx.NewDb(sql.OpenDB(memoryConnector{}), memoryDriverPrefix+targetDB)
+}
+
+// memoryDBName は DB_DRIVER=memory で開いたハンドルの対象DB名を返す
+func memoryDBName(db any) string {
+	if d, ok := db.(interface{ DriverName() string }); ok {
+		return strings.TrimPrefix(d.DriverName(), memoryDriverPrefix)
+	}
+	return ""
+}
+
+// MemoryData はインメモリのリポジトリが共有するデータ。
+// 利用者やスレッドは本APIの外で作られるため、LoadSeed で投入する
+type MemoryData struct {
+	mu          sync.RWMutex
+	lastIDs     map[string]int64
+	apiKeys     map[string]string
+	credentials map[string][]*entity.MessageAPICredential
+	threads     []*entity.MessageThread
+	messages    []*entity.Message
+	attachments []*entity.Attachment
+	reactions   []*entity.Reaction
+	templates   []*entity.MessageTemplate
+}
+
+func NewMemoryData() *MemoryData {
+	return &MemoryData{
+		lastIDs:     make(map[string]int64),
+		apiKeys:     make(map[string]string),
+		credentials: make(map[string][]*entity.MessageAPICredential),
+	}
+}
+
+type memorySeed struct {
+	APIKeys               map[string]string `json:"api_keys"`
+	MessageAPICredentials map[string][]struct {
+		UserID       int64      `json:"user_id"`
+		ClientID     string     `json:"client_id"`
+		ClientSecret string     `json:"client_secret"`
+		AccessToken  string     `json:"access_token"`
+		RefreshToken string     `json:"refresh_token"`
+		ExpiresAt    *time.Time `json:"expires_at"`
+	} `json:"message_api_credentials"`
+	MessageThreads []struct {
+		ID            entity.MessageThreadID `json:"id"`
+		CompanyUserID int64                  `json:"company_user_id"`
+		StudentUserID int64                  `json:"student_user_id"`
+	} `json:"message_threads"`
+}
+
+// LoadSeed は次の形式の JSON を読み込む。APIキーは平文で指定し、保存時にハッシュ化する。
+//
+//	{
+//	  "api_keys": {"company": "...", "student": "..."},
+//	  "message_api_credentials": {"company": [{"user_id": 1, "client_id": "...", "client_secret": "...", "access_token": "...", "refresh_token": "...", "expires_at": "2025-01-01T09:15:00+09:00"}]},
+//	  "message_threads": [{"id": 1, "company_user_id": 1, "student_user_id": 1}]
+//	}
+func (md *MemoryData) LoadSeed(r io.Reader, clocker clock.Clocker) error {
+	var seed memorySeed
+	if err := json.NewDecoder(r).Decode(&seed); err != nil {
+		return err
+	}
+	md.mu.Lock()
+	defer md.mu.Unlock()
+	for targetDB, apiKey := range seed.APIKeys {
+		if targetDB != "company" && targetDB != "student" {
+			return errors.New("api_keys must be keyed by company or student")
+		}
+		md.apiKeys[targetDB] = credential.HashAPIKey(apiKey)
+	}
+	for targetDB, credentials := range seed.MessageAPICredentials {
+		if targetDB != "company" && targetDB != "student" {
+			return errors.New("message_api_credentials must be keyed by company or student")
+		}
+		for _, c := range credentials {
+			var expiresAt *sql.NullTime
+			if c.ExpiresAt != nil {
+				expiresAt = &sql.NullTime{Time: *c.ExpiresAt, Valid: true}
+			}
+			md.credentials[targetDB] = append(md.credentials[targetDB], &entity.MessageAPICredential{
+				ID:           entity.MessageAPICredentialID(md.nextID(targetDB + ".message_api_credentials")),
+				UserID:       c.UserID,
+				ClientID:     c.ClientID,
+				ClientSecret: c.ClientSecret,
+				AccessToken:  c.AccessToken,
+				RefreshToken: c.RefreshToken,
+				ExpiresAt:    expiresAt,
+				CreatedAt:    clocker.Now(),
+			})
+		}
+	}
+	for _, t := range seed.MessageThreads {
+		id := int64(t.ID)
+		if id == 0 {
+			id = md.nextID("message_threads")
+		} else if id > md.lastIDs["message_threads"] {
+			md.lastIDs["message_threads"] = id
+		}
+		md.threads = append(md.threads, &entity.MessageThread{
+			ID:            entity.MessageThreadID(id),
+			CompanyUserID: t.CompanyUserID,
+			StudentUserID: t.StudentUserID,
+			CreatedAt:     clocker.Now(),
+		})
+	}
+	return nil
+}
+
+// nextID は AUTO_INCREMENT 相当の連番を返す。呼び出し元で mu を書き込みロックしておくこと
+func (md *MemoryData) nextID(table string) int64 {
+	md.lastIDs[table]++
+	return md.lastIDs[table]
+}
+
+func (md *MemoryData) findThread(id entity.MessageThreadID) *entity.MessageThread {
+	for _, t := range md.threads {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (md *MemoryData) findMessage(id entity.MessageID) *entity.Message {
+	for _, m := range md.messages {
+		if m.ID == id {
+			return m
+		}
+	}
+	return nil
+}
+
+func isDeleted(deletedAt *sql.NullTime) bool {
+	return deletedAt != nil && deletedAt.Valid
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+// MemoryAttachmentRepository は AttachmentRepository のインメモリ実装
+type MemoryAttachmentRepository struct {
+	Data    *MemoryData
+	Clocker clock.Clocker
+}
+
+func NewMemoryAttachmentRepository(data *MemoryData, clocker clock.Clocker) *MemoryAttachmentRepository {
+	return &MemoryAttachmentRepository{
+		Data:    data,
+		Clocker: clocker,
+	}
+}
+
+func (mar *MemoryAttachmentRepository) GetAttachment(ctx context.Context, db Queryer, id entity.AttachmentID) (*entity.Attachment, error) {
+	mar.Data.mu.RLock()
+	defer mar.Data.mu.RUnlock()
+	for _, a := range mar.Data.attachments {
+		if a.ID == id && !isDeleted(a.DeletedAt) {
+			return copyAttachment(a), nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (mar *MemoryAttachmentRepository) GetAttachmentsByMessageIDs(ctx context.Context, db Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+	mar.Data.mu.RLock()
+	defer mar.Data.mu.RUnlock()
+	var attachments entity.Attachments
+	for _, a := range mar.Data.attachments {
+		if slices.Contains(messageIDs, a.MessageID) && !isDeleted(a.DeletedAt) {
+			attachments = append(attachments, copyAttachment(a))
+		}
+	}
+	return attachments, nil
+}
+
+func (mar *MemoryAttachmentRepository) AddAttachment(ctx context.Context, db Execer, param *entity.Attachment) error {
+	param.CreatedAt = mar.Clocker.Now()
+	mar.Data.mu.Lock()
+	defer mar.Data.mu.Unlock()
+	param.ID = entity.AttachmentID(mar.Data.nextID("message_attachments"))
+	stored := *param
+	mar.Data.attachments = append(mar.Data.attachments, &stored)
+	return nil
+}
+
+// copyAttachment は AttachmentRepository が返す列（作成・削除日時を除く）のみを複製する
+func copyAttachment(a *entity.Attachment) *entity.Attachment {
+	return &entity.Attachment{
+		ID:          a.ID,
+		MessageID:   a.MessageID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		StorageKey:  a.StorageKey,
+	}
+}
@@ -0,0 +1,240 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"sort"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+// MemoryMessageRepository は MessageRepository のインメモリ実装
+type MemoryMessageRepository struct {
+	Data    *MemoryData
+	Clocker clock.Clocker
+}
+
+func NewMemoryMessageRepository(data *MemoryData, clocker clock.Clocker) *MemoryMessageRepository {
+	return &MemoryMessageRepository{
+		Data:    data,
+		Clocker: clocker,
+	}
+}
+
+func (mmr *MemoryMessageRepository) GetThreadCompanyOwner(ctx context.Context, db Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+	mmr.Data.mu.RLock()
+	defer mmr.Data.mu.RUnlock()
+	t := mmr.Data.findThread(messageThreadID)
+	if t == nil || isDeleted(t.DeletedAt) {
+		return 0, sql.ErrNoRows
+	}
+	return t.CompanyUserID, nil
+}
+
+func (mmr *MemoryMessageRepository) GetThreadStudentOwner(ctx context.Context, db Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+	mmr.Data.mu.RLock()
+	defer mmr.Data.mu.RUnlock()
+	t := mmr.Data.findThread(messageThreadID)
+	if t == nil || isDeleted(t.DeletedAt) {
+		return 0, sql.ErrNoRows
+	}
+	return t.StudentUserID, nil
+}
+
+func (mmr *MemoryMessageRepository) GetThreadsByIDs(ctx context.Context, db Queryer, ids []entity.MessageThreadID) ([]*entity.MessageThread, error) {
+	mmr.Data.mu.RLock()
+	defer mmr.Data.mu.RUnlock()
+	var threads []*entity.MessageThread
+	for _, t := range mmr.Data.threads {
+		if slices.Contains(ids, t.ID) && !isDeleted(t.DeletedAt) {
+			threads = append(threads, &entity.MessageThread{
+				ID:            t.ID,
+				CompanyUserID: t.CompanyUserID,
+				StudentUserID: t.StudentUserID,
+			})
+		}
+	}
+	return threads, nil
+}
+
+func (mmr *MemoryMessageRepository) GetThreadCompanyOwnerByMessageID(ctx context.Context, db Queryer, messageID entity.MessageID) (int64, error) {
+	t, err := mmr.threadByMessageID(messageID, func(m *entity.Message) bool {
+		return m.IsFromCompany == 1
+	})
+	if err != nil {
+		return 0, err
+	}
+	return t.CompanyUserID, nil
+}
+
+func (mmr *MemoryMessageRepository) GetThreadStudentOwnerByMessageID(ctx context.Context, db Queryer, messageID entity.MessageID) (int64, error) {
+	t, err := mmr.threadByMessageID(messageID, func(m *entity.Message) bool {
+		return m.IsFromStudent == 1
+	})
+	if err != nil {
+		return 0, err
+	}
+	return t.StudentUserID, nil
+}
+
+func (mmr *MemoryMessageRepository) GetThreadCompanyOwnerByVisibleMessageID(ctx context.Context, db Queryer, messageID entity.MessageID) (int64, error) {
+	t, err := mmr.threadByMessageID(messageID, func(m *entity.Message) bool {
+		return !isDeleted(m.DeletedAt) && (m.IsFromCompany == 1 || m.IsSent == 1)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return t.CompanyUserID, nil
+}
+
+func (mmr *MemoryMessageRepository) GetThreadStudentOwnerByVisibleMessageID(ctx context.Context, db Queryer, messageID entity.MessageID) (int64, error) {
+	t, err := mmr.threadByMessageID(messageID, func(m *entity.Message) bool {
+		return !isDeleted(m.DeletedAt) && (m.IsFromStudent == 1 || m.IsSent == 1)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return t.StudentUserID, nil
+}
+
+func (mmr *MemoryMessageRepository) GetAllMessagesForCompanyUser(ctx context.Context, db Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
+	return mmr.threadMessages(messageThreadID, func(m *entity.Message) bool {
+		return m.IsFromCompany == 1 || (m.IsFromStudent == 1 && m.IsSent == 1)
+	}), nil
+}
+
+func (mmr *MemoryMessageRepository) GetAllMessagesForStudentUser(ctx context.Context, db Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
+	return mmr.threadMessages(messageThreadID, func(m *entity.Message) bool {
+		return m.IsFromStudent == 1 || (m.IsFromCompany == 1 && m.IsSent == 1)
+	}), nil
+}
+
+func (mmr *MemoryMessageRepository) EachMessageForCompanyUser(ctx context.Context, db Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+	messages, _ := mmr.GetAllMessagesForCompanyUser(ctx, db, messageThreadID)
+	return eachMessage(messages, fn)
+}
+
+func (mmr *MemoryMessageRepository) EachMessageForStudentUser(ctx context.Context, db Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+	messages, _ := mmr.GetAllMessagesForStudentUser(ctx, db, messageThreadID)
+	return eachMessage(messages, fn)
+}
+
+// eachMessage はロックを解放してから fn を呼ぶため、取得済みのコピーを渡す
+func eachMessage(messages entity.Messages, fn func(*entity.Message) error) error {
+	for _, m := range messages {
+		if err := fn(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mmr *MemoryMessageRepository) GetSentMessageThreadID(ctx context.Context, db Queryer, id entity.MessageID) (entity.MessageThreadID, error) {
+	mmr.Data.mu.RLock()
+	defer mmr.Data.mu.RUnlock()
+	m := mmr.Data.findMessage(id)
+	if m == nil || m.IsSent != 1 || isDeleted(m.DeletedAt) {
+		return 0, sql.ErrNoRows
+	}
+	return m.MessageThreadID, nil
+}
+
+func (mmr *MemoryMessageRepository) GetMessagesByIDs(ctx context.Context, db Queryer, ids []entity.MessageID) (entity.Messages, error) {
+	mmr.Data.mu.RLock()
+	defer mmr.Data.mu.RUnlock()
+	var messages entity.Messages
+	for _, m := range mmr.Data.messages {
+		if slices.Contains(ids, m.ID) && !isDeleted(m.DeletedAt) {
+			messages = append(messages, &entity.Message{
+				ID:            m.ID,
+				IsFromCompany: m.IsFromCompany,
+				IsFromStudent: m.IsFromStudent,
+				Content:       m.Content,
+			})
+		}
+	}
+	return messages, nil
+}
+
+func (mmr *MemoryMessageRepository) AddMessage(ctx context.Context, db Execer, param *entity.Message) error {
+	param.CreatedAt = mmr.Clocker.Now()
+	mmr.Data.mu.Lock()
+	defer mmr.Data.mu.Unlock()
+	param.ID = entity.MessageID(mmr.Data.nextID("messages"))
+	mmr.Data.messages = append(mmr.Data.messages, &entity.Message{
+		ID:               param.ID,
+		MessageThreadID:  param.MessageThreadID,
+		IsFromCompany:    param.IsFromCompany,
+		IsFromStudent:    param.IsFromStudent,
+		Content:          param.Content,
+		IsSent:           param.IsSent,
+		SentAt:           param.SentAt,
+		ReplyToMessageID: param.ReplyToMessageID,
+		CreatedAt:        param.CreatedAt,
+	})
+	return nil
+}
+
+func (mmr *MemoryMessageRepository) EditMessage(ctx context.Context, db Execer, param *entity.Message) error {
+	param.UpdatedAt = mmr.Clocker.Now()
+	mmr.Data.mu.Lock()
+	defer mmr.Data.mu.Unlock()
+	if m := mmr.Data.findMessage(param.ID); m != nil {
+		m.Content = param.Content
+		m.UpdatedAt = param.UpdatedAt
+	}
+	return nil
+}
+
+func (mmr *MemoryMessageRepository) DeleteMessage(ctx context.Context, db Execer, id entity.MessageID) error {
+	mmr.Data.mu.Lock()
+	defer mmr.Data.mu.Unlock()
+	if m := mmr.Data.findMessage(id); m != nil {
+		m.DeletedAt = mmr.Clocker.Now()
+	}
+	return nil
+}
+
+func (mmr *MemoryMessageRepository) threadByMessageID(messageID entity.MessageID, match func(m *entity.Message) bool) (*entity.MessageThread, error) {
+	mmr.Data.mu.RLock()
+	defer mmr.Data.mu.RUnlock()
+	m := mmr.Data.findMessage(messageID)
+	if m == nil || !match(m) {
+		return nil, sql.ErrNoRows
+	}
+	t := mmr.Data.findThread(m.MessageThreadID)
+	if t == nil {
+		return nil, sql.ErrNoRows
+	}
+	return t, nil
+}
+
+// threadMessages は未削除のメッセージのうち visible を満たすものを、送信日時・IDの昇順で返す
+func (mmr *MemoryMessageRepository) threadMessages(messageThreadID entity.MessageThreadID, visible func(m *entity.Message) bool) entity.Messages {
+	mmr.Data.mu.RLock()
+	defer mmr.Data.mu.RUnlock()
+	var messages entity.Messages
+	for _, m := range mmr.Data.messages {
+		if m.MessageThreadID == messageThreadID && !isDeleted(m.DeletedAt) && visible(m) {
+			messages = append(messages, &entity.Message{
+				ID:               m.ID,
+				IsFromCompany:    m.IsFromCompany,
+				IsFromStudent:    m.IsFromStudent,
+				Content:          m.Content,
+				IsSent:           m.IsSent,
+				SentAt:           m.SentAt,
+				ReplyToMessageID: m.ReplyToMessageID,
+				UpdatedAt:        m.UpdatedAt,
+			})
+		}
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		if !messages[i].SentAt.Equal(messages[j].SentAt) {
+			return messages[i].SentAt.Before(messages[j].SentAt)
+		}
+		return messages[i].ID < messages[j].ID
+	})
+	return messages
+}
@@ -0,0 +1,92 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+// MemoryMessageTemplateRepository は MessageTemplateRepository のインメモリ実装
+type MemoryMessageTemplateRepository struct {
+	Data    *MemoryData
+	Clocker clock.Clocker
+}
+
+func NewMemoryMessageTemplateRepository(data *MemoryData, clocker clock.Clocker) *MemoryMessageTemplateRepository {
+	return &MemoryMessageTemplateRepository{
+		Data:    data,
+		Clocker: clocker,
+	}
+}
+
+func (mmtr *MemoryMessageTemplateRepository) GetAllMessageTemplates(ctx context.Context, db Queryer, companyUserID int64) (entity.MessageTemplates, error) {
+	mmtr.Data.mu.RLock()
+	defer mmtr.Data.mu.RUnlock()
+	var templates entity.MessageTemplates
+	for _, t := range mmtr.Data.templates {
+		if t.CompanyUserID == companyUserID && !isDeleted(t.DeletedAt) {
+			templates = append(templates, copyMessageTemplate(t))
+		}
+	}
+	return templates, nil
+}
+
+func (mmtr *MemoryMessageTemplateRepository) GetMessageTemplate(ctx context.Context, db Queryer, id entity.MessageTemplateID) (*entity.MessageTemplate, error) {
+	mmtr.Data.mu.RLock()
+	defer mmtr.Data.mu.RUnlock()
+	if t := mmtr.find(id); t != nil && !isDeleted(t.DeletedAt) {
+		return copyMessageTemplate(t), nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (mmtr *MemoryMessageTemplateRepository) AddMessageTemplate(ctx context.Context, db Execer, param *entity.MessageTemplate) error {
+	param.CreatedAt = mmtr.Clocker.Now()
+	mmtr.Data.mu.Lock()
+	defer mmtr.Data.mu.Unlock()
+	param.ID = entity.MessageTemplateID(mmtr.Data.nextID("message_templates"))
+	stored := *param
+	mmtr.Data.templates = append(mmtr.Data.templates, &stored)
+	return nil
+}
+
+func (mmtr *MemoryMessageTemplateRepository) EditMessageTemplate(ctx context.Context, db Execer, param *entity.MessageTemplate) error {
+	param.UpdatedAt = mmtr.Clocker.Now()
+	mmtr.Data.mu.Lock()
+	defer mmtr.Data.mu.Unlock()
+	if t := mmtr.find(param.ID); t != nil {
+		t.Name = param.Name
+		t.Content = param.Content
+		t.UpdatedAt = param.UpdatedAt
+	}
+	return nil
+}
+
+func (mmtr *MemoryMessageTemplateRepository) DeleteMessageTemplate(ctx context.Context, db Execer, id entity.MessageTemplateID) error {
+	mmtr.Data.mu.Lock()
+	defer mmtr.Data.mu.Unlock()
+	if t := mmtr.find(id); t != nil {
+		t.DeletedAt = mmtr.Clocker.Now()
+	}
+	return nil
+}
+
+func (mmtr *MemoryMessageTemplateRepository) find(id entity.MessageTemplateID) *entity.MessageTemplate {
+	for _, t := range mmtr.Data.templates {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func copyMessageTemplate(t *entity.MessageTemplate) *entity.MessageTemplate {
+	return &entity.MessageTemplate{
+		ID:            t.ID,
+		CompanyUserID: t.CompanyUserID,
+		Name:          t.Name,
+		Content:       t.Content,
+	}
+}
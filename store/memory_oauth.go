@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+// MemoryOAuthRepository は OAuthRepository のインメモリ実装。認証情報は company と student で別々に持つ
+type MemoryOAuthRepository struct {
+	Data    *MemoryData
+	Clocker clock.Clocker
+}
+
+func NewMemoryOAuthRepository(data *MemoryData, clocker clock.Clocker) *MemoryOAuthRepository {
+	return &MemoryOAuthRepository{
+		Data:    data,
+		Clocker: clocker,
+	}
+}
+
+func (mor *MemoryOAuthRepository) GetAPIKey(ctx context.Context, db Queryer) (string, error) {
+	mor.Data.mu.RLock()
+	defer mor.Data.mu.RUnlock()
+	apiKey, ok := mor.Data.apiKeys[memoryDBName(db)]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	return apiKey, nil
+}
+
+func (mor *MemoryOAuthRepository) GetClientID(ctx context.Context, db Queryer, userID int64) (string, error) {
+	c, err := mor.findByUserID(db, userID)
+	if err != nil {
+		return "", err
+	}
+	return c.ClientID, nil
+}
+
+func (mor *MemoryOAuthRepository) GetClientSecret(ctx context.Context, db Queryer, userID int64) (string, error) {
+	c, err := mor.findByUserID(db, userID)
+	if err != nil {
+		return "", err
+	}
+	return c.ClientSecret, nil
+}
+
+func (mor *MemoryOAuthRepository) GetAccessToken(ctx context.Context, db Queryer, userID int64) (string, *sql.NullTime, error) {
+	c, err := mor.findByUserID(db, userID)
+	if err != nil {
+		return "", &sql.NullTime{}, err
+	}
+	return c.AccessToken, c.ExpiresAt, nil
+}
+
+func (mor *MemoryOAuthRepository) GetRefreshToken(ctx context.Context, db Queryer, userID int64) (string, error) {
+	c, err := mor.findByUserID(db, userID)
+	if err != nil {
+		return "", err
+	}
+	return c.RefreshToken, nil
+}
+
+func (mor *MemoryOAuthRepository) SearchByClientID(ctx context.Context, db Queryer, clientID string) (bool, error) {
+	return mor.exists(db, func(c *entity.MessageAPICredential) bool { return c.ClientID == clientID }), nil
+}
+
+func (mor *MemoryOAuthRepository) SearchByClientSecret(ctx context.Context, db Queryer, clientSecret string) (bool, error) {
+	return mor.exists(db, func(c *entity.MessageAPICredential) bool { return c.ClientSecret == clientSecret }), nil
+}
+
+func (mor *MemoryOAuthRepository) SearchByAccessToken(ctx context.Context, db Queryer, accessToken string) (bool, error) {
+	return mor.exists(db, func(c *entity.MessageAPICredential) bool { return accessToken != "" && c.AccessToken == accessToken }), nil
+}
+
+func (mor *MemoryOAuthRepository) SearchByRefreshToken(ctx context.Context, db Queryer, refreshToken string) (bool, error) {
+	return mor.exists(db, func(c *entity.MessageAPICredential) bool { return refreshToken != "" && c.RefreshToken == refreshToken }), nil
+}
+
+func (mor *MemoryOAuthRepository) SaveClientIDSecret(ctx context.Context, db Execer, param *entity.MessageAPICredential) error {
+	param.CreatedAt = mor.Clocker.Now()
+	mor.Data.mu.Lock()
+	defer mor.Data.mu.Unlock()
+	targetDB := memoryDBName(db)
+	param.ID = entity.MessageAPICredentialID(mor.Data.nextID(targetDB + ".message_api_credentials"))
+	mor.Data.credentials[targetDB] = append(mor.Data.credentials[targetDB], &entity.MessageAPICredential{
+		ID:           param.ID,
+		UserID:       param.UserID,
+		ClientID:     param.ClientID,
+		ClientSecret: param.ClientSecret,
+		CreatedAt:    param.CreatedAt,
+	})
+	return nil
+}
+
+func (mor *MemoryOAuthRepository) SaveToken(ctx context.Context, db Execer, param *entity.MessageAPICredential) error {
+	param.UpdatedAt = mor.Clocker.Now()
+	mor.Data.mu.Lock()
+	defer mor.Data.mu.Unlock()
+	for _, c := range mor.Data.credentials[memoryDBName(db)] {
+		if c.UserID == param.UserID {
+			c.AccessToken = param.AccessToken
+			c.RefreshToken = param.RefreshToken
+			c.ExpiresAt = param.ExpiresAt
+			c.UpdatedAt = param.UpdatedAt
+		}
+	}
+	return nil
+}
+
+// findByUserID は未削除の認証情報の複製を返す
+func (mor *MemoryOAuthRepository) findByUserID(db Queryer, userID int64) (*entity.MessageAPICredential, error) {
+	mor.Data.mu.RLock()
+	defer mor.Data.mu.RUnlock()
+	for _, c := range mor.Data.credentials[memoryDBName(db)] {
+		if c.UserID == userID && !isDeleted(c.DeletedAt) {
+			found := *c
+			return &found, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (mor *MemoryOAuthRepository) exists(db Queryer, match func(c *entity.MessageAPICredential) bool) bool {
+	mor.Data.mu.RLock()
+	defer mor.Data.mu.RUnlock()
+	for _, c := range mor.Data.credentials[memoryDBName(db)] {
+		if !isDeleted(c.DeletedAt) && match(c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"slices"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+// MemoryReactionRepository は ReactionRepository のインメモリ実装
+type MemoryReactionRepository struct {
+	Data    *MemoryData
+	Clocker clock.Clocker
+}
+
+func NewMemoryReactionRepository(data *MemoryData, clocker clock.Clocker) *MemoryReactionRepository {
+	return &MemoryReactionRepository{
+		Data:    data,
+		Clocker: clocker,
+	}
+}
+
+func (mrr *MemoryReactionRepository) GetReactionsByMessageIDs(ctx context.Context, db Queryer, messageIDs []entity.MessageID) (entity.Reactions, error) {
+	mrr.Data.mu.RLock()
+	defer mrr.Data.mu.RUnlock()
+	var reactions entity.Reactions
+	for _, r := range mrr.Data.reactions {
+		if slices.Contains(messageIDs, r.MessageID) {
+			reactions = append(reactions, &entity.Reaction{
+				ID:            r.ID,
+				MessageID:     r.MessageID,
+				IsFromCompany: r.IsFromCompany,
+				IsFromStudent: r.IsFromStudent,
+				Reaction:      r.Reaction,
+			})
+		}
+	}
+	return reactions, nil
+}
+
+func (mrr *MemoryReactionRepository) AddReaction(ctx context.Context, db Execer, param *entity.Reaction) error {
+	param.CreatedAt = mrr.Clocker.Now()
+	mrr.Data.mu.Lock()
+	defer mrr.Data.mu.Unlock()
+	if slices.ContainsFunc(mrr.Data.reactions, func(r *entity.Reaction) bool {
+		return sameReaction(r, param)
+	}) {
+		return nil
+	}
+	param.ID = entity.ReactionID(mrr.Data.nextID("message_reactions"))
+	stored := *param
+	mrr.Data.reactions = append(mrr.Data.reactions, &stored)
+	return nil
+}
+
+func (mrr *MemoryReactionRepository) DeleteReaction(ctx context.Context, db Execer, param *entity.Reaction) error {
+	mrr.Data.mu.Lock()
+	defer mrr.Data.mu.Unlock()
+	mrr.Data.reactions = slices.DeleteFunc(mrr.Data.reactions, func(r *entity.Reaction) bool {
+		return sameReaction(r, param)
+	})
+	return nil
+}
+
+func sameReaction(a, b *entity.Reaction) bool {
+	return a.MessageID == b.MessageID && a.IsFromCompany == b.IsFromCompany && a.IsFromStudent == b.IsFromStudent && a.Reaction == b.Reaction
+}
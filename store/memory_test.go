@@ -0,0 +1,172 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/credential"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func newMemoryDBHandlers(t *testing.T) map[string]*sqlx.DB {
+	t.Helper()
+	dbHandlers := make(map[string]*sqlx.DB, 3)
+	for _, v := range []string{"company", "student", "common"} {
+		db := newMemoryDB(v)
+		t.Cleanup(func() { _ = db.Close() })
+		dbHandlers[v] = db
+	}
+	return dbHandlers
+}
+
+func TestMemoryData_LoadSeed(t *testing.T) {
+	data := NewMemoryData()
+	seed := `{
+		"api_keys": {"company": "company-key"},
+		"message_api_credentials": {"student": [{"user_id": 2, "client_id": "cid", "client_secret": "secret", "access_token": "at", "refresh_token": "rt", "expires_at": "2025-01-01T09:15:00+09:00"}]},
+		"message_threads": [{"id": 5, "company_user_id": 1, "student_user_id": 2}, {"company_user_id": 3, "student_user_id": 4}]
+	}`
+	require.NoError(t, data.LoadSeed(strings.NewReader(seed), clock.FixedClocker{}))
+	dbHandlers := newMemoryDBHandlers(t)
+	ctx := context.Background()
+
+	oAuthRepo := NewMemoryOAuthRepository(data, clock.FixedClocker{})
+	apiKey, err := oAuthRepo.GetAPIKey(ctx, dbHandlers["company"])
+	assert.NoError(t, err)
+	assert.Equal(t, credential.HashAPIKey("company-key"), apiKey)
+	_, err = oAuthRepo.GetAPIKey(ctx, dbHandlers["student"])
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	accessToken, expiresAt, err := oAuthRepo.GetAccessToken(ctx, dbHandlers["student"], 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "at", accessToken)
+	assert.True(t, expiresAt.Time.Equal(time.Date(2025, 1, 1, 0, 15, 0, 0, time.UTC)))
+
+	messageRepo := NewMemoryMessageRepository(data, clock.FixedClocker{})
+	threads, err := messageRepo.GetThreadsByIDs(ctx, dbHandlers["common"], []entity.MessageThreadID{5, 6})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.MessageThread{
+		{ID: 5, CompanyUserID: 1, StudentUserID: 2},
+		{ID: 6, CompanyUserID: 3, StudentUserID: 4},
+	}, threads)
+
+	assert.Error(t, data.LoadSeed(strings.NewReader(`{"api_keys": {"common": "key"}}`), clock.FixedClocker{}))
+}
+
+func TestMemoryOAuthRepository_SeparatesDatabases(t *testing.T) {
+	data := NewMemoryData()
+	dbHandlers := newMemoryDBHandlers(t)
+	ctx := context.Background()
+	repo := NewMemoryOAuthRepository(data, clock.FixedClocker{})
+
+	err := repo.SaveClientIDSecret(ctx, dbHandlers["company"], &entity.MessageAPICredential{UserID: 1, ClientID: "company-client", ClientSecret: "company-secret"})
+	require.NoError(t, err)
+	err = repo.SaveToken(ctx, dbHandlers["company"], &entity.MessageAPICredential{UserID: 1, AccessToken: "at", RefreshToken: "rt", ExpiresAt: clock.FixedClocker{}.Now()})
+	require.NoError(t, err)
+
+	clientID, err := repo.GetClientID(ctx, dbHandlers["company"], 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "company-client", clientID)
+	_, err = repo.GetClientID(ctx, dbHandlers["student"], 1)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	exist, err := repo.SearchByAccessToken(ctx, dbHandlers["company"], "at")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	exist, err = repo.SearchByAccessToken(ctx, dbHandlers["student"], "at")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	// トークン未発行の認証情報が空文字で一致しないこと
+	exist, err = repo.SearchByRefreshToken(ctx, dbHandlers["company"], "")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func TestMemoryMessageRepository_Visibility(t *testing.T) {
+	data := NewMemoryData()
+	require.NoError(t, data.LoadSeed(strings.NewReader(`{"message_threads": [{"id": 1, "company_user_id": 10, "student_user_id": 20}]}`), clock.FixedClocker{}))
+	db := newMemoryDB("common")
+	t.Cleanup(func() { _ = db.Close() })
+	ctx := context.Background()
+	repo := NewMemoryMessageRepository(data, clock.FixedClocker{})
+	sentAt := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	messages := []*entity.Message{
+		{MessageThreadID: 1, IsFromCompany: 1, Content: "company sent", IsSent: 1, SentAt: sentAt.Add(time.Minute)},
+		{MessageThreadID: 1, IsFromCompany: 1, Content: "company draft", IsSent: 0, SentAt: sentAt},
+		{MessageThreadID: 1, IsFromStudent: 1, Content: "student draft", IsSent: 0, SentAt: sentAt},
+		{MessageThreadID: 1, IsFromStudent: 1, Content: "student deleted", IsSent: 1, SentAt: sentAt},
+	}
+	for _, m := range messages {
+		require.NoError(t, repo.AddMessage(ctx, db, m))
+	}
+	require.NoError(t, repo.DeleteMessage(ctx, db, messages[3].ID))
+	require.NoError(t, repo.EditMessage(ctx, db, &entity.Message{ID: messages[0].ID, Content: "company edited"}))
+
+	got, err := repo.GetAllMessagesForCompanyUser(ctx, db, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "company draft", got[0].Content)
+		assert.Equal(t, "company edited", got[1].Content)
+		assert.NotNil(t, got[1].UpdatedAt)
+	}
+	got, err = repo.GetAllMessagesForStudentUser(ctx, db, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "student draft", got[0].Content)
+		assert.Equal(t, "company edited", got[1].Content)
+	}
+
+	owner, err := repo.GetThreadStudentOwnerByVisibleMessageID(ctx, db, messages[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), owner)
+	_, err = repo.GetThreadStudentOwnerByVisibleMessageID(ctx, db, messages[1].ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = repo.GetSentMessageThreadID(ctx, db, messages[3].ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestMemoryMessageRepository_ConcurrentAdd(t *testing.T) {
+	data := NewMemoryData()
+	db := newMemoryDB("common")
+	t.Cleanup(func() { _ = db.Close() })
+	repo := NewMemoryMessageRepository(data, clock.FixedClocker{})
+	const n = 50
+	ids := make([]entity.MessageID, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m := &entity.Message{MessageThreadID: 1, IsFromCompany: 1, Content: "hello", IsSent: 1}
+			assert.NoError(t, repo.AddMessage(context.Background(), db, m))
+			ids[i] = m.ID
+		}(i)
+	}
+	wg.Wait()
+	seen := make(map[entity.MessageID]bool, n)
+	for _, id := range ids {
+		assert.False(t, seen[id], "duplicate id %d", id)
+		seen[id] = true
+	}
+	got, err := repo.GetAllMessagesForCompanyUser(context.Background(), db, 1)
+	assert.NoError(t, err)
+	assert.Len(t, got, n)
+}
+
+func TestMemoryDB_RejectsSQL(t *testing.T) {
+	db := newMemoryDB("common")
+	t.Cleanup(func() { _ = db.Close() })
+	assert.Equal(t, "common", memoryDBName(db))
+	_, err := db.ExecContext(context.Background(), "DELETE FROM messages;")
+	assert.ErrorIs(t, err, errMemoryNoSQL)
+	_, err = db.BeginTxx(context.Background(), nil)
+	assert.ErrorIs(t, err, errMemoryNoTx)
+}
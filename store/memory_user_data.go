@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+// MemoryUserDataRepository は UserDataRepository のインメモリ実装
+type MemoryUserDataRepository struct {
+	Data    *MemoryData
+	Clocker clock.Clocker
+}
+
+func NewMemoryUserDataRepository(data *MemoryData, clocker clock.Clocker) *MemoryUserDataRepository {
+	return &MemoryUserDataRepository{
+		Data:    data,
+		Clocker: clocker,
+	}
+}
+
+func (mudr *MemoryUserDataRepository) GetThreadsByUserID(ctx context.Context, db Queryer, appKind string, userID int64) ([]*entity.MessageThread, error) {
+	if appKind != "company" && appKind != "student" {
+		return nil, fmt.Errorf("invalid app kind: %s", appKind)
+	}
+	mudr.Data.mu.RLock()
+	defer mudr.Data.mu.RUnlock()
+	var threads []*entity.MessageThread
+	for _, t := range mudr.Data.threads {
+		if (appKind == "company" && t.CompanyUserID == userID) || (appKind == "student" && t.StudentUserID == userID) {
+			found := *t
+			threads = append(threads, &found)
+		}
+	}
+	return threads, nil
+}
+
+func (mudr *MemoryUserDataRepository) GetMessagesByThreadIDs(ctx context.Context, db Queryer, appKind string, threadIDs []entity.MessageThreadID) (entity.Messages, error) {
+	if appKind != "company" && appKind != "student" {
+		return nil, fmt.Errorf("invalid app kind: %s", appKind)
+	}
+	mudr.Data.mu.RLock()
+	defer mudr.Data.mu.RUnlock()
+	var messages entity.Messages
+	for _, m := range mudr.Data.messages {
+		if !slices.Contains(threadIDs, m.MessageThreadID) {
+			continue
+		}
+		own := (appKind == "company" && m.IsFromCompany == 1) || (appKind == "student" && m.IsFromStudent == 1)
+		if own || (m.IsSent == 1 && !isDeleted(m.DeletedAt)) {
+			found := *m
+			messages = append(messages, &found)
+		}
+	}
+	return messages, nil
+}
+
+func (mudr *MemoryUserDataRepository) GetAttachmentsByMessageIDs(ctx context.Context, db Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+	mudr.Data.mu.RLock()
+	defer mudr.Data.mu.RUnlock()
+	var attachments entity.Attachments
+	for _, a := range mudr.Data.attachments {
+		if slices.Contains(messageIDs, a.MessageID) {
+			found := *a
+			attachments = append(attachments, &found)
+		}
+	}
+	return attachments, nil
+}
+
+func (mudr *MemoryUserDataRepository) GetCredentialsByUserID(ctx context.Context, db Queryer, userID int64) ([]*entity.MessageAPICredential, error) {
+	mudr.Data.mu.RLock()
+	defer mudr.Data.mu.RUnlock()
+	var credentials []*entity.MessageAPICredential
+	for _, c := range mudr.Data.credentials[memoryDBName(db)] {
+		if c.UserID == userID {
+			credentials = append(credentials, &entity.MessageAPICredential{
+				ID:        c.ID,
+				UserID:    c.UserID,
+				ClientID:  c.ClientID,
+				ExpiresAt: c.ExpiresAt,
+				CreatedAt: c.CreatedAt,
+				UpdatedAt: c.UpdatedAt,
+				DeletedAt: c.DeletedAt,
+			})
+		}
+	}
+	return credentials, nil
+}
+
+func (mudr *MemoryUserDataRepository) AnonymizeMessages(ctx context.Context, db Execer, ids []entity.MessageID) error {
+	now := mudr.Clocker.Now()
+	mudr.Data.mu.Lock()
+	defer mudr.Data.mu.Unlock()
+	for _, m := range mudr.Data.messages {
+		if slices.Contains(ids, m.ID) {
+			m.Content = entity.ErasedMessageContent
+			m.UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+func (mudr *MemoryUserDataRepository) DeleteAttachments(ctx context.Context, db Execer, ids []entity.AttachmentID) error {
+	mudr.Data.mu.Lock()
+	defer mudr.Data.mu.Unlock()
+	mudr.Data.attachments = slices.DeleteFunc(mudr.Data.attachments, func(a *entity.Attachment) bool {
+		return slices.Contains(ids, a.ID)
+	})
+	return nil
+}
+
+func (mudr *MemoryUserDataRepository) DeleteCredentialsByUserID(ctx context.Context, db Execer, userID int64) error {
+	mudr.Data.mu.Lock()
+	defer mudr.Data.mu.Unlock()
+	targetDB := memoryDBName(db)
+	mudr.Data.credentials[targetDB] = slices.DeleteFunc(mudr.Data.credentials[targetDB], func(c *entity.MessageAPICredential) bool {
+		return c.UserID == userID
+	})
+	return nil
+}
@@ -3,6 +3,8 @@ package store
 import (
 	"context"
 
+	"github.com/jmoiron/sqlx"
+
 	"github.com/yuyacode/AppLiftMessageApi/clock"
 	"github.com/yuyacode/AppLiftMessageApi/entity"
 )
@@ -18,24 +20,59 @@ func NewMessageRepository(clocker clock.Clocker) *MessageRepository {
 }
 
 func (mr *MessageRepository) GetThreadCompanyOwner(ctx context.Context, db Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+	ctx, span := startSpan(ctx, db, "MessageRepository.GetThreadCompanyOwner")
+	defer span.End()
 	query := "SELECT company_user_id FROM message_threads WHERE id = ? AND deleted_at IS NULL;"
 	var companyUserID int64
 	if err := db.GetContext(ctx, &companyUserID, query, messageThreadID); err != nil {
-		return 0, err
+		return 0, recordError(span, err)
 	}
 	return companyUserID, nil
 }
 
 func (mr *MessageRepository) GetThreadStudentOwner(ctx context.Context, db Queryer, messageThreadID entity.MessageThreadID) (int64, error) {
+	ctx, span := startSpan(ctx, db, "MessageRepository.GetThreadStudentOwner")
+	defer span.End()
 	query := "SELECT student_user_id FROM message_threads WHERE id = ? AND deleted_at IS NULL;"
 	var studentUserID int64
 	if err := db.GetContext(ctx, &studentUserID, query, messageThreadID); err != nil {
-		return 0, err
+		return 0, recordError(span, err)
 	}
 	return studentUserID, nil
 }
 
+func (mr *MessageRepository) GetThreadsByIDs(ctx context.Context, db Queryer, ids []entity.MessageThreadID) ([]*entity.MessageThread, error) {
+	ctx, span := startSpan(ctx, db, "MessageRepository.GetThreadsByIDs")
+	defer span.End()
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	query, args, err := sqlx.In("SELECT id, company_user_id, student_user_id FROM message_threads WHERE id IN (?) AND deleted_at IS NULL;", ids)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	defer rows.Close()
+	var threads []*entity.MessageThread
+	for rows.Next() {
+		var mt entity.MessageThread
+		if err := rows.StructScan(&mt); err != nil {
+			return nil, recordError(span, err)
+		}
+		threads = append(threads, &mt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, recordError(span, err)
+	}
+	return threads, nil
+}
+
 func (mr *MessageRepository) GetThreadCompanyOwnerByMessageID(ctx context.Context, db Queryer, messageID entity.MessageID) (int64, error) {
+	ctx, span := startSpan(ctx, db, "MessageRepository.GetThreadCompanyOwnerByMessageID")
+	defer span.End()
 	query := `
 		SELECT company_user_id
 		FROM message_threads
@@ -45,12 +82,14 @@ func (mr *MessageRepository) GetThreadCompanyOwnerByMessageID(ctx context.Contex
 	`
 	var companyUserID int64
 	if err := db.GetContext(ctx, &companyUserID, query, messageID); err != nil {
-		return 0, err
+		return 0, recordError(span, err)
 	}
 	return companyUserID, nil
 }
 
 func (mr *MessageRepository) GetThreadStudentOwnerByMessageID(ctx context.Context, db Queryer, messageID entity.MessageID) (int64, error) {
+	ctx, span := startSpan(ctx, db, "MessageRepository.GetThreadStudentOwnerByMessageID")
+	defer span.End()
 	query := `
 		SELECT student_user_id
 		FROM message_threads
@@ -60,14 +99,52 @@ func (mr *MessageRepository) GetThreadStudentOwnerByMessageID(ctx context.Contex
 	`
 	var studentUserID int64
 	if err := db.GetContext(ctx, &studentUserID, query, messageID); err != nil {
-		return 0, err
+		return 0, recordError(span, err)
 	}
 	return studentUserID, nil
 }
 
-func (mr *MessageRepository) GetAllMessagesForCompanyUser(ctx context.Context, db Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
+func (mr *MessageRepository) GetThreadCompanyOwnerByVisibleMessageID(ctx context.Context, db Queryer, messageID entity.MessageID) (int64, error) {
+	ctx, span := startSpan(ctx, db, "MessageRepository.GetThreadCompanyOwnerByVisibleMessageID")
+	defer span.End()
+	query := `
+		SELECT company_user_id
+		FROM message_threads
+		INNER JOIN messages
+		ON message_threads.id = messages.message_thread_id
+		WHERE messages.id = ?
+		AND messages.deleted_at IS NULL
+		AND (messages.is_from_company = 1 OR messages.is_sent = 1);
+	`
+	var companyUserID int64
+	if err := db.GetContext(ctx, &companyUserID, query, messageID); err != nil {
+		return 0, recordError(span, err)
+	}
+	return companyUserID, nil
+}
+
+func (mr *MessageRepository) GetThreadStudentOwnerByVisibleMessageID(ctx context.Context, db Queryer, messageID entity.MessageID) (int64, error) {
+	ctx, span := startSpan(ctx, db, "MessageRepository.GetThreadStudentOwnerByVisibleMessageID")
+	defer span.End()
 	query := `
-        SELECT id, is_from_company, is_from_student, content, is_sent, sent_at
+		SELECT student_user_id
+		FROM message_threads
+		INNER JOIN messages
+		ON message_threads.id = messages.message_thread_id
+		WHERE messages.id = ?
+		AND messages.deleted_at IS NULL
+		AND (messages.is_from_student = 1 OR messages.is_sent = 1);
+	`
+	var studentUserID int64
+	if err := db.GetContext(ctx, &studentUserID, query, messageID); err != nil {
+		return 0, recordError(span, err)
+	}
+	return studentUserID, nil
+}
+
+// 企業ユーザーには自分の下書きと送信済みのメッセージ、学生ユーザーが送信したメッセージを表示する
+const companyThreadMessagesQuery = `
+        SELECT id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, updated_at
         FROM messages
         WHERE message_thread_id = ?
 		AND deleted_at IS NULL
@@ -79,28 +156,9 @@ func (mr *MessageRepository) GetAllMessagesForCompanyUser(ctx context.Context, d
 		)
 		ORDER BY sent_at ASC, id ASC;
     `
-	rows, err := db.QueryxContext(ctx, query, messageThreadID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var messages entity.Messages
-	for rows.Next() {
-		var m entity.Message
-		if err := rows.StructScan(&m); err != nil {
-			return nil, err
-		}
-		messages = append(messages, &m)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return messages, nil
-}
 
-func (mr *MessageRepository) GetAllMessagesForStudentUser(ctx context.Context, db Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
-	query := `
-        SELECT id, is_from_company, is_from_student, content, is_sent, sent_at
+const studentThreadMessagesQuery = `
+        SELECT id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, updated_at
         FROM messages
         WHERE message_thread_id = ?
 		AND deleted_at IS NULL
@@ -112,55 +170,150 @@ func (mr *MessageRepository) GetAllMessagesForStudentUser(ctx context.Context, d
 		)
 		ORDER BY sent_at ASC, id ASC;
     `
+
+func (mr *MessageRepository) GetAllMessagesForCompanyUser(ctx context.Context, db Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
+	ctx, span := startSpan(ctx, db, "MessageRepository.GetAllMessagesForCompanyUser")
+	defer span.End()
+	var messages entity.Messages
+	err := eachThreadMessage(ctx, db, companyThreadMessagesQuery, messageThreadID, func(m *entity.Message) error {
+		messages = append(messages, m)
+		return nil
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return messages, nil
+}
+
+func (mr *MessageRepository) GetAllMessagesForStudentUser(ctx context.Context, db Queryer, messageThreadID entity.MessageThreadID) (entity.Messages, error) {
+	ctx, span := startSpan(ctx, db, "MessageRepository.GetAllMessagesForStudentUser")
+	defer span.End()
+	var messages entity.Messages
+	err := eachThreadMessage(ctx, db, studentThreadMessagesQuery, messageThreadID, func(m *entity.Message) error {
+		messages = append(messages, m)
+		return nil
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return messages, nil
+}
+
+// EachMessageForCompanyUser は GetAllMessagesForCompanyUser と同じメッセージを、すべてをメモリに載せずに1件ずつ fn に渡す
+func (mr *MessageRepository) EachMessageForCompanyUser(ctx context.Context, db Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+	ctx, span := startSpan(ctx, db, "MessageRepository.EachMessageForCompanyUser")
+	defer span.End()
+	if err := eachThreadMessage(ctx, db, companyThreadMessagesQuery, messageThreadID, fn); err != nil {
+		return recordError(span, err)
+	}
+	return nil
+}
+
+// EachMessageForStudentUser は GetAllMessagesForStudentUser と同じメッセージを、すべてをメモリに載せずに1件ずつ fn に渡す
+func (mr *MessageRepository) EachMessageForStudentUser(ctx context.Context, db Queryer, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
+	ctx, span := startSpan(ctx, db, "MessageRepository.EachMessageForStudentUser")
+	defer span.End()
+	if err := eachThreadMessage(ctx, db, studentThreadMessagesQuery, messageThreadID, fn); err != nil {
+		return recordError(span, err)
+	}
+	return nil
+}
+
+func eachThreadMessage(ctx context.Context, db Queryer, query string, messageThreadID entity.MessageThreadID, fn func(*entity.Message) error) error {
 	rows, err := db.QueryxContext(ctx, query, messageThreadID)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var m entity.Message
+		if err := rows.StructScan(&m); err != nil {
+			return err
+		}
+		if err := fn(&m); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetSentMessageThreadID は送信済みで未削除のメッセージが属するスレッドIDを返す（返信先の検証に使う）
+func (mr *MessageRepository) GetSentMessageThreadID(ctx context.Context, db Queryer, id entity.MessageID) (entity.MessageThreadID, error) {
+	ctx, span := startSpan(ctx, db, "MessageRepository.GetSentMessageThreadID")
+	defer span.End()
+	query := "SELECT message_thread_id FROM messages WHERE id = ? AND is_sent = 1 AND deleted_at IS NULL;"
+	var messageThreadID entity.MessageThreadID
+	if err := db.GetContext(ctx, &messageThreadID, query, id); err != nil {
+		return 0, recordError(span, err)
+	}
+	return messageThreadID, nil
+}
+
+func (mr *MessageRepository) GetMessagesByIDs(ctx context.Context, db Queryer, ids []entity.MessageID) (entity.Messages, error) {
+	ctx, span := startSpan(ctx, db, "MessageRepository.GetMessagesByIDs")
+	defer span.End()
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	query, args, err := sqlx.In("SELECT id, is_from_company, is_from_student, content FROM messages WHERE id IN (?) AND deleted_at IS NULL;", ids)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, recordError(span, err)
 	}
 	defer rows.Close()
 	var messages entity.Messages
 	for rows.Next() {
 		var m entity.Message
 		if err := rows.StructScan(&m); err != nil {
-			return nil, err
+			return nil, recordError(span, err)
 		}
 		messages = append(messages, &m)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, recordError(span, err)
 	}
 	return messages, nil
 }
 
 func (mr *MessageRepository) AddMessage(ctx context.Context, db Execer, param *entity.Message) error {
+	ctx, span := startSpan(ctx, db, "MessageRepository.AddMessage")
+	defer span.End()
 	param.CreatedAt = mr.Clocker.Now()
-	query := "INSERT INTO messages (message_thread_id, is_from_company, is_from_student, content, is_sent, sent_at, created_at) VALUES (:message_thread_id, :is_from_company, :is_from_student, :content, :is_sent, :sent_at, :created_at);"
+	query := "INSERT INTO messages (message_thread_id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, created_at) VALUES (:message_thread_id, :is_from_company, :is_from_student, :content, :is_sent, :sent_at, :reply_to_message_id, :created_at);"
 	result, err := db.NamedExecContext(ctx, query, param)
 	if err != nil {
-		return err
+		return recordError(span, err)
 	}
 	id, err := result.LastInsertId()
 	if err != nil {
-		return err
+		return recordError(span, err)
 	}
 	param.ID = entity.MessageID(id)
 	return nil
 }
 
 func (mr *MessageRepository) EditMessage(ctx context.Context, db Execer, param *entity.Message) error {
+	ctx, span := startSpan(ctx, db, "MessageRepository.EditMessage")
+	defer span.End()
 	param.UpdatedAt = mr.Clocker.Now()
 	query := "UPDATE messages SET content = :content, updated_at = :updated_at WHERE id = :id;"
 	_, err := db.NamedExecContext(ctx, query, param)
 	if err != nil {
-		return err
+		return recordError(span, err)
 	}
 	return nil
 }
 
 func (mr *MessageRepository) DeleteMessage(ctx context.Context, db Execer, id entity.MessageID) error {
+	ctx, span := startSpan(ctx, db, "MessageRepository.DeleteMessage")
+	defer span.End()
 	query := "UPDATE messages SET deleted_at = ? WHERE id = ?;"
 	_, err := db.ExecContext(ctx, query, mr.Clocker.Now(), id)
 	if err != nil {
-		return err
+		return recordError(span, err)
 	}
 	return nil
 }
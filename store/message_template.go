@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type MessageTemplateRepository struct {
+	Clocker clock.Clocker
+}
+
+func NewMessageTemplateRepository(clocker clock.Clocker) *MessageTemplateRepository {
+	return &MessageTemplateRepository{
+		Clocker: clocker,
+	}
+}
+
+func (mtr *MessageTemplateRepository) GetAllMessageTemplates(ctx context.Context, db Queryer, companyUserID int64) (entity.MessageTemplates, error) {
+	query := "SELECT id, company_user_id, name, content FROM message_templates WHERE company_user_id = ? AND deleted_at IS NULL ORDER BY id ASC;"
+	rows, err := db.QueryxContext(ctx, query, companyUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var templates entity.MessageTemplates
+	for rows.Next() {
+		var t entity.MessageTemplate
+		if err := rows.StructScan(&t); err != nil {
+			return nil, err
+		}
+		templates = append(templates, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (mtr *MessageTemplateRepository) GetMessageTemplate(ctx context.Context, db Queryer, id entity.MessageTemplateID) (*entity.MessageTemplate, error) {
+	query := "SELECT id, company_user_id, name, content FROM message_templates WHERE id = ? AND deleted_at IS NULL;"
+	var t entity.MessageTemplate
+	if err := db.GetContext(ctx, &t, query, id); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (mtr *MessageTemplateRepository) AddMessageTemplate(ctx context.Context, db Execer, param *entity.MessageTemplate) error {
+	param.CreatedAt = mtr.Clocker.Now()
+	query := "INSERT INTO message_templates (company_user_id, name, content, created_at) VALUES (:company_user_id, :name, :content, :created_at);"
+	result, err := db.NamedExecContext(ctx, query, param)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	param.ID = entity.MessageTemplateID(id)
+	return nil
+}
+
+func (mtr *MessageTemplateRepository) EditMessageTemplate(ctx context.Context, db Execer, param *entity.MessageTemplate) error {
+	param.UpdatedAt = mtr.Clocker.Now()
+	query := "UPDATE message_templates SET name = :name, content = :content, updated_at = :updated_at WHERE id = :id;"
+	_, err := db.NamedExecContext(ctx, query, param)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (mtr *MessageTemplateRepository) DeleteMessageTemplate(ctx context.Context, db Execer, id entity.MessageTemplateID) error {
+	query := "UPDATE message_templates SET deleted_at = ? WHERE id = ?;"
+	_, err := db.ExecContext(ctx, query, mtr.Clocker.Now(), id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestMessageTemplateRepository_GetAllMessageTemplates(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	mtr := NewMessageTemplateRepository(clock.FixedClocker{})
+	query := `^SELECT id, company_user_id, name, content FROM message_templates WHERE company_user_id = \? AND deleted_at IS NULL ORDER BY id ASC;$`
+	tests := map[string]struct {
+		companyUserID int64
+		mockSetup     func()
+		wantErr       bool
+		wantTemplates entity.MessageTemplates
+	}{
+		"DB error": {
+			companyUserID: 1,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"No rows": {
+			companyUserID: 2,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "company_user_id", "name", "content"}))
+			},
+			wantErr:       false,
+			wantTemplates: nil,
+		},
+		"Multiple rows": {
+			companyUserID: 3,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(3)).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "company_user_id", "name", "content"}).
+							AddRow(int64(1), int64(3), "面接案内", "{{student_name}}様 面接日は{{interview_date}}です").
+							AddRow(int64(2), int64(3), "お礼", "ご応募ありがとうございます"),
+					)
+			},
+			wantErr: false,
+			wantTemplates: entity.MessageTemplates{
+				&entity.MessageTemplate{ID: 1, CompanyUserID: 3, Name: "面接案内", Content: "{{student_name}}様 面接日は{{interview_date}}です"},
+				&entity.MessageTemplate{ID: 2, CompanyUserID: 3, Name: "お礼", Content: "ご応募ありがとうございます"},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := mtr.GetAllMessageTemplates(context.Background(), sqlxDB, tc.companyUserID)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantTemplates, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMessageTemplateRepository_GetMessageTemplate(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	mtr := NewMessageTemplateRepository(clock.FixedClocker{})
+	query := `^SELECT id, company_user_id, name, content FROM message_templates WHERE id = \? AND deleted_at IS NULL;$`
+	tests := map[string]struct {
+		id           entity.MessageTemplateID
+		mockSetup    func()
+		wantErr      bool
+		wantTemplate *entity.MessageTemplate
+	}{
+		"DB error": {
+			id: 1,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"No rows": {
+			id: 2,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(2)).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+		"Success": {
+			id: 3,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(3)).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "company_user_id", "name", "content"}).
+							AddRow(int64(3), int64(10), "面接案内", "{{student_name}}様"),
+					)
+			},
+			wantErr:      false,
+			wantTemplate: &entity.MessageTemplate{ID: 3, CompanyUserID: 10, Name: "面接案内", Content: "{{student_name}}様"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := mtr.GetMessageTemplate(context.Background(), sqlxDB, tc.id)
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantTemplate, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMessageTemplateRepository_AddMessageTemplate(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	mtr := NewMessageTemplateRepository(clock.FixedClocker{})
+	query := `^INSERT INTO message_templates \(company_user_id, name, content, created_at\) VALUES \(\?, \?, \?, \?\);$`
+	tests := map[string]struct {
+		input     *entity.MessageTemplate
+		mockSetup func(*entity.MessageTemplate)
+		wantErr   bool
+		wantID    entity.MessageTemplateID
+	}{
+		"DB error on Exec": {
+			input: &entity.MessageTemplate{CompanyUserID: 1, Name: "面接案内", Content: "{{student_name}}様"},
+			mockSetup: func(param *entity.MessageTemplate) {
+				mock.ExpectExec(query).
+					WithArgs(param.CompanyUserID, param.Name, param.Content, clock.FixedClocker{}.Now()).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"LastInsertId error": {
+			input: &entity.MessageTemplate{CompanyUserID: 1, Name: "面接案内", Content: "{{student_name}}様"},
+			mockSetup: func(param *entity.MessageTemplate) {
+				mock.ExpectExec(query).
+					WithArgs(param.CompanyUserID, param.Name, param.Content, clock.FixedClocker{}.Now()).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("cannot get lastInsertID")))
+			},
+			wantErr: true,
+		},
+		"Success": {
+			input: &entity.MessageTemplate{CompanyUserID: 1, Name: "面接案内", Content: "{{student_name}}様"},
+			mockSetup: func(param *entity.MessageTemplate) {
+				mock.ExpectExec(query).
+					WithArgs(param.CompanyUserID, param.Name, param.Content, clock.FixedClocker{}.Now()).
+					WillReturnResult(sqlmock.NewResult(21, 1))
+			},
+			wantErr: false,
+			wantID:  21,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup(tc.input)
+			err := mtr.AddMessageTemplate(context.Background(), sqlxDB, tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantID, tc.input.ID)
+				assert.Equal(t, clock.FixedClocker{}.Now(), tc.input.CreatedAt)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMessageTemplateRepository_EditMessageTemplate(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	mtr := NewMessageTemplateRepository(clock.FixedClocker{})
+	query := `^UPDATE message_templates SET name = \?, content = \?, updated_at = \? WHERE id = \?;$`
+	tests := map[string]struct {
+		input     *entity.MessageTemplate
+		mockSetup func(*entity.MessageTemplate)
+		wantErr   bool
+	}{
+		"DB error on Exec": {
+			input: &entity.MessageTemplate{ID: 1, Name: "面接案内", Content: "{{student_name}}様"},
+			mockSetup: func(param *entity.MessageTemplate) {
+				mock.ExpectExec(query).
+					WithArgs(param.Name, param.Content, clock.FixedClocker{}.Now(), param.ID).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Success": {
+			input: &entity.MessageTemplate{ID: 1, Name: "面接案内", Content: "{{student_name}}様"},
+			mockSetup: func(param *entity.MessageTemplate) {
+				mock.ExpectExec(query).
+					WithArgs(param.Name, param.Content, clock.FixedClocker{}.Now(), param.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup(tc.input)
+			err := mtr.EditMessageTemplate(context.Background(), sqlxDB, tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, clock.FixedClocker{}.Now(), tc.input.UpdatedAt)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMessageTemplateRepository_DeleteMessageTemplate(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	mtr := NewMessageTemplateRepository(clock.FixedClocker{})
+	query := `^UPDATE message_templates SET deleted_at = \? WHERE id = \?;$`
+	tests := map[string]struct {
+		id        entity.MessageTemplateID
+		mockSetup func(entity.MessageTemplateID)
+		wantErr   bool
+	}{
+		"DB error": {
+			id: 1,
+			mockSetup: func(id entity.MessageTemplateID) {
+				mock.ExpectExec(query).
+					WithArgs(clock.FixedClocker{}.Now(), id).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Success": {
+			id: 2,
+			mockSetup: func(id entity.MessageTemplateID) {
+				mock.ExpectExec(query).
+					WithArgs(clock.FixedClocker{}.Now(), id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup(tc.id)
+			err := mtr.DeleteMessageTemplate(context.Background(), sqlxDB, tc.id)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -105,6 +105,64 @@ func TestMessageRepository_GetThreadStudentOwner(t *testing.T) {
 	}
 }
 
+func TestMessageRepository_GetThreadsByIDs(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	mr := NewMessageRepository(clock.FixedClocker{})
+	query := `^SELECT id, company_user_id, student_user_id FROM message_threads WHERE id IN \(\?, \?\) AND deleted_at IS NULL;$`
+	tests := map[string]struct {
+		ids         []entity.MessageThreadID
+		mockSetup   func()
+		wantErr     bool
+		wantThreads []*entity.MessageThread
+	}{
+		"Empty IDs": {
+			ids:         nil,
+			mockSetup:   func() {},
+			wantErr:     false,
+			wantThreads: nil,
+		},
+		"DB error": {
+			ids: []entity.MessageThreadID{1, 2},
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1), int64(2)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Multiple rows": {
+			ids: []entity.MessageThreadID{1, 2},
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1), int64(2)).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "company_user_id", "student_user_id"}).
+							AddRow(int64(1), int64(10), int64(20)).
+							AddRow(int64(2), int64(10), int64(21)),
+					)
+			},
+			wantErr: false,
+			wantThreads: []*entity.MessageThread{
+				{ID: 1, CompanyUserID: 10, StudentUserID: 20},
+				{ID: 2, CompanyUserID: 10, StudentUserID: 21},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := mr.GetThreadsByIDs(context.Background(), sqlxDB, tc.ids)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantThreads, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestMessageRepository_GetThreadCompanyOwnerByMessageID(t *testing.T) {
 	sqlxDB, mock := newMockDB(t)
 	mr := NewMessageRepository(clock.FixedClocker{})
@@ -199,6 +257,122 @@ func TestMessageRepository_GetThreadStudentOwnerByMessageID(t *testing.T) {
 	}
 }
 
+func TestMessageRepository_GetThreadCompanyOwnerByVisibleMessageID(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	mr := NewMessageRepository(clock.FixedClocker{})
+	query := `^SELECT company_user_id\s+FROM message_threads\s+INNER JOIN messages\s+ON message_threads.id = messages.message_thread_id\s+WHERE messages.id = \?\s+AND messages.deleted_at IS NULL\s+AND \(messages.is_from_company = 1 OR messages.is_sent = 1\);$`
+	tests := map[string]struct {
+		messageID  entity.MessageID
+		mockSetup  func()
+		wantErr    bool
+		wantResult int64
+	}{
+		"DB error": {
+			messageID: 1,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1)).
+					WillReturnError(assertAnError())
+			},
+			wantErr:    true,
+			wantResult: 0,
+		},
+		"No rows": {
+			messageID: 2,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(2)).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr:    true,
+			wantResult: 0,
+		},
+		"Success": {
+			messageID: 3,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(3)).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"company_user_id"}).AddRow(int64(9999)),
+					)
+			},
+			wantErr:    false,
+			wantResult: 9999,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := mr.GetThreadCompanyOwnerByVisibleMessageID(context.Background(), sqlxDB, tc.messageID)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantResult, got)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMessageRepository_GetThreadStudentOwnerByVisibleMessageID(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	mr := NewMessageRepository(clock.FixedClocker{})
+	query := `^SELECT student_user_id\s+FROM message_threads\s+INNER JOIN messages\s+ON message_threads.id = messages.message_thread_id\s+WHERE messages.id = \?\s+AND messages.deleted_at IS NULL\s+AND \(messages.is_from_student = 1 OR messages.is_sent = 1\);$`
+	tests := map[string]struct {
+		messageID  entity.MessageID
+		mockSetup  func()
+		wantErr    bool
+		wantResult int64
+	}{
+		"DB error": {
+			messageID: 1,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1)).
+					WillReturnError(assertAnError())
+			},
+			wantErr:    true,
+			wantResult: 0,
+		},
+		"No rows": {
+			messageID: 2,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(2)).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr:    true,
+			wantResult: 0,
+		},
+		"Success": {
+			messageID: 3,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(3)).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"student_user_id"}).AddRow(int64(9999)),
+					)
+			},
+			wantErr:    false,
+			wantResult: 9999,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := mr.GetThreadStudentOwnerByVisibleMessageID(context.Background(), sqlxDB, tc.messageID)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantResult, got)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestMessageRepository_GetAllMessagesForCompanyUser(t *testing.T) {
 	sqlxDB, mock := newMockDB(t)
 	mr := NewMessageRepository(clock.FixedClocker{})
@@ -211,7 +385,7 @@ func TestMessageRepository_GetAllMessagesForCompanyUser(t *testing.T) {
 		"DB error": {
 			messageThreadID: 1,
 			mockSetup: func() {
-				mock.ExpectQuery(`^SELECT id, is_from_company, is_from_student, content, is_sent, sent_at\s+FROM messages\s+WHERE message_thread_id = \?\s+AND deleted_at IS NULL\s+AND\s+\(\s*\(is_from_company = 1 AND is_sent = 0\)\s+OR \(is_from_company = 1 AND is_sent = 1\)\s+OR \(is_from_student = 1 AND is_sent = 1\)\s*\)\s+ORDER BY sent_at ASC, id ASC;$`).
+				mock.ExpectQuery(`^SELECT id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, updated_at\s+FROM messages\s+WHERE message_thread_id = \?\s+AND deleted_at IS NULL\s+AND\s+\(\s*\(is_from_company = 1 AND is_sent = 0\)\s+OR \(is_from_company = 1 AND is_sent = 1\)\s+OR \(is_from_student = 1 AND is_sent = 1\)\s*\)\s+ORDER BY sent_at ASC, id ASC;$`).
 					WithArgs(int64(1)).
 					WillReturnError(assertAnError())
 			},
@@ -221,11 +395,11 @@ func TestMessageRepository_GetAllMessagesForCompanyUser(t *testing.T) {
 		"No rows": {
 			messageThreadID: 2,
 			mockSetup: func() {
-				mock.ExpectQuery(`^SELECT id, is_from_company, is_from_student, content, is_sent, sent_at\s+FROM messages\s+WHERE message_thread_id = \?\s+AND deleted_at IS NULL\s+AND\s+\(\s*\(is_from_company = 1 AND is_sent = 0\)\s+OR \(is_from_company = 1 AND is_sent = 1\)\s+OR \(is_from_student = 1 AND is_sent = 1\)\s*\)\s+ORDER BY sent_at ASC, id ASC;$`).
+				mock.ExpectQuery(`^SELECT id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, updated_at\s+FROM messages\s+WHERE message_thread_id = \?\s+AND deleted_at IS NULL\s+AND\s+\(\s*\(is_from_company = 1 AND is_sent = 0\)\s+OR \(is_from_company = 1 AND is_sent = 1\)\s+OR \(is_from_student = 1 AND is_sent = 1\)\s*\)\s+ORDER BY sent_at ASC, id ASC;$`).
 					WithArgs(int64(2)).
 					WillReturnRows(
 						sqlmock.NewRows([]string{
-							"id", "is_from_company", "is_from_student", "content", "is_sent", "sent_at",
+							"id", "is_from_company", "is_from_student", "content", "is_sent", "sent_at", "reply_to_message_id", "updated_at",
 						}),
 					)
 			},
@@ -236,12 +410,12 @@ func TestMessageRepository_GetAllMessagesForCompanyUser(t *testing.T) {
 			messageThreadID: 3,
 			mockSetup: func() {
 				rows := sqlmock.NewRows([]string{
-					"id", "is_from_company", "is_from_student", "content", "is_sent", "sent_at",
+					"id", "is_from_company", "is_from_student", "content", "is_sent", "sent_at", "reply_to_message_id", "updated_at",
 				}).
-					AddRow(int64(10), int8(1), int8(0), "Hello", int64(1), time.Date(2025, 1, 1, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60))).
-					AddRow(int64(11), int8(0), int8(1), "World", int64(0), time.Date(2025, 1, 1, 12, 5, 0, 0, time.FixedZone("JST", 9*60*60)))
+					AddRow(int64(10), int8(1), int8(0), "Hello", int64(1), time.Date(2025, 1, 1, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60)), nil, nil).
+					AddRow(int64(11), int8(0), int8(1), "World", int64(0), time.Date(2025, 1, 1, 12, 5, 0, 0, time.FixedZone("JST", 9*60*60)), int64(10), time.Date(2025, 1, 1, 12, 10, 0, 0, time.FixedZone("JST", 9*60*60)))
 
-				mock.ExpectQuery(`^SELECT id, is_from_company, is_from_student, content, is_sent, sent_at\s+FROM messages\s+WHERE message_thread_id = \?\s+AND deleted_at IS NULL\s+AND\s+\(\s*\(is_from_company = 1 AND is_sent = 0\)\s+OR \(is_from_company = 1 AND is_sent = 1\)\s+OR \(is_from_student = 1 AND is_sent = 1\)\s*\)\s+ORDER BY sent_at ASC, id ASC;$`).
+				mock.ExpectQuery(`^SELECT id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, updated_at\s+FROM messages\s+WHERE message_thread_id = \?\s+AND deleted_at IS NULL\s+AND\s+\(\s*\(is_from_company = 1 AND is_sent = 0\)\s+OR \(is_from_company = 1 AND is_sent = 1\)\s+OR \(is_from_student = 1 AND is_sent = 1\)\s*\)\s+ORDER BY sent_at ASC, id ASC;$`).
 					WithArgs(int64(3)).
 					WillReturnRows(rows)
 			},
@@ -256,12 +430,14 @@ func TestMessageRepository_GetAllMessagesForCompanyUser(t *testing.T) {
 					SentAt:        time.Date(2025, 1, 1, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60)),
 				},
 				&entity.Message{
-					ID:            11,
-					IsFromCompany: 0,
-					IsFromStudent: 1,
-					Content:       "World",
-					IsSent:        0,
-					SentAt:        time.Date(2025, 1, 1, 12, 5, 0, 0, time.FixedZone("JST", 9*60*60)),
+					ID:               11,
+					IsFromCompany:    0,
+					IsFromStudent:    1,
+					Content:          "World",
+					IsSent:           0,
+					SentAt:           time.Date(2025, 1, 1, 12, 5, 0, 0, time.FixedZone("JST", 9*60*60)),
+					UpdatedAt:        &sql.NullTime{Time: time.Date(2025, 1, 1, 12, 10, 0, 0, time.FixedZone("JST", 9*60*60)), Valid: true},
+					ReplyToMessageID: messageIDPtr(10),
 				},
 			},
 		},
@@ -293,7 +469,7 @@ func TestMessageRepository_GetAllMessagesForStudentUser(t *testing.T) {
 		"DB error": {
 			messageThreadID: 1,
 			mockSetup: func() {
-				mock.ExpectQuery(`^SELECT id, is_from_company, is_from_student, content, is_sent, sent_at\s+FROM messages\s+WHERE message_thread_id = \?\s+AND deleted_at IS NULL\s+AND\s+\(\s*\(is_from_student = 1 AND is_sent = 0\)\s+OR \(is_from_student = 1 AND is_sent = 1\)\s+OR \(is_from_company = 1 AND is_sent = 1\)\s*\)\s+ORDER BY sent_at ASC, id ASC;$`).
+				mock.ExpectQuery(`^SELECT id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, updated_at\s+FROM messages\s+WHERE message_thread_id = \?\s+AND deleted_at IS NULL\s+AND\s+\(\s*\(is_from_student = 1 AND is_sent = 0\)\s+OR \(is_from_student = 1 AND is_sent = 1\)\s+OR \(is_from_company = 1 AND is_sent = 1\)\s*\)\s+ORDER BY sent_at ASC, id ASC;$`).
 					WithArgs(int64(1)).
 					WillReturnError(assertAnError())
 			},
@@ -303,11 +479,11 @@ func TestMessageRepository_GetAllMessagesForStudentUser(t *testing.T) {
 		"No rows": {
 			messageThreadID: 2,
 			mockSetup: func() {
-				mock.ExpectQuery(`^SELECT id, is_from_company, is_from_student, content, is_sent, sent_at\s+FROM messages\s+WHERE message_thread_id = \?\s+AND deleted_at IS NULL\s+AND\s+\(\s*\(is_from_student = 1 AND is_sent = 0\)\s+OR \(is_from_student = 1 AND is_sent = 1\)\s+OR \(is_from_company = 1 AND is_sent = 1\)\s*\)\s+ORDER BY sent_at ASC, id ASC;$`).
+				mock.ExpectQuery(`^SELECT id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, updated_at\s+FROM messages\s+WHERE message_thread_id = \?\s+AND deleted_at IS NULL\s+AND\s+\(\s*\(is_from_student = 1 AND is_sent = 0\)\s+OR \(is_from_student = 1 AND is_sent = 1\)\s+OR \(is_from_company = 1 AND is_sent = 1\)\s*\)\s+ORDER BY sent_at ASC, id ASC;$`).
 					WithArgs(int64(2)).
 					WillReturnRows(
 						sqlmock.NewRows([]string{
-							"id", "is_from_company", "is_from_student", "content", "is_sent", "sent_at",
+							"id", "is_from_company", "is_from_student", "content", "is_sent", "sent_at", "reply_to_message_id", "updated_at",
 						}),
 					)
 			},
@@ -318,12 +494,12 @@ func TestMessageRepository_GetAllMessagesForStudentUser(t *testing.T) {
 			messageThreadID: 3,
 			mockSetup: func() {
 				rows := sqlmock.NewRows([]string{
-					"id", "is_from_company", "is_from_student", "content", "is_sent", "sent_at",
+					"id", "is_from_company", "is_from_student", "content", "is_sent", "sent_at", "reply_to_message_id", "updated_at",
 				}).
-					AddRow(int64(10), int8(1), int8(0), "Hello", int64(1), time.Date(2025, 1, 1, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60))).
-					AddRow(int64(11), int8(0), int8(1), "World", int64(0), time.Date(2025, 1, 1, 12, 5, 0, 0, time.FixedZone("JST", 9*60*60)))
+					AddRow(int64(10), int8(1), int8(0), "Hello", int64(1), time.Date(2025, 1, 1, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60)), nil, nil).
+					AddRow(int64(11), int8(0), int8(1), "World", int64(0), time.Date(2025, 1, 1, 12, 5, 0, 0, time.FixedZone("JST", 9*60*60)), int64(10), time.Date(2025, 1, 1, 12, 10, 0, 0, time.FixedZone("JST", 9*60*60)))
 
-				mock.ExpectQuery(`^SELECT id, is_from_company, is_from_student, content, is_sent, sent_at\s+FROM messages\s+WHERE message_thread_id = \?\s+AND deleted_at IS NULL\s+AND\s+\(\s*\(is_from_student = 1 AND is_sent = 0\)\s+OR \(is_from_student = 1 AND is_sent = 1\)\s+OR \(is_from_company = 1 AND is_sent = 1\)\s*\)\s+ORDER BY sent_at ASC, id ASC;$`).
+				mock.ExpectQuery(`^SELECT id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, updated_at\s+FROM messages\s+WHERE message_thread_id = \?\s+AND deleted_at IS NULL\s+AND\s+\(\s*\(is_from_student = 1 AND is_sent = 0\)\s+OR \(is_from_student = 1 AND is_sent = 1\)\s+OR \(is_from_company = 1 AND is_sent = 1\)\s*\)\s+ORDER BY sent_at ASC, id ASC;$`).
 					WithArgs(int64(3)).
 					WillReturnRows(rows)
 			},
@@ -338,12 +514,14 @@ func TestMessageRepository_GetAllMessagesForStudentUser(t *testing.T) {
 					SentAt:        time.Date(2025, 1, 1, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60)),
 				},
 				&entity.Message{
-					ID:            11,
-					IsFromCompany: 0,
-					IsFromStudent: 1,
-					Content:       "World",
-					IsSent:        0,
-					SentAt:        time.Date(2025, 1, 1, 12, 5, 0, 0, time.FixedZone("JST", 9*60*60)),
+					ID:               11,
+					IsFromCompany:    0,
+					IsFromStudent:    1,
+					Content:          "World",
+					IsSent:           0,
+					SentAt:           time.Date(2025, 1, 1, 12, 5, 0, 0, time.FixedZone("JST", 9*60*60)),
+					UpdatedAt:        &sql.NullTime{Time: time.Date(2025, 1, 1, 12, 10, 0, 0, time.FixedZone("JST", 9*60*60)), Valid: true},
+					ReplyToMessageID: messageIDPtr(10),
 				},
 			},
 		},
@@ -363,6 +541,118 @@ func TestMessageRepository_GetAllMessagesForStudentUser(t *testing.T) {
 	}
 }
 
+func TestMessageRepository_GetSentMessageThreadID(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	mr := NewMessageRepository(clock.FixedClocker{})
+	query := `^SELECT message_thread_id FROM messages WHERE id = \? AND is_sent = 1 AND deleted_at IS NULL;$`
+	tests := map[string]struct {
+		messageID           entity.MessageID
+		mockSetup           func()
+		wantErr             bool
+		wantMessageThreadID entity.MessageThreadID
+	}{
+		"DB error": {
+			messageID: 1,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"No rows": {
+			messageID: 2,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(2)).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+		"Success": {
+			messageID: 3,
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(3)).
+					WillReturnRows(sqlmock.NewRows([]string{"message_thread_id"}).AddRow(int64(50)))
+			},
+			wantErr:             false,
+			wantMessageThreadID: 50,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := mr.GetSentMessageThreadID(context.Background(), sqlxDB, tc.messageID)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantMessageThreadID, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMessageRepository_GetMessagesByIDs(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	mr := NewMessageRepository(clock.FixedClocker{})
+	query := `^SELECT id, is_from_company, is_from_student, content FROM messages WHERE id IN \(\?, \?\) AND deleted_at IS NULL;$`
+	tests := map[string]struct {
+		ids          []entity.MessageID
+		mockSetup    func()
+		wantErr      bool
+		wantMessages entity.Messages
+	}{
+		"Empty IDs": {
+			ids:          nil,
+			mockSetup:    func() {},
+			wantErr:      false,
+			wantMessages: nil,
+		},
+		"DB error": {
+			ids: []entity.MessageID{1, 2},
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1), int64(2)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Multiple rows": {
+			ids: []entity.MessageID{1, 2},
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1), int64(2)).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "is_from_company", "is_from_student", "content"}).
+							AddRow(int64(1), int8(1), int8(0), "Hello").
+							AddRow(int64(2), int8(0), int8(1), "World"),
+					)
+			},
+			wantErr: false,
+			wantMessages: entity.Messages{
+				&entity.Message{ID: 1, IsFromCompany: 1, IsFromStudent: 0, Content: "Hello"},
+				&entity.Message{ID: 2, IsFromCompany: 0, IsFromStudent: 1, Content: "World"},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := mr.GetMessagesByIDs(context.Background(), sqlxDB, tc.ids)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantMessages, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestMessageRepository_AddMessage(t *testing.T) {
 	sqlxDB, mock := newMockDB(t)
 	mr := NewMessageRepository(clock.FixedClocker{})
@@ -384,7 +674,7 @@ func TestMessageRepository_AddMessage(t *testing.T) {
 				CreatedAt:       clock.FixedClocker{}.Now(),
 			},
 			mockSetup: func(param *entity.Message) {
-				mock.ExpectExec(`^INSERT INTO messages \(message_thread_id, is_from_company, is_from_student, content, is_sent, sent_at, created_at\) VALUES \(\?, \?, \?, \?, \?, \?, \?\);$`).
+				mock.ExpectExec(`^INSERT INTO messages \(message_thread_id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, created_at\) VALUES \(\?, \?, \?, \?, \?, \?, \?, \?\);$`).
 					WithArgs(
 						param.MessageThreadID,
 						param.IsFromCompany,
@@ -392,6 +682,7 @@ func TestMessageRepository_AddMessage(t *testing.T) {
 						param.Content,
 						param.IsSent,
 						param.SentAt,
+						param.ReplyToMessageID,
 						param.CreatedAt,
 					).
 					WillReturnError(assertAnError())
@@ -409,7 +700,7 @@ func TestMessageRepository_AddMessage(t *testing.T) {
 				CreatedAt:       clock.FixedClocker{}.Now(),
 			},
 			mockSetup: func(param *entity.Message) {
-				mock.ExpectExec(`^INSERT INTO messages \(message_thread_id, is_from_company, is_from_student, content, is_sent, sent_at, created_at\) VALUES \(\?, \?, \?, \?, \?, \?, \?\);$`).
+				mock.ExpectExec(`^INSERT INTO messages \(message_thread_id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, created_at\) VALUES \(\?, \?, \?, \?, \?, \?, \?, \?\);$`).
 					WithArgs(
 						param.MessageThreadID,
 						param.IsFromCompany,
@@ -417,6 +708,7 @@ func TestMessageRepository_AddMessage(t *testing.T) {
 						param.Content,
 						param.IsSent,
 						param.SentAt,
+						param.ReplyToMessageID,
 						param.CreatedAt,
 					).
 					WillReturnResult(sqlmock.NewErrorResult(errors.New("cannot get lastInsertID")))
@@ -434,7 +726,7 @@ func TestMessageRepository_AddMessage(t *testing.T) {
 				CreatedAt:       clock.FixedClocker{}.Now(),
 			},
 			mockSetup: func(param *entity.Message) {
-				mock.ExpectExec(`^INSERT INTO messages \(message_thread_id, is_from_company, is_from_student, content, is_sent, sent_at, created_at\) VALUES \(\?, \?, \?, \?, \?, \?, \?\);$`).
+				mock.ExpectExec(`^INSERT INTO messages \(message_thread_id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, created_at\) VALUES \(\?, \?, \?, \?, \?, \?, \?, \?\);$`).
 					WithArgs(
 						param.MessageThreadID,
 						param.IsFromCompany,
@@ -442,6 +734,7 @@ func TestMessageRepository_AddMessage(t *testing.T) {
 						param.Content,
 						param.IsSent,
 						param.SentAt,
+						param.ReplyToMessageID,
 						param.CreatedAt,
 					).
 					WillReturnResult(sqlmock.NewResult(999, 1))
@@ -450,6 +743,35 @@ func TestMessageRepository_AddMessage(t *testing.T) {
 			wantID:        999,
 			wantCreatedAt: clock.FixedClocker{}.Now(),
 		},
+		"Success with reply": {
+			inputMessage: &entity.Message{
+				MessageThreadID:  300,
+				IsFromCompany:    0,
+				IsFromStudent:    1,
+				Content:          "Reply case",
+				IsSent:           1,
+				SentAt:           time.Date(2025, 3, 1, 9, 20, 0, 0, time.UTC),
+				ReplyToMessageID: messageIDPtr(999),
+				CreatedAt:        clock.FixedClocker{}.Now(),
+			},
+			mockSetup: func(param *entity.Message) {
+				mock.ExpectExec(`^INSERT INTO messages \(message_thread_id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, created_at\) VALUES \(\?, \?, \?, \?, \?, \?, \?, \?\);$`).
+					WithArgs(
+						param.MessageThreadID,
+						param.IsFromCompany,
+						param.IsFromStudent,
+						param.Content,
+						param.IsSent,
+						param.SentAt,
+						int64(999),
+						param.CreatedAt,
+					).
+					WillReturnResult(sqlmock.NewResult(1000, 1))
+			},
+			wantErr:       false,
+			wantID:        1000,
+			wantCreatedAt: clock.FixedClocker{}.Now(),
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -567,3 +889,7 @@ func TestMessageRepository_DeleteMessage(t *testing.T) {
 		})
 	}
 }
+
+func messageIDPtr(id entity.MessageID) *entity.MessageID {
+	return &id
+}
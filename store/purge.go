@@ -0,0 +1,166 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+// PurgeRepository は保持期間を過ぎたデータの物理削除を扱う。
+// 対象の抽出は id の昇順で afterID より後ろを limit 件ずつ行い、トランザクション内では FOR UPDATE で行ロックを取る（SQLite を除く）
+type PurgeRepository struct {
+	Clocker clock.Clocker
+}
+
+func NewPurgeRepository(clocker clock.Clocker) *PurgeRepository {
+	return &PurgeRepository{
+		Clocker: clocker,
+	}
+}
+
+func (pr *PurgeRepository) GetDeletedMessageIDs(ctx context.Context, db Queryer, before time.Time, afterID entity.MessageID, limit int) ([]entity.MessageID, error) {
+	query := "SELECT id FROM messages WHERE deleted_at < ? AND id > ? ORDER BY id ASC LIMIT ?" + forUpdate(db) + ";"
+	rows, err := db.QueryxContext(ctx, query, before, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []entity.MessageID
+	for rows.Next() {
+		var id entity.MessageID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetAbandonedDraftIDs は最後の更新（未編集の場合は作成）から before までに送信されなかった下書きのIDを返す
+func (pr *PurgeRepository) GetAbandonedDraftIDs(ctx context.Context, db Queryer, before time.Time, afterID entity.MessageID, limit int) ([]entity.MessageID, error) {
+	query := "SELECT id FROM messages WHERE is_sent = 0 AND deleted_at IS NULL AND COALESCE(updated_at, created_at) < ? AND id > ? ORDER BY id ASC LIMIT ?" + forUpdate(db) + ";"
+	rows, err := db.QueryxContext(ctx, query, before, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []entity.MessageID
+	for rows.Next() {
+		var id entity.MessageID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetDeletedCredentialIDs は論理削除から before を過ぎた認証情報のIDを返す。
+// expires_at はアクセストークンの有効期限で、期限切れでもリフレッシュトークンで再発行できるため対象にしない
+func (pr *PurgeRepository) GetDeletedCredentialIDs(ctx context.Context, db Queryer, before time.Time, afterID entity.MessageAPICredentialID, limit int) ([]entity.MessageAPICredentialID, error) {
+	query := "SELECT id FROM message_api_credentials WHERE deleted_at < ? AND id > ? ORDER BY id ASC LIMIT ?" + forUpdate(db) + ";"
+	rows, err := db.QueryxContext(ctx, query, before, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []entity.MessageAPICredentialID
+	for rows.Next() {
+		var id entity.MessageAPICredentialID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (pr *PurgeRepository) GetAttachmentsByMessageIDs(ctx context.Context, db Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+	query, args, err := sqlx.In("SELECT id, message_id, storage_key FROM message_attachments WHERE message_id IN (?) ORDER BY id ASC;", messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var attachments entity.Attachments
+	for rows.Next() {
+		var a entity.Attachment
+		if err := rows.StructScan(&a); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (pr *PurgeRepository) CountReactionsByMessageIDs(ctx context.Context, db Queryer, messageIDs []entity.MessageID) (int64, error) {
+	if len(messageIDs) == 0 {
+		return 0, nil
+	}
+	query, args, err := sqlx.In("SELECT COUNT(*) FROM message_reactions WHERE message_id IN (?);", messageIDs)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteMessages は返信元の参照を外したうえで、メッセージとそのリアクション・添付ファイルを削除する
+func (pr *PurgeRepository) DeleteMessages(ctx context.Context, db Execer, ids []entity.MessageID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	queries := []string{
+		"DELETE FROM message_reactions WHERE message_id IN (?);",
+		"DELETE FROM message_attachments WHERE message_id IN (?);",
+		"UPDATE messages SET reply_to_message_id = NULL WHERE reply_to_message_id IN (?);",
+		"DELETE FROM messages WHERE id IN (?);",
+	}
+	for _, q := range queries {
+		query, args, err := sqlx.In(q, ids)
+		if err != nil {
+			return err
+		}
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pr *PurgeRepository) DeleteCredentials(ctx context.Context, db Execer, ids []entity.MessageAPICredentialID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query, args, err := sqlx.In("DELETE FROM message_api_credentials WHERE id IN (?);", ids)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, query, args...)
+	return err
+}
@@ -0,0 +1,189 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestPurgeRepository_GetDeletedMessageIDs(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	pr := NewPurgeRepository(clock.FixedClocker{})
+	before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		mockSetup func()
+		wantErr   bool
+		wantIDs   []entity.MessageID
+	}{
+		"DB error": {
+			mockSetup: func() {
+				mock.ExpectQuery(`^SELECT id FROM messages WHERE deleted_at < \? AND id > \? ORDER BY id ASC LIMIT \? FOR UPDATE;$`).
+					WithArgs(before, int64(10), 2).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Success": {
+			mockSetup: func() {
+				mock.ExpectQuery(`^SELECT id FROM messages WHERE deleted_at < \? AND id > \? ORDER BY id ASC LIMIT \? FOR UPDATE;$`).
+					WithArgs(before, int64(10), 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(11)).AddRow(int64(15)))
+			},
+			wantErr: false,
+			wantIDs: []entity.MessageID{11, 15},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := pr.GetDeletedMessageIDs(context.Background(), sqlxDB, before, 10, 2)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantIDs, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPurgeRepository_GetAbandonedDraftIDs(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	pr := NewPurgeRepository(clock.FixedClocker{})
+	before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`^SELECT id FROM messages WHERE is_sent = 0 AND deleted_at IS NULL AND COALESCE\(updated_at, created_at\) < \? AND id > \? ORDER BY id ASC LIMIT \? FOR UPDATE;$`).
+		WithArgs(before, int64(0), 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(3)))
+	got, err := pr.GetAbandonedDraftIDs(context.Background(), sqlxDB, before, 0, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.MessageID{3}, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeRepository_GetDeletedCredentialIDs(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	pr := NewPurgeRepository(clock.FixedClocker{})
+	before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`^SELECT id FROM message_api_credentials WHERE deleted_at < \? AND id > \? ORDER BY id ASC LIMIT \? FOR UPDATE;$`).
+		WithArgs(before, int64(0), 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	got, err := pr.GetDeletedCredentialIDs(context.Background(), sqlxDB, before, 0, 100)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeRepository_CountReactionsByMessageIDs(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	pr := NewPurgeRepository(clock.FixedClocker{})
+	tests := map[string]struct {
+		ids       []entity.MessageID
+		mockSetup func()
+		wantErr   bool
+		wantCount int64
+	}{
+		"Empty IDs": {
+			ids:       nil,
+			mockSetup: func() {},
+			wantErr:   false,
+			wantCount: 0,
+		},
+		"Success": {
+			ids: []entity.MessageID{1, 2},
+			mockSetup: func() {
+				mock.ExpectQuery(`^SELECT COUNT\(\*\) FROM message_reactions WHERE message_id IN \(\?, \?\);$`).
+					WithArgs(int64(1), int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(int64(4)))
+			},
+			wantErr:   false,
+			wantCount: 4,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := pr.CountReactionsByMessageIDs(context.Background(), sqlxDB, tc.ids)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantCount, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPurgeRepository_DeleteMessages(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	pr := NewPurgeRepository(clock.FixedClocker{})
+	tests := map[string]struct {
+		ids       []entity.MessageID
+		mockSetup func()
+		wantErr   bool
+	}{
+		"Empty IDs": {
+			ids:       nil,
+			mockSetup: func() {},
+			wantErr:   false,
+		},
+		"Fail to delete reactions": {
+			ids: []entity.MessageID{1},
+			mockSetup: func() {
+				mock.ExpectExec(`^DELETE FROM message_reactions WHERE message_id IN \(\?\);$`).
+					WithArgs(int64(1)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Success": {
+			ids: []entity.MessageID{1, 2},
+			mockSetup: func() {
+				mock.ExpectExec(`^DELETE FROM message_reactions WHERE message_id IN \(\?, \?\);$`).
+					WithArgs(int64(1), int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec(`^DELETE FROM message_attachments WHERE message_id IN \(\?, \?\);$`).
+					WithArgs(int64(1), int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`^UPDATE messages SET reply_to_message_id = NULL WHERE reply_to_message_id IN \(\?, \?\);$`).
+					WithArgs(int64(1), int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`^DELETE FROM messages WHERE id IN \(\?, \?\);$`).
+					WithArgs(int64(1), int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			wantErr: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			err := pr.DeleteMessages(context.Background(), sqlxDB, tc.ids)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPurgeRepository_DeleteCredentials(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	pr := NewPurgeRepository(clock.FixedClocker{})
+	mock.ExpectExec(`^DELETE FROM message_api_credentials WHERE id IN \(\?\);$`).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err := pr.DeleteCredentials(context.Background(), sqlxDB, []entity.MessageAPICredentialID{7})
+	assert.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+type ReactionRepository struct {
+	Clocker clock.Clocker
+}
+
+func NewReactionRepository(clocker clock.Clocker) *ReactionRepository {
+	return &ReactionRepository{
+		Clocker: clocker,
+	}
+}
+
+func (rr *ReactionRepository) GetReactionsByMessageIDs(ctx context.Context, db Queryer, messageIDs []entity.MessageID) (entity.Reactions, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+	query, args, err := sqlx.In("SELECT id, message_id, is_from_company, is_from_student, reaction FROM message_reactions WHERE message_id IN (?) ORDER BY id ASC;", messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var reactions entity.Reactions
+	for rows.Next() {
+		var r entity.Reaction
+		if err := rows.StructScan(&r); err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, &r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return reactions, nil
+}
+
+// AddReaction は同じリアクションが既にある場合は挿入せず、ID も設定しない。
+// 一意キーの重複を DB 側で無視するため、同時に同じリアクションを付けてもエラーにならない
+func (rr *ReactionRepository) AddReaction(ctx context.Context, db Execer, param *entity.Reaction) error {
+	param.CreatedAt = rr.Clocker.Now()
+	query := insertIgnore(db, "INTO message_reactions (message_id, is_from_company, is_from_student, reaction, created_at) VALUES (:message_id, :is_from_company, :is_from_student, :reaction, :created_at)")
+	result, err := db.NamedExecContext(ctx, query, param)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return nil
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	param.ID = entity.ReactionID(id)
+	return nil
+}
+
+func (rr *ReactionRepository) DeleteReaction(ctx context.Context, db Execer, param *entity.Reaction) error {
+	query := "DELETE FROM message_reactions WHERE message_id = :message_id AND is_from_company = :is_from_company AND is_from_student = :is_from_student AND reaction = :reaction;"
+	_, err := db.NamedExecContext(ctx, query, param)
+	return err
+}
@@ -0,0 +1,177 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestReactionRepository_GetReactionsByMessageIDs(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	rr := NewReactionRepository(clock.FixedClocker{})
+	query := `^SELECT id, message_id, is_from_company, is_from_student, reaction FROM message_reactions WHERE message_id IN \(\?, \?\) ORDER BY id ASC;$`
+	tests := map[string]struct {
+		messageIDs    []entity.MessageID
+		mockSetup     func()
+		wantErr       bool
+		wantReactions entity.Reactions
+	}{
+		"Empty message IDs": {
+			messageIDs:    nil,
+			mockSetup:     func() {},
+			wantErr:       false,
+			wantReactions: nil,
+		},
+		"DB error": {
+			messageIDs: []entity.MessageID{1, 2},
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1), int64(2)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Multiple rows": {
+			messageIDs: []entity.MessageID{1, 2},
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1), int64(2)).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "message_id", "is_from_company", "is_from_student", "reaction"}).
+							AddRow(int64(5), int64(1), int8(0), int8(1), "👍").
+							AddRow(int64(6), int64(2), int8(1), int8(0), "確認しました"),
+					)
+			},
+			wantErr: false,
+			wantReactions: entity.Reactions{
+				&entity.Reaction{ID: 5, MessageID: 1, IsFromCompany: 0, IsFromStudent: 1, Reaction: "👍"},
+				&entity.Reaction{ID: 6, MessageID: 2, IsFromCompany: 1, IsFromStudent: 0, Reaction: "確認しました"},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := rr.GetReactionsByMessageIDs(context.Background(), sqlxDB, tc.messageIDs)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantReactions, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReactionRepository_AddReaction(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	rr := NewReactionRepository(clock.FixedClocker{})
+	query := `^INSERT IGNORE INTO message_reactions \(message_id, is_from_company, is_from_student, reaction, created_at\) VALUES \(\?, \?, \?, \?, \?\);$`
+	tests := map[string]struct {
+		input     *entity.Reaction
+		mockSetup func(*entity.Reaction)
+		wantErr   bool
+		wantID    entity.ReactionID
+	}{
+		"DB error on Exec": {
+			input: &entity.Reaction{MessageID: 1, IsFromCompany: 0, IsFromStudent: 1, Reaction: "👍"},
+			mockSetup: func(param *entity.Reaction) {
+				mock.ExpectExec(query).
+					WithArgs(param.MessageID, param.IsFromCompany, param.IsFromStudent, param.Reaction, clock.FixedClocker{}.Now()).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"LastInsertId error": {
+			input: &entity.Reaction{MessageID: 1, IsFromCompany: 0, IsFromStudent: 1, Reaction: "👍"},
+			mockSetup: func(param *entity.Reaction) {
+				mock.ExpectExec(query).
+					WithArgs(param.MessageID, param.IsFromCompany, param.IsFromStudent, param.Reaction, clock.FixedClocker{}.Now()).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("cannot get lastInsertID")))
+			},
+			wantErr: true,
+		},
+		"Success": {
+			input: &entity.Reaction{MessageID: 1, IsFromCompany: 0, IsFromStudent: 1, Reaction: "👍"},
+			mockSetup: func(param *entity.Reaction) {
+				mock.ExpectExec(query).
+					WithArgs(param.MessageID, param.IsFromCompany, param.IsFromStudent, param.Reaction, clock.FixedClocker{}.Now()).
+					WillReturnResult(sqlmock.NewResult(12, 1))
+			},
+			wantErr: false,
+			wantID:  12,
+		},
+		"Duplicate is ignored": {
+			input: &entity.Reaction{MessageID: 1, IsFromCompany: 0, IsFromStudent: 1, Reaction: "👍"},
+			mockSetup: func(param *entity.Reaction) {
+				mock.ExpectExec(query).
+					WithArgs(param.MessageID, param.IsFromCompany, param.IsFromStudent, param.Reaction, clock.FixedClocker{}.Now()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: false,
+			wantID:  0,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup(tc.input)
+			err := rr.AddReaction(context.Background(), sqlxDB, tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantID, tc.input.ID)
+				assert.Equal(t, clock.FixedClocker{}.Now(), tc.input.CreatedAt)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReactionRepository_DeleteReaction(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	rr := NewReactionRepository(clock.FixedClocker{})
+	query := `^DELETE FROM message_reactions WHERE message_id = \? AND is_from_company = \? AND is_from_student = \? AND reaction = \?;$`
+	param := &entity.Reaction{MessageID: 1, IsFromCompany: 1, IsFromStudent: 0, Reaction: "確認しました"}
+	tests := map[string]struct {
+		mockSetup func()
+		wantErr   bool
+	}{
+		"DB error": {
+			mockSetup: func() {
+				mock.ExpectExec(query).
+					WithArgs(param.MessageID, param.IsFromCompany, param.IsFromStudent, param.Reaction).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Success": {
+			mockSetup: func() {
+				mock.ExpectExec(query).
+					WithArgs(param.MessageID, param.IsFromCompany, param.IsFromStudent, param.Reaction).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			err := rr.DeleteReaction(context.Background(), sqlxDB, param)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
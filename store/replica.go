@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/config"
+)
+
+// NewReplica は targetDB のリードレプリカに接続する。レプリカの DSN が未設定の場合は nil を返す。
+// DSN は go-sql-driver/mysql の形式で指定し、日時の扱いを primary と揃えるため parseTime と loc は上書きする。
+// レプリカは DB_DRIVER=mysql の場合のみ使え、sqlite・memory で DSN を設定すると設定の検証でエラーになる。
+// コネクションプールの設定は primary と共通
+func NewReplica(ctx context.Context, cfg *config.Config, targetDB string) (*sqlx.DB, func(), error) {
+	dsn, err := selectReplicaDSN(cfg, targetDB)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	if dsn == "" {
+		return nil, func() {}, nil
+	}
+	if cfg.DBDriver != "mysql" {
+		return nil, func() {}, fmt.Errorf("read replica is not supported with db driver: %s", cfg.DBDriver)
+	}
+	mysqlCfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("invalid %s replica dsn: %w", targetDB, err)
+	}
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		return nil, func() {}, err
+	}
+	mysqlCfg.ParseTime = true
+	mysqlCfg.Loc = loc
+	db, err := sql.Open("mysql", mysqlCfg.FormatDSN())
+	if err != nil {
+		return nil, func() {}, err
+	}
+	pool, err := selectPool(cfg, targetDB)
+	if err != nil {
+		return nil, func() { _ = db.Close() }, err
+	}
+	db.SetMaxOpenConns(pool.maxOpenConns)
+	db.SetMaxIdleConns(pool.maxIdleConns)
+	db.SetConnMaxLifetime(pool.connMaxLifetime)
+	if err := pingWithRetry(ctx, db, targetDB+" replica", cfg.DBConnectRetries, cfg.DBConnectBackoff, cfg.DBConnectMaxBackoff); err != nil {
+		return nil, func() { _ = db.Close() }, err
+	}
+	return sqlx.NewDb(db, "mysql"), func() { _ = db.Close() }, nil
+}
+
+func selectReplicaDSN(cfg *config.Config, targetDB string) (string, error) {
+	switch targetDB {
+	case "company":
+		return cfg.DBCompanyReplicaDSN, nil
+	case "student":
+		return cfg.DBStudentReplicaDSN, nil
+	case "common":
+		return cfg.DBCommonReplicaDSN, nil
+	default:
+		return "", fmt.Errorf("invalid database: %s", targetDB)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -23,9 +24,16 @@ type Queryer interface {
 	GetContext(ctx context.Context, dest interface{}, query string, args ...any) error
 }
 
+type Beginner interface {
+	BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error)
+}
+
 var (
-	_ Execer  = (*sqlx.DB)(nil)
-	_ Queryer = (*sqlx.DB)(nil)
+	_ Execer   = (*sqlx.DB)(nil)
+	_ Queryer  = (*sqlx.DB)(nil)
+	_ Beginner = (*sqlx.DB)(nil)
+	_ Execer   = (*sqlx.Tx)(nil)
+	_ Queryer  = (*sqlx.Tx)(nil)
 )
 
 func New(ctx context.Context, cfg *config.Config, targetDB string) (*sqlx.DB, func(), error) {
@@ -33,26 +41,89 @@ func New(ctx context.Context, cfg *config.Config, targetDB string) (*sqlx.DB, fu
 	if err != nil {
 		return nil, func() {}, err
 	}
-	db, err := sql.Open("mysql", fmt.Sprintf(
-		"%s:%s@tcp(%s:%d)/%s?parseTime=true&loc=Asia%%2FTokyo",
-		cfg.DBUserName,
-		cfg.DBPassword,
-		cfg.DBHost,
-		cfg.DBPort,
-		dbName,
-	))
+	var db *sql.DB
+	switch cfg.DBDriver {
+	case "memory":
+		xdb := newMemoryDB(targetDB)
+		return xdb, func() { _ = xdb.Close() }, nil
+	case "mysql":
+		db, err = sql.Open("mysql", fmt.Sprintf(
+			"%s:%s@tcp(%s:%d)/%s?parseTime=true&loc=Asia%%2FTokyo",
+			cfg.DBUserName,
+			cfg.DBPassword,
+			cfg.DBHost,
+			cfg.DBPort,
+			dbName,
+		))
+	case sqliteDriverName:
+		db, err = openSQLite(cfg, targetDB)
+	default:
+		return nil, func() {}, fmt.Errorf("invalid db driver: %s", cfg.DBDriver)
+	}
 	if err != nil {
 		return nil, func() {}, err
 	}
-	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
-	defer cancel()
-	if err := db.PingContext(ctx); err != nil {
+	pool, err := selectPool(cfg, targetDB)
+	if err != nil {
 		return nil, func() { _ = db.Close() }, err
 	}
-	xdb := sqlx.NewDb(db, "mysql")
+	db.SetMaxOpenConns(pool.maxOpenConns)
+	db.SetMaxIdleConns(pool.maxIdleConns)
+	db.SetConnMaxLifetime(pool.connMaxLifetime)
+	if err := pingWithRetry(ctx, db, targetDB, cfg.DBConnectRetries, cfg.DBConnectBackoff, cfg.DBConnectMaxBackoff); err != nil {
+		return nil, func() { _ = db.Close() }, err
+	}
+	xdb := sqlx.NewDb(db, cfg.DBDriver)
 	return xdb, func() { _ = db.Close() }, nil
 }
 
+type poolConfig struct {
+	maxOpenConns    int
+	maxIdleConns    int
+	connMaxLifetime time.Duration
+}
+
+func selectPool(cfg *config.Config, targetDB string) (poolConfig, error) {
+	switch targetDB {
+	case "company":
+		return poolConfig{cfg.DBCompanyMaxOpenConns, cfg.DBCompanyMaxIdleConns, cfg.DBCompanyConnMaxLifetime}, nil
+	case "student":
+		return poolConfig{cfg.DBStudentMaxOpenConns, cfg.DBStudentMaxIdleConns, cfg.DBStudentConnMaxLifetime}, nil
+	case "common":
+		return poolConfig{cfg.DBCommonMaxOpenConns, cfg.DBCommonMaxIdleConns, cfg.DBCommonConnMaxLifetime}, nil
+	default:
+		return poolConfig{}, fmt.Errorf("invalid database: %s", targetDB)
+	}
+}
+
+// pingWithRetry は DB が応答するまで、待ち時間を倍にしながら（maxBackoff まで）最大 retries 回まで再試行する。
+// docker compose などで DB より先に API が起動しても、DB の起動を待てるようにする
+func pingWithRetry(ctx context.Context, db *sql.DB, targetDB string, retries int, backoff, maxBackoff time.Duration) error {
+	for attempt := 0; ; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		err := db.PingContext(pingCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempt >= retries {
+			return fmt.Errorf("failed to connect to %s db after %d attempts: %w", targetDB, attempt+1, err)
+		}
+		slog.WarnContext(ctx, "failed to connect to db, retrying",
+			slog.String("db", targetDB),
+			slog.Int("attempt", attempt+1),
+			slog.Duration("backoff", backoff),
+			slog.String("error", err.Error()),
+		)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
 func selectDB(cfg *config.Config, targetDB string) (string, error) {
 	if targetDB == "company" {
 		return cfg.DBCompany, nil
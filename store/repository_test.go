@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/config"
+)
+
+func TestNew_AppliesPoolConfig(t *testing.T) {
+	cfg := &config.Config{
+		DBDriver:                "sqlite",
+		DBCompany:               "company",
+		DBStudent:               "student",
+		DBCommon:                "common",
+		SQLiteDir:               t.TempDir(),
+		DBStudentMaxOpenConns:   3,
+		DBStudentMaxIdleConns:   2,
+		DBCommonMaxOpenConns:    7,
+		DBCommonConnMaxLifetime: time.Minute,
+	}
+	db, closeFunc, err := New(context.Background(), cfg, "student")
+	t.Cleanup(closeFunc)
+	require.NoError(t, err)
+	assert.Equal(t, 3, db.Stats().MaxOpenConnections)
+
+	db, closeFunc, err = New(context.Background(), cfg, "common")
+	t.Cleanup(closeFunc)
+	require.NoError(t, err)
+	assert.Equal(t, 7, db.Stats().MaxOpenConnections)
+}
+
+func TestNew_RetriesPing(t *testing.T) {
+	cfg := &config.Config{
+		DBDriver:            "mysql",
+		DBHost:              "127.0.0.1",
+		DBPort:              1, // 接続を拒否されるポート
+		DBCompany:           "company",
+		DBConnectRetries:    2,
+		DBConnectBackoff:    10 * time.Millisecond,
+		DBConnectMaxBackoff: 15 * time.Millisecond,
+	}
+	start := time.Now()
+	_, closeFunc, err := New(context.Background(), cfg, "company")
+	t.Cleanup(closeFunc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "after 3 attempts")
+	// 10ms + 15ms（上限で頭打ち）待ってから諦める
+	assert.GreaterOrEqual(t, time.Since(start), 25*time.Millisecond)
+}
+
+func TestNewReplica(t *testing.T) {
+	t.Run("no dsn", func(t *testing.T) {
+		db, closeFunc, err := NewReplica(context.Background(), &config.Config{DBDriver: "mysql"}, "common")
+		t.Cleanup(closeFunc)
+		require.NoError(t, err)
+		assert.Nil(t, db)
+	})
+	t.Run("unsupported driver", func(t *testing.T) {
+		cfg := &config.Config{DBDriver: "sqlite", DBCommonReplicaDSN: "user:pass@tcp(replica:3306)/common"}
+		_, closeFunc, err := NewReplica(context.Background(), cfg, "common")
+		t.Cleanup(closeFunc)
+		assert.Error(t, err)
+	})
+	t.Run("invalid dsn", func(t *testing.T) {
+		cfg := &config.Config{DBDriver: "mysql", DBCommonReplicaDSN: "not a dsn"}
+		_, closeFunc, err := NewReplica(context.Background(), cfg, "common")
+		t.Cleanup(closeFunc)
+		assert.ErrorContains(t, err, "invalid common replica dsn")
+	})
+}
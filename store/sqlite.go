@@ -0,0 +1,136 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"modernc.org/sqlite"
+
+	"github.com/yuyacode/AppLiftMessageApi/config"
+)
+
+// DB_DRIVER=sqlite のとき、company, student, common をそれぞれ SQLITE_DIR/<DB名>.db に置く。
+// 対象DBのファイルを main として開き、残りの2つは論理名（company など）で ATTACH する。
+// INTEGER PRIMARY KEY の列は LastInsertId で採番値を返すため、INSERT 後の ID の取得は MySQL と共通
+const sqliteDriverName = "sqlite"
+
+func init() {
+	sqlx.BindDriver(sqliteDriverName, sqlx.QUESTION)
+}
+
+type sqliteAttachment struct {
+	name string
+	path string
+}
+
+// sqliteDriverConn は modernc.org/sqlite のコネクションが実装しているインターフェース
+type sqliteDriverConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+}
+
+type sqliteConnector struct {
+	dsn         string
+	attachments []sqliteAttachment
+	loc         *time.Location
+}
+
+func (c *sqliteConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := (&sqlite.Driver{}).Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	sc, ok := conn.(sqliteDriverConn)
+	if !ok {
+		_ = conn.Close()
+		return nil, fmt.Errorf("unsupported sqlite connection: %T", conn)
+	}
+	for _, a := range c.attachments {
+		if _, err := sc.ExecContext(ctx, "ATTACH DATABASE ? AS "+a.name+";", []driver.NamedValue{{Ordinal: 1, Value: a.path}}); err != nil {
+			_ = sc.Close()
+			return nil, fmt.Errorf("failed to attach %s: %w", a.name, err)
+		}
+	}
+	return &sqliteConn{sqliteDriverConn: sc, loc: c.loc}, nil
+}
+
+func (c *sqliteConnector) Driver() driver.Driver { return &sqlite.Driver{} }
+
+// sqliteConn は MySQL の loc=Asia/Tokyo と同じく、日時を日本時間に揃えてから書き込む。
+// SQLite は日時を文字列で保存して比較するため、オフセットが混在すると大小関係が崩れる
+type sqliteConn struct {
+	sqliteDriverConn
+	loc *time.Location
+}
+
+func (c *sqliteConn) CheckNamedValue(nv *driver.NamedValue) error {
+	v, err := driver.DefaultParameterConverter.ConvertValue(nv.Value)
+	if err != nil {
+		return err
+	}
+	if t, ok := v.(time.Time); ok {
+		v = t.In(c.loc)
+	}
+	nv.Value = v
+	return nil
+}
+
+func openSQLite(cfg *config.Config, targetDB string) (*sql.DB, error) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(cfg.SQLiteDir, 0o750); err != nil {
+		return nil, err
+	}
+	var mainPath string
+	var attachments []sqliteAttachment
+	for _, name := range []string{"company", "student", "common"} {
+		dbName, err := selectDB(cfg, name)
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(cfg.SQLiteDir, dbName+".db")
+		if name == targetDB {
+			mainPath = path
+		} else {
+			attachments = append(attachments, sqliteAttachment{name: name, path: path})
+		}
+	}
+	params := url.Values{}
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Set("_time_format", "sqlite")
+	return sql.OpenDB(&sqliteConnector{
+		dsn:         "file:" + mainPath + "?" + params.Encode(),
+		attachments: attachments,
+		loc:         loc,
+	}), nil
+}
+
+// forUpdate は行ロックの句を返す。SQLite は書き込みを DB ファイル単位で直列化するため、行ロックの構文を持たない
+func forUpdate(db any) string {
+	if d, ok := db.(interface{ DriverName() string }); ok && d.DriverName() == sqliteDriverName {
+		return ""
+	}
+	return " FOR UPDATE"
+}
+
+// insertIgnore は一意キーが重複する行を挿入せずに無視する INSERT 文を返す。insert には INTO 以降を渡す
+func insertIgnore(db any, insert string) string {
+	if d, ok := db.(interface{ DriverName() string }); ok && d.DriverName() == sqliteDriverName {
+		return "INSERT " + insert + " ON CONFLICT DO NOTHING;"
+	}
+	return "INSERT IGNORE " + insert + ";"
+}
@@ -0,0 +1,136 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/config"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+	"github.com/yuyacode/AppLiftMessageApi/migration"
+)
+
+func newSQLiteDBs(t *testing.T) map[string]*sqlx.DB {
+	t.Helper()
+	ctx := context.Background()
+	cfg := &config.Config{
+		DBDriver:  "sqlite",
+		DBCompany: "company",
+		DBStudent: "student",
+		DBCommon:  "common",
+		SQLiteDir: t.TempDir(),
+	}
+	dbs := make(map[string]*sqlx.DB)
+	for _, targetDB := range []string{"company", "student", "common"} {
+		db, closeFunc, err := New(ctx, cfg, targetDB)
+		t.Cleanup(closeFunc)
+		require.NoError(t, err)
+		migrations, err := migration.Load("sqlite", targetDB)
+		require.NoError(t, err)
+		_, err = migration.NewMigrator(db, migrations, clock.FixedClocker{}).Up(ctx)
+		require.NoError(t, err)
+		dbs[targetDB] = db
+	}
+	return dbs
+}
+
+func TestSQLite_AttachesOtherDBs(t *testing.T) {
+	dbs := newSQLiteDBs(t)
+	var names []string
+	err := dbs["common"].SelectContext(context.Background(), &names, "SELECT name FROM pragma_database_list ORDER BY seq;")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main", "company", "student"}, names)
+
+	var count int
+	err = dbs["common"].GetContext(context.Background(), &count, "SELECT COUNT(*) FROM company.message_api_keys;")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestSQLite_MessageRepository(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteDBs(t)["common"]
+	_, err := db.ExecContext(ctx, "INSERT INTO message_threads (company_user_id, student_user_id, created_at) VALUES (?, ?, ?);", 1, 2, clock.FixedClocker{}.Now())
+	require.NoError(t, err)
+
+	mr := NewMessageRepository(clock.FixedClocker{})
+	sentAt := time.Date(2025, 1, 1, 0, 30, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		m := &entity.Message{MessageThreadID: 1, IsFromCompany: 1, Content: "hello", IsSent: 1, SentAt: sentAt}
+		require.NoError(t, mr.AddMessage(ctx, db, m))
+		assert.Equal(t, entity.MessageID(i+1), m.ID)
+	}
+
+	// UTC で渡した日時も日本時間で保存される
+	var stored string
+	require.NoError(t, db.GetContext(ctx, &stored, "SELECT CAST(sent_at AS TEXT) FROM messages WHERE id = 1;"))
+	assert.Equal(t, "2025-01-01 09:30:00+09:00", stored)
+
+	messages, err := mr.GetAllMessagesForCompanyUser(ctx, db, 1)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.True(t, sentAt.Equal(messages[0].SentAt))
+
+	require.NoError(t, mr.DeleteMessage(ctx, db, 2))
+	messages, err = mr.GetAllMessagesForCompanyUser(ctx, db, 1)
+	require.NoError(t, err)
+	assert.Len(t, messages, 1)
+
+	var ids []entity.MessageID
+	require.NoError(t, mr.EachMessageForCompanyUser(ctx, db, 1, func(m *entity.Message) error {
+		ids = append(ids, m.ID)
+		return nil
+	}))
+	assert.Equal(t, []entity.MessageID{1}, ids)
+}
+
+func TestSQLite_PurgeRepository(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteDBs(t)["common"]
+	_, err := db.ExecContext(ctx, "INSERT INTO message_threads (company_user_id, student_user_id, created_at) VALUES (?, ?, ?);", 1, 2, clock.FixedClocker{}.Now())
+	require.NoError(t, err)
+	deletedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err = db.ExecContext(ctx, "INSERT INTO messages (message_thread_id, is_from_company, content, is_sent, sent_at, created_at, deleted_at) VALUES (1, 1, 'a', 1, ?, ?, ?), (1, 1, 'b', 1, ?, ?, NULL);", deletedAt, deletedAt, deletedAt, deletedAt, deletedAt)
+	require.NoError(t, err)
+
+	tx, err := db.BeginTxx(ctx, nil)
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback() }()
+	pr := NewPurgeRepository(clock.FixedClocker{})
+	ids, err := pr.GetDeletedMessageIDs(ctx, tx, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.MessageID{1}, ids)
+	require.NoError(t, pr.DeleteMessages(ctx, tx, ids))
+	require.NoError(t, tx.Commit())
+
+	var count int
+	require.NoError(t, db.GetContext(ctx, &count, "SELECT COUNT(*) FROM messages;"))
+	assert.Equal(t, 1, count)
+}
+
+func TestSQLite_ReactionRepository(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteDBs(t)["common"]
+	_, err := db.ExecContext(ctx, "INSERT INTO message_threads (company_user_id, student_user_id, created_at) VALUES (?, ?, ?);", 1, 2, clock.FixedClocker{}.Now())
+	require.NoError(t, err)
+	mr := NewMessageRepository(clock.FixedClocker{})
+	require.NoError(t, mr.AddMessage(ctx, db, &entity.Message{MessageThreadID: 1, IsFromCompany: 1, Content: "hello", IsSent: 1, SentAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}))
+
+	// 同じリアクションの2件目は一意キーの重複として無視され、エラーにならない
+	rr := NewReactionRepository(clock.FixedClocker{})
+	first := &entity.Reaction{MessageID: 1, IsFromStudent: 1, Reaction: "👍"}
+	require.NoError(t, rr.AddReaction(ctx, db, first))
+	assert.Equal(t, entity.ReactionID(1), first.ID)
+	second := &entity.Reaction{MessageID: 1, IsFromStudent: 1, Reaction: "👍"}
+	require.NoError(t, rr.AddReaction(ctx, db, second))
+	assert.Equal(t, entity.ReactionID(0), second.ID)
+
+	reactions, err := rr.GetReactionsByMessageIDs(ctx, db, []entity.MessageID{1})
+	require.NoError(t, err)
+	assert.Len(t, reactions, 1)
+}
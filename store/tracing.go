@@ -0,0 +1,34 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/yuyacode/AppLiftMessageApi/store")
+
+// startSpan はリポジトリのメソッド1回分（DB への問い合わせ）のスパンを開始する
+func startSpan(ctx context.Context, db any, name string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{}
+	if d, ok := db.(interface{ DriverName() string }); ok {
+		attrs = append(attrs, attribute.String("db.system", d.DriverName()))
+	}
+	return tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// recordError は err をスパンに記録し、そのまま返す。
+// sql.ErrNoRows は呼び出し側で 404 などとして扱う正常な結果のため、エラーとして記録しない
+func recordError(span trace.Span, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	return err
+}
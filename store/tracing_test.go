@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRecordError(t *testing.T) {
+	tests := map[string]struct {
+		err        error
+		wantStatus codes.Code
+		wantEvents int
+	}{
+		"no rows":         {err: sql.ErrNoRows, wantStatus: codes.Unset, wantEvents: 0},
+		"wrapped no rows": {err: fmt.Errorf("get message: %w", sql.ErrNoRows), wantStatus: codes.Unset, wantEvents: 0},
+		"other error":     {err: errors.New("connection refused"), wantStatus: codes.Error, wantEvents: 1},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			_, span := tp.Tracer("test").Start(context.Background(), "query")
+			assert.Equal(t, tt.err, recordError(span, tt.err))
+			span.End()
+
+			spans := sr.Ended()
+			if assert.Len(t, spans, 1) {
+				assert.Equal(t, tt.wantStatus, spans[0].Status().Code)
+				assert.Len(t, spans[0].Events(), tt.wantEvents)
+			}
+		})
+	}
+}
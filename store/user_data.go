@@ -0,0 +1,168 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+// UserDataRepository は利用者単位の個人データ（エクスポート・消去）を扱う。
+// 論理削除済みの行も個人データに含まれるため、deleted_at による絞り込みは行わない
+type UserDataRepository struct {
+	Clocker clock.Clocker
+}
+
+func NewUserDataRepository(clocker clock.Clocker) *UserDataRepository {
+	return &UserDataRepository{
+		Clocker: clocker,
+	}
+}
+
+func (udr *UserDataRepository) GetThreadsByUserID(ctx context.Context, db Queryer, appKind string, userID int64) ([]*entity.MessageThread, error) {
+	var query string
+	switch appKind {
+	case "company":
+		query = "SELECT id, company_user_id, student_user_id, created_at, updated_at, deleted_at FROM message_threads WHERE company_user_id = ? ORDER BY id ASC;"
+	case "student":
+		query = "SELECT id, company_user_id, student_user_id, created_at, updated_at, deleted_at FROM message_threads WHERE student_user_id = ? ORDER BY id ASC;"
+	default:
+		return nil, fmt.Errorf("invalid app kind: %s", appKind)
+	}
+	rows, err := db.QueryxContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var threads []*entity.MessageThread
+	for rows.Next() {
+		var mt entity.MessageThread
+		if err := rows.StructScan(&mt); err != nil {
+			return nil, err
+		}
+		threads = append(threads, &mt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return threads, nil
+}
+
+// GetMessagesByThreadIDs は自分が送ったメッセージ（下書き・削除済みを含む）と、相手から届いた未削除の送信済みメッセージを返す
+func (udr *UserDataRepository) GetMessagesByThreadIDs(ctx context.Context, db Queryer, appKind string, threadIDs []entity.MessageThreadID) (entity.Messages, error) {
+	if len(threadIDs) == 0 {
+		return nil, nil
+	}
+	var q string
+	switch appKind {
+	case "company":
+		q = "SELECT id, message_thread_id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, created_at, updated_at, deleted_at FROM messages WHERE message_thread_id IN (?) AND (is_from_company = 1 OR (is_sent = 1 AND deleted_at IS NULL)) ORDER BY id ASC;"
+	case "student":
+		q = "SELECT id, message_thread_id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, created_at, updated_at, deleted_at FROM messages WHERE message_thread_id IN (?) AND (is_from_student = 1 OR (is_sent = 1 AND deleted_at IS NULL)) ORDER BY id ASC;"
+	default:
+		return nil, fmt.Errorf("invalid app kind: %s", appKind)
+	}
+	query, args, err := sqlx.In(q, threadIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var messages entity.Messages
+	for rows.Next() {
+		var m entity.Message
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+		messages = append(messages, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (udr *UserDataRepository) GetAttachmentsByMessageIDs(ctx context.Context, db Queryer, messageIDs []entity.MessageID) (entity.Attachments, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+	query, args, err := sqlx.In("SELECT id, message_id, file_name, content_type, size, storage_key, created_at, deleted_at FROM message_attachments WHERE message_id IN (?) ORDER BY id ASC;", messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var attachments entity.Attachments
+	for rows.Next() {
+		var a entity.Attachment
+		if err := rows.StructScan(&a); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// GetCredentialsByUserID はシークレットやトークンを除いた認証情報を返す
+func (udr *UserDataRepository) GetCredentialsByUserID(ctx context.Context, db Queryer, userID int64) ([]*entity.MessageAPICredential, error) {
+	query := "SELECT id, user_id, client_id, expires_at, created_at, updated_at, deleted_at FROM message_api_credentials WHERE user_id = ? ORDER BY id ASC;"
+	rows, err := db.QueryxContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var credentials []*entity.MessageAPICredential
+	for rows.Next() {
+		var c entity.MessageAPICredential
+		if err := rows.StructScan(&c); err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+func (udr *UserDataRepository) AnonymizeMessages(ctx context.Context, db Execer, ids []entity.MessageID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query, args, err := sqlx.In("UPDATE messages SET content = ?, updated_at = ? WHERE id IN (?);", entity.ErasedMessageContent, udr.Clocker.Now(), ids)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (udr *UserDataRepository) DeleteAttachments(ctx context.Context, db Execer, ids []entity.AttachmentID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query, args, err := sqlx.In("DELETE FROM message_attachments WHERE id IN (?);", ids)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (udr *UserDataRepository) DeleteCredentialsByUserID(ctx context.Context, db Execer, userID int64) error {
+	query := "DELETE FROM message_api_credentials WHERE user_id = ?;"
+	_, err := db.ExecContext(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,353 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yuyacode/AppLiftMessageApi/clock"
+	"github.com/yuyacode/AppLiftMessageApi/entity"
+)
+
+func TestUserDataRepository_GetThreadsByUserID(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	udr := NewUserDataRepository(clock.FixedClocker{})
+	createdAt := time.Date(2025, 1, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	tests := map[string]struct {
+		appKind     string
+		mockSetup   func()
+		wantErr     bool
+		wantThreads []*entity.MessageThread
+	}{
+		"Invalid app kind": {
+			appKind:   "admin",
+			mockSetup: func() {},
+			wantErr:   true,
+		},
+		"DB error": {
+			appKind: "company",
+			mockSetup: func() {
+				mock.ExpectQuery(`^SELECT id, company_user_id, student_user_id, created_at, updated_at, deleted_at FROM message_threads WHERE company_user_id = \? ORDER BY id ASC;$`).
+					WithArgs(int64(1)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Student": {
+			appKind: "student",
+			mockSetup: func() {
+				mock.ExpectQuery(`^SELECT id, company_user_id, student_user_id, created_at, updated_at, deleted_at FROM message_threads WHERE student_user_id = \? ORDER BY id ASC;$`).
+					WithArgs(int64(1)).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "company_user_id", "student_user_id", "created_at", "updated_at", "deleted_at"}).
+							AddRow(int64(5), int64(10), int64(1), createdAt, nil, nil),
+					)
+			},
+			wantErr: false,
+			wantThreads: []*entity.MessageThread{
+				{ID: 5, CompanyUserID: 10, StudentUserID: 1, CreatedAt: &sql.NullTime{Time: createdAt, Valid: true}},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := udr.GetThreadsByUserID(context.Background(), sqlxDB, tc.appKind, 1)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantThreads, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserDataRepository_GetMessagesByThreadIDs(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	udr := NewUserDataRepository(clock.FixedClocker{})
+	sentAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	columns := []string{"id", "message_thread_id", "is_from_company", "is_from_student", "content", "is_sent", "sent_at", "reply_to_message_id", "created_at", "updated_at", "deleted_at"}
+	tests := map[string]struct {
+		appKind      string
+		threadIDs    []entity.MessageThreadID
+		mockSetup    func()
+		wantErr      bool
+		wantMessages entity.Messages
+	}{
+		"Empty thread IDs": {
+			appKind:   "company",
+			threadIDs: nil,
+			mockSetup: func() {},
+			wantErr:   false,
+		},
+		"DB error": {
+			appKind:   "student",
+			threadIDs: []entity.MessageThreadID{1, 2},
+			mockSetup: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM messages WHERE message_thread_id IN \(\?, \?\) AND \(is_from_student = 1 OR \(is_sent = 1 AND deleted_at IS NULL\)\) ORDER BY id ASC;$`).
+					WithArgs(int64(1), int64(2)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Company": {
+			appKind:   "company",
+			threadIDs: []entity.MessageThreadID{1},
+			mockSetup: func() {
+				mock.ExpectQuery(`^SELECT id, message_thread_id, is_from_company, is_from_student, content, is_sent, sent_at, reply_to_message_id, created_at, updated_at, deleted_at FROM messages WHERE message_thread_id IN \(\?\) AND \(is_from_company = 1 OR \(is_sent = 1 AND deleted_at IS NULL\)\) ORDER BY id ASC;$`).
+					WithArgs(int64(1)).
+					WillReturnRows(
+						sqlmock.NewRows(columns).
+							AddRow(int64(3), int64(1), int8(1), int8(0), "Hello", int8(0), sentAt, nil, nil, nil, nil),
+					)
+			},
+			wantErr: false,
+			wantMessages: entity.Messages{
+				&entity.Message{ID: 3, MessageThreadID: 1, IsFromCompany: 1, Content: "Hello", SentAt: sentAt},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := udr.GetMessagesByThreadIDs(context.Background(), sqlxDB, tc.appKind, tc.threadIDs)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantMessages, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserDataRepository_GetAttachmentsByMessageIDs(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	udr := NewUserDataRepository(clock.FixedClocker{})
+	query := `^SELECT id, message_id, file_name, content_type, size, storage_key, created_at, deleted_at FROM message_attachments WHERE message_id IN \(\?, \?\) ORDER BY id ASC;$`
+	tests := map[string]struct {
+		messageIDs      []entity.MessageID
+		mockSetup       func()
+		wantErr         bool
+		wantAttachments entity.Attachments
+	}{
+		"Empty message IDs": {
+			messageIDs: nil,
+			mockSetup:  func() {},
+			wantErr:    false,
+		},
+		"DB error": {
+			messageIDs: []entity.MessageID{1, 2},
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1), int64(2)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Success": {
+			messageIDs: []entity.MessageID{1, 2},
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1), int64(2)).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "message_id", "file_name", "content_type", "size", "storage_key", "created_at", "deleted_at"}).
+							AddRow(int64(7), int64(2), "resume.pdf", "application/pdf", int64(2048), "attachments/2/abc", nil, nil),
+					)
+			},
+			wantErr: false,
+			wantAttachments: entity.Attachments{
+				&entity.Attachment{ID: 7, MessageID: 2, FileName: "resume.pdf", ContentType: "application/pdf", Size: 2048, StorageKey: "attachments/2/abc"},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := udr.GetAttachmentsByMessageIDs(context.Background(), sqlxDB, tc.messageIDs)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantAttachments, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserDataRepository_GetCredentialsByUserID(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	udr := NewUserDataRepository(clock.FixedClocker{})
+	query := `^SELECT id, user_id, client_id, expires_at, created_at, updated_at, deleted_at FROM message_api_credentials WHERE user_id = \? ORDER BY id ASC;$`
+	tests := map[string]struct {
+		mockSetup       func()
+		wantErr         bool
+		wantCredentials []*entity.MessageAPICredential
+	}{
+		"DB error": {
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Success": {
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(int64(1)).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "user_id", "client_id", "expires_at", "created_at", "updated_at", "deleted_at"}).
+							AddRow(int64(4), int64(1), "client", nil, nil, nil, nil),
+					)
+			},
+			wantErr: false,
+			wantCredentials: []*entity.MessageAPICredential{
+				{ID: 4, UserID: 1, ClientID: "client"},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			got, err := udr.GetCredentialsByUserID(context.Background(), sqlxDB, 1)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantCredentials, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserDataRepository_AnonymizeMessages(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	udr := NewUserDataRepository(clock.FixedClocker{})
+	tests := map[string]struct {
+		ids       []entity.MessageID
+		mockSetup func()
+		wantErr   bool
+	}{
+		"Empty IDs": {
+			ids:       nil,
+			mockSetup: func() {},
+			wantErr:   false,
+		},
+		"DB error": {
+			ids: []entity.MessageID{1, 2},
+			mockSetup: func() {
+				mock.ExpectExec(`^UPDATE messages SET content = \?, updated_at = \? WHERE id IN \(\?, \?\);$`).
+					WithArgs(entity.ErasedMessageContent, clock.FixedClocker{}.Now(), int64(1), int64(2)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Success": {
+			ids: []entity.MessageID{1, 2},
+			mockSetup: func() {
+				mock.ExpectExec(`^UPDATE messages SET content = \?, updated_at = \? WHERE id IN \(\?, \?\);$`).
+					WithArgs(entity.ErasedMessageContent, clock.FixedClocker{}.Now(), int64(1), int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			wantErr: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			err := udr.AnonymizeMessages(context.Background(), sqlxDB, tc.ids)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserDataRepository_DeleteAttachments(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	udr := NewUserDataRepository(clock.FixedClocker{})
+	tests := map[string]struct {
+		ids       []entity.AttachmentID
+		mockSetup func()
+		wantErr   bool
+	}{
+		"Empty IDs": {
+			ids:       nil,
+			mockSetup: func() {},
+			wantErr:   false,
+		},
+		"Success": {
+			ids: []entity.AttachmentID{3},
+			mockSetup: func() {
+				mock.ExpectExec(`^DELETE FROM message_attachments WHERE id IN \(\?\);$`).
+					WithArgs(int64(3)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			err := udr.DeleteAttachments(context.Background(), sqlxDB, tc.ids)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserDataRepository_DeleteCredentialsByUserID(t *testing.T) {
+	sqlxDB, mock := newMockDB(t)
+	udr := NewUserDataRepository(clock.FixedClocker{})
+	tests := map[string]struct {
+		mockSetup func()
+		wantErr   bool
+	}{
+		"DB error": {
+			mockSetup: func() {
+				mock.ExpectExec(`^DELETE FROM message_api_credentials WHERE user_id = \?;$`).
+					WithArgs(int64(1)).
+					WillReturnError(assertAnError())
+			},
+			wantErr: true,
+		},
+		"Success": {
+			mockSetup: func() {
+				mock.ExpectExec(`^DELETE FROM message_api_credentials WHERE user_id = \?;$`).
+					WithArgs(int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.mockSetup()
+			err := udr.DeleteCredentialsByUserID(context.Background(), sqlxDB, 1)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}